@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"strings"
@@ -12,199 +16,331 @@ import (
 	"os/exec"
 	"runtime"
 
-	"github.com/eclipse-softworks/luna-sdk-go/luna"
 	"github.com/spf13/cobra"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna"
+	internalauth "github.com/eclipse-softworks/luna-sdk/cli/internal/auth"
 )
 
-var authCmd = &cobra.Command{
-	Use:   "auth",
-	Short: "Manage authentication",
-	Long:  `Manage authentication credentials for the Luna CLI.`,
+// cliAuth implements the `luna auth` command group.
+type cliAuth struct {
+	cmd       *cobra.Command
+	getConfig configGetter
+	getClient clientGetter
+
+	loginAccount string
+}
+
+// NewCLIAuth constructs the `luna auth` command group, wiring its
+// subcommands to getConfig/getClient instead of package-level globals.
+func NewCLIAuth(getConfig configGetter, getClient clientGetter) *cliAuth {
+	c := &cliAuth{getConfig: getConfig, getClient: getClient}
+
+	cmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage authentication",
+		Long:  `Manage authentication credentials for the Luna CLI.`,
+	}
+
+	loginCmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in to Luna",
+		Long:  `Log in to your Luna account using browser-based OAuth.`,
+		RunE:  c.runLogin,
+	}
+	loginCmd.Flags().StringVar(&c.loginAccount, "account", "", "Token-store account to bind this profile to (defaults to the profile name)")
+
+	logoutCmd := &cobra.Command{
+		Use:   "logout [account]",
+		Short: "Log out of Luna",
+		Long: `Clear stored authentication credentials.
+
+With no argument, logs out the active profile (--profile, default
+"default"): its bound token-store account is cleared and unbound from the
+profile. With an account argument, clears that token-store account
+directly, regardless of which profile(s) reference it.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: c.runLogout,
+	}
+
+	accountsCmd := &cobra.Command{
+		Use:   "accounts",
+		Short: "List accounts with stored credentials",
+		Long:  `List the token-store accounts created by "luna auth login", marking the one bound to the active profile.`,
+		RunE:  c.runAccounts,
+	}
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show authentication status",
+		Long:  `Display current authentication status and user information.`,
+		RunE:  c.runStatus,
+	}
+
+	verifyCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Verify API credentials",
+		Long:  "Test the currently configured API key against the server.",
+		RunE:  c.runVerify,
+	}
+
+	cmd.AddCommand(loginCmd, logoutCmd, accountsCmd, statusCmd, verifyCmd)
+	c.cmd = cmd
+	return c
+}
+
+// newTokenStore returns the TokenStore backing `luna auth login/logout/
+// accounts`: the system keyring when it's usable on this machine, falling
+// back to the (optionally encrypted) credentials file otherwise.
+func newTokenStore() (internalauth.TokenStore, error) {
+	return internalauth.NewDefaultTokenStore(true)
+}
+
+// Command returns the cobra command for this resource.
+func (c *cliAuth) Command() *cobra.Command {
+	return c.cmd
 }
 
-var loginCmd = &cobra.Command{
-	Use:   "login",
-	Short: "Log in to Luna",
-	Long:  `Log in to your Luna account using browser-based OAuth.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		// 1. Create a channel to signal completion
-		done := make(chan string)
-		errChan := make(chan error)
-
-		// 2. Start local server
-		server := &http.Server{Addr: "127.0.0.1:9999"}
-		http.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
-			code := r.URL.Query().Get("code")
-			if code == "" {
-				http.Error(w, "Code not found", http.StatusBadRequest)
-				errChan <- fmt.Errorf("authorization code not found in callback")
-				return
-			}
-			fmt.Fprintf(w, "Authorization successful! You can close this window now.")
-			done <- code
-		})
-
-		go func() {
-			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-				errChan <- fmt.Errorf("failed to start local server: %w", err)
-			}
-		}()
-
-		// 3. Open browser
-		authURL := "https://auth.eclipse.dev/authorize?client_id=luna-cli&redirect_uri=http://localhost:9999/callback&response_type=code"
-		fmt.Println("Opening browser for authentication...")
-		fmt.Printf("If browser does not open, visit: %s\n", authURL)
-
-		if err := openBrowser(authURL); err != nil {
-			fmt.Printf("Failed to open browser: %v\n", err)
+func (c *cliAuth) runLogin(cmd *cobra.Command, args []string) error {
+	// 1. Bind an ephemeral port so concurrent `luna auth login` invocations
+	// on a shared machine never collide, and read back the port we got.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to start local server: %w", err)
+	}
+	redirectURI := fmt.Sprintf("http://127.0.0.1:%d/callback", listener.Addr().(*net.TCPAddr).Port)
+
+	// 2. Generate PKCE (RFC 7636) verifier/challenge and anti-CSRF state.
+	verifier, err := generatePKCEVerifier()
+	if err != nil {
+		return fmt.Errorf("failed to generate PKCE verifier: %w", err)
+	}
+	challenge := pkceS256Challenge(verifier)
+	state, err := generateRandomToken(32)
+	if err != nil {
+		return fmt.Errorf("failed to generate state: %w", err)
+	}
+
+	done := make(chan string)
+	errChan := make(chan error, 1)
+
+	// 3. Register the callback on a per-invocation mux rather than
+	// http.DefaultServeMux, which would panic if login ran twice in one
+	// process (e.g. from tests or a long-lived CLI server mode).
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		if returnedState := r.URL.Query().Get("state"); returnedState != state {
+			http.Error(w, "State mismatch", http.StatusBadRequest)
+			errChan <- fmt.Errorf("state mismatch in callback: possible CSRF attempt")
+			return
+		}
+		code := r.URL.Query().Get("code")
+		if code == "" {
+			http.Error(w, "Code not found", http.StatusBadRequest)
+			errChan <- fmt.Errorf("authorization code not found in callback")
+			return
 		}
+		fmt.Fprintf(w, "Authorization successful! You can close this window now.")
+		done <- code
+	})
+	server := &http.Server{Handler: mux}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			errChan <- fmt.Errorf("local server error: %w", err)
+		}
+	}()
+
+	// 4. Open browser
+	authURL := fmt.Sprintf(
+		"https://auth.eclipse.dev/authorize?client_id=luna-cli&redirect_uri=%s&response_type=code&state=%s&code_challenge=%s&code_challenge_method=S256",
+		url.QueryEscape(redirectURI), url.QueryEscape(state), url.QueryEscape(challenge),
+	)
+	fmt.Println("Opening browser for authentication...")
+	fmt.Printf("If browser does not open, visit: %s\n", authURL)
+
+	if err := openBrowser(authURL); err != nil {
+		fmt.Printf("Failed to open browser: %v\n", err)
+	}
 
-		// 4. Wait for callback
-		fmt.Println("Waiting for authentication...")
-		select {
-		case code := <-done:
-			_ = server.Shutdown(context.Background())
-			fmt.Println("✓ Successfully authenticated!")
-
-			// Exchange code for tokens
-			tokens, err := exchangeToken(code)
-			if err != nil {
-				// Fallback for demo/offline if real endpoint fails
-				// But we try to be as real as possible first
-				errChan <- fmt.Errorf("failed to exchange token: %w", err)
-				return nil
-			}
-
-			cfg, err := LoadConfig()
-			if err != nil {
-				// If config doesn't exist, create default
-				cfg = &Config{
-					DefaultProfile: "default",
-					Profiles:       make(map[string]Profile),
-					Settings: Settings{
-						OutputFormat: "table",
-						Color:        true,
-					},
-				}
-			}
-
-			if cfg.Profiles == nil {
-				cfg.Profiles = make(map[string]Profile)
-			}
-
-			// Update profile with real tokens
-			profile := cfg.Profiles[cfgProfile]
-			profile.AccessToken = tokens.AccessToken
-			profile.RefreshToken = tokens.RefreshToken
-			cfg.Profiles[cfgProfile] = profile
-
-			if err := SaveConfig(cfg); err != nil {
-				errChan <- fmt.Errorf("failed to save config: %w", err)
-				return nil
-			}
-
-			// We are done
-			return nil
-
-		case err := <-errChan:
-			_ = server.Shutdown(context.Background())
-			return err
-		case <-time.After(2 * time.Minute):
-			_ = server.Shutdown(context.Background())
-			return fmt.Errorf("authentication timed out")
+	// 5. Wait for callback
+	fmt.Println("Waiting for authentication...")
+	select {
+	case code := <-done:
+		_ = server.Shutdown(context.Background())
+		fmt.Println("✓ Successfully authenticated!")
+
+		// Exchange code for tokens
+		tokens, err := exchangeToken(code, redirectURI, verifier)
+		if err != nil {
+			return fmt.Errorf("failed to exchange token: %w", err)
+		}
+
+		account := c.loginAccount
+		if account == "" {
+			account = cfgProfile
 		}
-	},
-}
 
-var logoutCmd = &cobra.Command{
-	Use:   "logout",
-	Short: "Log out of Luna",
-	Long:  `Clear stored authentication credentials.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, err := LoadConfig()
+		store, err := newTokenStore()
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to open token store: %w", err)
+		}
+		if err := store.Save(account, tokens.AccessToken, tokens.RefreshToken); err != nil {
+			return fmt.Errorf("failed to save credentials: %w", err)
 		}
 
-		if profile, ok := cfg.Profiles[cfgProfile]; ok {
-			profile.APIKey = ""
-			profile.AccessToken = ""
-			profile.RefreshToken = ""
-			cfg.Profiles[cfgProfile] = profile
+		cfg := c.getConfig()
+		if cfg.Profiles == nil {
+			cfg.Profiles = make(map[string]Profile)
 		}
 
+		profile := cfg.Profiles[cfgProfile]
+		profile.Account = account
+		cfg.Profiles[cfgProfile] = profile
+
 		if err := SaveConfig(cfg); err != nil {
 			return fmt.Errorf("failed to save config: %w", err)
 		}
 
-		fmt.Println("✓ Logged out successfully")
 		return nil
-	},
+
+	case err := <-errChan:
+		_ = server.Shutdown(context.Background())
+		return err
+	case <-time.After(2 * time.Minute):
+		_ = server.Shutdown(context.Background())
+		return fmt.Errorf("authentication timed out")
+	}
 }
 
-var statusCmd = &cobra.Command{
-	Use:   "status",
-	Short: "Show authentication status",
-	Long:  `Display current authentication status and user information.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		apiKey := getAPIKey()
-
-		if apiKey == "" {
-			fmt.Println("Authenticated: No")
-			fmt.Println("\nRun 'luna auth login' or set LUNA_API_KEY to authenticate.")
-			return nil
-		}
+// generatePKCEVerifier generates a random 32-byte RFC 7636 code_verifier,
+// base64url-encoded without padding.
+func generatePKCEVerifier() (string, error) {
+	return generateRandomToken(32)
+}
 
-		// Mask API key
-		maskedKey := apiKey[:7] + "****" + apiKey[len(apiKey)-4:]
+// pkceS256Challenge derives the S256 code_challenge for verifier: the
+// base64url (no padding) encoding of SHA-256(verifier).
+func pkceS256Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
 
-		fmt.Println("Authenticated: Yes")
-		fmt.Printf("API Key: %s\n", maskedKey)
-		fmt.Printf("Profile: %s\n", cfgProfile)
+// generateRandomToken returns a base64url-encoded (no padding) random token
+// backed by n bytes of crypto/rand, suitable for PKCE verifiers and OAuth
+// state values.
+func generateRandomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
 
+func (c *cliAuth) runLogout(cmd *cobra.Command, args []string) error {
+	store, err := newTokenStore()
+	if err != nil {
+		return fmt.Errorf("failed to open token store: %w", err)
+	}
+
+	cfg := c.getConfig()
+
+	if len(args) == 1 {
+		account := args[0]
+		if err := store.Clear(account); err != nil {
+			return fmt.Errorf("failed to clear account %q: %w", account, err)
+		}
+		fmt.Printf("✓ Logged out account %q\n", account)
 		return nil
-	},
-}
+	}
 
-var verifyCmd = &cobra.Command{
-	Use:   "verify",
-	Short: "Verify API credentials",
-	Long:  "Test the currently configured API key against the server.",
-	RunE: func(cmd *cobra.Command, args []string) error {
-		apiKey := getAPIKey()
-		if apiKey == "" {
-			return fmt.Errorf("not authenticated")
+	profile, ok := cfg.Profiles[cfgProfile]
+	if ok && profile.Account != "" {
+		if err := store.Clear(profile.Account); err != nil {
+			return fmt.Errorf("failed to clear account %q: %w", profile.Account, err)
 		}
+	}
 
-		client, err := luna.NewClient(luna.WithAPIKey(apiKey))
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
+	if ok {
+		profile.APIKey = ""
+		profile.Account = ""
+		cfg.Profiles[cfgProfile] = profile
+
+		if err := SaveConfig(cfg); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
 		}
+	}
 
-		// Attempt to fetch something simpler or just use existing resources to verify auth
-		// Since there isn't an explicit "Verify" or "Me" endpoint exposed in the top level resources we see,
-		// we'll try to list project or users with limit 1 to check credentials.
-		// Actually, let's assume we can list users (self) or similar.
+	fmt.Println("✓ Logged out successfully")
+	return nil
+}
 
-		// A common pattern is to check "Me" but we don't have that resource visible in client.go right now.
-		// We'll use List Users as a proxy for "Is Authenticated".
+func (c *cliAuth) runAccounts(cmd *cobra.Command, args []string) error {
+	store, err := newTokenStore()
+	if err != nil {
+		return fmt.Errorf("failed to open token store: %w", err)
+	}
 
-		_, err = client.Users().List(cmd.Context(), &luna.ListParams{Limit: 1})
-		if err != nil {
-			return fmt.Errorf("verification failed: %w", err)
+	accounts, err := store.List()
+	if err != nil {
+		return fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	if len(accounts) == 0 {
+		fmt.Println("No accounts found. Run 'luna auth login' to create one.")
+		return nil
+	}
+
+	active := ""
+	if profile, ok := c.getConfig().Profiles[cfgProfile]; ok {
+		active = profile.Account
+	}
+
+	for _, account := range accounts {
+		if account == active {
+			fmt.Printf("* %s (bound to profile %q)\n", account, cfgProfile)
+		} else {
+			fmt.Printf("  %s\n", account)
 		}
+	}
+
+	return nil
+}
+
+func (c *cliAuth) runStatus(cmd *cobra.Command, args []string) error {
+	key := resolveAPIKey(c.getConfig())
 
-		fmt.Println("✓ Credentials are valid")
-		// We can't easily get the user details without a Me endpoint, but we confirmed the key works.
+	if key == "" {
+		fmt.Println("Authenticated: No")
+		fmt.Println("\nRun 'luna auth login' or set LUNA_API_KEY to authenticate.")
 		return nil
-	},
+	}
+
+	maskedKey := key[:7] + "****" + key[len(key)-4:]
+
+	fmt.Println("Authenticated: Yes")
+	fmt.Printf("API Key: %s\n", maskedKey)
+	fmt.Printf("Profile: %s\n", cfgProfile)
+
+	return nil
 }
 
-func init() {
-	authCmd.AddCommand(loginCmd)
-	authCmd.AddCommand(logoutCmd)
-	authCmd.AddCommand(statusCmd)
-	authCmd.AddCommand(verifyCmd)
+func (c *cliAuth) runVerify(cmd *cobra.Command, args []string) error {
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+
+	// A common pattern is to check "Me" but we don't have that resource
+	// visible in client.go right now, so list users with limit 1 as a proxy
+	// for "is this key valid".
+	_, err = client.Users().List(cmd.Context(), &luna.ListParams{Limit: 1})
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	fmt.Println("✓ Credentials are valid")
+	return nil
 }
 
 // openBrowser opens a URL in the default browser
@@ -233,7 +369,7 @@ type TokenResponse struct {
 	ExpiresIn    int    `json:"expires_in"`
 }
 
-func exchangeToken(code string) (*TokenResponse, error) {
+func exchangeToken(code, redirectURI, codeVerifier string) (*TokenResponse, error) {
 	// Real HTTP request to exchange code
 	// Use custom client with timeout
 	client := &http.Client{
@@ -244,7 +380,8 @@ func exchangeToken(code string) (*TokenResponse, error) {
 	data.Set("grant_type", "authorization_code")
 	data.Set("client_id", "luna-cli")
 	data.Set("code", code)
-	data.Set("redirect_uri", "http://localhost:9999/callback")
+	data.Set("redirect_uri", redirectURI)
+	data.Set("code_verifier", codeVerifier)
 
 	req, err := http.NewRequest("POST", "https://auth.eclipse.dev/oauth/token", strings.NewReader(data.Encode()))
 	if err != nil {
@@ -18,10 +18,13 @@ type Config struct {
 
 // Profile represents a configuration profile
 type Profile struct {
-	APIKey       string `yaml:"api_key,omitempty"`
-	BaseURL      string `yaml:"base_url,omitempty"`
-	AccessToken  string `yaml:"access_token,omitempty"`
-	RefreshToken string `yaml:"refresh_token,omitempty"`
+	APIKey  string `yaml:"api_key,omitempty"`
+	BaseURL string `yaml:"base_url,omitempty"`
+	// Account names the token-store account (see internal/auth.TokenStore)
+	// holding this profile's OAuth tokens. It's set by `luna auth login`
+	// and defaults to the profile's own name, but several profiles can
+	// point at the same account to share one login.
+	Account string `yaml:"account,omitempty"`
 }
 
 // Settings holds CLI settings
@@ -0,0 +1,373 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+)
+
+// DevConfig describes a `.luna-watch.json` file: what to watch, what to
+// ignore, and what to re-run when a watched file changes.
+type DevConfig struct {
+	// Command is run through "sh -c" on startup and on every rebuild.
+	Command string `json:"command"`
+	// Paths are the root directories to watch, recursively.
+	Paths []string `json:"paths"`
+	// Include is a set of filepath.Match glob patterns checked against a
+	// changed file's base name; the file is ignored unless at least one
+	// matches.
+	Include []string `json:"include"`
+	// Exclude is a set of filepath.Match glob patterns checked against
+	// path components; a file under a matching directory (or matching
+	// itself) is ignored even if Include matches.
+	Exclude []string `json:"exclude"`
+	// Depth bounds how many directory levels below each entry in Paths
+	// are watched; 0 means unlimited.
+	Depth int `json:"depth"`
+	// DebounceMillis is how long to wait for the filesystem to go quiet
+	// before restarting the command.
+	DebounceMillis int `json:"debounce_ms"`
+	// ShutdownTimeoutMillis is how long to wait after SIGTERM before
+	// SIGKILL-ing a still-running child on restart or exit.
+	ShutdownTimeoutMillis int `json:"shutdown_timeout_ms"`
+}
+
+// defaultDevConfig returns the config used when no .luna-watch.json exists
+// and no overriding flags were passed.
+func defaultDevConfig() *DevConfig {
+	return &DevConfig{
+		Command:               "go build ./... && go run .",
+		Paths:                 []string{"."},
+		Include:               []string{"*.go"},
+		Exclude:               []string{".git", "node_modules", "vendor", "bin"},
+		Depth:                 0,
+		DebounceMillis:        300,
+		ShutdownTimeoutMillis: 5000,
+	}
+}
+
+// loadDevConfig reads path if it exists, falling back to defaultDevConfig
+// when it doesn't. A malformed file is a hard error.
+func loadDevConfig(path string) (*DevConfig, error) {
+	cfg := defaultDevConfig()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+var devCmd = &cobra.Command{
+	Use:   "dev",
+	Short: "Watch for source changes and re-run a build/run command",
+	Long: `Watch a path for file changes and re-execute a build+run command on
+every change, debouncing bursts of edits and restarting the previous run
+gracefully (SIGTERM, then SIGKILL after a timeout).
+
+Configuration can be checked into the repo as .luna-watch.json:
+
+  {
+    "command": "go build ./... && go run .",
+    "paths": ["."],
+    "include": ["*.go"],
+    "exclude": [".git", "vendor"],
+    "debounce_ms": 300
+  }
+
+Flags override the config file when both are given.`,
+	RunE: runDev,
+}
+
+func init() {
+	devCmd.Flags().String("config", ".luna-watch.json", "Path to the watch config file")
+	devCmd.Flags().StringSlice("path", nil, "Path to watch (repeatable; overrides the config file)")
+	devCmd.Flags().StringSlice("include", nil, "Glob pattern a changed file must match (repeatable; overrides the config file)")
+	devCmd.Flags().StringSlice("exclude", nil, "Glob pattern to ignore (repeatable; overrides the config file)")
+	devCmd.Flags().Int("depth", -1, "Directory depth to watch below each path, 0 for unlimited (overrides the config file)")
+	devCmd.Flags().String("cmd", "", "Command to run on every change (overrides the config file)")
+	devCmd.Flags().Int("debounce", 0, "Debounce window in milliseconds (overrides the config file)")
+	rootCmd.AddCommand(devCmd)
+}
+
+func runDev(cmd *cobra.Command, args []string) error {
+	configPath, _ := cmd.Flags().GetString("config")
+	cfg, err := loadDevConfig(configPath)
+	if err != nil {
+		return err
+	}
+	applyDevFlagOverrides(cmd, cfg)
+
+	if cfg.Command == "" {
+		return fmt.Errorf("no command to run: set \"command\" in %s or pass --cmd", configPath)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to start file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	watched := 0
+	for _, root := range cfg.Paths {
+		n, err := addWatchesRecursive(watcher, root, cfg.Depth, cfg.Exclude)
+		if err != nil {
+			return fmt.Errorf("failed to watch %s: %w", root, err)
+		}
+		watched += n
+	}
+	fmt.Printf("Watching %d director%s for changes to %s\n", watched, pluralY(watched), strings.Join(cfg.Include, ", "))
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	shutdownTimeout := time.Duration(cfg.ShutdownTimeoutMillis) * time.Millisecond
+	debounce := time.Duration(cfg.DebounceMillis) * time.Millisecond
+
+	runner := newDevRunner(cfg.Command, shutdownTimeout)
+	defer runner.stop()
+	runner.restart()
+
+	var timer *time.Timer
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !devFileMatches(event.Name, cfg.Include, cfg.Exclude) {
+				continue
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Remove) != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					addWatchesRecursive(watcher, event.Name, cfg.Depth, cfg.Exclude)
+				}
+			}
+			if timer == nil {
+				timer = time.AfterFunc(debounce, func() { runner.restart() })
+			} else {
+				timer.Reset(debounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			fmt.Fprintf(os.Stderr, "watch error: %v\n", err)
+		}
+	}
+}
+
+// applyDevFlagOverrides layers any explicitly-set dev flags on top of cfg.
+func applyDevFlagOverrides(cmd *cobra.Command, cfg *DevConfig) {
+	flags := cmd.Flags()
+	if flags.Changed("path") {
+		cfg.Paths, _ = flags.GetStringSlice("path")
+	}
+	if flags.Changed("include") {
+		cfg.Include, _ = flags.GetStringSlice("include")
+	}
+	if flags.Changed("exclude") {
+		cfg.Exclude, _ = flags.GetStringSlice("exclude")
+	}
+	if flags.Changed("depth") {
+		cfg.Depth, _ = flags.GetInt("depth")
+	}
+	if flags.Changed("cmd") {
+		cfg.Command, _ = flags.GetString("cmd")
+	}
+	if flags.Changed("debounce") {
+		ms, _ := flags.GetInt("debounce")
+		cfg.DebounceMillis = ms
+	}
+}
+
+// addWatchesRecursive adds root and its subdirectories (up to maxDepth
+// levels below root; 0 means unlimited) to watcher, skipping any directory
+// whose name matches one of exclude.
+func addWatchesRecursive(watcher *fsnotify.Watcher, root string, maxDepth int, exclude []string) (int, error) {
+	rootDepth := strings.Count(filepath.Clean(root), string(filepath.Separator))
+	watched := 0
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if devNameMatches(filepath.Base(path), exclude) {
+			return filepath.SkipDir
+		}
+		if maxDepth > 0 {
+			depth := strings.Count(filepath.Clean(path), string(filepath.Separator)) - rootDepth
+			if depth > maxDepth {
+				return filepath.SkipDir
+			}
+		}
+		if err := watcher.Add(path); err != nil {
+			return err
+		}
+		watched++
+		return nil
+	})
+	return watched, err
+}
+
+// devFileMatches reports whether path's base name matches one of include
+// and no path component matches one of exclude.
+func devFileMatches(path string, include, exclude []string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if devNameMatches(part, exclude) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	return devNameMatches(filepath.Base(path), include)
+}
+
+func devNameMatches(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// devRunner owns the single in-flight child process for `luna dev`,
+// serializing restarts so a burst of debounced events can't start two
+// overlapping runs.
+type devRunner struct {
+	command         string
+	shutdownTimeout time.Duration
+
+	mu   sync.Mutex
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+func newDevRunner(command string, shutdownTimeout time.Duration) *devRunner {
+	return &devRunner{command: command, shutdownTimeout: shutdownTimeout}
+}
+
+// restart stops any running child, then starts a fresh one streaming
+// colorized, prefixed output.
+func (r *devRunner) restart() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopLocked()
+
+	fmt.Println(devPrefix("watch", "restarting: "+r.command))
+	cmd := exec.Command("sh", "-c", r.command)
+	cmd.SysProcAttr = newDevSysProcAttr()
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, devPrefix("watch", "failed to attach stdout: "+err.Error()))
+		return
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, devPrefix("watch", "failed to attach stderr: "+err.Error()))
+		return
+	}
+
+	if err := cmd.Start(); err != nil {
+		fmt.Fprintln(os.Stderr, devPrefix("watch", "failed to start: "+err.Error()))
+		return
+	}
+	r.cmd = cmd
+	done := make(chan struct{})
+	r.done = done
+
+	go streamPrefixed(os.Stdout, "app", stdout)
+	go streamPrefixed(os.Stderr, "app", stderr)
+	go func(cmd *exec.Cmd, done chan struct{}) {
+		defer close(done)
+		if err := cmd.Wait(); err != nil {
+			if _, ok := err.(*exec.ExitError); !ok {
+				fmt.Fprintln(os.Stderr, devPrefix("watch", "wait error: "+err.Error()))
+			}
+		}
+	}(cmd, done)
+}
+
+// stop gracefully terminates any running child.
+func (r *devRunner) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stopLocked()
+}
+
+// stopLocked sends SIGTERM to the child's process group and escalates to
+// SIGKILL if it hasn't exited within r.shutdownTimeout. Callers must hold
+// r.mu.
+func (r *devRunner) stopLocked() {
+	if r.cmd == nil || r.cmd.Process == nil {
+		return
+	}
+	done := r.done
+
+	terminateDevProcess(r.cmd, false)
+	select {
+	case <-done:
+	case <-time.After(r.shutdownTimeout):
+		terminateDevProcess(r.cmd, true)
+		<-done
+	}
+	r.cmd = nil
+	r.done = nil
+}
+
+// streamPrefixed copies lines from r to w, prefixing each with a
+// colorized "[label]" tag (disabled via --no-color).
+func streamPrefixed(w io.Writer, label string, r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fmt.Fprintln(w, devPrefix(label, scanner.Text()))
+	}
+}
+
+// devPrefix renders "[label] line", coloring the label when --no-color
+// wasn't passed.
+func devPrefix(label, line string) string {
+	if noColor {
+		return fmt.Sprintf("[%s] %s", label, line)
+	}
+	color := "36" // cyan
+	if label == "watch" {
+		color = "33" // yellow
+	}
+	return fmt.Sprintf("\x1b[%sm[%s]\x1b[0m %s", color, label, line)
+}
+
+func pluralY(n int) string {
+	if n == 1 {
+		return "y"
+	}
+	return "ies"
+}
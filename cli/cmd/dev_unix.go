@@ -0,0 +1,27 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// newDevSysProcAttr puts the child in its own process group so
+// terminateDevProcess can signal it (and anything it spawned) as a unit.
+func newDevSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+// terminateDevProcess sends SIGTERM (or SIGKILL, if hard) to cmd's process
+// group.
+func terminateDevProcess(cmd *exec.Cmd, hard bool) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	sig := syscall.SIGTERM
+	if hard {
+		sig = syscall.SIGKILL
+	}
+	return syscall.Kill(-cmd.Process.Pid, sig)
+}
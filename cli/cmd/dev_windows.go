@@ -0,0 +1,23 @@
+//go:build windows
+
+package cmd
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// newDevSysProcAttr is a no-op on Windows, which has no process-group
+// equivalent usable from os/exec.
+func newDevSysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{}
+}
+
+// terminateDevProcess kills cmd's process directly: Windows has no SIGTERM
+// to give it a chance to shut down gracefully first.
+func terminateDevProcess(cmd *exec.Cmd, hard bool) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/messaging"
+)
+
+// cliMessaging implements the `luna messaging` command group.
+type cliMessaging struct {
+	cmd       *cobra.Command
+	getConfig configGetter
+	getClient clientGetter
+}
+
+// NewCLIMessaging constructs the `luna messaging` command group, wiring its
+// subcommands to getConfig/getClient instead of package-level globals.
+func NewCLIMessaging(getConfig configGetter, getClient clientGetter) *cliMessaging {
+	c := &cliMessaging{getConfig: getConfig, getClient: getClient}
+
+	cmd := &cobra.Command{
+		Use:   "messaging",
+		Short: "Manage messaging channels",
+		Long:  `Manage SMS, WhatsApp, and USSD messaging channels on the Luna platform.`,
+	}
+
+	pairCmd := &cobra.Command{
+		Use:   "whatsapp-pair [phone-e164]",
+		Short: "Request a WhatsApp pairing code",
+		Long:  `Request an 8-character WhatsApp pairing code to link a sender phone number without scanning a QR code.`,
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.runWhatsAppPair,
+	}
+	pairCmd.Flags().String("provider", string(messaging.WhatsAppCloudAPI), "WhatsApp provider")
+	pairCmd.Flags().String("push-name", "", "Display name shown on the paired handset")
+
+	cmd.AddCommand(pairCmd)
+	c.cmd = cmd
+	return c
+}
+
+// Command returns the cobra command for this resource.
+func (c *cliMessaging) Command() *cobra.Command {
+	return c.cmd
+}
+
+func (c *cliMessaging) runWhatsAppPair(cmd *cobra.Command, args []string) error {
+	phone := args[0]
+	provider, _ := cmd.Flags().GetString("provider")
+	pushName, _ := cmd.Flags().GetString("push-name")
+
+	wa := messaging.NewWhatsApp(nil, messaging.WhatsAppConfig{
+		Provider: messaging.WhatsAppProvider(provider),
+	})
+
+	pairing, err := wa.RequestPairingCode(cmd.Context(), phone, &messaging.PairingOptions{
+		PushName: pushName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to request pairing code: %w", err)
+	}
+
+	fmt.Printf("Pairing code: %s (expires %s)\n", pairing.Code, pairing.ExpiresAt.Format("15:04:05"))
+	fmt.Println("Enter this code on the handset to link it as a WhatsApp sender.")
+	return nil
+}
@@ -0,0 +1,204 @@
+// Package output renders CLI command results in the user's chosen format.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/itchyny/gojq"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// Format is a supported rendering format.
+type Format string
+
+const (
+	FormatTable Format = "table"
+	FormatJSON  Format = "json"
+	FormatJSONL Format = "jsonl"
+	FormatYAML  Format = "yaml"
+)
+
+// Column describes one table column: Key is matched against --columns,
+// Header is the printed title, and Value renders one item's cell.
+type Column[T any] struct {
+	Key    string
+	Header string
+	Value  func(item T) string
+}
+
+// RenderOptions controls how Render formats a result.
+type RenderOptions[T any] struct {
+	Format Format
+	// Template is a text/template string, with sprig functions available,
+	// applied when set and overriding Format entirely (--template).
+	Template string
+	// JQ is a jq expression (github.com/itchyny/gojq) applied to the
+	// JSON-encoded items before output, overriding Format and Template
+	// (--jq).
+	JQ string
+	// Columns are the available table columns, in default display order.
+	Columns []Column[T]
+	// SelectColumns restricts Columns to these Keys, in the given order
+	// (--columns id,name,email). Empty means "use all of Columns".
+	SelectColumns []string
+}
+
+// ParseColumns splits a --columns flag value ("id,name,email") into column
+// keys, ignoring empty entries from stray commas or whitespace.
+func ParseColumns(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var keys []string
+	for _, part := range strings.Split(s, ",") {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			keys = append(keys, trimmed)
+		}
+	}
+	return keys
+}
+
+// Render writes items to w using opts. The type parameter lets one
+// implementation serve any resource without the caller first marshalling
+// to JSON and unmarshalling into map[string]interface{}.
+func Render[T any](w io.Writer, items []T, opts RenderOptions[T]) error {
+	if opts.JQ != "" {
+		return renderJQ(w, items, opts.JQ)
+	}
+	if opts.Template != "" {
+		return renderTemplate(w, items, opts.Template)
+	}
+
+	switch opts.Format {
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	case FormatJSONL:
+		return renderJSONL(w, items)
+	case FormatYAML:
+		return yaml.NewEncoder(w).Encode(items)
+	default:
+		return renderTable(w, items, selectColumns(opts.Columns, opts.SelectColumns))
+	}
+}
+
+// renderJSONL writes one JSON object per line, e.g. for piping `luna users
+// list` through jq or fx.
+func renderJSONL[T any](w io.Writer, items []T) error {
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderJQ compiles expr with gojq and streams its results, one JSON value
+// per line, against items.
+func renderJQ[T any](w io.Writer, items []T, expr string) error {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return fmt.Errorf("invalid --jq expression: %w", err)
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	iter := query.Run(input)
+	for {
+		v, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+		if err, ok := v.(error); ok {
+			return fmt.Errorf("jq: %w", err)
+		}
+		if err := enc.Encode(v); err != nil {
+			return err
+		}
+	}
+}
+
+// renderTemplate executes tmplText (with sprig functions available) against
+// items. A single-item result is unwrapped so `{{.Name}}` works directly on
+// `get`-style commands, while `list`-style commands range over the slice.
+func renderTemplate[T any](w io.Writer, items []T, tmplText string) error {
+	tmpl, err := template.New("output").Funcs(sprig.TxtFuncMap()).Parse(tmplText)
+	if err != nil {
+		return fmt.Errorf("invalid --template: %w", err)
+	}
+
+	var data interface{} = items
+	if len(items) == 1 {
+		data = items[0]
+	}
+
+	return tmpl.Execute(w, data)
+}
+
+func renderTable[T any](w io.Writer, items []T, columns []Column[T]) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = c.Header
+	}
+	fmt.Fprintln(tw, joinTab(headers))
+
+	for _, item := range items {
+		values := make([]string, len(columns))
+		for i, c := range columns {
+			values[i] = c.Value(item)
+		}
+		fmt.Fprintln(tw, joinTab(values))
+	}
+
+	return tw.Flush()
+}
+
+// selectColumns filters all down to the columns named in keys, in the order
+// given. An empty keys returns all unchanged.
+func selectColumns[T any](all []Column[T], keys []string) []Column[T] {
+	if len(keys) == 0 {
+		return all
+	}
+
+	byKey := make(map[string]Column[T], len(all))
+	for _, c := range all {
+		byKey[c.Key] = c
+	}
+
+	selected := make([]Column[T], 0, len(keys))
+	for _, k := range keys {
+		if c, ok := byKey[strings.TrimSpace(k)]; ok {
+			selected = append(selected, c)
+		}
+	}
+	return selected
+}
+
+func joinTab(values []string) string {
+	out := ""
+	for i, v := range values {
+		if i > 0 {
+			out += "\t"
+		}
+		out += v
+	}
+	return out
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is the filename prefix plugin discovery looks for, mirroring
+// kubectl/gh: an executable named luna-foo becomes `luna foo`.
+const pluginPrefix = "luna-"
+
+// pluginDir returns the directory plugin executables are discovered in:
+// ~/.luna/plugins.
+func pluginDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".luna", "plugins")
+}
+
+// discoverPlugins lists the executable luna-<name> entries in dir, keyed
+// by the <name> they'll be registered as. A missing dir is not an error —
+// it just means no plugins are installed.
+func discoverPlugins(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	plugins := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), pluginPrefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0111 == 0 {
+			continue
+		}
+		name := strings.TrimPrefix(entry.Name(), pluginPrefix)
+		if name == "" {
+			continue
+		}
+		plugins[name] = filepath.Join(dir, entry.Name())
+	}
+	return plugins, nil
+}
+
+// newPluginCommand builds the synthetic `luna <name>` command that execs
+// path, passing every flag/arg straight through (DisableFlagParsing means
+// cobra doesn't try to interpret them) and injecting the resolved profile
+// via environment variables, the same way kubectl/gh plugins receive
+// their invoking context.
+func newPluginCommand(name, path string, getConfig configGetter) *cobra.Command {
+	return &cobra.Command{
+		Use:                name,
+		Short:              fmt.Sprintf("Plugin command (%s)", path),
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := getConfig()
+
+			pluginCmd := exec.Command(path, args...)
+			pluginCmd.Stdin = os.Stdin
+			pluginCmd.Stdout = os.Stdout
+			pluginCmd.Stderr = os.Stderr
+			pluginCmd.Env = append(os.Environ(),
+				"LUNA_API_KEY="+resolveAPIKey(cfg),
+				"LUNA_BASE_URL="+resolveBaseURL(cfg),
+				"LUNA_OUTPUT="+outputFmt,
+				"LUNA_PROFILE="+cfgProfile,
+			)
+
+			if err := pluginCmd.Run(); err != nil {
+				// Plugin exit codes are propagated verbatim rather than
+				// wrapped, so scripts calling `luna <plugin>` see the
+				// plugin's own exit status.
+				var exitErr *exec.ExitError
+				if errors.As(err, &exitErr) {
+					os.Exit(exitErr.ExitCode())
+				}
+				return fmt.Errorf("plugin %s: %w", name, err)
+			}
+			return nil
+		},
+	}
+}
+
+// registerPlugins discovers executables in pluginDir() and adds each as a
+// subcommand of rootCmd. A plugin whose name collides with a built-in
+// command is skipped so it can never shadow core functionality.
+func registerPlugins(rootCmd *cobra.Command, getConfig configGetter) {
+	plugins, err := discoverPlugins(pluginDir())
+	if err != nil || len(plugins) == 0 {
+		return
+	}
+
+	for name, path := range plugins {
+		if cmd, _, err := rootCmd.Find([]string{name}); err == nil && cmd != rootCmd {
+			continue
+		}
+		rootCmd.AddCommand(newPluginCommand(name, path, getConfig))
+	}
+}
@@ -1,165 +1,180 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v3"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna"
+	"github.com/eclipse-softworks/luna-sdk/cli/cmd/output"
 )
 
-var projectsCmd = &cobra.Command{
-	Use:   "projects",
-	Short: "Manage projects",
-	Long:  `Manage projects on the Luna platform.`,
+var projectsColumns = []output.Column[luna.Project]{
+	{Key: "id", Header: "ID", Value: func(p luna.Project) string { return p.ID }},
+	{Key: "name", Header: "NAME", Value: func(p luna.Project) string { return p.Name }},
+	{Key: "owner_id", Header: "OWNER", Value: func(p luna.Project) string { return p.OwnerID }},
+	{Key: "created_at", Header: "CREATED", Value: func(p luna.Project) string { return p.CreatedAt.String() }},
 }
 
-var projectsListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all projects",
-	Long:  `List all projects with pagination support.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		limit, _ := cmd.Flags().GetInt("limit")
-		cursor, _ := cmd.Flags().GetString("cursor")
-		_ = limit
-		_ = cursor
-
-		apiKey := getAPIKey()
-		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'luna auth login' or set LUNA_API_KEY")
-		}
-
-		// Mock response for demonstration
-		projects := []map[string]interface{}{
-			{"id": "prj_abc123", "name": "Project Alpha", "owner_id": "usr_xyz", "created_at": "2024-01-15"},
-			{"id": "prj_def456", "name": "Project Beta", "owner_id": "usr_xyz", "created_at": "2024-01-16"},
-		}
-
-		return outputProjects(projects)
-	},
+// cliProjects implements the `luna projects` command group.
+type cliProjects struct {
+	cmd       *cobra.Command
+	getConfig configGetter
+	getClient clientGetter
 }
 
-var projectsGetCmd = &cobra.Command{
-	Use:   "get [project-id]",
-	Short: "Get a project by ID",
-	Long:  `Retrieve detailed information about a specific project.`,
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		projectID := args[0]
-
-		apiKey := getAPIKey()
-		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'luna auth login' or set LUNA_API_KEY")
-		}
-
-		// Mock response for demonstration
-		project := map[string]interface{}{
-			"id":          projectID,
-			"name":        "Project Alpha",
-			"description": "A sample project",
-			"owner_id":    "usr_xyz",
-			"created_at":  "2024-01-15",
-			"updated_at":  "2024-01-15",
-		}
-
-		return outputProject(project)
-	},
+// NewCLIProjects constructs the `luna projects` command group, wiring its
+// subcommands to getConfig/getClient instead of package-level globals.
+func NewCLIProjects(getConfig configGetter, getClient clientGetter) *cliProjects {
+	c := &cliProjects{getConfig: getConfig, getClient: getClient}
+
+	cmd := &cobra.Command{
+		Use:   "projects",
+		Short: "Manage projects",
+		Long:  `Manage projects on the Luna platform.`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all projects",
+		Long:  `List all projects with pagination support.`,
+		RunE:  c.runList,
+	}
+	listCmd.Flags().Int("limit", 20, "Maximum number of results")
+	listCmd.Flags().String("cursor", "", "Pagination cursor")
+	listCmd.Flags().String("template", "", "Go-template (with sprig functions) for rendering results")
+	listCmd.Flags().String("jq", "", "jq expression applied to the results")
+	listCmd.Flags().String("columns", "", "Comma-separated table columns to show, e.g. id,name,owner_id")
+
+	getCmd := &cobra.Command{
+		Use:   "get [project-id]",
+		Short: "Get a project by ID",
+		Long:  `Retrieve detailed information about a specific project.`,
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.runGet,
+	}
+	getCmd.Flags().String("template", "", "Go-template (with sprig functions) for rendering results")
+	getCmd.Flags().String("jq", "", "jq expression applied to the result")
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new project",
+		Long:  `Create a new project with the specified details.`,
+		RunE:  c.runCreate,
+	}
+	createCmd.Flags().String("name", "", "Project name (required)")
+	createCmd.Flags().String("description", "", "Project description")
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete [project-id]",
+		Short: "Delete a project",
+		Long:  `Delete a project by its ID.`,
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.runDelete,
+	}
+
+	cmd.AddCommand(listCmd, getCmd, createCmd, deleteCmd)
+	c.cmd = cmd
+	return c
 }
 
-var projectsCreateCmd = &cobra.Command{
-	Use:   "create",
-	Short: "Create a new project",
-	Long:  `Create a new project with the specified details.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		name, _ := cmd.Flags().GetString("name")
-		description, _ := cmd.Flags().GetString("description")
-
-		if name == "" {
-			return fmt.Errorf("--name is required")
-		}
-
-		apiKey := getAPIKey()
-		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'luna auth login' or set LUNA_API_KEY")
-		}
-
-		fmt.Printf("✓ Created project: %s\n", name)
-		if description != "" {
-			fmt.Printf("  Description: %s\n", description)
-		}
-		return nil
-	},
+// Command returns the cobra command for this resource.
+func (c *cliProjects) Command() *cobra.Command {
+	return c.cmd
 }
 
-var projectsDeleteCmd = &cobra.Command{
-	Use:   "delete [project-id]",
-	Short: "Delete a project",
-	Long:  `Delete a project by its ID.`,
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		projectID := args[0]
-
-		apiKey := getAPIKey()
-		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'luna auth login' or set LUNA_API_KEY")
-		}
-
-		fmt.Printf("✓ Deleted project: %s\n", projectID)
-		return nil
-	},
+func (c *cliProjects) runList(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	cursor, _ := cmd.Flags().GetString("cursor")
+	tmpl, _ := cmd.Flags().GetString("template")
+	jq, _ := cmd.Flags().GetString("jq")
+	columns, _ := cmd.Flags().GetString("columns")
+
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+
+	projects, err := client.Projects().List(cmd.Context(), &luna.ListParams{
+		Limit:  limit,
+		Cursor: cursor,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list projects: %w", err)
+	}
+
+	return output.Render(os.Stdout, projects.Data, output.RenderOptions[luna.Project]{
+		Format:        output.Format(outputFmt),
+		Template:      tmpl,
+		JQ:            jq,
+		Columns:       projectsColumns,
+		SelectColumns: output.ParseColumns(columns),
+	})
 }
 
-func init() {
-	projectsListCmd.Flags().Int("limit", 20, "Maximum number of results")
-	projectsListCmd.Flags().String("cursor", "", "Pagination cursor")
+func (c *cliProjects) runGet(cmd *cobra.Command, args []string) error {
+	projectID := args[0]
+	tmpl, _ := cmd.Flags().GetString("template")
+	jq, _ := cmd.Flags().GetString("jq")
 
-	projectsCreateCmd.Flags().String("name", "", "Project name (required)")
-	projectsCreateCmd.Flags().String("description", "", "Project description")
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
 
-	projectsCmd.AddCommand(projectsListCmd)
-	projectsCmd.AddCommand(projectsGetCmd)
-	projectsCmd.AddCommand(projectsCreateCmd)
-	projectsCmd.AddCommand(projectsDeleteCmd)
+	project, err := client.Projects().Get(cmd.Context(), projectID)
+	if err != nil {
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+
+	return output.Render(os.Stdout, []luna.Project{*project}, output.RenderOptions[luna.Project]{
+		Format:   output.Format(outputFmt),
+		Template: tmpl,
+		JQ:       jq,
+		Columns:  projectsColumns,
+	})
 }
 
-func outputProjects(projects []map[string]interface{}) error {
-	switch outputFmt {
-	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(projects)
-	case "yaml":
-		return yaml.NewEncoder(os.Stdout).Encode(projects)
-	default: // table
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "ID\tNAME\tOWNER\tCREATED")
-		for _, p := range projects {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-				p["id"], p["name"], p["owner_id"], p["created_at"])
-		}
-		return w.Flush()
+func (c *cliProjects) runCreate(cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("name")
+	description, _ := cmd.Flags().GetString("description")
+
+	if name == "" {
+		return fmt.Errorf("--name is required")
+	}
+
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+
+	create := luna.ProjectCreate{Name: name}
+	if description != "" {
+		create.Description = &description
 	}
+
+	project, err := client.Projects().Create(cmd.Context(), create)
+	if err != nil {
+		return fmt.Errorf("failed to create project: %w", err)
+	}
+
+	fmt.Printf("✓ Created project: %s (%s)\n", project.Name, project.ID)
+	return nil
 }
 
-func outputProject(project map[string]interface{}) error {
-	switch outputFmt {
-	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(project)
-	case "yaml":
-		return yaml.NewEncoder(os.Stdout).Encode(project)
-	default: // table
-		fmt.Printf("ID:          %s\n", project["id"])
-		fmt.Printf("Name:        %s\n", project["name"])
-		if desc, ok := project["description"]; ok && desc != "" {
-			fmt.Printf("Description: %s\n", desc)
-		}
-		fmt.Printf("Owner:       %s\n", project["owner_id"])
-		fmt.Printf("Created:     %s\n", project["created_at"])
-		fmt.Printf("Updated:     %s\n", project["updated_at"])
-		return nil
+func (c *cliProjects) runDelete(cmd *cobra.Command, args []string) error {
+	projectID := args[0]
+
+	client, err := c.getClient()
+	if err != nil {
+		return err
 	}
+
+	if err := client.Projects().Delete(cmd.Context(), projectID); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+
+	fmt.Printf("✓ Deleted project: %s\n", projectID)
+	return nil
 }
@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna"
 )
 
 var (
@@ -18,6 +20,18 @@ var (
 	debug      bool
 )
 
+// configGetter returns the effective CLI configuration, resolving the
+// profile named by --profile. Resource commands take one as a constructor
+// dependency instead of reaching for a global LoadConfig()/getAPIKey(),
+// which makes them testable with a stub config.
+type configGetter func() *Config
+
+// clientGetter constructs a Luna SDK client authenticated from whatever
+// configGetter resolves. Resource commands take one as a constructor
+// dependency instead of calling luna.NewClient directly, so tests can
+// inject an in-memory client stub.
+type clientGetter func() (*luna.Client, error)
+
 // rootCmd represents the base command
 var rootCmd = &cobra.Command{
 	Use:   "luna",
@@ -43,37 +57,94 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgProfile, "profile", "default", "Configuration profile to use")
 	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "API key (overrides config)")
-	rootCmd.PersistentFlags().StringVarP(&outputFmt, "format", "f", "table", "Output format: table, json, yaml")
+	rootCmd.PersistentFlags().StringVarP(&outputFmt, "format", "f", "table", "Output format: table, json, jsonl, yaml")
 	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug mode")
 
-	// Add subcommands
-	rootCmd.AddCommand(authCmd)
-	rootCmd.AddCommand(usersCmd)
-	rootCmd.AddCommand(projectsCmd)
+	getConfig := newConfigGetter()
+	getClient := newClientGetter(getConfig)
+
+	rootCmd.AddCommand(NewCLIAuth(getConfig, getClient).Command())
+	rootCmd.AddCommand(NewCLIUsers(getConfig, getClient).Command())
+	rootCmd.AddCommand(NewCLIProjects(getConfig, getClient).Command())
+	rootCmd.AddCommand(NewCLIMessaging(getConfig, getClient).Command())
+	rootCmd.AddCommand(NewCLIServe(getConfig, getClient).Command())
+	rootCmd.AddCommand(NewCLITestutil(getConfig, getClient).Command())
 	rootCmd.AddCommand(configCmd)
+
+	registerPlugins(rootCmd, getConfig)
 }
 
-// getAPIKey returns the API key from flag or config
-func getAPIKey() string {
+// newConfigGetter returns a configGetter that lazily loads and caches the
+// config file for the lifetime of one CLI invocation.
+func newConfigGetter() configGetter {
+	var loaded *Config
+	return func() *Config {
+		if loaded != nil {
+			return loaded
+		}
+
+		cfg, err := LoadConfig()
+		if err != nil {
+			cfg = &Config{
+				DefaultProfile: "default",
+				Profiles:       make(map[string]Profile),
+				Settings: Settings{
+					OutputFormat: "table",
+					Color:        true,
+				},
+			}
+		}
+		loaded = cfg
+		return loaded
+	}
+}
+
+// newClientGetter returns a clientGetter that resolves credentials via
+// getConfig (flag > env var > config profile) and builds a Luna SDK client.
+func newClientGetter(getConfig configGetter) clientGetter {
+	return func() (*luna.Client, error) {
+		key := resolveAPIKey(getConfig())
+		if key == "" {
+			return nil, fmt.Errorf("not authenticated. Run 'luna auth login' or set LUNA_API_KEY")
+		}
+		return luna.NewClient(luna.WithAPIKey(key))
+	}
+}
+
+// resolveAPIKey returns the API key from flag, environment variable, or the
+// active profile in cfg, in that order of precedence.
+func resolveAPIKey(cfg *Config) string {
 	if apiKey != "" {
 		return apiKey
 	}
-
-	// Try environment variable
 	if envKey := os.Getenv("LUNA_API_KEY"); envKey != "" {
 		return envKey
 	}
-
-	// Try config file
-	cfg, err := LoadConfig()
-	if err == nil {
+	if cfg != nil {
 		if profile, ok := cfg.Profiles[cfgProfile]; ok {
 			return profile.APIKey
 		}
 	}
+	return ""
+}
 
+// getAPIKey returns the API key from flag or config. Retained for commands
+// (config, doctor) that only need a best-effort presence check rather than
+// a full client.
+func getAPIKey() string {
+	return resolveAPIKey(newConfigGetter()())
+}
+
+// resolveBaseURL returns the base URL from the active profile in cfg, if
+// any was configured.
+func resolveBaseURL(cfg *Config) string {
+	if cfg != nil {
+		if profile, ok := cfg.Profiles[cfgProfile]; ok {
+			return profile.BaseURL
+		}
+	}
 	return ""
 }
 
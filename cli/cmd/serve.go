@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/provisioning"
+)
+
+// cliServe implements the `luna serve` command group.
+type cliServe struct {
+	cmd       *cobra.Command
+	getConfig configGetter
+	getClient clientGetter
+}
+
+// NewCLIServe constructs the `luna serve` command group, wiring its
+// subcommands to getConfig/getClient instead of package-level globals.
+func NewCLIServe(getConfig configGetter, getClient clientGetter) *cliServe {
+	c := &cliServe{getConfig: getConfig, getClient: getClient}
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run a long-lived Luna server process",
+		Long:  `Run a long-lived Luna server process, such as the headless provisioning API.`,
+	}
+
+	provisioningCmd := &cobra.Command{
+		Use:   "provisioning",
+		Short: "Run the provisioning HTTP API",
+		Long: `Run the provisioning HTTP API, which lets operators enroll bots/services
+without a browser on the target host. It is an alternative to
+'luna auth login' for headless environments and is protected by the
+shared secret in LUNA_PROVISIONING_SECRET (or --secret).`,
+		RunE: c.runProvisioning,
+	}
+	provisioningCmd.Flags().String("addr", "127.0.0.1:8787", "Address to listen on")
+	provisioningCmd.Flags().String("prefix", "", "URL prefix to mount the API under (default /luna/provision/v1)")
+	provisioningCmd.Flags().String("secret", "", "Shared secret bearer token (default: $LUNA_PROVISIONING_SECRET)")
+
+	cmd.AddCommand(provisioningCmd)
+	c.cmd = cmd
+	return c
+}
+
+// Command returns the cobra command for this resource.
+func (c *cliServe) Command() *cobra.Command {
+	return c.cmd
+}
+
+func (c *cliServe) runProvisioning(cmd *cobra.Command, args []string) error {
+	addr, _ := cmd.Flags().GetString("addr")
+	prefix, _ := cmd.Flags().GetString("prefix")
+	secret, _ := cmd.Flags().GetString("secret")
+
+	if secret == "" {
+		secret = os.Getenv("LUNA_PROVISIONING_SECRET")
+	}
+	if prefix == "" {
+		prefix = os.Getenv("LUNA_PROVISIONING_PREFIX")
+	}
+	if secret == "" {
+		return fmt.Errorf("a provisioning secret is required: pass --secret or set LUNA_PROVISIONING_SECRET")
+	}
+
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+
+	handler := provisioning.NewHandler(client, provisioning.Config{
+		Prefix: prefix,
+		Secret: secret,
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle(handler.MountPrefix()+"/", handler)
+
+	fmt.Printf("Provisioning API listening on http://%s%s\n", addr, handler.MountPrefix())
+	return http.ListenAndServe(addr, mux)
+}
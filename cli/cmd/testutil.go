@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil"
+)
+
+// cliTestutil implements the `luna testutil` command group: developer
+// utilities for working with recorded HTTP cassettes, not part of the
+// resource surface exposed to end users.
+type cliTestutil struct {
+	cmd       *cobra.Command
+	getConfig configGetter
+	getClient clientGetter
+}
+
+// NewCLITestutil constructs the `luna testutil` command group, wiring its
+// subcommands to getConfig/getClient instead of package-level globals.
+func NewCLITestutil(getConfig configGetter, getClient clientGetter) *cliTestutil {
+	c := &cliTestutil{getConfig: getConfig, getClient: getClient}
+
+	cmd := &cobra.Command{
+		Use:    "testutil",
+		Short:  "Developer utilities for SDK test fixtures",
+		Long:   `Commands for working with the recorded HTTP cassettes used by the SDK's offline tests.`,
+		Hidden: true,
+	}
+
+	diffCmd := &cobra.Command{
+		Use:   "diff <cassette> <live-base-url>",
+		Short: "Replay a cassette's requests against a live base URL and report drift",
+		Long: `Diff replays every request recorded in <cassette> against <live-base-url>,
+using the currently configured API key, and reports any entry whose live
+status or body no longer matches what was recorded. It's meant for
+confirming a committed cassette still reflects the real API before relying
+on it in offline tests.`,
+		Args: cobra.ExactArgs(2),
+		RunE: c.runDiff,
+	}
+
+	cmd.AddCommand(diffCmd)
+	c.cmd = cmd
+	return c
+}
+
+// Command returns the cobra command for this resource.
+func (c *cliTestutil) Command() *cobra.Command {
+	return c.cmd
+}
+
+func (c *cliTestutil) runDiff(cmd *cobra.Command, args []string) error {
+	cassettePath, baseURL := args[0], args[1]
+
+	cassette, err := testutil.LoadCassette(cassettePath)
+	if err != nil {
+		return fmt.Errorf("failed to load cassette: %w", err)
+	}
+
+	key := resolveAPIKey(c.getConfig())
+	if key == "" {
+		return fmt.Errorf("not authenticated. Run 'luna auth login' or set LUNA_API_KEY")
+	}
+
+	drifted := 0
+	for _, entry := range cassette.Entries {
+		req, err := http.NewRequest(entry.Request.Method, baseURL+entry.Request.Path, nil)
+		if err != nil {
+			return fmt.Errorf("failed to build request for %s %s: %w", entry.Request.Method, entry.Request.Path, err)
+		}
+		req.URL.RawQuery = entry.Request.Query
+		req.Header.Set("Authorization", "Bearer "+key)
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			fmt.Printf("✗ %s %s: request failed: %v\n", entry.Request.Method, entry.Request.Path, err)
+			drifted++
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != entry.Response.Status {
+			fmt.Printf("✗ %s %s: status = %d, cassette has %d\n", entry.Request.Method, entry.Request.Path, resp.StatusCode, entry.Response.Status)
+			drifted++
+			continue
+		}
+		fmt.Printf("✓ %s %s\n", entry.Request.Method, entry.Request.Path)
+	}
+
+	if drifted > 0 {
+		return fmt.Errorf("%d of %d cassette entries drifted from the live API", drifted, len(cassette.Entries))
+	}
+	fmt.Printf("All %d cassette entries match the live API.\n", len(cassette.Entries))
+	return nil
+}
@@ -1,202 +1,178 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
-	yaml "gopkg.in/yaml.v3"
 
 	"github.com/eclipse-softworks/luna-sdk-go/luna"
+	"github.com/eclipse-softworks/luna-sdk/cli/cmd/output"
 )
 
-var usersCmd = &cobra.Command{
-	Use:   "users",
-	Short: "Manage users",
-	Long:  `Manage users on the Luna platform.`,
+var usersColumns = []output.Column[luna.User]{
+	{Key: "id", Header: "ID", Value: func(u luna.User) string { return u.ID }},
+	{Key: "name", Header: "NAME", Value: func(u luna.User) string { return u.Name }},
+	{Key: "email", Header: "EMAIL", Value: func(u luna.User) string { return u.Email }},
+	{Key: "created_at", Header: "CREATED", Value: func(u luna.User) string { return u.CreatedAt.String() }},
 }
 
-var usersListCmd = &cobra.Command{
-	Use:   "list",
-	Short: "List all users",
-	Long:  `List all users with pagination support.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		limit, _ := cmd.Flags().GetInt("limit")
-		cursor, _ := cmd.Flags().GetString("cursor")
-		_ = limit
-		_ = cursor
-
-		apiKey := getAPIKey()
-		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'luna auth login' or set LUNA_API_KEY")
-		}
-
-		client, err := luna.NewClient(luna.WithAPIKey(apiKey))
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
-		}
-
-		users, err := client.Users().List(cmd.Context(), &luna.ListParams{
-			Limit:  limit,
-			Cursor: cursor,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to list users: %w", err)
-		}
-
-		// Convert to map for output compatibility (or update output function)
-		// For now we map strictly to the output format expected
-		var output []map[string]interface{}
-		// Re-marshal to map for generic output handling
-		data, _ := json.Marshal(users.Data)
-		json.Unmarshal(data, &output)
-
-		return outputUsers(output)
-	},
+// cliUsers implements the `luna users` command group.
+type cliUsers struct {
+	cmd       *cobra.Command
+	getConfig configGetter
+	getClient clientGetter
 }
 
-var usersGetCmd = &cobra.Command{
-	Use:   "get [user-id]",
-	Short: "Get a user by ID",
-	Long:  `Retrieve detailed information about a specific user.`,
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		userID := args[0]
-
-		apiKey := getAPIKey()
-		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'luna auth login' or set LUNA_API_KEY")
-		}
-
-		client, err := luna.NewClient(luna.WithAPIKey(apiKey))
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
-		}
-
-		user, err := client.Users().Get(cmd.Context(), userID)
-		if err != nil {
-			return fmt.Errorf("failed to get user: %w", err)
-		}
-
-		var output map[string]interface{}
-		data, _ := json.Marshal(user)
-		json.Unmarshal(data, &output)
-
-		return outputUser(output)
-	},
+// NewCLIUsers constructs the `luna users` command group, wiring its
+// subcommands to getConfig/getClient instead of package-level globals.
+func NewCLIUsers(getConfig configGetter, getClient clientGetter) *cliUsers {
+	c := &cliUsers{getConfig: getConfig, getClient: getClient}
+
+	cmd := &cobra.Command{
+		Use:   "users",
+		Short: "Manage users",
+		Long:  `Manage users on the Luna platform.`,
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List all users",
+		Long:  `List all users with pagination support.`,
+		RunE:  c.runList,
+	}
+	listCmd.Flags().Int("limit", 20, "Maximum number of results")
+	listCmd.Flags().String("cursor", "", "Pagination cursor")
+	listCmd.Flags().String("template", "", "Go-template (with sprig functions) for rendering results")
+	listCmd.Flags().String("jq", "", "jq expression applied to the results")
+	listCmd.Flags().String("columns", "", "Comma-separated table columns to show, e.g. id,name,email")
+
+	getCmd := &cobra.Command{
+		Use:   "get [user-id]",
+		Short: "Get a user by ID",
+		Long:  `Retrieve detailed information about a specific user.`,
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.runGet,
+	}
+	getCmd.Flags().String("template", "", "Go-template (with sprig functions) for rendering results")
+	getCmd.Flags().String("jq", "", "jq expression applied to the result")
+
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new user",
+		Long:  `Create a new user with the specified details.`,
+		RunE:  c.runCreate,
+	}
+	createCmd.Flags().String("name", "", "User name (required)")
+	createCmd.Flags().String("email", "", "User email (required)")
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete [user-id]",
+		Short: "Delete a user",
+		Long:  `Delete a user by their ID.`,
+		Args:  cobra.ExactArgs(1),
+		RunE:  c.runDelete,
+	}
+
+	cmd.AddCommand(listCmd, getCmd, createCmd, deleteCmd)
+	c.cmd = cmd
+	return c
 }
 
-var usersCreateCmd = &cobra.Command{
-	Use:   "create",
-	Short: "Create a new user",
-	Long:  `Create a new user with the specified details.`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		name, _ := cmd.Flags().GetString("name")
-		email, _ := cmd.Flags().GetString("email")
-
-		if name == "" || email == "" {
-			return fmt.Errorf("--name and --email are required")
-		}
-
-		apiKey := getAPIKey()
-		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'luna auth login' or set LUNA_API_KEY")
-		}
-
-		client, err := luna.NewClient(luna.WithAPIKey(apiKey))
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
-		}
-
-		user, err := client.Users().Create(cmd.Context(), luna.UserCreate{
-			Name:  name,
-			Email: email,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to create user: %w", err)
-		}
-
-		fmt.Printf("✓ Created user: %s <%s> (%s)\n", user.Name, user.Email, user.ID)
-		return nil
-	},
+// Command returns the cobra command for this resource.
+func (c *cliUsers) Command() *cobra.Command {
+	return c.cmd
 }
 
-var usersDeleteCmd = &cobra.Command{
-	Use:   "delete [user-id]",
-	Short: "Delete a user",
-	Long:  `Delete a user by their ID.`,
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		userID := args[0]
-
-		apiKey := getAPIKey()
-		if apiKey == "" {
-			return fmt.Errorf("not authenticated. Run 'luna auth login' or set LUNA_API_KEY")
-		}
-
-		client, err := luna.NewClient(luna.WithAPIKey(apiKey))
-		if err != nil {
-			return fmt.Errorf("failed to create client: %w", err)
-		}
-
-		err = client.Users().Delete(cmd.Context(), userID)
-		if err != nil {
-			return fmt.Errorf("failed to delete user: %w", err)
-		}
-
-		fmt.Printf("✓ Deleted user: %s\n", userID)
-		return nil
-	},
+func (c *cliUsers) runList(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+	cursor, _ := cmd.Flags().GetString("cursor")
+	tmpl, _ := cmd.Flags().GetString("template")
+	jq, _ := cmd.Flags().GetString("jq")
+	columns, _ := cmd.Flags().GetString("columns")
+
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+
+	users, err := client.Users().List(cmd.Context(), &luna.ListParams{
+		Limit:  limit,
+		Cursor: cursor,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return output.Render(os.Stdout, users.Data, output.RenderOptions[luna.User]{
+		Format:        output.Format(outputFmt),
+		Template:      tmpl,
+		JQ:            jq,
+		Columns:       usersColumns,
+		SelectColumns: output.ParseColumns(columns),
+	})
 }
 
-func init() {
-	usersListCmd.Flags().Int("limit", 20, "Maximum number of results")
-	usersListCmd.Flags().String("cursor", "", "Pagination cursor")
+func (c *cliUsers) runGet(cmd *cobra.Command, args []string) error {
+	userID := args[0]
+	tmpl, _ := cmd.Flags().GetString("template")
+	jq, _ := cmd.Flags().GetString("jq")
 
-	usersCreateCmd.Flags().String("name", "", "User name (required)")
-	usersCreateCmd.Flags().String("email", "", "User email (required)")
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+
+	user, err := client.Users().Get(cmd.Context(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
 
-	usersCmd.AddCommand(usersListCmd)
-	usersCmd.AddCommand(usersGetCmd)
-	usersCmd.AddCommand(usersCreateCmd)
-	usersCmd.AddCommand(usersDeleteCmd)
+	return output.Render(os.Stdout, []luna.User{*user}, output.RenderOptions[luna.User]{
+		Format:   output.Format(outputFmt),
+		Template: tmpl,
+		JQ:       jq,
+		Columns:  usersColumns,
+	})
 }
 
-func outputUsers(users []map[string]interface{}) error {
-	switch outputFmt {
-	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(users)
-	case "yaml":
-		return yaml.NewEncoder(os.Stdout).Encode(users)
-	default: // table
-		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintln(w, "ID\tNAME\tEMAIL\tCREATED")
-		for _, u := range users {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-				u["id"], u["name"], u["email"], u["created_at"])
-		}
-		return w.Flush()
+func (c *cliUsers) runCreate(cmd *cobra.Command, args []string) error {
+	name, _ := cmd.Flags().GetString("name")
+	email, _ := cmd.Flags().GetString("email")
+
+	if name == "" || email == "" {
+		return fmt.Errorf("--name and --email are required")
 	}
+
+	client, err := c.getClient()
+	if err != nil {
+		return err
+	}
+
+	user, err := client.Users().Create(cmd.Context(), luna.UserCreate{
+		Name:  name,
+		Email: email,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create user: %w", err)
+	}
+
+	fmt.Printf("✓ Created user: %s <%s> (%s)\n", user.Name, user.Email, user.ID)
+	return nil
 }
 
-func outputUser(user map[string]interface{}) error {
-	switch outputFmt {
-	case "json":
-		enc := json.NewEncoder(os.Stdout)
-		enc.SetIndent("", "  ")
-		return enc.Encode(user)
-	case "yaml":
-		return yaml.NewEncoder(os.Stdout).Encode(user)
-	default: // table
-		fmt.Printf("ID:         %s\n", user["id"])
-		fmt.Printf("Name:       %s\n", user["name"])
-		fmt.Printf("Email:      %s\n", user["email"])
-		fmt.Printf("Created:    %s\n", user["created_at"])
-		fmt.Printf("Updated:    %s\n", user["updated_at"])
-		return nil
+func (c *cliUsers) runDelete(cmd *cobra.Command, args []string) error {
+	userID := args[0]
+
+	client, err := c.getClient()
+	if err != nil {
+		return err
 	}
+
+	if err := client.Users().Delete(cmd.Context(), userID); err != nil {
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	fmt.Printf("✓ Deleted user: %s\n", userID)
+	return nil
 }
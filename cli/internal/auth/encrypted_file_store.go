@@ -0,0 +1,319 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// argon2Params describes the Argon2id parameters used to derive an
+// EncryptedFileStore's AES-256-GCM key. They're persisted alongside the
+// ciphertext (see encryptedFileEnvelope) so a future version can change
+// them without leaving existing files unreadable.
+type argon2Params struct {
+	Memory      uint32 `json:"memory"`
+	Iterations  uint32 `json:"iterations"`
+	Parallelism uint8  `json:"parallelism"`
+	KeyLength   uint32 `json:"key_length"`
+}
+
+// defaultArgon2Params follows OWASP's current Argon2id baseline.
+var defaultArgon2Params = argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 2,
+	KeyLength:   32,
+}
+
+const encryptedFileAlg = "argon2id$aes256gcm"
+
+// encryptedFileEnvelope is the self-describing on-disk format for
+// EncryptedFileStore. Salt, nonce, and the KDF parameters travel with the
+// ciphertext so Load can always re-derive the right key, even after
+// defaultArgon2Params changes in a future release.
+type encryptedFileEnvelope struct {
+	Alg        string       `json:"alg"`
+	Salt       string       `json:"salt"`
+	Nonce      string       `json:"nonce"`
+	Params     argon2Params `json:"params"`
+	Ciphertext string       `json:"ciphertext"`
+}
+
+// ErrInvalidPassphrase is returned by EncryptedFileStore.Load when the
+// passphrase is wrong or the store has been tampered with; AES-GCM's
+// authentication tag can't fail for any other reason, so there's nothing
+// more specific to report without leaking crypto internals.
+var ErrInvalidPassphrase = errors.New("invalid passphrase or corrupted store")
+
+// EncryptedFileStore implements TokenStore like FileStore, but encrypts
+// the token blob at rest with AES-256-GCM under a key derived from a
+// user-supplied passphrase via Argon2id, so a copied credentials file is
+// useless without the passphrase.
+type EncryptedFileStore struct {
+	Path       string
+	Passphrase string
+	Params     argon2Params
+}
+
+// NewEncryptedFileStore creates an EncryptedFileStore rooted at
+// ~/.luna/credentials.enc.json, keyed by passphrase.
+func NewEncryptedFileStore(passphrase string) *EncryptedFileStore {
+	home, _ := os.UserHomeDir()
+	return &EncryptedFileStore{
+		Path:       filepath.Join(home, ".luna", "credentials.enc.json"),
+		Passphrase: passphrase,
+		Params:     defaultArgon2Params,
+	}
+}
+
+// encryptedFileAccount is the per-account record inside an
+// EncryptedFileStore's decrypted payload.
+type encryptedFileAccount struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// encryptedFilePayload is the plaintext sealed inside encryptedFileEnvelope,
+// keyed by account name so one passphrase-protected file can hold more than
+// one logged-in profile.
+type encryptedFilePayload struct {
+	Accounts map[string]encryptedFileAccount `json:"accounts"`
+}
+
+func (s *EncryptedFileStore) Save(account, accessToken, refreshToken string) error {
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return err
+	}
+
+	payload, err := s.readPayload()
+	if err != nil {
+		return err
+	}
+
+	payload.Accounts[account] = encryptedFileAccount{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		UpdatedAt:    time.Now(),
+	}
+
+	return s.writePayload(payload)
+}
+
+func (s *EncryptedFileStore) Load(account string) (string, string, error) {
+	payload, err := s.readPayload()
+	if err != nil {
+		return "", "", err
+	}
+
+	entry, ok := payload.Accounts[account]
+	if !ok {
+		return "", "", fmt.Errorf("auth: no stored credentials for account %q", account)
+	}
+	return entry.AccessToken, entry.RefreshToken, nil
+}
+
+func (s *EncryptedFileStore) Clear(account string) error {
+	payload, err := s.readPayload()
+	if err != nil {
+		return err
+	}
+	delete(payload.Accounts, account)
+	return s.writePayload(payload)
+}
+
+// List returns the accounts with credentials in this EncryptedFileStore,
+// sorted alphabetically.
+func (s *EncryptedFileStore) List() ([]string, error) {
+	payload, err := s.readPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]string, 0, len(payload.Accounts))
+	for account := range payload.Accounts {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
+
+// readPayload loads and decrypts the store's payload, returning an empty
+// one if the file doesn't exist yet.
+func (s *EncryptedFileStore) readPayload() (*encryptedFilePayload, error) {
+	data, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return &encryptedFilePayload{Accounts: make(map[string]encryptedFileAccount)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope encryptedFileEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+
+	plaintext, err := s.open(envelope)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+
+	var payload encryptedFilePayload
+	if err := json.Unmarshal(plaintext, &payload); err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	if payload.Accounts == nil {
+		payload.Accounts = make(map[string]encryptedFileAccount)
+	}
+	return &payload, nil
+}
+
+// writePayload re-encrypts payload under a fresh salt/nonce and persists it
+// via write-then-rename, so a crash mid-write never corrupts the file.
+func (s *EncryptedFileStore) writePayload(payload *encryptedFilePayload) error {
+	plaintext, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	envelope, err := s.seal(plaintext)
+	if err != nil {
+		return fmt.Errorf("auth: failed to encrypt credentials: %w", err)
+	}
+
+	data, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), ".credentials-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, s.Path)
+}
+
+// seal encrypts plaintext under a fresh random 16-byte salt and 12-byte
+// GCM nonce, returning the envelope to persist.
+func (s *EncryptedFileStore) seal(plaintext []byte) (*encryptedFileEnvelope, error) {
+	params := s.Params
+	if params == (argon2Params{}) {
+		params = defaultArgon2Params
+	}
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	gcm, err := s.gcm(salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, plaintext, nil)
+
+	return &encryptedFileEnvelope{
+		Alg:        encryptedFileAlg,
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Params:     params,
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+// open decrypts envelope, authenticating the GCM tag.
+func (s *EncryptedFileStore) open(envelope encryptedFileEnvelope) ([]byte, error) {
+	if envelope.Alg != encryptedFileAlg {
+		return nil, fmt.Errorf("unsupported envelope alg %q", envelope.Alg)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(envelope.Salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := base64.StdEncoding.DecodeString(envelope.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(envelope.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := s.gcm(salt, envelope.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+func (s *EncryptedFileStore) gcm(salt []byte, params argon2Params) (cipher.AEAD, error) {
+	key := argon2.IDKey([]byte(s.Passphrase), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+var _ TokenStore = (*EncryptedFileStore)(nil)
+
+// isInteractiveTerminal reports whether f looks like a terminal rather
+// than a pipe or redirected file, without pulling in a terminal-handling
+// dependency just to check.
+func isInteractiveTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// promptPassphrase reads a single line from in, writing prompt to out
+// first. It doesn't suppress terminal echo; NewFileStore only calls it
+// when LUNA_CREDS_PASSPHRASE wasn't set, as a convenience for interactive
+// use rather than a hardened credential prompt.
+func promptPassphrase(in io.Reader, out io.Writer) (string, error) {
+	fmt.Fprint(out, "Credentials passphrase: ")
+	line, err := bufio.NewReader(in).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -2,8 +2,11 @@ package auth
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/zalando/go-keyring"
 )
@@ -14,11 +17,27 @@ const (
 
 // TokenStore defines the interface for token storage
 type TokenStore interface {
-	Save(accessToken, refreshToken string) error
+	Save(account, accessToken, refreshToken string) error
 	Load(account string) (string, string, error)
 	Clear(account string) error
+	// List returns the names of accounts with stored credentials, sorted
+	// alphabetically.
+	List() ([]string, error)
 }
 
+var (
+	_ TokenStore = (*KeyringStore)(nil)
+	_ TokenStore = (*FileStore)(nil)
+)
+
+// keyringIndexUser is the keyring entry KeyringStore uses to track which
+// accounts it has written. The zalando/go-keyring backends expose Set/Get/
+// Delete by (service, user) but no enumeration call, so List() can only be
+// "where feasible": we maintain this index ourselves and accept that
+// entries created outside KeyringStore (or on a machine where the index
+// entry itself was deleted out of band) won't show up in it.
+const keyringIndexUser = "_accounts"
+
 // KeyringStore implements TokenStore using system keyring
 type KeyringStore struct{}
 
@@ -26,6 +45,31 @@ func NewKeyringStore() *KeyringStore {
 	return &KeyringStore{}
 }
 
+// Available reports whether the system keyring actually works here, by
+// round-tripping a throwaway entry. Headless Linux boxes without a
+// secret-service daemon (common in CI and containers) return an error from
+// every keyring call, so callers use this to decide whether to fall back
+// to NewFileStore instead of failing outright.
+func (s *KeyringStore) Available() bool {
+	const probeUser = "_probe"
+	if err := keyring.Set(ServiceName, probeUser, "ok"); err != nil {
+		return false
+	}
+	_ = keyring.Delete(ServiceName, probeUser)
+	return true
+}
+
+// NewDefaultTokenStore returns the system keyring when it's usable on this
+// machine, falling back to NewFileStore (subject to its own passphrase/
+// allowPlaintext rules) when it's not.
+func NewDefaultTokenStore(allowPlaintext bool) (TokenStore, error) {
+	keyringStore := NewKeyringStore()
+	if keyringStore.Available() {
+		return keyringStore, nil
+	}
+	return NewFileStore(allowPlaintext)
+}
+
 func (s *KeyringStore) Save(account, accessToken, refreshToken string) error {
 	if err := keyring.Set(ServiceName, account+"_access", accessToken); err != nil {
 		return err
@@ -35,7 +79,7 @@ func (s *KeyringStore) Save(account, accessToken, refreshToken string) error {
 			return err
 		}
 	}
-	return nil
+	return s.addToIndex(account)
 }
 
 func (s *KeyringStore) Load(account string) (string, string, error) {
@@ -50,7 +94,64 @@ func (s *KeyringStore) Load(account string) (string, string, error) {
 func (s *KeyringStore) Clear(account string) error {
 	_ = keyring.Delete(ServiceName, account+"_access")
 	_ = keyring.Delete(ServiceName, account+"_refresh")
-	return nil
+	return s.removeFromIndex(account)
+}
+
+// List returns the accounts KeyringStore has Saved, per the index entry
+// described by keyringIndexUser. It returns an empty list rather than an
+// error when no index entry exists yet (nothing has ever been saved).
+func (s *KeyringStore) List() ([]string, error) {
+	raw, err := keyring.Get(ServiceName, keyringIndexUser)
+	if err != nil {
+		if err == keyring.ErrNotFound {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+
+	var accounts []string
+	if err := json.Unmarshal([]byte(raw), &accounts); err != nil {
+		return nil, fmt.Errorf("auth: corrupt keyring account index: %w", err)
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
+
+func (s *KeyringStore) addToIndex(account string) error {
+	accounts, err := s.List()
+	if err != nil {
+		return err
+	}
+	for _, a := range accounts {
+		if a == account {
+			return nil
+		}
+	}
+	accounts = append(accounts, account)
+	sort.Strings(accounts)
+	return s.writeIndex(accounts)
+}
+
+func (s *KeyringStore) removeFromIndex(account string) error {
+	accounts, err := s.List()
+	if err != nil {
+		return err
+	}
+	kept := accounts[:0]
+	for _, a := range accounts {
+		if a != account {
+			kept = append(kept, a)
+		}
+	}
+	return s.writeIndex(kept)
+}
+
+func (s *KeyringStore) writeIndex(accounts []string) error {
+	raw, err := json.Marshal(accounts)
+	if err != nil {
+		return err
+	}
+	return keyring.Set(ServiceName, keyringIndexUser, string(raw))
 }
 
 // FileStore implements TokenStore using a local file (fallback)
@@ -58,11 +159,52 @@ type FileStore struct {
 	Path string
 }
 
-func NewFileStore() *FileStore {
-	home, _ := os.UserHomeDir()
+// NewFileStore selects which on-disk TokenStore to use. If a passphrase is
+// available — from LUNA_CREDS_PASSPHRASE, or typed at an interactive
+// prompt when stdin is a terminal — it returns an EncryptedFileStore keyed
+// by that passphrase. Otherwise, unless allowPlaintext is true (an
+// explicit opt-in), it refuses to fall back to the legacy plaintext
+// FileStore.
+func NewFileStore(allowPlaintext bool) (TokenStore, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to resolve home directory: %w", err)
+	}
+
+	passphrase := os.Getenv("LUNA_CREDS_PASSPHRASE")
+	if passphrase == "" && isInteractiveTerminal(os.Stdin) {
+		passphrase, err = promptPassphrase(os.Stdin, os.Stderr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if passphrase != "" {
+		return NewEncryptedFileStore(passphrase), nil
+	}
+
+	if !allowPlaintext {
+		return nil, fmt.Errorf("auth: no credentials passphrase available; set LUNA_CREDS_PASSPHRASE or explicitly allow the plaintext file store")
+	}
+
 	return &FileStore{
 		Path: filepath.Join(home, ".luna", "credentials.json"),
-	}
+	}, nil
+}
+
+// fileStoreAccount is the per-account record in a FileStore's on-disk
+// document.
+type fileStoreAccount struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// fileStoreDocument is the on-disk format for FileStore, keyed by account
+// name so the same credentials file can hold more than one logged-in
+// profile.
+type fileStoreDocument struct {
+	Accounts map[string]fileStoreAccount `json:"accounts"`
 }
 
 func (s *FileStore) Save(account, accessToken, refreshToken string) error {
@@ -70,30 +212,103 @@ func (s *FileStore) Save(account, accessToken, refreshToken string) error {
 		return err
 	}
 
-	// Simple single-account implementation for file store
-	data := map[string]string{
-		"access_token":  accessToken,
-		"refresh_token": refreshToken,
+	doc, err := s.readDocument()
+	if err != nil {
+		return err
+	}
+
+	doc.Accounts[account] = fileStoreAccount{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		UpdatedAt:    time.Now(),
 	}
 
-	bytes, _ := json.MarshalIndent(data, "", "  ")
-	return os.WriteFile(s.Path, bytes, 0600)
+	return s.writeDocument(doc)
 }
 
 func (s *FileStore) Load(account string) (string, string, error) {
-	bytes, err := os.ReadFile(s.Path)
+	doc, err := s.readDocument()
 	if err != nil {
 		return "", "", err
 	}
 
-	var data map[string]string
-	if err := json.Unmarshal(bytes, &data); err != nil {
-		return "", "", err
+	entry, ok := doc.Accounts[account]
+	if !ok {
+		return "", "", fmt.Errorf("auth: no stored credentials for account %q", account)
 	}
-
-	return data["access_token"], data["refresh_token"], nil
+	return entry.AccessToken, entry.RefreshToken, nil
 }
 
 func (s *FileStore) Clear(account string) error {
-	return os.Remove(s.Path)
+	doc, err := s.readDocument()
+	if err != nil {
+		return err
+	}
+	delete(doc.Accounts, account)
+	return s.writeDocument(doc)
+}
+
+// List returns the accounts with credentials in this FileStore, sorted
+// alphabetically.
+func (s *FileStore) List() ([]string, error) {
+	doc, err := s.readDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]string, 0, len(doc.Accounts))
+	for account := range doc.Accounts {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
+
+func (s *FileStore) readDocument() (*fileStoreDocument, error) {
+	bytes, err := os.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return &fileStoreDocument{Accounts: make(map[string]fileStoreAccount)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var doc fileStoreDocument
+	if err := json.Unmarshal(bytes, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Accounts == nil {
+		doc.Accounts = make(map[string]fileStoreAccount)
+	}
+	return &doc, nil
+}
+
+// writeDocument persists doc via write-then-rename, so a crash or
+// concurrent `luna auth login` never leaves credentials.json truncated or
+// half-written.
+func (s *FileStore) writeDocument(doc *fileStoreDocument) error {
+	bytes, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), ".credentials-*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	if _, err := tmp.Write(bytes); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpPath, s.Path)
 }
@@ -0,0 +1,60 @@
+package scaffold
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// "all:" includes dotfiles like .env.local.tmpl that go:embed would
+// otherwise silently skip.
+//
+//go:embed all:templates
+var builtinFS embed.FS
+
+// builtinRoot is where templates are rooted inside builtinFS.
+const builtinRoot = "templates"
+
+// Builtin returns the embedded template named name (e.g. "ts-nextjs"), or
+// an error listing the available names if it isn't one of them.
+func Builtin(name string) (Template, error) {
+	names, err := BuiltinNames()
+	if err != nil {
+		return nil, err
+	}
+
+	found := false
+	for _, n := range names {
+		if n == name {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("unknown template %q (built-in templates: %v)", name, names)
+	}
+
+	sub, err := fs.Sub(builtinFS, builtinRoot+"/"+name)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: %w", name, err)
+	}
+	return &fsTemplate{name: name, fsys: sub}, nil
+}
+
+// BuiltinNames lists the templates embedded in the binary, sorted.
+func BuiltinNames() ([]string, error) {
+	entries, err := fs.ReadDir(builtinFS, builtinRoot)
+	if err != nil {
+		return nil, fmt.Errorf("list built-in templates: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -0,0 +1,91 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// fsTemplate is a Template backed by any fs.FS rooted at the template's
+// own directory (an embed.FS subtree for built-ins, a checked-out git
+// worktree for remote ones).
+type fsTemplate struct {
+	name string
+	fsys fs.FS
+}
+
+var _ Template = (*fsTemplate)(nil)
+
+func (t *fsTemplate) Name() string { return t.name }
+
+func (t *fsTemplate) Manifest() (*Manifest, error) {
+	raw, err := fs.ReadFile(t.fsys, ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("template %s: missing %s: %w", t.name, ManifestFile, err)
+	}
+	return parseManifest(t.name, raw)
+}
+
+// Render walks t.fsys, executing every *.tmpl file (stripping the suffix
+// from its output path) through text/template with ctx and copying every
+// other file verbatim. ManifestFile itself is never part of the project
+// output.
+func (t *fsTemplate) Render(ctx TemplateContext) ([]File, error) {
+	var files []File
+	err := fs.WalkDir(t.fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || p == ManifestFile {
+			return nil
+		}
+
+		content, err := fs.ReadFile(t.fsys, p)
+		if err != nil {
+			return fmt.Errorf("template %s: read %s: %w", t.name, p, err)
+		}
+
+		outPath := p
+		if strings.HasSuffix(p, ".tmpl") {
+			outPath = strings.TrimSuffix(p, ".tmpl")
+			rendered, err := renderFile(p, content, ctx)
+			if err != nil {
+				return err
+			}
+			content = rendered
+
+			// A .tmpl file that renders to nothing but whitespace is how a
+			// template conditionally omits a whole file (e.g. a Dockerfile
+			// gated on `{{if .HasFeature "docker"}}`) rather than writing
+			// an empty one into every project.
+			if len(bytes.TrimSpace(content)) == 0 {
+				return nil
+			}
+		}
+
+		files = append(files, File{Path: outPath, Content: content, Mode: 0644})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// renderFile executes content as a text/template named after its path
+// (for useful error messages) against ctx.
+func renderFile(templatePath string, content []byte, ctx TemplateContext) ([]byte, error) {
+	tmpl, err := template.New(path.Base(templatePath)).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", templatePath, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ctx); err != nil {
+		return nil, fmt.Errorf("render %s: %w", templatePath, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,114 @@
+package scaffold
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// gitTemplateCacheDir returns ~/.luna/templates, where remote template
+// repos are cloned to, alongside ~/.luna/plugins and ~/.luna/config.yaml.
+func gitTemplateCacheDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".luna", "templates"), nil
+}
+
+// IsGitSpec reports whether name looks like a remote template spec
+// (`--template github.com/org/luna-template@v1`) rather than a built-in
+// name: it contains a "/", which no built-in template name does.
+func IsGitSpec(name string) bool {
+	return strings.Contains(name, "/")
+}
+
+// parseGitSpec splits a "<repo>@<ref>" spec into its repo URL and ref. The
+// ref defaults to "" (the remote's default branch) when omitted. A bare
+// host/path like "github.com/org/repo" is expanded to an https:// URL;
+// specs that already name a scheme (git@, https://, ssh://) are left as-is.
+func parseGitSpec(spec string) (repoURL, ref string) {
+	repoURL, ref, hasRef := strings.Cut(spec, "@")
+	if !hasRef {
+		ref = ""
+	}
+
+	switch {
+	case strings.Contains(repoURL, "://"), strings.HasPrefix(repoURL, "git@"):
+	default:
+		repoURL = "https://" + repoURL
+	}
+	return repoURL, ref
+}
+
+// FetchGitTemplate resolves a `--template <spec>` value that IsGitSpec
+// accepted: it shallow-clones the repo into the template cache dir (or
+// reuses an existing clone, fast-forwarding it) and returns a Template
+// backed by the checked-out tree.
+func FetchGitTemplate(spec string) (Template, error) {
+	repoURL, ref := parseGitSpec(spec)
+
+	cacheDir, err := gitTemplateCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("resolve template cache dir: %w", err)
+	}
+	dir := filepath.Join(cacheDir, cacheKey(spec))
+
+	if _, err := os.Stat(dir); err == nil {
+		if err := gitUpdate(dir, ref); err != nil {
+			return nil, fmt.Errorf("update cached template %s: %w", spec, err)
+		}
+	} else {
+		if err := gitShallowClone(repoURL, ref, dir); err != nil {
+			return nil, fmt.Errorf("clone template %s: %w", spec, err)
+		}
+	}
+
+	return &fsTemplate{name: spec, fsys: os.DirFS(dir)}, nil
+}
+
+// cacheKey derives the cache directory name for a git spec: stable across
+// runs, filesystem-safe, and collision-resistant without needing to parse
+// the (host, org, repo, ref) tuple back out of it.
+func cacheKey(spec string) string {
+	sum := sha256.Sum256([]byte(spec))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func gitShallowClone(repoURL, ref, dir string) error {
+	args := []string{"clone", "--depth", "1"}
+	if ref != "" {
+		args = append(args, "--branch", ref)
+	}
+	args = append(args, repoURL, dir)
+
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// gitUpdate fast-forwards an already-cloned template to the latest commit
+// on ref (or its default branch), so repeated `luna init --template`
+// invocations pick up upstream template changes instead of going stale
+// forever.
+func gitUpdate(dir, ref string) error {
+	fetchArgs := []string{"-C", dir, "fetch", "--depth", "1", "origin"}
+	if ref != "" {
+		fetchArgs = append(fetchArgs, ref)
+	}
+	if err := exec.Command("git", fetchArgs...).Run(); err != nil {
+		return err
+	}
+
+	resetTarget := "origin/HEAD"
+	if ref != "" {
+		resetTarget = "FETCH_HEAD"
+	}
+	cmd := exec.Command("git", "-C", dir, "reset", "--hard", resetTarget)
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
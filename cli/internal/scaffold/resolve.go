@@ -0,0 +1,75 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Resolve looks up a `luna init --template` value: a built-in name (e.g.
+// "go-cobra") or, per IsGitSpec, a remote git repo to fetch.
+func Resolve(nameOrSpec string) (Template, error) {
+	if IsGitSpec(nameOrSpec) {
+		return FetchGitTemplate(nameOrSpec)
+	}
+	return Builtin(nameOrSpec)
+}
+
+// Apply renders template against ctx, writes its files under dir (which
+// must already exist), validates --feature names against the manifest,
+// and runs the manifest's post-init commands inside dir.
+func Apply(t Template, dir string, ctx TemplateContext) error {
+	manifest, err := t.Manifest()
+	if err != nil {
+		return err
+	}
+	if err := validateFeatures(manifest, ctx.Features); err != nil {
+		return err
+	}
+
+	files, err := t.Render(ctx)
+	if err != nil {
+		return fmt.Errorf("template %s: %w", t.Name(), err)
+	}
+
+	for _, f := range files {
+		dest := filepath.Join(dir, f.Path)
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(dest, f.Content, os.FileMode(f.Mode)); err != nil {
+			return err
+		}
+	}
+
+	return runPostInit(dir, manifest, ctx)
+}
+
+// runPostInit renders and runs each of manifest.PostInit in dir, in order,
+// stopping at the first failure (mirroring how scaffoldGo previously ran
+// `go mod init` as a single hard-coded post-init step).
+func runPostInit(dir string, manifest *Manifest, ctx TemplateContext) error {
+	for _, raw := range manifest.PostInit {
+		tmpl, err := template.New("post_init").Parse(raw)
+		if err != nil {
+			return fmt.Errorf("template %s: invalid post_init command %q: %w", manifest.Name, raw, err)
+		}
+
+		var buf strings.Builder
+		if err := tmpl.Execute(&buf, ctx); err != nil {
+			return fmt.Errorf("template %s: render post_init command %q: %w", manifest.Name, raw, err)
+		}
+
+		cmd := exec.Command("sh", "-c", buf.String())
+		cmd.Dir = dir
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("post_init command %q: %w", buf.String(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Package scaffold provides the project templates behind `luna init`. A
+// Template is a file tree plus a luna.template.yaml manifest; built-in
+// templates are embedded in the binary, and remote ones are shallow-cloned
+// from a git repo into a local cache. Either way, the CLI renders every
+// *.tmpl file in the tree through text/template with a TemplateContext and
+// runs the manifest's post-init commands, so adding a new stack (or an
+// internal, team-specific one) never requires patching the CLI itself.
+package scaffold
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFile is the name of a template's manifest, expected at the root
+// of its file tree.
+const ManifestFile = "luna.template.yaml"
+
+// Manifest describes a template: what it's for, what post-init commands to
+// run after its files are written, and what optional features it supports.
+type Manifest struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Language    string `yaml:"language"`
+	// PostInit commands run, in order, inside the new project directory
+	// after its files are written (e.g. "go mod init {{.ModuleName}}").
+	// Each is rendered through text/template with the same
+	// TemplateContext as the template's files before being run via "sh -c".
+	PostInit []string  `yaml:"post_init"`
+	Features []Feature `yaml:"features"`
+}
+
+// Feature is one optional, named addition a template supports, toggled on
+// with `luna init --feature <name>` and exposed to templates as
+// TemplateContext.Features / TemplateContext.HasFeature.
+type Feature struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// HasFeature reports whether name is declared by the manifest, so `luna
+// init --feature` can reject unknown ones with a helpful error.
+func (m *Manifest) HasFeature(name string) bool {
+	for _, f := range m.Features {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// TemplateContext is the data made available to a template's files and
+// post-init commands via text/template.
+type TemplateContext struct {
+	ProjectName  string
+	ModuleName   string
+	APIKeyEnvVar string
+	Features     []string
+}
+
+// HasFeature reports whether name was requested via --feature, for use in
+// template files as `{{if .HasFeature "foo"}}`.
+func (c TemplateContext) HasFeature(name string) bool {
+	for _, f := range c.Features {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// File is one file belonging to a Template, resolved and ready to write:
+// Path is relative to the project root, Content has already had any
+// .tmpl rendering applied.
+type File struct {
+	Path    string
+	Content []byte
+	Mode    uint32
+}
+
+// Template is a named, versioned project scaffold: a manifest plus the
+// file tree to render into a new project directory.
+type Template interface {
+	// Name identifies the template, e.g. "ts-nextjs" or a git spec.
+	Name() string
+	// Manifest returns the template's parsed luna.template.yaml.
+	Manifest() (*Manifest, error)
+	// Render walks the template's file tree, executing every *.tmpl file
+	// (stripping the suffix from its output path) through text/template
+	// with ctx and copying every other file verbatim.
+	Render(ctx TemplateContext) ([]File, error)
+}
+
+// parseManifest unmarshals raw into a Manifest, wrapping yaml errors with
+// enough context (which template, which file) to debug a bad
+// luna.template.yaml without digging into scaffold internals.
+func parseManifest(templateName string, raw []byte) (*Manifest, error) {
+	var m Manifest
+	if err := yaml.Unmarshal(raw, &m); err != nil {
+		return nil, fmt.Errorf("template %s: invalid %s: %w", templateName, ManifestFile, err)
+	}
+	return &m, nil
+}
+
+// validateFeatures checks requested against what manifest declares,
+// returning an error naming the first unknown feature.
+func validateFeatures(manifest *Manifest, requested []string) error {
+	for _, name := range requested {
+		if !manifest.HasFeature(name) {
+			known := make([]string, len(manifest.Features))
+			for i, f := range manifest.Features {
+				known[i] = f.Name
+			}
+			return fmt.Errorf("unknown feature %q for template %s (available: %s)", name, manifest.Name, strings.Join(known, ", "))
+		}
+	}
+	return nil
+}
@@ -7,25 +7,25 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/eclipse-softworks/Luna-SDK-go/luna"
+	"github.com/eclipse-softworks/Luna-SDK-go/luna/agent"
+	"github.com/eclipse-softworks/Luna-SDK-go/luna/chatbot"
 )
 
-// ChatMessage represents a message in the conversation
-type ChatMessage struct {
-	Role    string // "system", "user", "assistant"
-	Content string
-}
-
-// LunaChatbot is a conversational AI assistant
+// LunaChatbot is a conversational AI assistant. Its conversation is a
+// branching tree (see luna/chatbot) rather than a flat history, so a
+// caller can fork an earlier turn or edit and resend a message without
+// losing the thread it branched from.
 type LunaChatbot struct {
-	client              *luna.Client
-	model               string
-	temperature         float64
-	systemPrompt        string
-	conversationHistory []ChatMessage
+	client       *luna.Client
+	model        string
+	temperature  float64
+	systemPrompt string
+	session      *chatbot.Session
 }
 
 // NewLunaChatbot creates a new chatbot instance
@@ -41,10 +41,7 @@ func NewLunaChatbot(client *luna.Client, opts ...ChatbotOption) *LunaChatbot {
 		opt(bot)
 	}
 
-	// Initialize with system prompt
-	bot.conversationHistory = []ChatMessage{
-		{Role: "system", Content: bot.systemPrompt},
-	}
+	bot.session = chatbot.NewSession("chat", bot.systemPrompt, client.AI(), bot.model, chatbot.WithTemperature(bot.temperature))
 
 	return bot
 }
@@ -73,55 +70,112 @@ func WithSystemPrompt(prompt string) ChatbotOption {
 	}
 }
 
-// Chat sends a message and gets a response
+// Chat sends a message and gets a response, appending both to the active
+// branch of bot's conversation.
 func (bot *LunaChatbot) Chat(ctx context.Context, userMessage string) (string, error) {
-	// Add user message to history
-	bot.conversationHistory = append(bot.conversationHistory, ChatMessage{
-		Role:    "user",
-		Content: userMessage,
-	})
+	reply, err := bot.session.Chat(ctx, userMessage)
+	if err != nil {
+		return "", fmt.Errorf("chat completion failed: %w", err)
+	}
+	return reply, nil
+}
 
-	// Build messages for API
-	messages := make([]luna.Message, len(bot.conversationHistory))
-	for i, msg := range bot.conversationHistory {
-		messages[i] = luna.Message{
-			Role:    msg.Role,
-			Content: msg.Content,
-		}
+// ChatStream sends a message and streams the assistant's response, calling
+// onToken for each partial token as it arrives over SSE. The full response
+// is still appended to the active branch once the stream ends, so
+// ChatStream and Chat can be mixed in the same conversation.
+func (bot *LunaChatbot) ChatStream(ctx context.Context, userMessage string, onToken func(string)) (string, error) {
+	bot.session.Conversation.Append("user", userMessage)
+
+	activePath := bot.session.Conversation.ActivePath()
+	messages := make([]luna.Message, len(activePath))
+	for i, msg := range activePath {
+		messages[i] = luna.Message{Role: msg.Role, Content: msg.Content}
 	}
 
-	// Call the AI API
-	response, err := bot.client.AI().ChatCompletions(ctx, luna.CompletionRequest{
+	stream, err := bot.client.AI().ChatCompletionsStream(ctx, &luna.CompletionRequest{
 		Model:       bot.model,
 		Messages:    messages,
 		Temperature: bot.temperature,
 	})
 	if err != nil {
-		return "", fmt.Errorf("chat completion failed: %w", err)
+		return "", fmt.Errorf("chat completion stream failed: %w", err)
 	}
+	defer stream.Close()
 
-	// Extract assistant response
-	assistantMessage := response.Choices[0].Message.Content
+	var assistantMessage strings.Builder
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("chat completion stream failed: %w", err)
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+		token := chunk.Choices[0].Delta.Content
+		assistantMessage.WriteString(token)
+		onToken(token)
+	}
+
+	bot.session.Conversation.Append("assistant", assistantMessage.String())
+
+	return assistantMessage.String(), nil
+}
 
-	// Add to history for context
-	bot.conversationHistory = append(bot.conversationHistory, ChatMessage{
-		Role:    "assistant",
-		Content: assistantMessage,
+// SuggestStarters fetches up to limit suggested opening prompts for this
+// chatbot, described by appName, to seed a REPL's initial prompt list.
+func (bot *LunaChatbot) SuggestStarters(ctx context.Context, appName string, limit int) ([]string, error) {
+	return bot.client.AI().PromptStarters(ctx, luna.PromptStarterRequest{
+		AppName:     appName,
+		Description: bot.systemPrompt,
+		Limit:       limit,
 	})
+}
+
+// Fork branches off bot's conversation at messageID (as returned by
+// GetHistory), returning a new, independent LunaChatbot positioned there --
+// for exploring an alternative continuation without disturbing bot's own
+// active branch.
+func (bot *LunaChatbot) Fork(messageID string) (*LunaChatbot, error) {
+	forkedSession, err := bot.session.Fork(messageID)
+	if err != nil {
+		return nil, err
+	}
 
-	return assistantMessage, nil
+	forked := *bot
+	forked.session = forkedSession
+	return &forked, nil
 }
 
-// ClearHistory clears the conversation history
-func (bot *LunaChatbot) ClearHistory() {
-	bot.conversationHistory = []ChatMessage{
-		{Role: "system", Content: bot.systemPrompt},
+// EditAndResend replaces the user turn at messageID with newContent on a
+// new sibling branch and resends it, for revising an earlier prompt
+// without losing the original branch's thread.
+func (bot *LunaChatbot) EditAndResend(ctx context.Context, messageID, newContent string) (string, error) {
+	reply, err := bot.session.EditAndResend(ctx, messageID, newContent)
+	if err != nil {
+		return "", fmt.Errorf("edit and resend failed: %w", err)
 	}
+	return reply, nil
+}
+
+// SwitchBranch moves bot's active branch to messageID, so the next Chat
+// extends that branch instead of whichever was active before.
+func (bot *LunaChatbot) SwitchBranch(messageID string) error {
+	return bot.session.SwitchBranch(messageID)
 }
 
-// GetHistory returns the conversation history
-func (bot *LunaChatbot) GetHistory() []ChatMessage {
-	return append([]ChatMessage{}, bot.conversationHistory...)
+// ClearHistory resets the conversation back to just its system prompt.
+func (bot *LunaChatbot) ClearHistory() {
+	bot.session = chatbot.NewSession("chat", bot.systemPrompt, bot.client.AI(), bot.model, chatbot.WithTemperature(bot.temperature))
+}
+
+// GetHistory returns the active branch of the conversation, from the
+// system prompt to the most recent reply.
+func (bot *LunaChatbot) GetHistory() []*chatbot.Message {
+	return bot.session.Conversation.ActivePath()
 }
 
 func main() {
@@ -144,6 +198,14 @@ func main() {
 		fmt.Printf("Specialized assistant failed: %v\n", err)
 	}
 
+	if err := streamingChatExample(ctx, client); err != nil {
+		fmt.Printf("Streaming chat failed: %v\n", err)
+	}
+
+	if err := toolCallingExample(ctx, client); err != nil {
+		fmt.Printf("Tool calling failed: %v\n", err)
+	}
+
 	// Uncomment to start interactive mode
 	// interactiveChat(ctx, client)
 }
@@ -253,6 +315,53 @@ func fetchUserData(userID string) interface{} {
 	return nil
 }
 
+// ============================================
+// Tool Calling Example
+// ============================================
+
+func toolCallingExample(ctx context.Context, client *luna.Client) error {
+	fmt.Println("\nTool Calling Example\n")
+
+	toolbox := agent.NewToolbox(agent.DirTreeTool, agent.HTTPGetTool)
+	a := agent.NewAgent(client.AI(), toolbox, "luna-gpt-4")
+
+	messages, err := a.Run(ctx, []luna.Message{
+		{Role: "system", Content: "You are a helpful assistant with access to tools."},
+		{Role: "user", Content: "List the files in the current directory."},
+	})
+	if err != nil {
+		return fmt.Errorf("agent run failed: %w", err)
+	}
+
+	fmt.Printf("Assistant: %s\n", messages[len(messages)-1].Content)
+
+	return nil
+}
+
+// ============================================
+// Streaming Chat Example
+// ============================================
+
+func streamingChatExample(ctx context.Context, client *luna.Client) error {
+	fmt.Println("\nStreaming Chat Example\n")
+
+	chatbot := NewLunaChatbot(client,
+		WithSystemPrompt("You are a helpful coding assistant."),
+	)
+
+	fmt.Println("User: What is a Go goroutine?")
+	fmt.Print("Assistant: ")
+	_, err := chatbot.ChatStream(ctx, "What is a Go goroutine?", func(token string) {
+		fmt.Print(token)
+	})
+	if err != nil {
+		return fmt.Errorf("streaming chat failed: %w", err)
+	}
+	fmt.Println()
+
+	return nil
+}
+
 // ============================================
 // Interactive Chat
 // ============================================
@@ -265,6 +374,16 @@ func interactiveChat(ctx context.Context, client *luna.Client) {
 		WithSystemPrompt("You are a helpful AI assistant. Be friendly and informative."),
 	)
 
+	if starters, err := chatbot.SuggestStarters(ctx, "interactive-chat-example", 3); err != nil {
+		fmt.Printf("Couldn't load suggested prompts: %v\n\n", err)
+	} else {
+		fmt.Println("Try asking:")
+		for _, starter := range starters {
+			fmt.Printf("  - %s\n", starter)
+		}
+		fmt.Println()
+	}
+
 	scanner := bufio.NewScanner(os.Stdin)
 
 	for {
@@ -72,7 +72,7 @@ func findStudentAccommodation(ctx context.Context, client *luna.Client, criteria
 	fmt.Println(strings.Repeat("-", 40))
 
 	// First, get available campuses
-	campuses, err := client.ResMate().Campuses().List(ctx)
+	campuses, err := client.ResMate().Campuses().List(ctx, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list campuses: %w", err)
 	}
@@ -0,0 +1,111 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
+)
+
+// defaultMaxIterations bounds how many times Agent.Run will call the
+// model before giving up, so a model stuck calling tools in a loop (or a
+// tool whose result keeps triggering another call) can't run forever.
+const defaultMaxIterations = 10
+
+// Completer is the subset of AiResource the Agent loop needs; satisfied
+// by *resources.AiResource without an adapter.
+type Completer interface {
+	ChatCompletions(ctx context.Context, params *resources.CompletionRequest) (*resources.CompletionResponse, error)
+}
+
+// Agent drives a tool-calling chat loop: send messages to the model, and
+// whenever its response requests tool calls, execute them against the
+// Toolbox and feed the results back as "tool" messages, repeating until
+// the model returns a message with no tool calls or MaxIterations is hit.
+type Agent struct {
+	completer     Completer
+	toolbox       *Toolbox
+	model         string
+	temperature   *float64
+	maxIterations int
+}
+
+// Option configures an Agent.
+type Option func(*Agent)
+
+// WithTemperature sets the sampling temperature used on every completion
+// call the Agent makes.
+func WithTemperature(temperature float64) Option {
+	return func(a *Agent) {
+		a.temperature = &temperature
+	}
+}
+
+// WithMaxIterations overrides defaultMaxIterations.
+func WithMaxIterations(n int) Option {
+	return func(a *Agent) {
+		a.maxIterations = n
+	}
+}
+
+// NewAgent creates an Agent that calls model through completer, using
+// toolbox to resolve tool_calls.
+func NewAgent(completer Completer, toolbox *Toolbox, model string, opts ...Option) *Agent {
+	a := &Agent{
+		completer:     completer,
+		toolbox:       toolbox,
+		model:         model,
+		maxIterations: defaultMaxIterations,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// ErrMaxIterationsExceeded is returned by Run when the model still hasn't
+// produced a tool-call-free message after MaxIterations round trips.
+var ErrMaxIterationsExceeded = fmt.Errorf("agent: exceeded max iterations without a final response")
+
+// Run sends messages to the model, executing any requested tool calls
+// and re-invoking the model with their results, until it returns a
+// message with no tool calls. It returns the full conversation including
+// every intermediate assistant/tool message, so callers can inspect or
+// persist the whole exchange.
+func (a *Agent) Run(ctx context.Context, messages []resources.Message) ([]resources.Message, error) {
+	for i := 0; i < a.maxIterations; i++ {
+		resp, err := a.completer.ChatCompletions(ctx, &resources.CompletionRequest{
+			Model:       a.model,
+			Messages:    messages,
+			Temperature: a.temperature,
+			Tools:       a.toolbox.definitions(),
+		})
+		if err != nil {
+			return messages, fmt.Errorf("agent: chat completion failed: %w", err)
+		}
+		if len(resp.Choices) == 0 {
+			return messages, fmt.Errorf("agent: chat completion returned no choices")
+		}
+
+		assistant := resp.Choices[0].Message
+		messages = append(messages, assistant)
+
+		if len(assistant.ToolCalls) == 0 {
+			return messages, nil
+		}
+
+		for _, call := range assistant.ToolCalls {
+			result, err := a.toolbox.call(ctx, call.Function.Name, call.Function.Arguments)
+			if err != nil {
+				result = err.Error()
+			}
+			messages = append(messages, resources.Message{
+				Role:       "tool",
+				Content:    result,
+				ToolCallID: call.ID,
+			})
+		}
+	}
+
+	return messages, ErrMaxIterationsExceeded
+}
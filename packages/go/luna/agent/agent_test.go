@@ -0,0 +1,135 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/agent"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
+)
+
+// scriptedCompleter returns one canned response per call, in order, so
+// tests can drive the Agent loop through a fixed sequence of tool calls
+// without a real model.
+type scriptedCompleter struct {
+	responses []resources.CompletionResponse
+	calls     int
+}
+
+func (s *scriptedCompleter) ChatCompletions(ctx context.Context, params *resources.CompletionRequest) (*resources.CompletionResponse, error) {
+	resp := s.responses[s.calls]
+	s.calls++
+	return &resp, nil
+}
+
+func TestAgentRunResolvesToolCallThenReturnsFinalMessage(t *testing.T) {
+	var sawArgs map[string]interface{}
+	toolbox := agent.NewToolbox(agent.Tool{
+		Name: "add",
+		Impl: func(ctx context.Context, args map[string]interface{}) (string, error) {
+			sawArgs = args
+			return "3", nil
+		},
+	})
+
+	completer := &scriptedCompleter{
+		responses: []resources.CompletionResponse{
+			{
+				Choices: []resources.Choice{{
+					Message: resources.Message{
+						Role: "assistant",
+						ToolCalls: []resources.ToolCall{{
+							ID:   "call_1",
+							Type: "function",
+							Function: resources.ToolCallFunction{
+								Name:      "add",
+								Arguments: `{"a":1,"b":2}`,
+							},
+						}},
+					},
+					FinishReason: "tool_calls",
+				}},
+			},
+			{
+				Choices: []resources.Choice{{
+					Message:      resources.Message{Role: "assistant", Content: "the answer is 3"},
+					FinishReason: "stop",
+				}},
+			},
+		},
+	}
+
+	a := agent.NewAgent(completer, toolbox, "luna-gpt-4")
+	messages, err := a.Run(context.Background(), []resources.Message{{Role: "user", Content: "what is 1+2?"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sawArgs["a"] != 1.0 || sawArgs["b"] != 2.0 {
+		t.Errorf("expected tool args a=1 b=2, got %v", sawArgs)
+	}
+
+	if len(messages) != 4 {
+		t.Fatalf("expected 4 messages (user, assistant tool-call, tool result, final assistant), got %d", len(messages))
+	}
+	if messages[2].Role != "tool" || messages[2].Content != "3" || messages[2].ToolCallID != "call_1" {
+		t.Errorf("expected tool result message echoing call_1, got %+v", messages[2])
+	}
+	if messages[3].Content != "the answer is 3" {
+		t.Errorf("expected final assistant message, got %+v", messages[3])
+	}
+}
+
+func TestAgentRunStopsWithoutToolCalls(t *testing.T) {
+	toolbox := agent.NewToolbox()
+	completer := &scriptedCompleter{
+		responses: []resources.CompletionResponse{
+			{Choices: []resources.Choice{{Message: resources.Message{Role: "assistant", Content: "hi"}, FinishReason: "stop"}}},
+		},
+	}
+
+	a := agent.NewAgent(completer, toolbox, "luna-gpt-4")
+	messages, err := a.Run(context.Background(), []resources.Message{{Role: "user", Content: "hello"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+}
+
+func TestAgentRunExceedsMaxIterations(t *testing.T) {
+	toolCallResponse := resources.CompletionResponse{
+		Choices: []resources.Choice{{
+			Message: resources.Message{
+				Role: "assistant",
+				ToolCalls: []resources.ToolCall{{
+					ID:       "call_loop",
+					Type:     "function",
+					Function: resources.ToolCallFunction{Name: "noop", Arguments: "{}"},
+				}},
+			},
+			FinishReason: "tool_calls",
+		}},
+	}
+
+	toolbox := agent.NewToolbox(agent.Tool{
+		Name: "noop",
+		Impl: func(ctx context.Context, args map[string]interface{}) (string, error) {
+			return "ok", nil
+		},
+	})
+
+	responses := make([]resources.CompletionResponse, 3)
+	for i := range responses {
+		responses[i] = toolCallResponse
+	}
+
+	completer := &scriptedCompleter{responses: responses}
+	a := agent.NewAgent(completer, toolbox, "luna-gpt-4", agent.WithMaxIterations(3))
+
+	_, err := a.Run(context.Background(), []resources.Message{{Role: "user", Content: "loop forever"}})
+	if err != agent.ErrMaxIterationsExceeded {
+		t.Fatalf("expected ErrMaxIterationsExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	defaultDirTreeMaxDepth  = 3
+	defaultHTTPGetTimeout   = 10 * time.Second
+	defaultHTTPGetMaxBytes  = 64 * 1024
+	httpGetDefaultUserAgent = "luna-sdk-go-agent/1.0"
+)
+
+// DirTreeTool lists a directory's contents as an indented tree, up to a
+// configurable depth. Arguments: "path" (string, required), "max_depth"
+// (number, optional, defaults to defaultDirTreeMaxDepth).
+var DirTreeTool = Tool{
+	Name:        "dir_tree",
+	Description: "List the file and directory structure rooted at path, up to max_depth levels deep.",
+	Parameters: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"path":      map[string]interface{}{"type": "string", "description": "Directory to list"},
+			"max_depth": map[string]interface{}{"type": "integer", "description": "Maximum depth to recurse (default 3)"},
+		},
+		"required": []string{"path"},
+	},
+	Impl: dirTree,
+}
+
+func dirTree(ctx context.Context, args map[string]interface{}) (string, error) {
+	path, _ := args["path"].(string)
+	if path == "" {
+		return "", fmt.Errorf("dir_tree: path argument is required")
+	}
+
+	maxDepth := defaultDirTreeMaxDepth
+	if v, ok := args["max_depth"].(float64); ok && v > 0 {
+		maxDepth = int(v)
+	}
+
+	var b strings.Builder
+	if err := writeDirTree(&b, path, "", 0, maxDepth); err != nil {
+		return "", fmt.Errorf("dir_tree: %w", err)
+	}
+	return b.String(), nil
+}
+
+func writeDirTree(b *strings.Builder, path, prefix string, depth, maxDepth int) error {
+	if depth > maxDepth {
+		return nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		fmt.Fprintf(b, "%s%s\n", prefix, entry.Name())
+		if entry.IsDir() {
+			if err := writeDirTree(b, filepath.Join(path, entry.Name()), prefix+"  ", depth+1, maxDepth); err != nil {
+				fmt.Fprintf(b, "%s  <error: %v>\n", prefix, err)
+			}
+		}
+	}
+	return nil
+}
+
+// HTTPGetTool performs an HTTP GET and returns the response status and a
+// truncated body. Arguments: "url" (string, required). Only the GET
+// method is exposed, and the response body is capped at
+// defaultHTTPGetMaxBytes, so a misbehaving tool call can't be used to
+// exfiltrate arbitrarily large responses into the conversation.
+var HTTPGetTool = Tool{
+	Name:        "http_get",
+	Description: "Fetch a URL with an HTTP GET request and return its status code and body (truncated).",
+	Parameters: map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"url": map[string]interface{}{"type": "string", "description": "URL to fetch"},
+		},
+		"required": []string{"url"},
+	},
+	Impl: httpGet,
+}
+
+func httpGet(ctx context.Context, args map[string]interface{}) (string, error) {
+	url, _ := args["url"].(string)
+	if url == "" {
+		return "", fmt.Errorf("http_get: url argument is required")
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, defaultHTTPGetTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("http_get: failed to build request: %w", err)
+	}
+	req.Header.Set("User-Agent", httpGetDefaultUserAgent)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("http_get: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, defaultHTTPGetMaxBytes))
+	if err != nil {
+		return "", fmt.Errorf("http_get: failed to read response: %w", err)
+	}
+
+	return fmt.Sprintf("status: %d\n\n%s", resp.StatusCode, body), nil
+}
@@ -0,0 +1,57 @@
+package agent_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/agent"
+)
+
+func TestDirTreeTool(t *testing.T) {
+	dir := t.TempDir()
+	if err := createFile(dir + "/a.txt"); err != nil {
+		t.Fatalf("failed to create fixture file: %v", err)
+	}
+
+	out, err := agent.DirTreeTool.Impl(context.Background(), map[string]interface{}{"path": dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "a.txt") {
+		t.Errorf("expected output to contain a.txt, got %q", out)
+	}
+}
+
+func TestDirTreeToolRequiresPath(t *testing.T) {
+	if _, err := agent.DirTreeTool.Impl(context.Background(), map[string]interface{}{}); err == nil {
+		t.Fatal("expected error for missing path argument")
+	}
+}
+
+func TestHTTPGetTool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("pong"))
+	}))
+	defer server.Close()
+
+	out, err := agent.HTTPGetTool.Impl(context.Background(), map[string]interface{}{"url": server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "status: 200") || !strings.Contains(out, "pong") {
+		t.Errorf("expected output to contain status 200 and body, got %q", out)
+	}
+}
+
+func createFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
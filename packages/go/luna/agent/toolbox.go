@@ -0,0 +1,80 @@
+// Package agent wraps the AI resource's tool-calling support into a
+// loop that executes requested tools locally and feeds their results back
+// to the model until it produces a final assistant message.
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
+)
+
+// Tool is a single function the model can call. Parameters is a
+// JSON-schema object describing its arguments (the same shape OpenAI's
+// `tools[].function.parameters` expects); Impl receives those arguments
+// already decoded from the model's JSON and returns the string fed back
+// to the model as the tool's result.
+type Tool struct {
+	Name        string
+	Description string
+	Parameters  map[string]interface{}
+	Impl        func(ctx context.Context, args map[string]interface{}) (string, error)
+}
+
+// Toolbox is a registry of Tools keyed by name, used to advertise their
+// schemas to the model and dispatch its tool_calls back to Impl.
+type Toolbox struct {
+	tools map[string]Tool
+}
+
+// NewToolbox creates a Toolbox containing tools.
+func NewToolbox(tools ...Tool) *Toolbox {
+	b := &Toolbox{tools: make(map[string]Tool, len(tools))}
+	for _, t := range tools {
+		b.Register(t)
+	}
+	return b
+}
+
+// Register adds or replaces a tool by name.
+func (b *Toolbox) Register(tool Tool) {
+	b.tools[tool.Name] = tool
+}
+
+// definitions returns the ToolDefinition for every registered tool, in the
+// shape CompletionRequest.Tools expects.
+func (b *Toolbox) definitions() []resources.ToolDefinition {
+	defs := make([]resources.ToolDefinition, 0, len(b.tools))
+	for _, t := range b.tools {
+		defs = append(defs, resources.ToolDefinition{
+			Type: "function",
+			Function: resources.ToolFunctionSchema{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.Parameters,
+			},
+		})
+	}
+	return defs
+}
+
+// call decodes argumentsJSON and invokes the named tool's Impl, returning
+// an error if no tool is registered under that name or the arguments
+// don't decode as a JSON object.
+func (b *Toolbox) call(ctx context.Context, name, argumentsJSON string) (string, error) {
+	tool, ok := b.tools[name]
+	if !ok {
+		return "", fmt.Errorf("agent: no tool registered with name %q", name)
+	}
+
+	args := map[string]interface{}{}
+	if argumentsJSON != "" {
+		if err := json.Unmarshal([]byte(argumentsJSON), &args); err != nil {
+			return "", fmt.Errorf("agent: failed to decode arguments for tool %q: %w", name, err)
+		}
+	}
+
+	return tool.Impl(ctx, args)
+}
@@ -0,0 +1,294 @@
+// Package analytics provides pluggable product-telemetry for the Luna SDK.
+package analytics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultQueueSize bounds the in-memory event queue. Once full, the oldest
+// queued event is dropped to make room for the newest.
+const defaultQueueSize = 1000
+
+const (
+	defaultBatchSize     = 20
+	defaultFlushInterval = 5 * time.Second
+)
+
+// Event is a single product-telemetry event.
+type Event struct {
+	Event      string                 `json:"event"`
+	UserID     string                 `json:"user_id,omitempty"`
+	DistinctID string                 `json:"distinct_id,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+	Timestamp  time.Time              `json:"ts"`
+}
+
+// Sink receives batches of analytics events. Implementations should not
+// block the caller for long; Client already batches and backgrounds delivery.
+type Sink interface {
+	Send(ctx context.Context, events []Event) error
+}
+
+// NoopSink discards every event. It is the default when no sink is configured.
+type NoopSink struct{}
+
+func (NoopSink) Send(ctx context.Context, events []Event) error { return nil }
+
+// MemorySink records events in memory. Intended for tests.
+type MemorySink struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// NewMemorySink creates a new MemorySink.
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) Send(ctx context.Context, events []Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, events...)
+	return nil
+}
+
+// Events returns a copy of every event recorded so far.
+func (s *MemorySink) Events() []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]Event, len(s.events))
+	copy(out, s.events)
+	return out
+}
+
+// HTTPSink POSTs newline-delimited JSON batches of events to a collector URL.
+type HTTPSink struct {
+	url        string
+	key        string
+	httpClient *http.Client
+}
+
+// NewHTTPAnalyticsSink creates a sink that posts ndjson batches to url,
+// authenticated with key.
+func NewHTTPAnalyticsSink(url, key string) *HTTPSink {
+	return &HTTPSink{
+		url:        url,
+		key:        key,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *HTTPSink) Send(ctx context.Context, events []Event) error {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, ev := range events {
+		if err := enc.Encode(ev); err != nil {
+			return fmt.Errorf("analytics: failed to encode event: %w", err)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, &buf)
+	if err != nil {
+		return fmt.Errorf("analytics: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	if s.key != "" {
+		req.Header.Set("Authorization", "Bearer "+s.key)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("analytics: delivery failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("analytics: sink returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+var _ Sink = NoopSink{}
+var _ Sink = (*MemorySink)(nil)
+var _ Sink = (*HTTPSink)(nil)
+
+// Client queues analytics events and flushes them to a Sink in batches of
+// BatchSize or every FlushInterval, whichever comes first. It is safe to
+// call Track concurrently.
+type Client struct {
+	sink          Sink
+	batchSize     int
+	flushInterval time.Duration
+
+	queue chan Event
+
+	dropped   int64
+	droppedMu sync.Mutex
+
+	wg       sync.WaitGroup
+	closeCh  chan struct{}
+	closeOne sync.Once
+}
+
+// NewClient creates an analytics Client backed by sink. If sink is nil, a
+// NoopSink is used and Track becomes a no-op.
+func NewClient(sink Sink) *Client {
+	if sink == nil {
+		sink = NoopSink{}
+	}
+
+	c := &Client{
+		sink:          sink,
+		batchSize:     defaultBatchSize,
+		flushInterval: defaultFlushInterval,
+		queue:         make(chan Event, defaultQueueSize),
+		closeCh:       make(chan struct{}),
+	}
+
+	c.wg.Add(1)
+	go c.run()
+
+	return c
+}
+
+// Track enqueues an event for delivery. It never blocks: if the queue is
+// full, the oldest queued event is dropped to make room.
+func (c *Client) Track(ctx context.Context, event string, properties map[string]interface{}) {
+	if c == nil {
+		return
+	}
+
+	ev := Event{
+		Event:      event,
+		Properties: properties,
+		Timestamp:  time.Now(),
+	}
+	if uid, ok := UserIDFromContext(ctx); ok {
+		ev.UserID = uid
+	}
+	if did, ok := DistinctIDFromContext(ctx); ok {
+		ev.DistinctID = did
+	}
+
+	select {
+	case c.queue <- ev:
+	default:
+		// Drop-oldest: make room for the newest event.
+		select {
+		case <-c.queue:
+			c.droppedMu.Lock()
+			c.dropped++
+			c.droppedMu.Unlock()
+		default:
+		}
+		select {
+		case c.queue <- ev:
+		default:
+		}
+	}
+}
+
+// Dropped returns the number of events dropped due to queue overflow.
+func (c *Client) Dropped() int64 {
+	c.droppedMu.Lock()
+	defer c.droppedMu.Unlock()
+	return c.dropped
+}
+
+func (c *Client) run() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]Event, 0, c.batchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		_ = c.sink.Send(context.Background(), batch)
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case ev := <-c.queue:
+			batch = append(batch, ev)
+			if len(batch) >= c.batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-c.closeCh:
+			// Drain whatever is already queued before the final flush.
+			for {
+				select {
+				case ev := <-c.queue:
+					batch = append(batch, ev)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// Close stops the background flusher and blocks until the queue has been
+// drained or ctx is done, whichever comes first.
+func (c *Client) Close(ctx context.Context) error {
+	if c == nil {
+		return nil
+	}
+
+	c.closeOne.Do(func() { close(c.closeCh) })
+
+	done := make(chan struct{})
+	go func() {
+		c.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type contextKey int
+
+const (
+	userIDKey contextKey = iota
+	distinctIDKey
+)
+
+// WithUserID attaches a user ID to ctx for events tracked downstream.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// WithDistinctID attaches a distinct ID to ctx for events tracked downstream.
+func WithDistinctID(ctx context.Context, distinctID string) context.Context {
+	return context.WithValue(ctx, distinctIDKey, distinctID)
+}
+
+// UserIDFromContext returns the user ID attached to ctx, if any.
+func UserIDFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(userIDKey).(string)
+	return v, ok
+}
+
+// DistinctIDFromContext returns the distinct ID attached to ctx, if any.
+func DistinctIDFromContext(ctx context.Context) (string, bool) {
+	v, ok := ctx.Value(distinctIDKey).(string)
+	return v, ok
+}
@@ -0,0 +1,27 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// machineBoundPassphrase returns a passphrase tied to this machine and
+// user, used to derive FileTokenStore's encryption key. It deliberately
+// doesn't attempt to read platform-specific hardware IDs (machine-id,
+// IOPlatformUUID, ...); hostname + home directory + OS is enough to make
+// a copied tokens.enc file unreadable on a different machine while
+// staying dependency-free and portable across darwin/linux/windows.
+func machineBoundPassphrase() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("auth: failed to resolve home directory: %w", err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("auth: failed to resolve hostname: %w", err)
+	}
+
+	return fmt.Sprintf("luna-sdk-go|%s|%s|%s", runtime.GOOS, hostname, home), nil
+}
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+)
+
+// MTLSAuth implements mutual-TLS authentication: the client certificate,
+// not an Authorization header, is the credential. Construct it with
+// NewMTLSAuth and pass it to the top-level luna.WithAuthProvider; the
+// underlying lunahttp.Client detects its TLSConfig method and installs the
+// certificate on its transport.
+type MTLSAuth struct {
+	tlsConfig *tls.Config
+}
+
+// NewMTLSAuth creates a new mTLS authentication provider from a PEM-encoded
+// client certificate and private key, and an optional PEM-encoded CA
+// bundle used to verify the server's certificate instead of the system
+// trust store. All parsing happens here so later use of MTLSAuth can never
+// fail.
+func NewMTLSAuth(certPEM, keyPEM, caPEM []byte) (*MTLSAuth, error) {
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to parse client certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if len(caPEM) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("auth: failed to parse CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &MTLSAuth{tlsConfig: tlsConfig}, nil
+}
+
+// GetHeaders returns no headers; the client certificate presented during
+// the TLS handshake is the credential.
+func (a *MTLSAuth) GetHeaders() (map[string]string, error) {
+	return nil, nil
+}
+
+// NeedsRefresh returns false as the client certificate doesn't rotate
+// through this provider.
+func (a *MTLSAuth) NeedsRefresh() bool {
+	return false
+}
+
+// Refresh is a no-op for mTLS.
+func (a *MTLSAuth) Refresh() error {
+	return nil
+}
+
+// TLSConfig returns the *tls.Config the HTTP client should use to present
+// the client certificate.
+func (a *MTLSAuth) TLSConfig() *tls.Config {
+	return a.tlsConfig
+}
+
+var _ Provider = (*MTLSAuth)(nil)
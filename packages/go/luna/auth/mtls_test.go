@@ -0,0 +1,109 @@
+package auth_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/auth"
+)
+
+func generateTestCertPEM(t *testing.T) (certPEM, keyPEM []byte) {
+	t.Helper()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "luna-sdk-test"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM
+}
+
+func TestMTLSAuth(t *testing.T) {
+	certPEM, keyPEM := generateTestCertPEM(t)
+
+	t.Run("creates auth with valid cert and key", func(t *testing.T) {
+		a, err := auth.NewMTLSAuth(certPEM, keyPEM, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if a.TLSConfig() == nil {
+			t.Fatal("expected a non-nil TLS config")
+		}
+		if len(a.TLSConfig().Certificates) != 1 {
+			t.Errorf("expected 1 certificate, got %d", len(a.TLSConfig().Certificates))
+		}
+	})
+
+	t.Run("returns error on invalid cert/key pair", func(t *testing.T) {
+		if _, err := auth.NewMTLSAuth([]byte("not a cert"), keyPEM, nil); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("returns error on invalid CA bundle", func(t *testing.T) {
+		if _, err := auth.NewMTLSAuth(certPEM, keyPEM, []byte("not a CA bundle")); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("sets RootCAs when a CA bundle is provided", func(t *testing.T) {
+		a, err := auth.NewMTLSAuth(certPEM, keyPEM, certPEM)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if a.TLSConfig().RootCAs == nil {
+			t.Error("expected RootCAs to be set")
+		}
+	})
+
+	t.Run("returns no Authorization header", func(t *testing.T) {
+		a, err := auth.NewMTLSAuth(certPEM, keyPEM, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		headers, err := a.GetHeaders()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if headers != nil {
+			t.Errorf("expected no headers, got %v", headers)
+		}
+	})
+
+	t.Run("does not need refresh", func(t *testing.T) {
+		a, err := auth.NewMTLSAuth(certPEM, keyPEM, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if a.NeedsRefresh() {
+			t.Error("expected NeedsRefresh to be false")
+		}
+	})
+}
@@ -0,0 +1,348 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Default OAuth 2.0 / OIDC endpoints for Eclipse's own auth server, used
+// whenever a constructor isn't given one of the With* URL overrides below
+// (e.g. for a third-party IdP).
+const (
+	defaultOAuth2AuthorizeURL           = "https://auth.eclipse.dev/authorize"
+	defaultOAuth2TokenURL               = "https://auth.eclipse.dev/oauth/token"
+	defaultOAuth2DeviceAuthorizationURL = "https://auth.eclipse.dev/oauth/device/authorize"
+)
+
+// oauth2TokenResponse is the token endpoint's JSON response shape (RFC
+// 6749 §5.1), shared by every grant type in this file.
+type oauth2TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	IDToken      string `json:"id_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// oauth2TokenError is the RFC 6749 §5.2 error response shape, returned
+// (wrapped) from postForm so callers like the device code poll loop can
+// distinguish "authorization_pending" from a fatal error.
+type oauth2TokenError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *oauth2TokenError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("auth: token request failed: %s (%s)", e.Code, e.Description)
+	}
+	return fmt.Sprintf("auth: token request failed: %s", e.Code)
+}
+
+// postForm POSTs values to tokenURL as a standard OAuth2 token (or device
+// code poll) request and decodes the resulting oauth2TokenResponse.
+func postForm(tokenURL string, values url.Values) (oauth2TokenResponse, error) {
+	return postFormContext(context.Background(), tokenURL, values)
+}
+
+// postFormContext is postForm with a caller-supplied context, used by
+// OIDCAuth so its token-endpoint calls honor the ctx the caller exchanged
+// or refreshed with.
+func postFormContext(ctx context.Context, tokenURL string, values url.Values) (oauth2TokenResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return oauth2TokenResponse{}, fmt.Errorf("auth: failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return oauth2TokenResponse{}, fmt.Errorf("auth: token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return oauth2TokenResponse{}, fmt.Errorf("auth: failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var tokErr oauth2TokenError
+		if jsonErr := json.Unmarshal(body, &tokErr); jsonErr == nil && tokErr.Code != "" {
+			return oauth2TokenResponse{}, &tokErr
+		}
+		return oauth2TokenResponse{}, fmt.Errorf("auth: token request failed with status %d", resp.StatusCode)
+	}
+
+	var token oauth2TokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return oauth2TokenResponse{}, fmt.Errorf("auth: failed to decode token response: %w", err)
+	}
+	return token, nil
+}
+
+// oidcDiscovery is the subset of a "/.well-known/openid-configuration"
+// document this package uses.
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+	// EndSessionEndpoint, if the IdP advertises one, lets OIDCAuth.EndSessionURL
+	// build an RP-initiated logout URL. Empty for IdPs that don't support it.
+	EndSessionEndpoint string `json:"end_session_endpoint"`
+}
+
+// discoverOIDC fetches issuer's OpenID Connect discovery document — the
+// same one `luna doctor` pings at auth.eclipse.dev/.well-known/openid-configuration.
+func discoverOIDC(issuer string) (*oidcDiscovery, error) {
+	return discoverOIDCContext(context.Background(), issuer)
+}
+
+// discoverOIDCContext is discoverOIDC with a caller-supplied context, so
+// NewOIDCAuth's discovery request can be cancelled or time out the same
+// way any other outbound call in this SDK can.
+func discoverOIDCContext(ctx context.Context, issuer string) (*oidcDiscovery, error) {
+	discoveryURL := strings.TrimRight(issuer, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to build OIDC discovery request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth: OIDC discovery request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth: OIDC discovery failed with status %d", resp.StatusCode)
+	}
+
+	var discovery oidcDiscovery
+	if err := json.NewDecoder(resp.Body).Decode(&discovery); err != nil {
+		return nil, fmt.Errorf("auth: failed to decode OIDC discovery document: %w", err)
+	}
+	return &discovery, nil
+}
+
+// oauth2Settings collects what every constructor in this file needs,
+// populated by OAuth2Option and defaulted to Eclipse's own auth server.
+type oauth2Settings struct {
+	tokenURL       string
+	authorizeURL   string
+	deviceAuthURL  string
+	leeway         time.Duration
+	store          TokenStore
+	profile        string
+	onAuthorizeURL func(authorizeURL string)
+}
+
+func newOAuth2Settings() *oauth2Settings {
+	return &oauth2Settings{
+		tokenURL:      defaultOAuth2TokenURL,
+		authorizeURL:  defaultOAuth2AuthorizeURL,
+		deviceAuthURL: defaultOAuth2DeviceAuthorizationURL,
+		leeway:        5 * time.Minute,
+	}
+}
+
+// OAuth2Option configures an OAuth2Provider constructor.
+type OAuth2Option func(*oauth2Settings)
+
+// WithOAuth2TokenURL overrides the token endpoint, for IdPs other than
+// auth.eclipse.dev.
+func WithOAuth2TokenURL(tokenURL string) OAuth2Option {
+	return func(s *oauth2Settings) { s.tokenURL = tokenURL }
+}
+
+// WithOAuth2AuthorizeURL overrides the authorization endpoint used by
+// NewOAuth2AuthCodePKCE.
+func WithOAuth2AuthorizeURL(authorizeURL string) OAuth2Option {
+	return func(s *oauth2Settings) { s.authorizeURL = authorizeURL }
+}
+
+// WithOAuth2DeviceAuthorizationURL overrides the device authorization
+// endpoint used by NewOAuth2DeviceCode.
+func WithOAuth2DeviceAuthorizationURL(deviceAuthURL string) OAuth2Option {
+	return func(s *oauth2Settings) { s.deviceAuthURL = deviceAuthURL }
+}
+
+// WithOAuth2Leeway overrides how long before the real expiry NeedsRefresh
+// starts reporting true. Defaults to 5 minutes, matching TokenAuth.
+func WithOAuth2Leeway(leeway time.Duration) OAuth2Option {
+	return func(s *oauth2Settings) { s.leeway = leeway }
+}
+
+// WithOAuth2TokenStore makes Refresh persist rotated tokens to store under
+// profile, exactly like WithTokenStore does for TokenAuth.
+func WithOAuth2TokenStore(store TokenStore, profile string) OAuth2Option {
+	return func(s *oauth2Settings) { s.store = store; s.profile = profile }
+}
+
+// WithOAuth2AuthorizeURLHandler is invoked by NewOAuth2AuthCodePKCE and
+// NewOAuth2DeviceCode with the URL (or verification URL) the user must
+// open, before blocking on the browser redirect / device approval. If
+// unset, the URL is printed to stdout.
+func WithOAuth2AuthorizeURLHandler(fn func(authorizeURL string)) OAuth2Option {
+	return func(s *oauth2Settings) { s.onAuthorizeURL = fn }
+}
+
+// OAuth2Provider implements Provider for any OAuth 2.0 grant that yields
+// an access token: NewOAuth2ClientCredentials, NewOAuth2AuthCodePKCE, and
+// NewOAuth2DeviceCode all return one. Refresh performs the refresh_token
+// grant against tokenURL when a refresh token is available; grants that
+// don't get one (client credentials) instead re-run their original grant.
+type OAuth2Provider struct {
+	mu           sync.RWMutex
+	clientID     string
+	tokenURL     string
+	accessToken  string
+	refreshToken string
+	idToken      string
+	expiresAt    *time.Time
+	leeway       time.Duration
+	store        TokenStore
+	profile      string
+	refreshOnce  coalescer
+	reacquire    func() (oauth2TokenResponse, error)
+}
+
+func newOAuth2Provider(clientID string, settings *oauth2Settings, token oauth2TokenResponse, reacquire func() (oauth2TokenResponse, error)) *OAuth2Provider {
+	p := &OAuth2Provider{
+		clientID:  clientID,
+		tokenURL:  settings.tokenURL,
+		leeway:    settings.leeway,
+		store:     settings.store,
+		profile:   settings.profile,
+		reacquire: reacquire,
+	}
+	p.applyToken(token)
+	return p
+}
+
+func (p *OAuth2Provider) applyToken(token oauth2TokenResponse) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.accessToken = token.AccessToken
+	if token.RefreshToken != "" {
+		p.refreshToken = token.RefreshToken
+	}
+	if token.IDToken != "" {
+		p.idToken = token.IDToken
+	}
+	if token.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+		p.expiresAt = &expiresAt
+	}
+}
+
+// GetHeaders returns the Authorization header for the current access
+// token, refreshing first if it's expiring within leeway.
+func (p *OAuth2Provider) GetHeaders() (map[string]string, error) {
+	if p.NeedsRefresh() {
+		if err := p.Refresh(); err != nil {
+			return nil, err
+		}
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return map[string]string{
+		"Authorization": "Bearer " + p.accessToken,
+	}, nil
+}
+
+// NeedsRefresh reports whether the access token expires within leeway.
+func (p *OAuth2Provider) NeedsRefresh() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.expiresAt == nil {
+		return false
+	}
+	return time.Now().After(p.expiresAt.Add(-p.leeway))
+}
+
+// IDToken returns the most recently issued OIDC ID token, or "" if the
+// grant never returned one.
+func (p *OAuth2Provider) IDToken() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.idToken
+}
+
+// Refresh rotates the access token, deduplicating concurrent callers
+// within this process the same way TokenAuth.Refresh does; WithOAuth2TokenStore
+// additionally serializes refreshes across processes.
+func (p *OAuth2Provider) Refresh() error {
+	return p.refreshOnce.Do(p.refreshLocked)
+}
+
+func (p *OAuth2Provider) refreshLocked() error {
+	if p.store != nil {
+		if err := p.store.Lock(p.profile); err != nil {
+			return fmt.Errorf("auth: failed to acquire token lock: %w", err)
+		}
+		defer p.store.Unlock(p.profile)
+
+		if stored, err := p.store.Load(p.profile); err == nil && stored.AccessToken != "" {
+			p.mu.Lock()
+			p.accessToken = stored.AccessToken
+			p.refreshToken = stored.RefreshToken
+			p.expiresAt = stored.ExpiresAt
+			p.mu.Unlock()
+			if !p.NeedsRefresh() {
+				return nil
+			}
+		}
+	}
+
+	p.mu.RLock()
+	refreshToken := p.refreshToken
+	p.mu.RUnlock()
+
+	var (
+		token oauth2TokenResponse
+		err   error
+	)
+	switch {
+	case refreshToken != "":
+		token, err = postForm(p.tokenURL, url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {refreshToken},
+			"client_id":     {p.clientID},
+		})
+	case p.reacquire != nil:
+		token, err = p.reacquire()
+	default:
+		return fmt.Errorf("auth: no refresh token available and this grant can't be silently re-run")
+	}
+	if err != nil {
+		return err
+	}
+
+	p.applyToken(token)
+
+	if p.store != nil {
+		p.mu.RLock()
+		tokens := Tokens{AccessToken: p.accessToken, RefreshToken: p.refreshToken, ExpiresAt: p.expiresAt}
+		p.mu.RUnlock()
+		if err := p.store.Save(p.profile, tokens); err != nil {
+			return fmt.Errorf("auth: failed to persist refreshed tokens: %w", err)
+		}
+	}
+
+	return nil
+}
+
+var _ Provider = (*OAuth2Provider)(nil)
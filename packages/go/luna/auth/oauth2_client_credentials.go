@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// NewOAuth2ClientCredentials performs the OAuth 2.0 client credentials
+// grant (RFC 6749 §4.4) and returns a Provider backed by the resulting
+// access token. This grant authenticates the application itself rather
+// than a user, so clientSecret is sent directly to the token endpoint —
+// it's meant for service-to-service and CLI/server contexts, never for
+// code that ships to end users' browsers or devices.
+func NewOAuth2ClientCredentials(clientID, clientSecret string, scopes []string, opts ...OAuth2Option) (*OAuth2Provider, error) {
+	if clientID == "" || clientSecret == "" {
+		return nil, fmt.Errorf("auth: client ID and client secret are required")
+	}
+
+	settings := newOAuth2Settings()
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	grant := func() (oauth2TokenResponse, error) {
+		values := url.Values{
+			"grant_type":    {"client_credentials"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+		}
+		if len(scopes) > 0 {
+			values.Set("scope", strings.Join(scopes, " "))
+		}
+		return postForm(settings.tokenURL, values)
+	}
+
+	token, err := grant()
+	if err != nil {
+		return nil, err
+	}
+
+	return newOAuth2Provider(clientID, settings, token, grant), nil
+}
@@ -0,0 +1,113 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// deviceAuthorizationResponse is the device authorization endpoint's JSON
+// response shape (RFC 8628 §3.2).
+type deviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// NewOAuth2DeviceCode performs the OAuth 2.0 device authorization grant
+// (RFC 8628) for headless machines with no browser or loopback port to
+// bind: it prints (or hands to WithOAuth2AuthorizeURLHandler) a short
+// verification URL and user code for the caller to enter on a second
+// device, then polls the token endpoint at the server-specified interval
+// until they do, the grant expires, or the device declines it.
+func NewOAuth2DeviceCode(clientID string, scopes []string, opts ...OAuth2Option) (*OAuth2Provider, error) {
+	if clientID == "" {
+		return nil, fmt.Errorf("auth: client ID is required")
+	}
+
+	settings := newOAuth2Settings()
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	values := url.Values{"client_id": {clientID}}
+	if len(scopes) > 0 {
+		values.Set("scope", strings.Join(scopes, " "))
+	}
+
+	resp, err := http.PostForm(settings.deviceAuthURL, values)
+	if err != nil {
+		return nil, fmt.Errorf("auth: device authorization request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to read device authorization response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth: device authorization failed with status %d", resp.StatusCode)
+	}
+
+	var deviceAuth deviceAuthorizationResponse
+	if err := json.Unmarshal(body, &deviceAuth); err != nil {
+		return nil, fmt.Errorf("auth: failed to decode device authorization response: %w", err)
+	}
+
+	verificationURL := deviceAuth.VerificationURIComplete
+	if verificationURL == "" {
+		verificationURL = deviceAuth.VerificationURI
+	}
+	if settings.onAuthorizeURL != nil {
+		settings.onAuthorizeURL(verificationURL)
+	} else {
+		fmt.Printf("To authenticate, visit %s and enter code: %s\n", deviceAuth.VerificationURI, deviceAuth.UserCode)
+	}
+
+	interval := time.Duration(deviceAuth.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(deviceAuth.ExpiresIn) * time.Second)
+
+	grant := func() (oauth2TokenResponse, error) {
+		return postForm(settings.tokenURL, url.Values{
+			"grant_type":  {"urn:ietf:params:oauth:grant-type:device_code"},
+			"device_code": {deviceAuth.DeviceCode},
+			"client_id":   {clientID},
+		})
+	}
+
+	for {
+		token, err := grant()
+		if err == nil {
+			return newOAuth2Provider(clientID, settings, token, nil), nil
+		}
+
+		var tokErr *oauth2TokenError
+		if !errors.As(err, &tokErr) {
+			return nil, err
+		}
+		switch tokErr.Code {
+		case "authorization_pending":
+			// fall through to the sleep/deadline check below
+		case "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, err
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("auth: device code expired before authorization completed")
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,173 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// NewOAuth2AuthCodePKCE performs the OAuth 2.0 authorization code grant
+// with PKCE (RFC 7636): it binds a loopback HTTP server, opens (or hands
+// to WithOAuth2AuthorizeURLHandler) the authorization URL, and blocks
+// until the browser redirects back or two minutes elapse. If redirectURL
+// is empty, an ephemeral "http://127.0.0.1:<port>/callback" is generated
+// and used; otherwise the server binds redirectURL's own host:port, for
+// IdPs that require a specific, pre-registered redirect URI.
+func NewOAuth2AuthCodePKCE(clientID, redirectURL string, scopes []string, opts ...OAuth2Option) (*OAuth2Provider, error) {
+	if clientID == "" {
+		return nil, fmt.Errorf("auth: client ID is required")
+	}
+
+	settings := newOAuth2Settings()
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	return authCodePKCE(clientID, redirectURL, scopes, settings, nil)
+}
+
+// authCodePKCE is the shared implementation behind NewOAuth2AuthCodePKCE
+// and NewOIDCProvider (which adds a "nonce" via extraAuthParams and
+// validates the resulting ID token itself).
+func authCodePKCE(clientID, redirectURL string, scopes []string, settings *oauth2Settings, extraAuthParams url.Values) (*OAuth2Provider, error) {
+	bindAddr := "127.0.0.1:0"
+	if redirectURL != "" {
+		parsed, err := url.Parse(redirectURL)
+		if err != nil {
+			return nil, fmt.Errorf("auth: invalid redirect URL: %w", err)
+		}
+		bindAddr = parsed.Host
+		if !strings.Contains(bindAddr, ":") {
+			bindAddr += ":0"
+		}
+	}
+
+	listener, err := net.Listen("tcp", bindAddr)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to start loopback callback server: %w", err)
+	}
+	if redirectURL == "" {
+		redirectURL = fmt.Sprintf("http://127.0.0.1:%d/callback", listener.Addr().(*net.TCPAddr).Port)
+	}
+
+	verifier, err := randomURLSafeToken(32)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+	challenge := pkceS256Challenge(verifier)
+	state, err := randomURLSafeToken(32)
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	values := url.Values{
+		"client_id":             {clientID},
+		"redirect_uri":          {redirectURL},
+		"response_type":         {"code"},
+		"state":                 {state},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}
+	if len(scopes) > 0 {
+		values.Set("scope", strings.Join(scopes, " "))
+	}
+	for key, vals := range extraAuthParams {
+		for _, v := range vals {
+			values.Add(key, v)
+		}
+	}
+	authorizeURL := settings.authorizeURL + "?" + values.Encode()
+
+	if settings.onAuthorizeURL != nil {
+		settings.onAuthorizeURL(authorizeURL)
+	} else {
+		fmt.Printf("Open the following URL to authenticate:\n%s\n", authorizeURL)
+	}
+
+	code, err := waitForCallback(listener, state)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := postForm(settings.tokenURL, url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {clientID},
+		"code":          {code},
+		"redirect_uri":  {redirectURL},
+		"code_verifier": {verifier},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newOAuth2Provider(clientID, settings, token, nil), nil
+}
+
+// waitForCallback serves a single OAuth redirect on listener, validating
+// state to guard against CSRF, then shuts the server down and returns the
+// authorization code.
+func waitForCallback(listener net.Listener, state string) (string, error) {
+	done := make(chan string, 1)
+	errCh := make(chan error, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("state"); got != state {
+			http.Error(w, "state mismatch", http.StatusBadRequest)
+			errCh <- fmt.Errorf("auth: state mismatch in OAuth callback: possible CSRF attempt")
+			return
+		}
+		code := r.URL.Query().Get("code")
+		if code == "" {
+			http.Error(w, "missing code", http.StatusBadRequest)
+			errCh <- fmt.Errorf("auth: authorization code missing from OAuth callback")
+			return
+		}
+		fmt.Fprint(w, "Authentication successful. You can close this window.")
+		done <- code
+	})
+
+	server := &http.Server{Handler: mux}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			errCh <- fmt.Errorf("auth: loopback callback server error: %w", err)
+		}
+	}()
+	defer server.Shutdown(context.Background())
+
+	select {
+	case code := <-done:
+		return code, nil
+	case err := <-errCh:
+		return "", err
+	case <-time.After(2 * time.Minute):
+		return "", fmt.Errorf("auth: timed out waiting for OAuth callback")
+	}
+}
+
+// randomURLSafeToken returns a base64url-encoded (no padding) random
+// token backed by n bytes of crypto/rand, suitable for PKCE verifiers,
+// OAuth state values, and OIDC nonces.
+func randomURLSafeToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// pkceS256Challenge derives the S256 code_challenge for verifier: the
+// base64url (no padding) encoding of SHA-256(verifier).
+func pkceS256Challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
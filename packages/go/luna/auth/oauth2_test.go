@@ -0,0 +1,159 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/auth"
+)
+
+func TestOAuth2ClientCredentials(t *testing.T) {
+	t.Run("requires client ID and secret", func(t *testing.T) {
+		if _, err := auth.NewOAuth2ClientCredentials("", "secret", nil); err == nil {
+			t.Fatal("expected error for missing client ID")
+		}
+		if _, err := auth.NewOAuth2ClientCredentials("client", "", nil); err == nil {
+			t.Fatal("expected error for missing client secret")
+		}
+	})
+
+	t.Run("exchanges client credentials for an access token", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseForm(); err != nil {
+				t.Fatalf("failed to parse form: %v", err)
+			}
+			if r.FormValue("grant_type") != "client_credentials" {
+				t.Errorf("expected grant_type=client_credentials, got %s", r.FormValue("grant_type"))
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "cc-access-token",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+		}))
+		defer server.Close()
+
+		provider, err := auth.NewOAuth2ClientCredentials("client", "secret", []string{"payments:read"}, auth.WithOAuth2TokenURL(server.URL))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		headers, err := provider.GetHeaders()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if headers["Authorization"] != "Bearer cc-access-token" {
+			t.Errorf("expected Authorization=Bearer cc-access-token, got %s", headers["Authorization"])
+		}
+		if provider.NeedsRefresh() {
+			t.Error("expected NeedsRefresh to be false immediately after the grant")
+		}
+	})
+
+	t.Run("returns an error for a token endpoint failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error":             "invalid_client",
+				"error_description": "unknown client",
+			})
+		}))
+		defer server.Close()
+
+		if _, err := auth.NewOAuth2ClientCredentials("client", "secret", nil, auth.WithOAuth2TokenURL(server.URL)); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestOAuth2DeviceCode(t *testing.T) {
+	t.Run("polls through authorization_pending to a token", func(t *testing.T) {
+		var pollCount int
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/device/authorize", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"device_code":      "dc-123",
+				"user_code":        "ABCD-EFGH",
+				"verification_uri": "https://example.test/device",
+				"expires_in":       600,
+				"interval":         0,
+			})
+		})
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			pollCount++
+			if pollCount < 2 {
+				w.WriteHeader(http.StatusBadRequest)
+				json.NewEncoder(w).Encode(map[string]string{"error": "authorization_pending"})
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "dc-access-token",
+				"expires_in":   3600,
+			})
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		var seenVerificationURL string
+		provider, err := auth.NewOAuth2DeviceCode(
+			"client",
+			nil,
+			auth.WithOAuth2DeviceAuthorizationURL(server.URL+"/device/authorize"),
+			auth.WithOAuth2TokenURL(server.URL+"/token"),
+			auth.WithOAuth2AuthorizeURLHandler(func(url string) { seenVerificationURL = url }),
+		)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if seenVerificationURL != "https://example.test/device" {
+			t.Errorf("expected the verification URL handler to fire, got %q", seenVerificationURL)
+		}
+
+		headers, err := provider.GetHeaders()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if headers["Authorization"] != "Bearer dc-access-token" {
+			t.Errorf("expected Authorization=Bearer dc-access-token, got %s", headers["Authorization"])
+		}
+		if pollCount < 2 {
+			t.Errorf("expected at least 2 polls, got %d", pollCount)
+		}
+	})
+
+	t.Run("stops polling on a fatal device error", func(t *testing.T) {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/device/authorize", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"device_code":      "dc-456",
+				"user_code":        "WXYZ-1234",
+				"verification_uri": "https://example.test/device",
+				"expires_in":       600,
+				"interval":         0,
+			})
+		})
+		mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "access_denied"})
+		})
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		_, err := auth.NewOAuth2DeviceCode(
+			"client",
+			nil,
+			auth.WithOAuth2DeviceAuthorizationURL(server.URL+"/device/authorize"),
+			auth.WithOAuth2TokenURL(server.URL+"/token"),
+		)
+		if err == nil {
+			t.Fatal("expected access_denied to abort the poll loop")
+		}
+	})
+}
@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OIDCProvider wraps the OAuth2Provider returned by an authorization
+// code + PKCE exchange and additionally validates the ID token the IdP
+// returns alongside it — signature, issuer, audience, expiry, and nonce —
+// per the OpenID Connect Core spec.
+type OIDCProvider struct {
+	*OAuth2Provider
+	issuer   string
+	clientID string
+	jwks     *jwksCache
+}
+
+// NewOIDCProvider discovers issuer's OpenID Connect configuration (issuer
+// + "/.well-known/openid-configuration" — the same document `luna doctor`
+// already pings at auth.eclipse.dev) and performs the authorization code
+// + PKCE flow against the discovered endpoints, validating the returned
+// ID token's signature and iss/aud/exp/nonce claims before returning.
+func NewOIDCProvider(issuer, clientID, redirectURL string, scopes []string, opts ...OAuth2Option) (*OIDCProvider, error) {
+	if issuer == "" || clientID == "" {
+		return nil, fmt.Errorf("auth: issuer and client ID are required")
+	}
+
+	discovery, err := discoverOIDC(issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := newOAuth2Settings()
+	settings.authorizeURL = discovery.AuthorizationEndpoint
+	settings.tokenURL = discovery.TokenEndpoint
+	for _, opt := range opts {
+		opt(settings)
+	}
+
+	if !containsScope(scopes, "openid") {
+		scopes = append(append([]string{}, scopes...), "openid")
+	}
+
+	nonce, err := randomURLSafeToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := authCodePKCE(clientID, redirectURL, scopes, settings, url.Values{"nonce": {nonce}})
+	if err != nil {
+		return nil, err
+	}
+
+	provider := &OIDCProvider{
+		OAuth2Provider: base,
+		issuer:         discovery.Issuer,
+		clientID:       clientID,
+		jwks:           newJWKSCache(discovery.JWKSURI),
+	}
+
+	if err := provider.validateIDToken(base.IDToken(), nonce); err != nil {
+		return nil, fmt.Errorf("auth: ID token validation failed: %w", err)
+	}
+
+	return provider, nil
+}
+
+func containsScope(scopes []string, scope string) bool {
+	for _, s := range scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// idTokenClaims holds the subset of standard OIDC claims this package
+// validates; everything else in the token is ignored.
+type idTokenClaims struct {
+	Issuer    string      `json:"iss"`
+	Audience  interface{} `json:"aud"`
+	Expiry    int64       `json:"exp"`
+	NotBefore int64       `json:"nbf"`
+	Nonce     string      `json:"nonce"`
+}
+
+func (c idTokenClaims) hasAudience(clientID string) bool {
+	switch aud := c.Audience.(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if s, ok := a.(string); ok && s == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validateIDToken checks idToken's signature against this provider's
+// JWKS, then its iss/aud/exp/nonce claims.
+func (p *OIDCProvider) validateIDToken(idToken, expectedNonce string) error {
+	_, err := verifyIDToken(p.jwks, p.issuer, p.clientID, idToken, expectedNonce)
+	return err
+}
+
+// verifyIDToken checks idToken's RS256 signature against jwks, then its
+// iss/aud/exp/nbf/nonce claims, returning the parsed claims on success.
+// Shared by OIDCProvider (the CLI-oriented loopback-server flow) and
+// OIDCAuth (the server-side authorization-code flow), so both validate ID
+// tokens identically.
+func verifyIDToken(jwks *jwksCache, issuer, clientID, idToken, expectedNonce string) (idTokenClaims, error) {
+	if idToken == "" {
+		return idTokenClaims{}, fmt.Errorf("no ID token returned alongside the access token")
+	}
+
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		return idTokenClaims{}, fmt.Errorf("malformed ID token")
+	}
+
+	header, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return idTokenClaims{}, fmt.Errorf("failed to decode ID token header: %w", err)
+	}
+	var h struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(header, &h); err != nil {
+		return idTokenClaims{}, fmt.Errorf("failed to parse ID token header: %w", err)
+	}
+	if h.Alg != "RS256" {
+		return idTokenClaims{}, fmt.Errorf("unsupported ID token signing algorithm %q", h.Alg)
+	}
+
+	key, err := jwks.key(h.Kid)
+	if err != nil {
+		return idTokenClaims{}, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return idTokenClaims{}, fmt.Errorf("failed to decode ID token signature: %w", err)
+	}
+	hashed := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, hashed[:], signature); err != nil {
+		return idTokenClaims{}, fmt.Errorf("ID token signature verification failed: %w", err)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return idTokenClaims{}, fmt.Errorf("failed to decode ID token claims: %w", err)
+	}
+	var claims idTokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return idTokenClaims{}, fmt.Errorf("failed to parse ID token claims: %w", err)
+	}
+
+	if claims.Issuer != issuer {
+		return idTokenClaims{}, fmt.Errorf("issuer %q does not match expected issuer %q", claims.Issuer, issuer)
+	}
+	if !claims.hasAudience(clientID) {
+		return idTokenClaims{}, fmt.Errorf("audience does not include client ID %q", clientID)
+	}
+	if time.Now().After(time.Unix(claims.Expiry, 0)) {
+		return idTokenClaims{}, fmt.Errorf("token has expired")
+	}
+	if claims.NotBefore > 0 && time.Now().Before(time.Unix(claims.NotBefore, 0)) {
+		return idTokenClaims{}, fmt.Errorf("token is not valid yet (nbf)")
+	}
+	if expectedNonce != "" && claims.Nonce != expectedNonce {
+		return idTokenClaims{}, fmt.Errorf("nonce does not match")
+	}
+
+	return claims, nil
+}
+
+var _ Provider = (*OIDCProvider)(nil)
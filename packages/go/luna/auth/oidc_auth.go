@@ -0,0 +1,318 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OIDCConfig configures NewOIDCAuth. Unlike NewOIDCProvider (which drives
+// a blocking, loopback-server authorization code flow meant for CLIs),
+// OIDCAuth is meant for a long-lived server process: it performs
+// discovery up front, then exposes AuthCodeURL/Exchange so the calling
+// application's own HTTP handlers can drive the redirect and callback.
+type OIDCConfig struct {
+	IssuerURL string
+	ClientID  string
+	// ClientSecret is sent alongside ClientID at the token endpoint for a
+	// confidential client. Empty for a public client (PKCE alone).
+	ClientSecret string
+	Scopes       []string
+	RedirectURL  string
+}
+
+// OIDCAuth implements Provider against an OIDC IdP discovered from
+// OIDCConfig.IssuerURL, validating ID tokens against the issuer's JWKS
+// (refreshed periodically by jwksCache) and refreshing the access token
+// automatically once it's within Skew of expiring.
+type OIDCAuth struct {
+	config    OIDCConfig
+	discovery *oidcDiscovery
+	jwks      *jwksCache
+	skew      time.Duration
+	callback  func(TokenPair) error
+	store     TokenStore
+	profile   string
+
+	mu           sync.RWMutex
+	accessToken  string
+	refreshToken string
+	idToken      string
+	expiresAt    *time.Time
+	refreshOnce  coalescer
+}
+
+// OIDCAuthOption configures NewOIDCAuth.
+type OIDCAuthOption func(*OIDCAuth)
+
+// WithOIDCSkew overrides how long before the real expiry NeedsRefresh
+// starts reporting true. Defaults to 30 seconds.
+func WithOIDCSkew(skew time.Duration) OIDCAuthOption {
+	return func(a *OIDCAuth) { a.skew = skew }
+}
+
+// WithOIDCRefreshCallback registers callback to be invoked with the
+// rotated TokenPair every time Refresh succeeds — the same contract
+// NewTokenAuth's callback parameter has, and what the top-level luna
+// package's WithTokenRefreshCallback ultimately wires up for
+// luna.WithOIDC.
+func WithOIDCRefreshCallback(callback func(TokenPair) error) OIDCAuthOption {
+	return func(a *OIDCAuth) { a.callback = callback }
+}
+
+// WithOIDCTokenStore makes Refresh persist rotated tokens to store under
+// profile, exactly like WithOAuth2TokenStore does for OAuth2Provider.
+func WithOIDCTokenStore(store TokenStore, profile string) OIDCAuthOption {
+	return func(a *OIDCAuth) { a.store = store; a.profile = profile }
+}
+
+// NewOIDCAuth discovers config.IssuerURL's OpenID Connect configuration
+// (honoring ctx for cancellation) and returns an unauthenticated OIDCAuth
+// ready to drive the authorization code flow via AuthCodeURL/Exchange. It
+// holds no tokens until Exchange succeeds.
+func NewOIDCAuth(ctx context.Context, config OIDCConfig, opts ...OIDCAuthOption) (*OIDCAuth, error) {
+	if config.IssuerURL == "" || config.ClientID == "" {
+		return nil, fmt.Errorf("auth: IssuerURL and ClientID are required")
+	}
+
+	discovery, err := discoverOIDCContext(ctx, config.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+	if !containsScope(config.Scopes, "openid") {
+		config.Scopes = append(append([]string{}, config.Scopes...), "openid")
+	}
+
+	a := &OIDCAuth{
+		config:    config,
+		discovery: discovery,
+		jwks:      newJWKSCache(discovery.JWKSURI),
+		skew:      30 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a, nil
+}
+
+// AuthCodeURL builds the authorization URL the caller should redirect the
+// user to, using PKCE (RFC 7636) in place of a client secret on the front
+// channel even for a confidential client. codeVerifier and nonce must be
+// held by the caller (typically in a short-lived server-side session tied
+// to state) and passed back into Exchange once the IdP redirects back.
+func (a *OIDCAuth) AuthCodeURL(state string) (authURL, codeVerifier, nonce string, err error) {
+	codeVerifier, err = randomURLSafeToken(32)
+	if err != nil {
+		return "", "", "", err
+	}
+	nonce, err = randomURLSafeToken(16)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	values := url.Values{
+		"client_id":             {a.config.ClientID},
+		"redirect_uri":          {a.config.RedirectURL},
+		"response_type":         {"code"},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {pkceS256Challenge(codeVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+	if len(a.config.Scopes) > 0 {
+		values.Set("scope", strings.Join(a.config.Scopes, " "))
+	}
+
+	return a.discovery.AuthorizationEndpoint + "?" + values.Encode(), codeVerifier, nonce, nil
+}
+
+// Exchange redeems code at the discovered token endpoint, validates the
+// returned ID token's signature and iss/aud/exp/nbf/nonce claims against
+// expectedNonce (the value AuthCodeURL generated for this flow), and
+// stores the resulting tokens so OIDCAuth can serve as a Provider from
+// here on.
+func (a *OIDCAuth) Exchange(ctx context.Context, code, codeVerifier, expectedNonce string) (TokenPair, error) {
+	values := url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {a.config.ClientID},
+		"code":          {code},
+		"redirect_uri":  {a.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	if a.config.ClientSecret != "" {
+		values.Set("client_secret", a.config.ClientSecret)
+	}
+
+	token, err := postFormContext(ctx, a.discovery.TokenEndpoint, values)
+	if err != nil {
+		return TokenPair{}, err
+	}
+
+	if _, err := verifyIDToken(a.jwks, a.discovery.Issuer, a.config.ClientID, token.IDToken, expectedNonce); err != nil {
+		return TokenPair{}, fmt.Errorf("auth: ID token validation failed: %w", err)
+	}
+
+	a.applyToken(token)
+	return a.tokenPair(), nil
+}
+
+// EndSessionURL builds an RP-initiated logout URL (as specified by OpenID
+// Connect Session Management) against the discovered end_session_endpoint,
+// for a caller that wants to log the user out at the IdP too. Returns an
+// error if the IdP didn't advertise one.
+func (a *OIDCAuth) EndSessionURL(idTokenHint, postLogoutRedirectURI string) (string, error) {
+	if a.discovery.EndSessionEndpoint == "" {
+		return "", fmt.Errorf("auth: issuer %q does not advertise an end_session_endpoint", a.discovery.Issuer)
+	}
+
+	values := url.Values{}
+	if idTokenHint != "" {
+		values.Set("id_token_hint", idTokenHint)
+	}
+	if postLogoutRedirectURI != "" {
+		values.Set("post_logout_redirect_uri", postLogoutRedirectURI)
+	}
+	if len(values) == 0 {
+		return a.discovery.EndSessionEndpoint, nil
+	}
+	return a.discovery.EndSessionEndpoint + "?" + values.Encode(), nil
+}
+
+// SetTokens seeds OIDCAuth with tokens obtained outside this instance --
+// typically by the host application's own login handler calling Exchange
+// on a short-lived OIDCAuth earlier in the request, then handing the
+// resulting TokenPair to a longer-lived one (or, as luna.WithOIDC does,
+// to the one backing an API client). It does not validate an ID token,
+// since none is available once tokens have been reduced to a TokenPair.
+func (a *OIDCAuth) SetTokens(tokens TokenPair) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.accessToken = tokens.AccessToken
+	a.refreshToken = tokens.RefreshToken
+	a.expiresAt = tokens.ExpiresAt
+}
+
+func (a *OIDCAuth) applyToken(token oauth2TokenResponse) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.accessToken = token.AccessToken
+	if token.RefreshToken != "" {
+		a.refreshToken = token.RefreshToken
+	}
+	if token.IDToken != "" {
+		a.idToken = token.IDToken
+	}
+	if token.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+		a.expiresAt = &expiresAt
+	}
+}
+
+func (a *OIDCAuth) tokenPair() TokenPair {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return TokenPair{AccessToken: a.accessToken, RefreshToken: a.refreshToken, ExpiresAt: a.expiresAt}
+}
+
+// GetHeaders implements Provider, refreshing first if the access token is
+// expiring within Skew.
+func (a *OIDCAuth) GetHeaders() (map[string]string, error) {
+	if a.NeedsRefresh() {
+		if err := a.Refresh(); err != nil {
+			return nil, err
+		}
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.accessToken == "" {
+		return nil, fmt.Errorf("auth: OIDCAuth has no access token yet; call Exchange first")
+	}
+	return map[string]string{
+		"Authorization": "Bearer " + a.accessToken,
+	}, nil
+}
+
+// NeedsRefresh implements Provider: true once the access token is within
+// Skew of its expiry.
+func (a *OIDCAuth) NeedsRefresh() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.expiresAt == nil {
+		return false
+	}
+	return time.Now().After(a.expiresAt.Add(-a.skew))
+}
+
+// Refresh implements Provider: it performs the refresh_token grant against
+// the discovered token endpoint, deduplicating concurrent callers the same
+// way OAuth2Provider.Refresh does, then invokes the WithOIDCRefreshCallback
+// callback (if any) with the rotated TokenPair.
+func (a *OIDCAuth) Refresh() error {
+	return a.refreshOnce.Do(a.refreshLocked)
+}
+
+func (a *OIDCAuth) refreshLocked() error {
+	if a.store != nil {
+		if err := a.store.Lock(a.profile); err != nil {
+			return fmt.Errorf("auth: failed to acquire token lock: %w", err)
+		}
+		defer a.store.Unlock(a.profile)
+
+		if stored, err := a.store.Load(a.profile); err == nil && stored.AccessToken != "" {
+			a.mu.Lock()
+			a.accessToken = stored.AccessToken
+			a.refreshToken = stored.RefreshToken
+			a.expiresAt = stored.ExpiresAt
+			a.mu.Unlock()
+			if !a.NeedsRefresh() {
+				return nil
+			}
+		}
+	}
+
+	a.mu.RLock()
+	refreshToken := a.refreshToken
+	a.mu.RUnlock()
+	if refreshToken == "" {
+		return fmt.Errorf("auth: no refresh token available")
+	}
+
+	values := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {a.config.ClientID},
+	}
+	if a.config.ClientSecret != "" {
+		values.Set("client_secret", a.config.ClientSecret)
+	}
+
+	token, err := postFormContext(context.Background(), a.discovery.TokenEndpoint, values)
+	if err != nil {
+		return err
+	}
+	a.applyToken(token)
+
+	pair := a.tokenPair()
+
+	if a.store != nil {
+		if err := a.store.Save(a.profile, Tokens{AccessToken: pair.AccessToken, RefreshToken: pair.RefreshToken, ExpiresAt: pair.ExpiresAt}); err != nil {
+			return fmt.Errorf("auth: failed to persist refreshed tokens: %w", err)
+		}
+	}
+
+	if a.callback != nil {
+		if err := a.callback(pair); err != nil {
+			return fmt.Errorf("auth: token refresh callback failed: %w", err)
+		}
+	}
+
+	return nil
+}
+
+var _ Provider = (*OIDCAuth)(nil)
@@ -9,23 +9,60 @@ import (
 	"time"
 )
 
+// TokenPair is the access/refresh token pair handed to a caller-supplied
+// refresh callback (see WithTokenRefreshCallback in the top-level luna
+// package) whenever Refresh rotates the tokens.
+type TokenPair struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    *time.Time
+}
+
 // TokenAuth implements OAuth token authentication with refresh
 type TokenAuth struct {
 	accessToken  string
 	refreshToken string
 	expiresAt    *time.Time
+	callback     func(TokenPair) error
+	store        TokenStore
+	profile      string
 	mu           sync.RWMutex
+	refreshOnce  coalescer
+}
+
+// TokenAuthOption configures optional TokenAuth behavior.
+type TokenAuthOption func(*TokenAuth)
+
+// WithTokenStore makes Refresh persist rotated tokens to store under
+// profile, re-reading the latest tokens from store (under a cross-process
+// lock) before refreshing so a rotated refresh token from one process
+// doesn't invalidate the others.
+func WithTokenStore(store TokenStore, profile string) TokenAuthOption {
+	return func(t *TokenAuth) {
+		t.store = store
+		t.profile = profile
+	}
 }
 
-// NewTokenAuth creates a new token authentication provider
-func NewTokenAuth(accessToken, refreshToken string) *TokenAuth {
+// NewTokenAuth creates a new token authentication provider. callback, if
+// non-nil, is invoked with the rotated TokenPair every time Refresh
+// succeeds.
+func NewTokenAuth(accessToken, refreshToken string, callback func(TokenPair) error, opts ...TokenAuthOption) (*TokenAuth, error) {
 	if accessToken == "" {
-		panic("auth: access token is required")
+		return nil, fmt.Errorf("auth: access token is required")
 	}
-	return &TokenAuth{
+
+	t := &TokenAuth{
 		accessToken:  accessToken,
 		refreshToken: refreshToken,
+		callback:     callback,
+	}
+
+	for _, opt := range opts {
+		opt(t)
 	}
+
+	return t, nil
 }
 
 // GetHeaders returns authorization headers with the access token
@@ -62,17 +99,39 @@ func (t *TokenAuth) NeedsRefresh() bool {
 	return time.Now().Add(buffer).After(*t.expiresAt)
 }
 
-// Refresh refreshes the access token
+// Refresh refreshes the access token. Concurrent callers within one
+// process share a single HTTP round trip via t.refreshOnce; across
+// processes, WithTokenStore's lock serializes the refresh and re-reads
+// whatever the winning process already persisted.
 func (t *TokenAuth) Refresh() error {
-	t.mu.Lock()
-	defer t.mu.Unlock()
+	return t.refreshOnce.Do(t.refreshLocked)
+}
+
+func (t *TokenAuth) refreshLocked() error {
+	if t.store != nil {
+		if err := t.store.Lock(t.profile); err != nil {
+			return fmt.Errorf("auth: failed to acquire token lock: %w", err)
+		}
+		defer t.store.Unlock(t.profile)
+
+		if stored, err := t.store.Load(t.profile); err == nil && stored.AccessToken != "" {
+			t.UpdateTokens(stored.AccessToken, stored.RefreshToken, stored.ExpiresAt)
+			if !t.NeedsRefresh() {
+				return nil
+			}
+		}
+	}
+
+	t.mu.RLock()
+	refreshToken := t.refreshToken
+	t.mu.RUnlock()
 
-	if t.refreshToken == "" {
+	if refreshToken == "" {
 		return fmt.Errorf("auth: no refresh token available")
 	}
 
 	body, _ := json.Marshal(map[string]string{
-		"refresh_token": t.refreshToken,
+		"refresh_token": refreshToken,
 	})
 
 	resp, err := http.Post(
@@ -99,10 +158,26 @@ func (t *TokenAuth) Refresh() error {
 		return fmt.Errorf("auth: failed to decode refresh response: %w", err)
 	}
 
-	t.accessToken = result.AccessToken
-	t.refreshToken = result.RefreshToken
 	expiresAt := time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
-	t.expiresAt = &expiresAt
+	t.UpdateTokens(result.AccessToken, result.RefreshToken, &expiresAt)
+
+	pair := TokenPair{AccessToken: result.AccessToken, RefreshToken: result.RefreshToken, ExpiresAt: &expiresAt}
+
+	if t.callback != nil {
+		if err := t.callback(pair); err != nil {
+			return fmt.Errorf("auth: token refresh callback failed: %w", err)
+		}
+	}
+
+	if t.store != nil {
+		if err := t.store.Save(t.profile, Tokens{
+			AccessToken:  pair.AccessToken,
+			RefreshToken: pair.RefreshToken,
+			ExpiresAt:    pair.ExpiresAt,
+		}); err != nil {
+			return fmt.Errorf("auth: failed to persist refreshed tokens: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -118,3 +193,37 @@ func (t *TokenAuth) UpdateTokens(accessToken, refreshToken string, expiresAt *ti
 }
 
 var _ Provider = (*TokenAuth)(nil)
+
+// coalescer runs fn at most once per overlapping set of callers, sharing
+// the single result with anyone who calls Do while it is in flight. It is
+// the in-process half of TokenAuth's refresh deduplication; WithTokenStore
+// handles the cross-process half.
+type coalescer struct {
+	mu       sync.Mutex
+	inFlight chan struct{}
+	err      error
+}
+
+func (c *coalescer) Do(fn func() error) error {
+	c.mu.Lock()
+	if c.inFlight != nil {
+		ch := c.inFlight
+		c.mu.Unlock()
+		<-ch
+		return c.err
+	}
+
+	ch := make(chan struct{})
+	c.inFlight = ch
+	c.mu.Unlock()
+
+	err := fn()
+
+	c.mu.Lock()
+	c.err = err
+	c.inFlight = nil
+	c.mu.Unlock()
+	close(ch)
+
+	return err
+}
@@ -0,0 +1,22 @@
+package auth
+
+import "time"
+
+// Tokens is the access/refresh/expiry triple a TokenStore persists.
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    *time.Time
+}
+
+// TokenStore persists OAuth tokens for a profile across process restarts.
+// Lock/Unlock additionally coordinate *concurrent processes* refreshing the
+// same profile: TokenAuth.Refresh acquires the lock, re-reads Load in case
+// another process already rotated the tokens, and only performs the HTTP
+// refresh if the re-read tokens still need it.
+type TokenStore interface {
+	Load(profile string) (Tokens, error)
+	Save(profile string, tokens Tokens) error
+	Lock(profile string) error
+	Unlock(profile string) error
+}
@@ -0,0 +1,223 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// FileTokenStore persists tokens in a single AES-256-GCM encrypted file,
+// one JSON object per profile. The encryption key is derived via scrypt
+// from a machine-bound passphrase (see machineBoundPassphrase) so the file
+// is unreadable if copied to another machine without also copying whatever
+// that function reads from.
+type FileTokenStore struct {
+	// Path is the encrypted token file. Defaults to
+	// ~/.luna/tokens.enc when created via NewFileTokenStore.
+	Path string
+	// Passphrase derives the encryption key. Defaults to a machine-bound
+	// value (see machineBoundPassphrase) when created via
+	// NewFileTokenStore; tests should set this explicitly instead.
+	Passphrase string
+
+	lockPath string
+}
+
+// NewFileTokenStore creates a FileTokenStore rooted at ~/.luna/tokens.enc,
+// keyed by a machine-bound passphrase.
+func NewFileTokenStore() (*FileTokenStore, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to resolve home directory: %w", err)
+	}
+
+	passphrase, err := machineBoundPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(home, ".luna", "tokens.enc")
+	return &FileTokenStore{
+		Path:       path,
+		Passphrase: passphrase,
+		lockPath:   path + ".lock",
+	}, nil
+}
+
+type fileStorePayload struct {
+	Profiles map[string]keyringPayload `json:"profiles"`
+}
+
+func (s *FileTokenStore) Load(profile string) (Tokens, error) {
+	payload, err := s.readAll()
+	if err != nil {
+		return Tokens{}, err
+	}
+
+	entry, ok := payload.Profiles[profile]
+	if !ok {
+		return Tokens{}, nil
+	}
+
+	return Tokens{
+		AccessToken:  entry.AccessToken,
+		RefreshToken: entry.RefreshToken,
+		ExpiresAt:    entry.ExpiresAt,
+	}, nil
+}
+
+func (s *FileTokenStore) Save(profile string, tokens Tokens) error {
+	payload, err := s.readAll()
+	if err != nil {
+		return err
+	}
+	if payload.Profiles == nil {
+		payload.Profiles = make(map[string]keyringPayload)
+	}
+
+	payload.Profiles[profile] = keyringPayload{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+	}
+
+	return s.writeAll(payload)
+}
+
+// Lock acquires a cross-process advisory lock for profile by atomically
+// creating a lock file, retrying with backoff until it succeeds or 10
+// seconds elapse. It guards the whole store rather than a single profile's
+// entry because Save rewrites the entire encrypted file.
+func (s *FileTokenStore) Lock(profile string) error {
+	if err := os.MkdirAll(filepath.Dir(s.lockPath), 0700); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		f, err := os.OpenFile(s.lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			return f.Close()
+		}
+		if !os.IsExist(err) {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("auth: timed out waiting for token store lock %s", s.lockPath)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// Unlock releases the lock acquired by Lock.
+func (s *FileTokenStore) Unlock(profile string) error {
+	err := os.Remove(s.lockPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileTokenStore) readAll() (fileStorePayload, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fileStorePayload{Profiles: make(map[string]keyringPayload)}, nil
+		}
+		return fileStorePayload{}, err
+	}
+
+	plaintext, err := s.decrypt(data)
+	if err != nil {
+		return fileStorePayload{}, fmt.Errorf("auth: failed to decrypt token store: %w", err)
+	}
+
+	var payload fileStorePayload
+	if err := json.Unmarshal(plaintext, &payload); err != nil {
+		return fileStorePayload{}, err
+	}
+	if payload.Profiles == nil {
+		payload.Profiles = make(map[string]keyringPayload)
+	}
+	return payload, nil
+}
+
+func (s *FileTokenStore) writeAll(payload fileStorePayload) error {
+	plaintext, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ciphertext, err := s.encrypt(plaintext)
+	if err != nil {
+		return fmt.Errorf("auth: failed to encrypt token store: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.Path, ciphertext, 0600)
+}
+
+// scryptSalt is fixed rather than random-per-file: the key already derives
+// from a machine-bound passphrase, and a fixed salt lets readAll decrypt
+// without a second unencrypted sidecar file to store a random one in.
+var scryptSalt = []byte("luna-sdk-go/token-store/v1")
+
+func (s *FileTokenStore) deriveKey() ([]byte, error) {
+	return scrypt.Key([]byte(s.Passphrase), scryptSalt, 1<<15, 8, 1, 32)
+}
+
+func (s *FileTokenStore) encrypt(plaintext []byte) ([]byte, error) {
+	key, err := s.deriveKey()
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (s *FileTokenStore) decrypt(data []byte) ([]byte, error) {
+	key, err := s.deriveKey()
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	nonce, ciphertext := data[:gcm.NonceSize()], data[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, ciphertext, nil)
+}
+
+var _ TokenStore = (*FileTokenStore)(nil)
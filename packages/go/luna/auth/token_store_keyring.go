@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService is the service name tokens are stored under in the OS
+// keychain (macOS Keychain, Windows Credential Manager, Secret Service on
+// Linux via go-keyring).
+const keyringService = "luna-sdk"
+
+// KeyringTokenStore persists tokens in the OS keychain. The keychain
+// already serializes concurrent writers, so Lock/Unlock are no-ops.
+type KeyringTokenStore struct{}
+
+// NewKeyringTokenStore creates a KeyringTokenStore.
+func NewKeyringTokenStore() *KeyringTokenStore {
+	return &KeyringTokenStore{}
+}
+
+type keyringPayload struct {
+	AccessToken  string     `json:"access_token"`
+	RefreshToken string     `json:"refresh_token"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+}
+
+func (s *KeyringTokenStore) Load(profile string) (Tokens, error) {
+	raw, err := keyring.Get(keyringService, profile)
+	if err != nil {
+		if err == keyring.ErrNotFound {
+			return Tokens{}, nil
+		}
+		return Tokens{}, err
+	}
+
+	var payload keyringPayload
+	if err := json.Unmarshal([]byte(raw), &payload); err != nil {
+		return Tokens{}, err
+	}
+
+	return Tokens{
+		AccessToken:  payload.AccessToken,
+		RefreshToken: payload.RefreshToken,
+		ExpiresAt:    payload.ExpiresAt,
+	}, nil
+}
+
+func (s *KeyringTokenStore) Save(profile string, tokens Tokens) error {
+	raw, err := json.Marshal(keyringPayload{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	return keyring.Set(keyringService, profile, string(raw))
+}
+
+func (s *KeyringTokenStore) Lock(profile string) error   { return nil }
+func (s *KeyringTokenStore) Unlock(profile string) error { return nil }
+
+var _ TokenStore = (*KeyringTokenStore)(nil)
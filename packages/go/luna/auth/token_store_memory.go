@@ -0,0 +1,59 @@
+package auth
+
+import "sync"
+
+// MemoryTokenStore is an in-process TokenStore backed by a map. It never
+// persists anything and its Lock/Unlock are plain mutexes, so it is only
+// suitable for tests and single-process use.
+type MemoryTokenStore struct {
+	mu     sync.Mutex
+	tokens map[string]Tokens
+	locks  map[string]*sync.Mutex
+}
+
+// NewMemoryTokenStore creates an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: make(map[string]Tokens),
+		locks:  make(map[string]*sync.Mutex),
+	}
+}
+
+func (s *MemoryTokenStore) Load(profile string) (Tokens, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tokens[profile], nil
+}
+
+func (s *MemoryTokenStore) Save(profile string, tokens Tokens) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[profile] = tokens
+	return nil
+}
+
+func (s *MemoryTokenStore) Lock(profile string) error {
+	s.mu.Lock()
+	l, ok := s.locks[profile]
+	if !ok {
+		l = &sync.Mutex{}
+		s.locks[profile] = l
+	}
+	s.mu.Unlock()
+
+	l.Lock()
+	return nil
+}
+
+func (s *MemoryTokenStore) Unlock(profile string) error {
+	s.mu.Lock()
+	l, ok := s.locks[profile]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	l.Unlock()
+	return nil
+}
+
+var _ TokenStore = (*MemoryTokenStore)(nil)
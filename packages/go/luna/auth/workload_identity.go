@@ -0,0 +1,130 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// WorkloadConfig configures WorkloadIdentityAuth.
+type WorkloadConfig struct {
+	// TokenFile is the path to a projected service-account token (e.g. a
+	// Kubernetes projected volume, or GCP/AWS workload identity file) that
+	// WorkloadIdentityAuth exchanges for a Luna access token.
+	TokenFile string
+	// Audience is sent as the intended audience of the exchanged token.
+	Audience string
+	// Endpoint is the token exchange URL.
+	Endpoint string
+}
+
+// WorkloadIdentityAuth implements authentication via workload identity
+// federation: it exchanges a platform-issued token (read fresh from
+// TokenFile on every refresh, since the platform rotates it) for a
+// short-lived Luna access token, without any long-lived secret of its own.
+type WorkloadIdentityAuth struct {
+	config      WorkloadConfig
+	accessToken string
+	expiresAt   time.Time
+	mu          sync.RWMutex
+	refreshOnce coalescer
+}
+
+// NewWorkloadIdentityAuth creates a new workload identity authentication
+// provider and performs an initial token exchange so construction fails
+// fast if TokenFile or Endpoint is misconfigured.
+func NewWorkloadIdentityAuth(config WorkloadConfig) (*WorkloadIdentityAuth, error) {
+	if config.TokenFile == "" {
+		return nil, fmt.Errorf("auth: token file is required")
+	}
+	if config.Endpoint == "" {
+		return nil, fmt.Errorf("auth: endpoint is required")
+	}
+
+	w := &WorkloadIdentityAuth{config: config}
+
+	if err := w.Refresh(); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// GetHeaders returns authorization headers with the exchanged access
+// token, refreshing it first if it's expiring soon.
+func (w *WorkloadIdentityAuth) GetHeaders() (map[string]string, error) {
+	if w.NeedsRefresh() {
+		if err := w.Refresh(); err != nil {
+			return nil, err
+		}
+	}
+
+	w.mu.RLock()
+	token := w.accessToken
+	w.mu.RUnlock()
+
+	return map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", token),
+	}, nil
+}
+
+// NeedsRefresh returns true if the exchanged token is expiring soon.
+func (w *WorkloadIdentityAuth) NeedsRefresh() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	// Refresh if expiring within 5 minutes
+	buffer := 5 * time.Minute
+	return time.Now().Add(buffer).After(w.expiresAt)
+}
+
+// Refresh exchanges the platform token in config.TokenFile for a fresh
+// Luna access token. Concurrent callers within one process share a single
+// HTTP round trip via w.refreshOnce.
+func (w *WorkloadIdentityAuth) Refresh() error {
+	return w.refreshOnce.Do(w.refreshLocked)
+}
+
+func (w *WorkloadIdentityAuth) refreshLocked() error {
+	subjectToken, err := os.ReadFile(w.config.TokenFile)
+	if err != nil {
+		return fmt.Errorf("auth: failed to read token file: %w", err)
+	}
+
+	body, _ := json.Marshal(map[string]string{
+		"audience":      w.config.Audience,
+		"subject_token": string(subjectToken),
+	})
+
+	resp, err := http.Post(w.config.Endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("auth: token exchange request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("auth: token exchange failed with status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("auth: failed to decode token exchange response: %w", err)
+	}
+
+	w.mu.Lock()
+	w.accessToken = result.AccessToken
+	w.expiresAt = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	w.mu.Unlock()
+
+	return nil
+}
+
+var _ Provider = (*WorkloadIdentityAuth)(nil)
@@ -0,0 +1,151 @@
+// Package chatbot provides a branching, persistable conversation tree for
+// multi-turn AI chat sessions, so a caller can explore alternative
+// responses (fork an earlier turn, edit and resend a message) without
+// losing the threads it branched from.
+package chatbot
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// Message is one turn in a Conversation tree. ParentID is empty only for
+// the root message; every other message's ParentID must name an existing
+// Message in the same Conversation.
+type Message struct {
+	ID        string    `json:"id"`
+	ParentID  string    `json:"parent_id,omitempty"`
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+	// Children lists, in creation order, every message whose ParentID is
+	// this Message's ID -- the set of branches that diverge from here.
+	Children []string `json:"children,omitempty"`
+}
+
+// Conversation is a tree of Messages rooted at a single system prompt.
+// ActiveID marks the leaf of the branch currently in use: ActivePath
+// replays it, and Append/Session.Chat extend it.
+type Conversation struct {
+	ID       string              `json:"id"`
+	RootID   string              `json:"root_id"`
+	ActiveID string              `json:"active_id"`
+	Messages map[string]*Message `json:"messages"`
+}
+
+// NewConversation creates a Conversation with a single root message
+// carrying systemPrompt, active from the start.
+func NewConversation(id string, systemPrompt string) *Conversation {
+	root := &Message{
+		ID:        generateMessageID(),
+		Role:      "system",
+		Content:   systemPrompt,
+		CreatedAt: time.Now(),
+	}
+	return &Conversation{
+		ID:       id,
+		RootID:   root.ID,
+		ActiveID: root.ID,
+		Messages: map[string]*Message{root.ID: root},
+	}
+}
+
+// Append adds a new Message as a child of the active leaf and makes it the
+// new active leaf. Use AppendTo to branch from an earlier message instead.
+func (c *Conversation) Append(role, content string) *Message {
+	msg, err := c.AppendTo(c.ActiveID, role, content)
+	if err != nil {
+		// ActiveID always names an existing message (NewConversation and
+		// every mutator that changes it enforce this), so AppendTo can
+		// only fail on an unknown parentID.
+		panic(err)
+	}
+	return msg
+}
+
+// AppendTo adds a new Message as a child of parentID -- a sibling branch if
+// parentID already has children -- and makes it the new active leaf. It
+// returns an error if parentID doesn't name an existing message.
+func (c *Conversation) AppendTo(parentID, role, content string) (*Message, error) {
+	parent, ok := c.Messages[parentID]
+	if !ok {
+		return nil, fmt.Errorf("chatbot: unknown parent message %q", parentID)
+	}
+
+	msg := &Message{
+		ID:        generateMessageID(),
+		ParentID:  parentID,
+		Role:      role,
+		Content:   content,
+		CreatedAt: time.Now(),
+	}
+	c.Messages[msg.ID] = msg
+	parent.Children = append(parent.Children, msg.ID)
+	c.ActiveID = msg.ID
+	return msg, nil
+}
+
+// SwitchBranch moves the active leaf to messageID, so the next Append
+// extends that branch instead of whichever was active before. It returns
+// an error if messageID doesn't name an existing message.
+func (c *Conversation) SwitchBranch(messageID string) error {
+	if _, ok := c.Messages[messageID]; !ok {
+		return fmt.Errorf("chatbot: unknown message %q", messageID)
+	}
+	c.ActiveID = messageID
+	return nil
+}
+
+// ActivePath returns the messages from the root to ActiveID, in
+// chronological order. This -- not the full tree -- is what Session.Chat
+// replays when building a request to the model.
+func (c *Conversation) ActivePath() []*Message {
+	return c.PathTo(c.ActiveID)
+}
+
+// PathTo returns the messages from the root to messageID, in chronological
+// order, or nil if messageID doesn't name an existing message.
+func (c *Conversation) PathTo(messageID string) []*Message {
+	msg, ok := c.Messages[messageID]
+	if !ok {
+		return nil
+	}
+
+	var path []*Message
+	for {
+		path = append([]*Message{msg}, path...)
+		if msg.ParentID == "" {
+			return path
+		}
+		msg, ok = c.Messages[msg.ParentID]
+		if !ok {
+			return path
+		}
+	}
+}
+
+// Clone returns a deep copy of c, so a forked Conversation can diverge
+// (Append, SwitchBranch) without mutating the original's Messages or
+// ActiveID.
+func (c *Conversation) Clone() *Conversation {
+	clone := &Conversation{
+		ID:       c.ID,
+		RootID:   c.RootID,
+		ActiveID: c.ActiveID,
+		Messages: make(map[string]*Message, len(c.Messages)),
+	}
+	for id, msg := range c.Messages {
+		copied := *msg
+		copied.Children = append([]string(nil), msg.Children...)
+		clone.Messages[id] = &copied
+	}
+	return clone
+}
+
+func generateMessageID() string {
+	timestamp := strconv.FormatInt(time.Now().UnixNano(), 36)
+	random := strconv.FormatInt(rand.Int63(), 36)[:8]
+	return fmt.Sprintf("msg_%s%s", timestamp, random)
+}
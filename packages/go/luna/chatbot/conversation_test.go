@@ -0,0 +1,64 @@
+package chatbot_test
+
+import (
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/chatbot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConversationActivePathFollowsActiveBranch(t *testing.T) {
+	conv := chatbot.NewConversation("conv_1", "you are helpful")
+	root := conv.ActiveID
+
+	conv.Append("user", "hello")
+	conv.Append("assistant", "hi there")
+
+	path := conv.ActivePath()
+	require.Len(t, path, 3)
+	assert.Equal(t, root, path[0].ID)
+	assert.Equal(t, "hello", path[1].Content)
+	assert.Equal(t, "hi there", path[2].Content)
+}
+
+func TestConversationSwitchBranchIsolatesSiblings(t *testing.T) {
+	conv := chatbot.NewConversation("conv_1", "you are helpful")
+
+	userMsg := conv.Append("user", "what's the weather?")
+	firstReply := conv.Append("assistant", "it's sunny")
+
+	require.NoError(t, conv.SwitchBranch(userMsg.ID))
+	secondReply, err := conv.AppendTo(userMsg.ID, "assistant", "it's raining")
+	require.NoError(t, err)
+
+	// The user message now has two sibling assistant replies.
+	assert.ElementsMatch(t, []string{firstReply.ID, secondReply.ID}, conv.Messages[userMsg.ID].Children)
+
+	// ActivePath only sees the branch currently active -- the second
+	// reply, not the first.
+	path := conv.ActivePath()
+	require.Len(t, path, 3)
+	assert.Equal(t, "it's raining", path[2].Content)
+
+	require.NoError(t, conv.SwitchBranch(firstReply.ID))
+	path = conv.ActivePath()
+	require.Len(t, path, 3)
+	assert.Equal(t, "it's sunny", path[2].Content)
+}
+
+func TestConversationSwitchBranchRejectsUnknownMessage(t *testing.T) {
+	conv := chatbot.NewConversation("conv_1", "you are helpful")
+	assert.Error(t, conv.SwitchBranch("msg_does_not_exist"))
+}
+
+func TestConversationCloneIsIndependent(t *testing.T) {
+	conv := chatbot.NewConversation("conv_1", "you are helpful")
+	conv.Append("user", "hello")
+
+	clone := conv.Clone()
+	clone.Append("assistant", "hi from the clone")
+
+	assert.Len(t, conv.ActivePath(), 2)
+	assert.Len(t, clone.ActivePath(), 3)
+}
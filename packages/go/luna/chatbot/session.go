@@ -0,0 +1,165 @@
+package chatbot
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
+)
+
+// Completer is the subset of AiResource a Session needs to generate
+// replies; satisfied by *resources.AiResource without an adapter.
+type Completer interface {
+	ChatCompletions(ctx context.Context, params *resources.CompletionRequest) (*resources.CompletionResponse, error)
+}
+
+// Session drives a Conversation through a Completer, replaying only the
+// active path on every turn (see Conversation.ActivePath) so a branch a
+// caller forked away from, or abandoned with SwitchBranch, never leaks
+// into the model's context.
+type Session struct {
+	Conversation *Conversation
+
+	completer   Completer
+	model       string
+	temperature *float64
+	store       ConversationStore
+}
+
+// Option configures a Session.
+type Option func(*Session)
+
+// WithTemperature sets the sampling temperature used on every completion
+// the Session requests.
+func WithTemperature(temperature float64) Option {
+	return func(s *Session) {
+		s.temperature = &temperature
+	}
+}
+
+// WithStore persists the Conversation through store after every Chat and
+// EditAndResend call. Without it, a Session's branches only live in
+// memory.
+func WithStore(store ConversationStore) Option {
+	return func(s *Session) {
+		s.store = store
+	}
+}
+
+// NewSession creates a Session with a fresh Conversation rooted at
+// systemPrompt.
+func NewSession(id, systemPrompt string, completer Completer, model string, opts ...Option) *Session {
+	s := &Session{
+		Conversation: NewConversation(id, systemPrompt),
+		completer:    completer,
+		model:        model,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Resume creates a Session around an already-loaded Conversation, e.g. one
+// returned by ConversationStore.Load, picking up wherever its ActiveID
+// left off.
+func Resume(conv *Conversation, completer Completer, model string, opts ...Option) *Session {
+	s := &Session{
+		Conversation: conv,
+		completer:    completer,
+		model:        model,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Chat appends userContent as a child of the active leaf, replays the
+// active path to the model, appends its reply as the new active leaf, and
+// returns the reply.
+func (s *Session) Chat(ctx context.Context, userContent string) (string, error) {
+	s.Conversation.Append("user", userContent)
+
+	reply, err := s.complete(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.Conversation.Append("assistant", reply)
+	return reply, s.persist()
+}
+
+// Fork returns a new Session whose Conversation is an independent copy of
+// s's, with its active leaf moved to messageID -- so exploring an
+// alternative continuation from an earlier turn doesn't disturb s's own
+// active branch or the branch from messageID might already be on.
+func (s *Session) Fork(messageID string) (*Session, error) {
+	clone := s.Conversation.Clone()
+	if err := clone.SwitchBranch(messageID); err != nil {
+		return nil, err
+	}
+
+	forked := *s
+	forked.Conversation = clone
+	return &forked, nil
+}
+
+// SwitchBranch moves s's active leaf to messageID, so the next Chat
+// extends that existing branch instead of whichever was active before.
+func (s *Session) SwitchBranch(messageID string) error {
+	return s.Conversation.SwitchBranch(messageID)
+}
+
+// EditAndResend replaces the user turn at messageID with newContent on a
+// new sibling branch and resends it to the model for a fresh reply --
+// for revising an earlier prompt without losing the original branch.
+func (s *Session) EditAndResend(ctx context.Context, messageID, newContent string) (string, error) {
+	msg, ok := s.Conversation.Messages[messageID]
+	if !ok {
+		return "", fmt.Errorf("chatbot: unknown message %q", messageID)
+	}
+	if msg.Role != "user" {
+		return "", fmt.Errorf("chatbot: EditAndResend requires a user message, got role %q", msg.Role)
+	}
+
+	if _, err := s.Conversation.AppendTo(msg.ParentID, "user", newContent); err != nil {
+		return "", err
+	}
+
+	reply, err := s.complete(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.Conversation.Append("assistant", reply)
+	return reply, s.persist()
+}
+
+func (s *Session) complete(ctx context.Context) (string, error) {
+	path := s.Conversation.ActivePath()
+	messages := make([]resources.Message, len(path))
+	for i, msg := range path {
+		messages[i] = resources.Message{Role: msg.Role, Content: msg.Content}
+	}
+
+	resp, err := s.completer.ChatCompletions(ctx, &resources.CompletionRequest{
+		Model:       s.model,
+		Messages:    messages,
+		Temperature: s.temperature,
+	})
+	if err != nil {
+		return "", fmt.Errorf("chatbot: completion failed: %w", err)
+	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("chatbot: completion returned no choices")
+	}
+	return resp.Choices[0].Message.Content, nil
+}
+
+func (s *Session) persist() error {
+	if s.store == nil {
+		return nil
+	}
+	return s.store.Save(s.Conversation)
+}
@@ -0,0 +1,105 @@
+package chatbot_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/chatbot"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingCompleter returns replies in order and records the messages it
+// was sent on each call, so tests can assert exactly what was replayed.
+type recordingCompleter struct {
+	replies []string
+	calls   [][]resources.Message
+}
+
+func (c *recordingCompleter) ChatCompletions(ctx context.Context, params *resources.CompletionRequest) (*resources.CompletionResponse, error) {
+	c.calls = append(c.calls, params.Messages)
+	reply := c.replies[len(c.calls)-1]
+	return &resources.CompletionResponse{
+		Choices: []resources.Choice{{Message: resources.Message{Role: "assistant", Content: reply}}},
+	}, nil
+}
+
+func TestSessionChatOnlyReplaysActivePath(t *testing.T) {
+	completer := &recordingCompleter{replies: []string{"hi there", "it's sunny"}}
+	session := chatbot.NewSession("conv_1", "you are helpful", completer, "luna-gpt-4")
+
+	_, err := session.Chat(context.Background(), "hello")
+	require.NoError(t, err)
+
+	userMsg := session.Conversation.ActivePath()[1]
+	_, err = session.Chat(context.Background(), "what's the weather?")
+	require.NoError(t, err)
+
+	// Fork back to the first user turn and take a different path; the
+	// forked Session's own Chat call must only replay up to that turn,
+	// not the weather question that came after it on the original branch.
+	forked, err := session.Fork(userMsg.ID)
+	require.NoError(t, err)
+	completer.replies = append(completer.replies, "forked reply")
+
+	_, err = forked.Chat(context.Background(), "tell me a joke instead")
+	require.NoError(t, err)
+
+	lastCall := completer.calls[len(completer.calls)-1]
+	for _, msg := range lastCall {
+		assert.NotEqual(t, "what's the weather?", msg.Content)
+	}
+	assert.Equal(t, "tell me a joke instead", lastCall[len(lastCall)-1].Content)
+
+	// The original session's active branch is untouched by the fork.
+	originalPath := session.Conversation.ActivePath()
+	assert.Equal(t, "what's the weather?", originalPath[3].Content)
+}
+
+func TestSessionEditAndResendCreatesSiblingBranch(t *testing.T) {
+	completer := &recordingCompleter{replies: []string{"it's sunny", "it's raining"}}
+	session := chatbot.NewSession("conv_1", "you are helpful", completer, "luna-gpt-4")
+
+	_, err := session.Chat(context.Background(), "what's the weather?")
+	require.NoError(t, err)
+	userMsg := session.Conversation.ActivePath()[1]
+
+	reply, err := session.EditAndResend(context.Background(), userMsg.ID, "what's the weather in Cape Town?")
+	require.NoError(t, err)
+	assert.Equal(t, "it's raining", reply)
+
+	// The original user message still has its original reply as a
+	// sibling, it just isn't on the active path anymore.
+	parent := session.Conversation.Messages[userMsg.ParentID]
+	assert.Len(t, parent.Children, 2)
+
+	path := session.Conversation.ActivePath()
+	assert.Equal(t, "what's the weather in Cape Town?", path[1].Content)
+	assert.Equal(t, "it's raining", path[2].Content)
+}
+
+func TestSessionEditAndResendRejectsNonUserMessage(t *testing.T) {
+	completer := &recordingCompleter{replies: []string{"hi there"}}
+	session := chatbot.NewSession("conv_1", "you are helpful", completer, "luna-gpt-4")
+
+	_, err := session.Chat(context.Background(), "hello")
+	require.NoError(t, err)
+	assistantMsg := session.Conversation.ActivePath()[2]
+
+	_, err = session.EditAndResend(context.Background(), assistantMsg.ID, "anything")
+	assert.Error(t, err)
+}
+
+func TestSessionPersistsThroughStore(t *testing.T) {
+	completer := &recordingCompleter{replies: []string{"hi there"}}
+	store := chatbot.NewMemoryStore()
+	session := chatbot.NewSession("conv_1", "you are helpful", completer, "luna-gpt-4", chatbot.WithStore(store))
+
+	_, err := session.Chat(context.Background(), "hello")
+	require.NoError(t, err)
+
+	saved, err := store.Load("conv_1")
+	require.NoError(t, err)
+	assert.Equal(t, session.Conversation.ActiveID, saved.ActiveID)
+}
@@ -0,0 +1,17 @@
+package chatbot
+
+import "fmt"
+
+// ErrNotFound is returned by ConversationStore.Load when id names no saved
+// Conversation.
+var ErrNotFound = fmt.Errorf("chatbot: conversation not found")
+
+// ConversationStore persists Conversations across process restarts, so a
+// Fork or SwitchBranch a user made last session is still there the next
+// time the same conversation ID is loaded.
+type ConversationStore interface {
+	Save(conv *Conversation) error
+	Load(id string) (*Conversation, error)
+	List() ([]string, error)
+	Delete(id string) error
+}
@@ -0,0 +1,52 @@
+package chatbot
+
+import "sync"
+
+// MemoryStore is an in-process ConversationStore backed by a map. It
+// doesn't persist anything, so it's only suitable for tests and
+// single-process use.
+type MemoryStore struct {
+	mu            sync.Mutex
+	conversations map[string]*Conversation
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{conversations: make(map[string]*Conversation)}
+}
+
+func (s *MemoryStore) Save(conv *Conversation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.conversations[conv.ID] = conv.Clone()
+	return nil
+}
+
+func (s *MemoryStore) Load(id string) (*Conversation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conv, ok := s.conversations[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return conv.Clone(), nil
+}
+
+func (s *MemoryStore) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.conversations))
+	for id := range s.conversations {
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.conversations, id)
+	return nil
+}
+
+var _ ConversationStore = (*MemoryStore)(nil)
@@ -0,0 +1,44 @@
+package chatbot_test
+
+import (
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/chatbot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreSaveLoadDelete(t *testing.T) {
+	store := chatbot.NewMemoryStore()
+	conv := chatbot.NewConversation("conv_1", "you are helpful")
+	conv.Append("user", "hello")
+
+	require.NoError(t, store.Save(conv))
+
+	loaded, err := store.Load("conv_1")
+	require.NoError(t, err)
+	assert.Equal(t, conv.ActiveID, loaded.ActiveID)
+	assert.Len(t, loaded.Messages, len(conv.Messages))
+
+	ids, err := store.List()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"conv_1"}, ids)
+
+	require.NoError(t, store.Delete("conv_1"))
+	_, err = store.Load("conv_1")
+	assert.ErrorIs(t, err, chatbot.ErrNotFound)
+}
+
+func TestMemoryStoreLoadReturnsACopy(t *testing.T) {
+	store := chatbot.NewMemoryStore()
+	conv := chatbot.NewConversation("conv_1", "you are helpful")
+	require.NoError(t, store.Save(conv))
+
+	loaded, err := store.Load("conv_1")
+	require.NoError(t, err)
+	loaded.Append("user", "mutate the copy, not the store")
+
+	reloaded, err := store.Load("conv_1")
+	require.NoError(t, err)
+	assert.Len(t, reloaded.Messages, 1)
+}
@@ -13,11 +13,17 @@
 package luna
 
 import (
+	"context"
 	"fmt"
 
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/analytics"
 	"github.com/eclipse-softworks/luna-sdk-go/luna/auth"
 	"github.com/eclipse-softworks/luna-sdk-go/luna/errors"
 	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/messagebus"
 	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
 	"github.com/eclipse-softworks/luna-sdk-go/luna/telemetry"
 )
@@ -39,41 +45,84 @@ type (
 	ServerError         = errors.ServerError
 
 	// Resource types
-	User               = resources.User
-	UserCreate         = resources.UserCreate
-	UserUpdate         = resources.UserUpdate
-	UserList           = resources.UserList
-	Project            = resources.Project
-	ProjectCreate      = resources.ProjectCreate
-	ProjectUpdate      = resources.ProjectUpdate
-	ProjectList        = resources.ProjectList
-	ListParams         = resources.ListParams
-	Residence          = resources.Residence
-	ResidenceList      = resources.ResidenceList
-	Campus             = resources.Campus
-	CampusList         = resources.CampusList
-	Group              = resources.Group
-	GroupCreate        = resources.GroupCreate
-	GroupList          = resources.GroupList
-	Bucket             = resources.Bucket
-	BucketList         = resources.BucketList
-	FileObject         = resources.FileObject
-	CompletionRequest  = resources.CompletionRequest
-	CompletionResponse = resources.CompletionResponse
-	Message            = resources.Message
-	Choice             = resources.Choice
-	Workflow           = resources.Workflow
-	WorkflowList       = resources.WorkflowList
-	WorkflowRun        = resources.WorkflowRun
+	User                 = resources.User
+	UserCreate           = resources.UserCreate
+	UserUpdate           = resources.UserUpdate
+	UserList             = resources.UserList
+	UserBatchResult      = resources.UserBatchResult
+	Project              = resources.Project
+	ProjectCreate        = resources.ProjectCreate
+	ProjectUpdate        = resources.ProjectUpdate
+	ProjectList          = resources.ProjectList
+	ProjectBatchResult   = resources.ProjectBatchResult
+	BatchOperation       = resources.BatchOperation
+	BatchOp              = resources.BatchOp
+	ListParams           = resources.ListParams
+	Residence            = resources.Residence
+	ResidenceList        = resources.ResidenceList
+	Campus               = resources.Campus
+	CampusList           = resources.CampusList
+	PageInfo             = resources.PageInfo
+	Group                = resources.Group
+	GroupCreate          = resources.GroupCreate
+	GroupList            = resources.GroupList
+	Bucket               = resources.Bucket
+	BucketList           = resources.BucketList
+	FileObject           = resources.FileObject
+	StorageConfig        = resources.StorageConfig
+	PresignOptions       = resources.PresignOptions
+	CompletionRequest    = resources.CompletionRequest
+	CompletionResponse   = resources.CompletionResponse
+	PromptStarterRequest = resources.PromptStarterRequest
+	Message              = resources.Message
+	Choice               = resources.Choice
+	ToolDefinition       = resources.ToolDefinition
+	ToolFunctionSchema   = resources.ToolFunctionSchema
+	ToolCall             = resources.ToolCall
+	ToolCallFunction     = resources.ToolCallFunction
+	Workflow             = resources.Workflow
+	WorkflowList         = resources.WorkflowList
+	WorkflowRun          = resources.WorkflowRun
+	WorkflowRunList      = resources.WorkflowRunList
+	WaitOptions          = resources.WaitOptions
+	LogEvent             = resources.LogEvent
+
+	WebhookSubscription        = resources.WebhookSubscription
+	WebhookSubscriptionCreate  = resources.WebhookSubscriptionCreate
+	WebhookSubscriptionCreated = resources.WebhookSubscriptionCreated
+	WebhookSubscriptionUpdate  = resources.WebhookSubscriptionUpdate
+	WebhookSubscriptionList    = resources.WebhookSubscriptionList
+	WebhookDelivery            = resources.WebhookDelivery
+	WebhookDeliveryList        = resources.WebhookDeliveryList
+
+	TokenReview           = resources.TokenReview
+	TokenReviewUser       = resources.TokenReviewUser
+	IntrospectionResponse = resources.IntrospectionResponse
+)
+
+// Batch operation kinds, for BatchOperation.Op.
+const (
+	BatchOpCreate = resources.BatchOpCreate
+	BatchOpUpdate = resources.BatchOpUpdate
+	BatchOpDelete = resources.BatchOpDelete
 )
 
 // Client configuration options
 type (
-	Option       = func(*Config)
-	Config       = clientConfig
-	Logger       = telemetry.Logger
-	LogLevel     = telemetry.LogLevel
-	AuthProvider = auth.Provider
+	Option          = func(*Config)
+	Config          = clientConfig
+	Logger          = telemetry.Logger
+	LogLevel        = telemetry.LogLevel
+	AuthProvider    = auth.Provider
+	AnalyticsSink   = analytics.Sink
+	Analytics       = analytics.Client
+	TokenStore      = auth.TokenStore
+	TokenPair       = auth.TokenPair
+	RetryPolicy     = lunahttp.RetryPolicy
+	RetryClassifier = lunahttp.RetryClassifier
+	MessageBus      = messagebus.MessageBus
+	MetricsSink     = lunahttp.MetricsSink
+	RequestMetric   = lunahttp.RequestMetric
 )
 
 // clientConfig holds client configuration
@@ -82,18 +131,32 @@ type clientConfig struct {
 	accessToken          string
 	refreshToken         string
 	baseURL              string
+	baseURLs             []string
 	timeout              int
 	maxRetries           int
 	logger               telemetry.Logger
 	logLevel             telemetry.LogLevel
 	tokenRefreshCallback func(auth.TokenPair) error
+	tokenStore           auth.TokenStore
+	tokenStoreProfile    string
+	oidcConfig           *auth.OIDCConfig
+	oidcTokens           auth.TokenPair
+	authProvider         auth.Provider
 	httpClient           *lunahttp.Client
+	analyticsSink        analytics.Sink
+	storageConfig        *resources.StorageConfig
+	tracerProvider       trace.TracerProvider
+	meterProvider        metric.MeterProvider
+	retryPolicy          *lunahttp.RetryPolicy
+	messageBus           messagebus.MessageBus
+	metricsSink          lunahttp.MetricsSink
 }
 
 // Client is the main Luna SDK client
 type Client struct {
 	config     *clientConfig
 	httpClient *lunahttp.Client
+	analytics  *analytics.Client
 	users      *resources.UsersResource
 	projects   *resources.ProjectsResource
 	resMate    *resources.ResMateResource
@@ -101,6 +164,8 @@ type Client struct {
 	storage    *resources.StorageResource
 	ai         *resources.AiResource
 	automation *resources.AutomationResource
+	webhooks   *resources.WebhooksResource
+	auth       *resources.AuthResource
 }
 
 // NewClient creates a new Luna SDK client
@@ -122,50 +187,89 @@ func NewClient(opts ...Option) (*Client, error) {
 	}
 
 	// Validate auth
-	if config.apiKey == "" && config.accessToken == "" {
-		return nil, fmt.Errorf("luna: either apiKey or accessToken must be provided")
+	if config.apiKey == "" && config.accessToken == "" && config.oidcConfig == nil && config.authProvider == nil {
+		return nil, fmt.Errorf("luna: either apiKey, accessToken, WithOIDC, or WithAuthProvider must be provided")
 	}
 
 	// Set up logger
 	logger := config.logger
 	if logger == nil {
-		logger = telemetry.NewConsoleLogger(config.logLevel)
+		logger = telemetry.NewZerologLogger(config.logLevel)
 	}
 
 	// Set up auth provider
 	var authProvider auth.Provider
-	if config.apiKey != "" {
+	switch {
+	case config.authProvider != nil:
+		authProvider = config.authProvider
+
+	case config.apiKey != "":
 		var err error
 		authProvider, err = auth.NewAPIKeyAuth(config.apiKey)
 		if err != nil {
 			return nil, err
 		}
-	} else {
+
+	case config.oidcConfig != nil:
+		var oidcOpts []auth.OIDCAuthOption
+		if config.tokenRefreshCallback != nil {
+			oidcOpts = append(oidcOpts, auth.WithOIDCRefreshCallback(config.tokenRefreshCallback))
+		}
+		if config.tokenStore != nil {
+			oidcOpts = append(oidcOpts, auth.WithOIDCTokenStore(config.tokenStore, config.tokenStoreProfile))
+		}
+		oidcAuth, err := auth.NewOIDCAuth(context.Background(), *config.oidcConfig, oidcOpts...)
+		if err != nil {
+			return nil, err
+		}
+		oidcAuth.SetTokens(config.oidcTokens)
+		authProvider = oidcAuth
+
+	default:
 		var err error
-		authProvider, err = auth.NewTokenAuth(config.accessToken, config.refreshToken, config.tokenRefreshCallback)
+		var tokenOpts []auth.TokenAuthOption
+		if config.tokenStore != nil {
+			tokenOpts = append(tokenOpts, auth.WithTokenStore(config.tokenStore, config.tokenStoreProfile))
+		}
+		authProvider, err = auth.NewTokenAuth(config.accessToken, config.refreshToken, config.tokenRefreshCallback, tokenOpts...)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	// Set up analytics. A Noop-backed Client is always created so resources
+	// can call Track unconditionally; disabling analytics is just omitting
+	// WithAnalytics.
+	analyticsClient := analytics.NewClient(config.analyticsSink)
+
 	// Create HTTP client
 	var httpClient *lunahttp.Client
 	if config.httpClient != nil {
 		httpClient = config.httpClient
 	} else {
+		var httpOpts []lunahttp.ClientOption
+		if config.retryPolicy != nil {
+			httpOpts = append(httpOpts, lunahttp.WithRetryPolicy(*config.retryPolicy))
+		}
 		// Allow for custom HTTP client if we add that option later, but for now use standard
 		httpClient = lunahttp.NewClient(lunahttp.ClientConfig{
 			BaseURL:      config.baseURL,
+			BaseURLs:     config.baseURLs,
 			Timeout:      config.timeout,
 			MaxRetries:   config.maxRetries,
 			AuthProvider: authProvider,
 			Logger:       logger,
-		})
+			Analytics:    analyticsClient,
+			Otel:         telemetry.NewOtel(config.tracerProvider, config.meterProvider),
+			Bus:          config.messageBus,
+			MetricsSink:  config.metricsSink,
+		}, httpOpts...)
 	}
 
 	client := &Client{
 		config:     config,
 		httpClient: httpClient,
+		analytics:  analyticsClient,
 	}
 
 	// Initialize resources
@@ -173,9 +277,15 @@ func NewClient(opts ...Option) (*Client, error) {
 	client.projects = resources.NewProjectsResource(httpClient)
 	client.resMate = resources.NewResMateResource(httpClient)
 	client.identity = resources.NewIdentityResource(httpClient)
-	client.storage = resources.NewStorageResource(httpClient)
+	var storageOpts []resources.StorageOption
+	if config.storageConfig != nil {
+		storageOpts = append(storageOpts, resources.WithStorageBackendConfig(*config.storageConfig))
+	}
+	client.storage = resources.NewStorageResource(httpClient, storageOpts...)
 	client.ai = resources.NewAiResource(httpClient)
 	client.automation = resources.NewAutomationResource(httpClient)
+	client.webhooks = resources.NewWebhooksResource(httpClient)
+	client.auth = resources.NewAuthResource(httpClient)
 
 	logger.Debug("LunaClient initialized", map[string]interface{}{
 		"base_url":  config.baseURL,
@@ -220,6 +330,18 @@ func (c *Client) Automation() *resources.AutomationResource {
 	return c.automation
 }
 
+// Webhooks returns the Webhooks resource
+func (c *Client) Webhooks() *resources.WebhooksResource {
+	return c.webhooks
+}
+
+// Auth returns the Auth resource, for validating a Luna-issued bearer
+// token server-side via Review/Introspect, or invalidating one via
+// Revoke.
+func (c *Client) Auth() *resources.AuthResource {
+	return c.auth
+}
+
 // WithAPIKey sets the API key for authentication
 func WithAPIKey(apiKey string) Option {
 	return func(c *clientConfig) {
@@ -242,6 +364,45 @@ func WithTokenRefreshCallback(callback func(auth.TokenPair) error) Option {
 	}
 }
 
+// WithTokenStore makes TokenAuth persist rotated tokens to store under
+// profile, so a refresh performed by one process is visible to others
+// sharing the same store (e.g. multiple CLI invocations). Only used when
+// the client is configured via WithTokens, not WithAPIKey.
+func WithTokenStore(store auth.TokenStore, profile string) Option {
+	return func(c *clientConfig) {
+		c.tokenStore = store
+		c.tokenStoreProfile = profile
+	}
+}
+
+// WithOIDC configures the client to authenticate against an OIDC IdP
+// discovered from config.IssuerURL, for corporate-SSO deployments where
+// an application's own login handler has already driven the
+// authorization code flow (typically via its own auth.OIDCAuth) and is
+// now handing the resulting tokens to the SDK client. NewClient performs
+// discovery and refreshes tokens automatically as they near expiry;
+// WithTokenRefreshCallback's callback, if set, is invoked with every
+// rotated TokenPair, and WithTokenStore makes the rotation visible to
+// other processes sharing the same store. Mutually exclusive with
+// WithAPIKey and WithTokens.
+func WithOIDC(config auth.OIDCConfig, tokens auth.TokenPair) Option {
+	return func(c *clientConfig) {
+		c.oidcConfig = &config
+		c.oidcTokens = tokens
+	}
+}
+
+// WithAuthProvider sets a caller-supplied auth.Provider as the client's
+// sole auth mechanism, bypassing WithAPIKey/WithTokens/WithOIDC entirely.
+// Use this for auth schemes the SDK doesn't model directly, such as
+// auth.NewMTLSAuth or auth.NewWorkloadIdentityAuth. Takes priority over
+// every other auth option if more than one is set.
+func WithAuthProvider(provider auth.Provider) Option {
+	return func(c *clientConfig) {
+		c.authProvider = provider
+	}
+}
+
 // WithBaseURL sets a custom base URL
 func WithBaseURL(baseURL string) Option {
 	return func(c *clientConfig) {
@@ -249,6 +410,21 @@ func WithBaseURL(baseURL string) Option {
 	}
 }
 
+// WithBaseURLs configures primary and any number of secondary base URLs
+// for the client to fail over to if primary (or whichever endpoint last
+// succeeded) returns a network error or a 5xx response -- real HA against
+// a regional outage, instead of the single-BaseURL model WithBaseURL
+// alone gives you. primary is also set as WithBaseURL would set it, so
+// this can be used standalone. See lunahttp.ClusterError for how failed
+// attempts are reported, and WithEndpointHealthCheck for having the
+// client prefer endpoints that are actually up.
+func WithBaseURLs(primary string, secondary ...string) Option {
+	return func(c *clientConfig) {
+		c.baseURL = primary
+		c.baseURLs = secondary
+	}
+}
+
 // WithTimeout sets the request timeout in milliseconds
 func WithTimeout(timeout int) Option {
 	return func(c *clientConfig) {
@@ -263,6 +439,17 @@ func WithMaxRetries(maxRetries int) Option {
 	}
 }
 
+// WithRetryPolicy configures which requests are retried (RetryableStatuses,
+// RetryableMethods, Classifier) and how long Client waits between attempts
+// (BaseDelay, MaxDelay, full jitter). Set MaxAttempts to override
+// WithMaxRetries as part of the same policy; left zero, WithMaxRetries (or
+// its default of 3) still applies.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *clientConfig) {
+		c.retryPolicy = &policy
+	}
+}
+
 // WithLogger sets a custom logger
 func WithLogger(logger telemetry.Logger) Option {
 	return func(c *clientConfig) {
@@ -277,6 +464,87 @@ func WithHTTPClient(client *lunahttp.Client) Option {
 	}
 }
 
+// WithAnalytics sets the sink that receives product-telemetry events emitted
+// by the SDK (sdk.request, messaging.sms.sent, identity.group.created, ...).
+// When omitted, events are generated but discarded.
+func WithAnalytics(sink AnalyticsSink) Option {
+	return func(c *clientConfig) {
+		c.analyticsSink = sink
+	}
+}
+
+// WithMessageBus sets the message bus Client publishes delivery-status and
+// webhook events to (see lunahttp.Client.Request and
+// messaging.SMS/WhatsApp's inbound webhook handlers), so downstream
+// services can consume those events asynchronously instead of polling
+// GetStatus. When omitted, no events are published; this is always safe
+// since luna/messagebus implementations are nil-checked before use.
+func WithMessageBus(bus MessageBus) Option {
+	return func(c *clientConfig) {
+		c.messageBus = bus
+	}
+}
+
+// WithMetricsSink sets the sink Client's HTTP layer reports one
+// RequestMetric to per attempt (including retries), for callers that want
+// to wire their own Prometheus/OpenTelemetry counters alongside (or
+// instead of) WithTracerProvider/WithMeterProvider. When omitted, no
+// metrics are recorded.
+func WithMetricsSink(sink MetricsSink) Option {
+	return func(c *clientConfig) {
+		c.metricsSink = sink
+	}
+}
+
+// WithStorageConfig configures the Storage resource to address an
+// S3-compatible backend (MinIO, DO Spaces, R2) directly for presigned
+// URLs, instead of the Luna-managed default.
+func WithStorageConfig(config StorageConfig) Option {
+	return func(c *clientConfig) {
+		c.storageConfig = &config
+	}
+}
+
+// WithTracerProvider sets the OpenTelemetry TracerProvider the SDK emits
+// per-call spans through. When omitted, spans are created from otel's
+// globally configured provider (a no-op until the host application calls
+// otel.SetTracerProvider).
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *clientConfig) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithMeterProvider sets the OpenTelemetry MeterProvider the SDK records
+// luna_http_requests_total/luna_http_request_duration_ms/
+// luna_http_retries_total/luna_http_rate_limit_total through. When
+// omitted, metrics are recorded against otel's globally configured
+// (possibly no-op) provider.
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *clientConfig) {
+		c.meterProvider = mp
+	}
+}
+
+// Close flushes any queued analytics events and releases client resources.
+// It blocks until the queue has been drained or ctx is done, whichever
+// comes first.
+func (c *Client) Close(ctx context.Context) error {
+	return c.analytics.Close(ctx)
+}
+
+// WithLoggerContext attaches logger to ctx so resource methods further down
+// the call chain can stamp request-scoped fields on it via LoggerFromContext.
+func WithLoggerContext(ctx context.Context, logger Logger) context.Context {
+	return telemetry.WithLoggerContext(ctx, logger)
+}
+
+// LoggerFromContext returns the logger attached to ctx by WithLoggerContext,
+// or a disabled logger if none was attached.
+func LoggerFromContext(ctx context.Context) Logger {
+	return telemetry.LoggerFromContext(ctx)
+}
+
 func getAuthType(c *clientConfig) string {
 	if c.apiKey != "" {
 		return "api_key"
@@ -2,8 +2,11 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config holds Luna SDK configuration.
@@ -15,33 +18,45 @@ type Config struct {
 	Timeout      int
 	MaxRetries   int
 	LogLevel     string
+
+	// ProvisioningPrefix is the URL prefix the provisioning HTTP API is
+	// mounted under (see package provisioning), e.g. "/luna/provision/v1".
+	ProvisioningPrefix string
+	// ProvisioningSecret is the shared-secret bearer token headless
+	// enrollers must present to the provisioning API.
+	ProvisioningSecret string
 }
 
 // EnvVars defines environment variable names.
 var EnvVars = struct {
-	APIKey       string
-	AccessToken  string
-	RefreshToken string
-	BaseURL      string
-	Timeout      string
-	MaxRetries   string
-	LogLevel     string
+	APIKey             string
+	AccessToken        string
+	RefreshToken       string
+	BaseURL            string
+	Timeout            string
+	MaxRetries         string
+	LogLevel           string
+	ProvisioningPrefix string
+	ProvisioningSecret string
 }{
-	APIKey:       "LUNA_API_KEY",
-	AccessToken:  "LUNA_ACCESS_TOKEN",
-	RefreshToken: "LUNA_REFRESH_TOKEN",
-	BaseURL:      "LUNA_BASE_URL",
-	Timeout:      "LUNA_TIMEOUT",
-	MaxRetries:   "LUNA_MAX_RETRIES",
-	LogLevel:     "LUNA_LOG_LEVEL",
+	APIKey:             "LUNA_API_KEY",
+	AccessToken:        "LUNA_ACCESS_TOKEN",
+	RefreshToken:       "LUNA_REFRESH_TOKEN",
+	BaseURL:            "LUNA_BASE_URL",
+	Timeout:            "LUNA_TIMEOUT",
+	MaxRetries:         "LUNA_MAX_RETRIES",
+	LogLevel:           "LUNA_LOG_LEVEL",
+	ProvisioningPrefix: "LUNA_PROVISIONING_PREFIX",
+	ProvisioningSecret: "LUNA_PROVISIONING_SECRET",
 }
 
 // Defaults provides default configuration values.
 var Defaults = Config{
-	BaseURL:    "https://api.eclipse.dev",
-	Timeout:    30000,
-	MaxRetries: 3,
-	LogLevel:   "info",
+	BaseURL:            "https://api.eclipse.dev",
+	Timeout:            30000,
+	MaxRetries:         3,
+	LogLevel:           "info",
+	ProvisioningPrefix: "/luna/provision/v1",
 }
 
 // LoadFromEnv loads configuration from environment variables.
@@ -80,60 +95,150 @@ func LoadFromEnv() Config {
 		config.LogLevel = logLevel
 	}
 
+	if prefix := os.Getenv(EnvVars.ProvisioningPrefix); prefix != "" {
+		config.ProvisioningPrefix = prefix
+	}
+
+	if secret := os.Getenv(EnvVars.ProvisioningSecret); secret != "" {
+		config.ProvisioningSecret = secret
+	}
+
 	return config
 }
 
-// Merge merges user config with environment and defaults.
+// Merge merges user config with environment and defaults. Equivalent to
+// Load(LoadOptions{UserConfig: userConfig}) but skips the config file
+// search entirely, which is why callers that already have an explicit
+// Config (e.g. values passed straight to luna.NewClient) use it instead of
+// Load.
 func Merge(userConfig Config) Config {
-	envConfig := LoadFromEnv()
-
-	result := Defaults
+	result := overlay(Defaults, LoadFromEnv())
+	result = overlay(result, userConfig)
+	return result
+}
 
-	// Apply env config
-	if envConfig.APIKey != "" {
-		result.APIKey = envConfig.APIKey
+// overlay returns base with every non-zero field of override applied on
+// top of it — the "last non-empty wins" rule each stage of the precedence
+// chain uses to merge into the next.
+func overlay(base, override Config) Config {
+	if override.APIKey != "" {
+		base.APIKey = override.APIKey
 	}
-	if envConfig.AccessToken != "" {
-		result.AccessToken = envConfig.AccessToken
+	if override.AccessToken != "" {
+		base.AccessToken = override.AccessToken
 	}
-	if envConfig.RefreshToken != "" {
-		result.RefreshToken = envConfig.RefreshToken
+	if override.RefreshToken != "" {
+		base.RefreshToken = override.RefreshToken
 	}
-	if envConfig.BaseURL != "" {
-		result.BaseURL = envConfig.BaseURL
+	if override.BaseURL != "" {
+		base.BaseURL = override.BaseURL
 	}
-	if envConfig.Timeout != 0 {
-		result.Timeout = envConfig.Timeout
+	if override.Timeout != 0 {
+		base.Timeout = override.Timeout
 	}
-	if envConfig.MaxRetries != 0 {
-		result.MaxRetries = envConfig.MaxRetries
+	if override.MaxRetries != 0 {
+		base.MaxRetries = override.MaxRetries
 	}
-	if envConfig.LogLevel != "" {
-		result.LogLevel = envConfig.LogLevel
+	if override.LogLevel != "" {
+		base.LogLevel = override.LogLevel
 	}
-
-	// Apply user config (takes precedence)
-	if userConfig.APIKey != "" {
-		result.APIKey = userConfig.APIKey
+	if override.ProvisioningPrefix != "" {
+		base.ProvisioningPrefix = override.ProvisioningPrefix
 	}
-	if userConfig.AccessToken != "" {
-		result.AccessToken = userConfig.AccessToken
+	if override.ProvisioningSecret != "" {
+		base.ProvisioningSecret = override.ProvisioningSecret
 	}
-	if userConfig.RefreshToken != "" {
-		result.RefreshToken = userConfig.RefreshToken
+	return base
+}
+
+// LoadOptions controls Load's config file search, profile selection, and
+// precedence chain.
+type LoadOptions struct {
+	// ConfigPath, when set, is used instead of the file search order (the
+	// CLI's --config flag).
+	ConfigPath string
+	// Profile selects a profile from the loaded file. Empty uses the
+	// file's DefaultProfile, falling back to "default".
+	Profile string
+	// Flags holds CLI-flag-sourced overrides, applied after the file and
+	// environment but before UserConfig.
+	Flags Config
+	// UserConfig holds explicit, highest-precedence overrides.
+	UserConfig Config
+}
+
+// Load resolves configuration in increasing order of precedence: defaults,
+// an optional config file (see SearchPaths for the search order and
+// LoadOptions.Profile for profile selection), environment variables,
+// LoadOptions.Flags, then LoadOptions.UserConfig. A missing config file is
+// not an error — Load simply skips that stage.
+func Load(opts LoadOptions) (Config, error) {
+	result := Defaults
+
+	fileConfig, _, err := LoadFile(opts.ConfigPath)
+	if err != nil && err != ErrNoConfigFile {
+		return Config{}, err
 	}
-	if userConfig.BaseURL != "" {
-		result.BaseURL = userConfig.BaseURL
+	if fileConfig != nil {
+		profileName := opts.Profile
+		if profileName == "" {
+			profileName = fileConfig.DefaultProfile
+		}
+		if profileName == "" {
+			profileName = "default"
+		}
+		if profile, ok := fileConfig.Profiles[profileName]; ok {
+			result = overlay(result, profile.toConfig())
+		}
 	}
-	if userConfig.Timeout != 0 {
-		result.Timeout = userConfig.Timeout
+
+	result = overlay(result, LoadFromEnv())
+	result = overlay(result, opts.Flags)
+	result = overlay(result, opts.UserConfig)
+
+	return result, nil
+}
+
+// ValidationError aggregates every problem Validate found, so callers can
+// report them all at once instead of fixing one at a time.
+type ValidationError struct {
+	Errors []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("config: %d validation error(s): %s", len(e.Errors), strings.Join(e.Errors, "; "))
+}
+
+var validLogLevels = map[string]bool{
+	"":      true,
+	"debug": true,
+	"info":  true,
+	"warn":  true,
+	"error": true,
+}
+
+// Validate checks that c is usable, returning a *ValidationError
+// aggregating every problem found, or nil if c is valid.
+func (c Config) Validate() error {
+	var errs []string
+
+	if c.BaseURL != "" {
+		u, err := url.Parse(c.BaseURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Sprintf("base_url %q is not a valid absolute URL", c.BaseURL))
+		}
 	}
-	if userConfig.MaxRetries != 0 {
-		result.MaxRetries = userConfig.MaxRetries
+
+	if c.Timeout <= 0 {
+		errs = append(errs, fmt.Sprintf("timeout must be > 0, got %d", c.Timeout))
 	}
-	if userConfig.LogLevel != "" {
-		result.LogLevel = userConfig.LogLevel
+
+	if !validLogLevels[c.LogLevel] {
+		errs = append(errs, fmt.Sprintf("log_level must be one of debug, info, warn, error, got %q", c.LogLevel))
 	}
 
-	return result
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: errs}
 }
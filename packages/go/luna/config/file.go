@@ -0,0 +1,40 @@
+package config
+
+// Profile holds per-profile overrides loaded from a config file. Its shape
+// mirrors Config directly so file-sourced values overlay the same way
+// env/flag-sourced ones do.
+type Profile struct {
+	APIKey       string `yaml:"api_key,omitempty" toml:"api_key,omitempty"`
+	AccessToken  string `yaml:"access_token,omitempty" toml:"access_token,omitempty"`
+	RefreshToken string `yaml:"refresh_token,omitempty" toml:"refresh_token,omitempty"`
+	BaseURL      string `yaml:"base_url,omitempty" toml:"base_url,omitempty"`
+	Timeout      int    `yaml:"timeout,omitempty" toml:"timeout,omitempty"`
+	MaxRetries   int    `yaml:"max_retries,omitempty" toml:"max_retries,omitempty"`
+	LogLevel     string `yaml:"log_level,omitempty" toml:"log_level,omitempty"`
+}
+
+// FileConfig is the on-disk shape of a Luna config file: one or more named
+// profiles plus which one applies when the caller doesn't pick one.
+//
+//	default_profile: prod
+//	profiles:
+//	  default:
+//	    base_url: https://api.eclipse.dev
+//	  prod:
+//	    api_key: lk_live_xxxx
+type FileConfig struct {
+	DefaultProfile string             `yaml:"default_profile" toml:"default_profile"`
+	Profiles       map[string]Profile `yaml:"profiles" toml:"profiles"`
+}
+
+func (p Profile) toConfig() Config {
+	return Config{
+		APIKey:       p.APIKey,
+		AccessToken:  p.AccessToken,
+		RefreshToken: p.RefreshToken,
+		BaseURL:      p.BaseURL,
+		Timeout:      p.Timeout,
+		MaxRetries:   p.MaxRetries,
+		LogLevel:     p.LogLevel,
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// ErrNoConfigFile indicates none of the search locations had a readable
+// config file. Callers may treat this the same as an empty file.
+var ErrNoConfigFile = errors.New("config: no config file found")
+
+// SearchPaths returns the config file search order, highest priority
+// first: an explicit path (the CLI's --config flag), then $LUNA_CONFIG,
+// then $XDG_CONFIG_HOME/luna/config.yaml, then ~/.luna/config.yaml.
+func SearchPaths(explicit string) []string {
+	var paths []string
+
+	if explicit != "" {
+		paths = append(paths, explicit)
+	}
+	if env := os.Getenv("LUNA_CONFIG"); env != "" {
+		paths = append(paths, env)
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		paths = append(paths, filepath.Join(xdg, "luna", "config.yaml"))
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		paths = append(paths, filepath.Join(home, ".luna", "config.yaml"))
+	}
+
+	return paths
+}
+
+// LoadFile searches SearchPaths(explicit) in order and parses the first
+// file that exists, selecting YAML or TOML by file extension (".toml" vs
+// anything else). It returns ErrNoConfigFile if none of the candidates
+// exist.
+func LoadFile(explicit string) (cfg *FileConfig, path string, err error) {
+	for _, candidate := range SearchPaths(explicit) {
+		data, readErr := os.ReadFile(candidate)
+		if readErr != nil {
+			if os.IsNotExist(readErr) {
+				continue
+			}
+			return nil, candidate, fmt.Errorf("config: failed to read %s: %w", candidate, readErr)
+		}
+
+		parsed, parseErr := parseFile(candidate, data)
+		if parseErr != nil {
+			return nil, candidate, fmt.Errorf("config: failed to parse %s: %w", candidate, parseErr)
+		}
+		return parsed, candidate, nil
+	}
+
+	return nil, "", ErrNoConfigFile
+}
+
+func parseFile(path string, data []byte) (*FileConfig, error) {
+	var cfg FileConfig
+
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		if err := toml.Unmarshal(data, &cfg); err != nil {
+			return nil, err
+		}
+		return &cfg, nil
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
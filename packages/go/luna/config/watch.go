@@ -0,0 +1,64 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Watch resolves the same config file Load(opts) would (see SearchPaths),
+// watches it for changes, and invokes onChange with the freshly reloaded
+// Config after each write or recreate — so a long-running process (e.g.
+// the provisioning API server) can rotate credentials without restarting.
+// It blocks until ctx is cancelled, then returns ctx.Err().
+func Watch(ctx context.Context, opts LoadOptions, onChange func(Config)) error {
+	_, path, err := LoadFile(opts.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("config: cannot watch, no config file found: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("config: failed to start file watcher: %w", err)
+	}
+	defer watcher.Close()
+
+	// Watch the containing directory rather than the file itself: editors
+	// and config-management tools commonly replace a file via rename,
+	// which a file-level watch would silently stop following.
+	dir := filepath.Dir(path)
+	if err := watcher.Add(dir); err != nil {
+		return fmt.Errorf("config: failed to watch %s: %w", dir, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if filepath.Clean(event.Name) != filepath.Clean(path) {
+				continue
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			cfg, err := Load(opts)
+			if err != nil {
+				continue
+			}
+			onChange(cfg)
+
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+		}
+	}
+}
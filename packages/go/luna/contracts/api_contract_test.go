@@ -5,250 +5,203 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"regexp"
 	"testing"
 
 	"github.com/eclipse-softworks/luna-sdk-go/luna"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/errors"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
 	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func TestUserContract(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(testutil.MockUser)
-	}))
-	defer server.Close()
-
-	client, err := luna.NewClient(
-		luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
-		luna.WithBaseURL(server.URL),
-	)
-	require.NoError(t, err)
+// schemaServer starts an httptest.Server that always serves fixture as
+// JSON, validating the response it writes against schemaName before it
+// ever reaches the client under test.
+func schemaServer(t *testing.T, schemaName string, fixture interface{}) *httptest.Server {
+	t.Helper()
 
-	t.Run("user has required fields", func(t *testing.T) {
-		user, err := client.Users().Get(context.Background(), "usr_123456789")
-
-		require.NoError(t, err)
-		assert.NotEmpty(t, user.ID)
-		assert.NotEmpty(t, user.Name)
-		assert.NotEmpty(t, user.Email)
-		assert.NotNil(t, user.CreatedAt)
-		assert.NotNil(t, user.UpdatedAt)
-	})
-
-	t.Run("user ID has correct prefix", func(t *testing.T) {
-		user, err := client.Users().Get(context.Background(), "usr_123456789")
-
-		require.NoError(t, err)
-		assert.Regexp(t, regexp.MustCompile(`^usr_`), user.ID)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(fixture)
 	})
+	return httptest.NewServer(schema.WrapHandler(t, schemaName, handler))
+}
 
-	t.Run("user email is valid format", func(t *testing.T) {
-		user, err := client.Users().Get(context.Background(), "usr_123456789")
+func schemaServerWithStatus(t *testing.T, schemaName string, status int, fixture interface{}) *httptest.Server {
+	t.Helper()
 
-		require.NoError(t, err)
-		emailRegex := regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
-		assert.Regexp(t, emailRegex, user.Email)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(fixture)
 	})
+	return httptest.NewServer(schema.WrapHandler(t, schemaName, handler))
 }
 
-func TestProjectContract(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(testutil.MockProject)
-	}))
-	defer server.Close()
+func newContractClient(t *testing.T, baseURL string) *luna.Client {
+	t.Helper()
 
 	client, err := luna.NewClient(
 		luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
-		luna.WithBaseURL(server.URL),
+		luna.WithBaseURL(baseURL),
 	)
 	require.NoError(t, err)
+	return client
+}
 
-	t.Run("project has required fields", func(t *testing.T) {
-		project, err := client.Projects().Get(context.Background(), "prj_123456789")
+func TestUserContract(t *testing.T) {
+	server := schemaServer(t, "User", testutil.MockUser)
+	defer server.Close()
+	client := newContractClient(t, server.URL)
 
-		require.NoError(t, err)
-		assert.NotEmpty(t, project.ID)
-		assert.NotEmpty(t, project.Name)
-		assert.NotNil(t, project.CreatedAt)
-		assert.NotNil(t, project.UpdatedAt)
-	})
+	user, err := client.Users().Get(context.Background(), "usr_123456789")
+
+	require.NoError(t, err)
+	assert.Equal(t, "usr_123456789", user.ID)
+}
+
+func TestProjectContract(t *testing.T) {
+	t.Run("project with a description", func(t *testing.T) {
+		server := schemaServer(t, "Project", testutil.MockProject)
+		defer server.Close()
+		client := newContractClient(t, server.URL)
 
-	t.Run("project ID has correct prefix", func(t *testing.T) {
 		project, err := client.Projects().Get(context.Background(), "prj_123456789")
 
 		require.NoError(t, err)
-		assert.Regexp(t, regexp.MustCompile(`^prj_`), project.ID)
+		assert.Equal(t, "prj_123456789", project.ID)
 	})
 
-	t.Run("project description is optional", func(t *testing.T) {
-		// Create server that returns project without description
-		noDescServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			project := testutil.MockProject
-			project["description"] = nil
-			json.NewEncoder(w).Encode(project)
-		}))
-		defer noDescServer.Close()
-
-		noDescClient, err := luna.NewClient(
-			luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
-			luna.WithBaseURL(noDescServer.URL),
-		)
-		require.NoError(t, err)
+	t.Run("project without a description", func(t *testing.T) {
+		noDesc := map[string]interface{}{}
+		for k, v := range testutil.MockProject {
+			noDesc[k] = v
+		}
+		noDesc["description"] = nil
+
+		server := schemaServer(t, "Project", noDesc)
+		defer server.Close()
+		client := newContractClient(t, server.URL)
 
-		project, err := noDescClient.Projects().Get(context.Background(), "prj_123456789")
+		project, err := client.Projects().Get(context.Background(), "prj_123456789")
 
 		require.NoError(t, err)
-		assert.NotEmpty(t, project.ID)
-		// Description can be nil or empty
+		assert.Nil(t, project.Description)
 	})
 }
 
 func TestBucketContract(t *testing.T) {
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(testutil.MockListResponse(testutil.MockBuckets, false, ""))
-	}))
+	server := schemaServer(t, "ListResponse", testutil.MockListResponse(testutil.MockBuckets, false, ""))
 	defer server.Close()
+	client := newContractClient(t, server.URL)
+
+	buckets, err := client.Storage().Buckets.List(context.Background())
 
-	client, err := luna.NewClient(
-		luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
-		luna.WithBaseURL(server.URL),
-	)
 	require.NoError(t, err)
+	require.Greater(t, len(buckets.Data), 0)
+	for _, bucket := range buckets.Data {
+		schema.AssertMatchesSchema(t, "Bucket", bucket)
+	}
+}
 
-	t.Run("bucket list returns buckets", func(t *testing.T) {
-		buckets, err := client.Storage().Buckets.List(context.Background())
+func TestResidenceSearchContract(t *testing.T) {
+	server := schemaServer(t, "ListResponse", testutil.MockResidenceList)
+	defer server.Close()
+	client := newContractClient(t, server.URL)
 
-		require.NoError(t, err)
-		assert.NotNil(t, buckets)
-		assert.Greater(t, len(buckets.Data), 0)
-	})
+	search := resources.NewResidenceSearch().
+		NearCampus("cmp_uct").
+		PriceBetween(3000, 6000).
+		WithNSFAS(true).
+		WithFacets("gender_policy").
+		Build()
 
-	t.Run("bucket has required fields", func(t *testing.T) {
-		buckets, err := client.Storage().Buckets.List(context.Background())
+	residences, err := client.ResMate().Residences.List(context.Background(), search)
 
-		require.NoError(t, err)
-		if len(buckets.Data) > 0 {
-			bucket := buckets.Data[0]
-			assert.NotEmpty(t, bucket.ID)
-			assert.NotEmpty(t, bucket.Name)
-			assert.Regexp(t, regexp.MustCompile(`^bkt_`), bucket.ID)
-		}
-	})
+	require.NoError(t, err)
+	require.Greater(t, len(residences.Data), 0)
+	for _, residence := range residences.Data {
+		schema.AssertMatchesSchema(t, "Residence", residence)
+	}
+	require.Contains(t, residences.Facets, "gender_policy")
+	assert.Greater(t, len(residences.Facets["gender_policy"]), 0)
 }
 
 func TestListResponseContract(t *testing.T) {
 	t.Run("list response has correct structure", func(t *testing.T) {
-		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(testutil.MockListResponse(testutil.MockUsers, false, ""))
-		}))
+		server := schemaServer(t, "ListResponse", testutil.MockListResponse(testutil.MockUsers, false, ""))
 		defer server.Close()
-
-		client, err := luna.NewClient(
-			luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
-			luna.WithBaseURL(server.URL),
-		)
-		require.NoError(t, err)
+		client := newContractClient(t, server.URL)
 
 		result, err := client.Users().List(context.Background(), nil)
 
 		require.NoError(t, err)
 		assert.NotNil(t, result.Data)
-		// HasMore should be a boolean (checked by type system)
+		assert.False(t, result.HasMore)
 	})
 
 	t.Run("list response includes next_cursor when has_more is true", func(t *testing.T) {
-		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(testutil.MockListResponse(testutil.MockUsers, true, "cursor_abc123"))
-		}))
+		server := schemaServer(t, "ListResponse", testutil.MockListResponse(testutil.MockUsers, true, "cursor_abc123"))
 		defer server.Close()
-
-		client, err := luna.NewClient(
-			luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
-			luna.WithBaseURL(server.URL),
-		)
-		require.NoError(t, err)
+		client := newContractClient(t, server.URL)
 
 		result, err := client.Users().List(context.Background(), nil)
 
 		require.NoError(t, err)
 		assert.True(t, result.HasMore)
-		assert.NotEmpty(t, result.NextCursor)
+		require.NotNil(t, result.NextCursor)
+		assert.NotEmpty(t, *result.NextCursor)
 	})
 }
 
 func TestErrorResponseContract(t *testing.T) {
-	t.Run("error response has correct structure", func(t *testing.T) {
-		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusNotFound)
-			json.NewEncoder(w).Encode(testutil.MockErrorNotFound)
-		}))
+	t.Run("not found error has correct structure", func(t *testing.T) {
+		server := schemaServerWithStatus(t, "ErrorResponse", http.StatusNotFound, testutil.MockErrorNotFound)
 		defer server.Close()
+		client := newContractClient(t, server.URL)
 
-		client, err := luna.NewClient(
-			luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
-			luna.WithBaseURL(server.URL),
-		)
-		require.NoError(t, err)
-
-		_, err = client.Users().Get(context.Background(), "usr_nonexistent")
+		_, err := client.Users().Get(context.Background(), "usr_nonexistent")
 
 		require.Error(t, err)
-		// Error should have message
 		assert.NotEmpty(t, err.Error())
 	})
 
 	t.Run("validation error includes details", func(t *testing.T) {
-		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(testutil.MockErrorValidation)
-		}))
+		server := schemaServerWithStatus(t, "ErrorResponse", http.StatusBadRequest, testutil.MockErrorValidation)
 		defer server.Close()
+		client := newContractClient(t, server.URL)
 
-		client, err := luna.NewClient(
-			luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
-			luna.WithBaseURL(server.URL),
-		)
-		require.NoError(t, err)
-
-		_, err = client.Users().Create(context.Background(), luna.UserCreate{
+		_, err := client.Users().Create(context.Background(), luna.UserCreate{
 			Name:  "Test User",
 			Email: "test@example.com",
 		})
 
 		require.Error(t, err)
-		// Error message should contain validation info
-		assert.Contains(t, err.Error(), "Validation")
+		var baseErr *errors.Error
+		require.ErrorAs(t, err, &baseErr)
+		assert.Equal(t, errors.CodeValidationFailed, baseErr.Code)
+
+		require.NotNil(t, baseErr.Details)
+		items, ok := baseErr.Details["items"].([]interface{})
+		require.True(t, ok)
+		require.Len(t, items, 1)
+		detail, ok := items[0].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, "email", detail["field"])
 	})
 }
 
 func TestTimestampFormats(t *testing.T) {
 	t.Run("timestamps are valid", func(t *testing.T) {
-		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			json.NewEncoder(w).Encode(testutil.MockUser)
-		}))
+		server := schemaServer(t, "User", testutil.MockUser)
 		defer server.Close()
-
-		client, err := luna.NewClient(
-			luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
-			luna.WithBaseURL(server.URL),
-		)
-		require.NoError(t, err)
+		client := newContractClient(t, server.URL)
 
 		user, err := client.Users().Get(context.Background(), "usr_123456789")
 
 		require.NoError(t, err)
-		// CreatedAt should be a valid time
 		assert.False(t, user.CreatedAt.IsZero())
 	})
 }
@@ -1,7 +1,15 @@
 // Package errors provides error types for the Luna SDK.
 package errors
 
-import "fmt"
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
 
 // ErrorCode constants
 const (
@@ -54,6 +62,36 @@ func (e *Error) Retryable() bool {
 	return retryableCodes[e.Code]
 }
 
+// Is reports whether target is an *Error with the same Code, so
+// errors.Is(err, ErrRateLimited) (and the other Err* sentinels below)
+// works against any error whose chain unwraps to an *Error — which every
+// typed error in this package does via its Unwrap method.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Code == t.Code
+}
+
+// Sentinel errors, one per Code* constant, for use with errors.Is:
+//
+//	if errors.Is(err, errors.ErrRateLimited) { ... }
+var (
+	ErrAuthInvalidKey             = &Error{Code: CodeAuthInvalidKey}
+	ErrAuthTokenExpired           = &Error{Code: CodeAuthTokenExpired}
+	ErrAuthInsufficientPermission = &Error{Code: CodeAuthInsufficientPermission}
+	ErrRateLimited                = &Error{Code: CodeRateLimitExceeded}
+	ErrResourceNotFound           = &Error{Code: CodeResourceNotFound}
+	ErrResourceConflict           = &Error{Code: CodeResourceConflict}
+	ErrValidationFailed           = &Error{Code: CodeValidationFailed}
+	ErrValidationInvalidParam     = &Error{Code: CodeValidationInvalidParam}
+	ErrNetworkTimeout             = &Error{Code: CodeNetworkTimeout}
+	ErrNetworkConnection          = &Error{Code: CodeNetworkConnection}
+	ErrServerInternal             = &Error{Code: CodeServerInternal}
+	ErrServerUnavailable          = &Error{Code: CodeServerUnavailable}
+)
+
 // AuthenticationError indicates authentication failure
 type AuthenticationError struct {
 	BaseError *Error
@@ -63,6 +101,12 @@ func (e *AuthenticationError) Error() string {
 	return e.BaseError.Error()
 }
 
+// Unwrap exposes BaseError so errors.Is/As and the helpers below see
+// through the wrapper to the underlying *Error.
+func (e *AuthenticationError) Unwrap() error {
+	return e.BaseError
+}
+
 // AuthorizationError indicates authorization failure
 type AuthorizationError struct {
 	BaseError *Error
@@ -72,6 +116,12 @@ func (e *AuthorizationError) Error() string {
 	return e.BaseError.Error()
 }
 
+// Unwrap exposes BaseError so errors.Is/As and the helpers below see
+// through the wrapper to the underlying *Error.
+func (e *AuthorizationError) Unwrap() error {
+	return e.BaseError
+}
+
 // ValidationError indicates validation failure
 type ValidationError struct {
 	BaseError *Error
@@ -81,6 +131,12 @@ func (e *ValidationError) Error() string {
 	return e.BaseError.Error()
 }
 
+// Unwrap exposes BaseError so errors.Is/As and the helpers below see
+// through the wrapper to the underlying *Error.
+func (e *ValidationError) Unwrap() error {
+	return e.BaseError
+}
+
 // RateLimitError indicates rate limit exceeded
 type RateLimitError struct {
 	BaseError  *Error
@@ -91,6 +147,12 @@ func (e *RateLimitError) Error() string {
 	return e.BaseError.Error()
 }
 
+// Unwrap exposes BaseError so errors.Is/As and the helpers below see
+// through the wrapper to the underlying *Error.
+func (e *RateLimitError) Unwrap() error {
+	return e.BaseError
+}
+
 // NetworkError indicates network-related errors
 type NetworkError struct {
 	BaseError *Error
@@ -100,6 +162,12 @@ func (e *NetworkError) Error() string {
 	return e.BaseError.Error()
 }
 
+// Unwrap exposes BaseError so errors.Is/As and the helpers below see
+// through the wrapper to the underlying *Error.
+func (e *NetworkError) Unwrap() error {
+	return e.BaseError
+}
+
 // NotFoundError indicates resource not found
 type NotFoundError struct {
 	BaseError *Error
@@ -109,6 +177,12 @@ func (e *NotFoundError) Error() string {
 	return e.BaseError.Error()
 }
 
+// Unwrap exposes BaseError so errors.Is/As and the helpers below see
+// through the wrapper to the underlying *Error.
+func (e *NotFoundError) Unwrap() error {
+	return e.BaseError
+}
+
 // ConflictError indicates resource conflict
 type ConflictError struct {
 	BaseError *Error
@@ -118,6 +192,12 @@ func (e *ConflictError) Error() string {
 	return e.BaseError.Error()
 }
 
+// Unwrap exposes BaseError so errors.Is/As and the helpers below see
+// through the wrapper to the underlying *Error.
+func (e *ConflictError) Unwrap() error {
+	return e.BaseError
+}
+
 // ServerError indicates server-side errors
 type ServerError struct {
 	BaseError *Error
@@ -127,6 +207,12 @@ func (e *ServerError) Error() string {
 	return e.BaseError.Error()
 }
 
+// Unwrap exposes BaseError so errors.Is/As and the helpers below see
+// through the wrapper to the underlying *Error.
+func (e *ServerError) Unwrap() error {
+	return e.BaseError
+}
+
 // New creates a new Error with the given parameters
 func New(code, message string, status int, requestID string, details map[string]interface{}) *Error {
 	return &Error{
@@ -168,3 +254,262 @@ func FromResponse(status int, code, message, requestID string, details map[strin
 		return base
 	}
 }
+
+// AsRateLimit reports whether err is, or wraps, a *RateLimitError,
+// returning it if so. It's a thin wrapper around errors.As so callers
+// don't need to declare the target variable themselves.
+func AsRateLimit(err error) (*RateLimitError, bool) {
+	var rl *RateLimitError
+	if stderrors.As(err, &rl) {
+		return rl, true
+	}
+	return nil, false
+}
+
+// IsRetryable reports whether err is, or wraps, an *Error marked
+// retryable (see retryableCodes). Errors outside this package return
+// false.
+func IsRetryable(err error) bool {
+	var e *Error
+	if stderrors.As(err, &e) {
+		return e.Retryable()
+	}
+	return false
+}
+
+// RetryAfter returns the delay a *RateLimitError asked the caller to wait
+// before retrying, or 0 if err isn't a rate limit error.
+func RetryAfter(err error) time.Duration {
+	rl, ok := AsRateLimit(err)
+	if !ok {
+		return 0
+	}
+	return time.Duration(rl.RetryAfter) * time.Second
+}
+
+// ClassName returns the Go type name of the concrete *XxxError err is or
+// wraps (e.g. "AuthenticationError", "RateLimitError"), or "" if err is
+// nil or isn't one of this package's error types. Callers that need to
+// tag an error with its class for logging or tracing, without caring
+// about its fields, can use this instead of a type switch.
+func ClassName(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var authn *AuthenticationError
+	if stderrors.As(err, &authn) {
+		return "AuthenticationError"
+	}
+	var authz *AuthorizationError
+	if stderrors.As(err, &authz) {
+		return "AuthorizationError"
+	}
+	var val *ValidationError
+	if stderrors.As(err, &val) {
+		return "ValidationError"
+	}
+	var rl *RateLimitError
+	if stderrors.As(err, &rl) {
+		return "RateLimitError"
+	}
+	var nf *NotFoundError
+	if stderrors.As(err, &nf) {
+		return "NotFoundError"
+	}
+	var conflict *ConflictError
+	if stderrors.As(err, &conflict) {
+		return "ConflictError"
+	}
+	var srv *ServerError
+	if stderrors.As(err, &srv) {
+		return "ServerError"
+	}
+	var net *NetworkError
+	if stderrors.As(err, &net) {
+		return "NetworkError"
+	}
+	return ""
+}
+
+// problemDetails mirrors RFC 7807's application/problem+json shape. Any
+// member beyond the five standard ones is an extension and is copied into
+// Error.Details.
+type problemDetails struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail"`
+	Instance string `json:"instance"`
+}
+
+var problemStandardMembers = map[string]bool{
+	"type": true, "title": true, "status": true, "detail": true,
+}
+
+// envelope mirrors the {"error": {...}} shape documented by
+// testutil/schema/schemas/ErrorResponse.schema.json and used by every
+// fixture in testutil/fixtures.go -- this is the wire format the SDK's own
+// APIs actually return.
+type envelope struct {
+	Error *envelopeError `json:"error"`
+}
+
+type envelopeError struct {
+	Message    string          `json:"message"`
+	Code       string          `json:"code"`
+	Status     int             `json:"status"`
+	RequestID  string          `json:"request_id"`
+	RetryAfter int             `json:"retry_after"`
+	Details    json.RawMessage `json:"details"`
+}
+
+// envelopeCodeAliases maps the provider-style codes the documented
+// envelope carries (e.g. "RATE_LIMIT_EXCEEDED") to this package's internal
+// Code* constants, so an error built from the envelope is recognized by
+// errors.Is/IsRetryable exactly like one built any other way.
+var envelopeCodeAliases = map[string]string{
+	"NOT_FOUND":            CodeResourceNotFound,
+	"VALIDATION_ERROR":     CodeValidationFailed,
+	"RATE_LIMIT_EXCEEDED":  CodeRateLimitExceeded,
+	"AUTHENTICATION_ERROR": CodeAuthInvalidKey,
+	"SERVER_ERROR":         CodeServerInternal,
+}
+
+// detailsFromRaw decodes an envelope's "details" member into Error's map
+// shape. The schema allows an object, an array, or null; an array (e.g. a
+// list of per-field validation issues) is wrapped under "items" since
+// Error.Details is keyed.
+func detailsFromRaw(raw json.RawMessage) map[string]interface{} {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil
+	}
+	var obj map[string]interface{}
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		return obj
+	}
+	var arr []interface{}
+	if err := json.Unmarshal(raw, &arr); err == nil {
+		return map[string]interface{}{"items": arr}
+	}
+	return nil
+}
+
+// FromHTTPResponse builds an error from an HTTP response, preferring the
+// documented {"error": {code, message, status, request_id, retry_after,
+// details}} envelope (see envelope above), then RFC 7807
+// application/problem+json bodies (using "type" as the error code,
+// "detail" falling back to "title" as the message, and every member
+// besides "type"/"title"/"status"/"detail" -- including "instance", which
+// callers need to correlate a problem back to the specific resource it
+// names -- copied into Details), and finally falling back to
+// FromResponse's {code, message, details} shape for anything else. It
+// consumes and closes resp.Body.
+func FromHTTPResponse(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return New(CodeNetworkConnection, err.Error(), resp.StatusCode, "", nil)
+	}
+
+	requestID := resp.Header.Get("X-Request-Id")
+
+	var retryAfter int
+	if ra := resp.Header.Get("Retry-After"); ra != "" {
+		fmt.Sscanf(ra, "%d", &retryAfter)
+	}
+
+	var env envelope
+	if jsonErr := json.Unmarshal(body, &env); jsonErr == nil && env.Error != nil && env.Error.Code != "" {
+		code := env.Error.Code
+		if alias, ok := envelopeCodeAliases[code]; ok {
+			code = alias
+		}
+
+		if requestID == "" {
+			requestID = env.Error.RequestID
+		}
+		if retryAfter == 0 {
+			retryAfter = env.Error.RetryAfter
+		}
+
+		return FromResponse(resp.StatusCode, code, env.Error.Message, requestID, detailsFromRaw(env.Error.Details), retryAfter)
+	}
+
+	if strings.Contains(resp.Header.Get("Content-Type"), "application/problem+json") {
+		var raw map[string]interface{}
+		var problem problemDetails
+		rawErr := json.Unmarshal(body, &raw)
+		problemErr := json.Unmarshal(body, &problem)
+		if jsonErr := firstError(rawErr, problemErr); jsonErr == nil {
+			code := problem.Type
+			if code == "" {
+				code = fallbackCodeForStatus(resp.StatusCode)
+			}
+			message := problem.Detail
+			if message == "" {
+				message = problem.Title
+			}
+
+			var details map[string]interface{}
+			for k, v := range raw {
+				if problemStandardMembers[k] {
+					continue
+				}
+				if details == nil {
+					details = map[string]interface{}{}
+				}
+				details[k] = v
+			}
+
+			return FromResponse(resp.StatusCode, code, message, requestID, details, retryAfter)
+		}
+	}
+
+	var legacy struct {
+		Code    string                 `json:"code"`
+		Message string                 `json:"message"`
+		Details map[string]interface{} `json:"details"`
+	}
+	if jsonErr := json.Unmarshal(body, &legacy); jsonErr == nil && legacy.Code != "" {
+		return FromResponse(resp.StatusCode, legacy.Code, legacy.Message, requestID, legacy.Details, retryAfter)
+	}
+
+	return FromResponse(resp.StatusCode, fallbackCodeForStatus(resp.StatusCode), string(body), requestID, nil, retryAfter)
+}
+
+// firstError returns the first non-nil error in errs, or nil if none.
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fallbackCodeForStatus picks one of the Code* constants for a response
+// that didn't carry its own error code, so FromResponse still has
+// something meaningful to key off of.
+func fallbackCodeForStatus(status int) string {
+	switch status {
+	case 400:
+		return CodeValidationFailed
+	case 401:
+		return CodeAuthInvalidKey
+	case 403:
+		return CodeAuthInsufficientPermission
+	case 404:
+		return CodeResourceNotFound
+	case 409:
+		return CodeResourceConflict
+	case 429:
+		return CodeRateLimitExceeded
+	default:
+		if status >= 500 {
+			return CodeServerInternal
+		}
+		return CodeValidationFailed
+	}
+}
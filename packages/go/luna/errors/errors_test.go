@@ -3,9 +3,13 @@ package errors_test
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/eclipse-softworks/luna-sdk-go/luna"
 	"github.com/eclipse-softworks/luna-sdk-go/luna/errors"
@@ -258,3 +262,150 @@ func TestErrorProperties(t *testing.T) {
 		assert.NotEmpty(t, errStr)
 	})
 }
+
+func TestErrorIsSentinel(t *testing.T) {
+	t.Run("errors.Is matches the sentinel for a rate limit response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(testutil.MockErrorRateLimit)
+		}))
+		defer server.Close()
+
+		client, err := luna.NewClient(
+			luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
+			luna.WithBaseURL(server.URL),
+		)
+		require.NoError(t, err)
+
+		_, err = client.Users().List(context.Background(), nil)
+
+		require.Error(t, err)
+		assert.True(t, stderrors.Is(err, errors.ErrRateLimited))
+		assert.False(t, stderrors.Is(err, errors.ErrResourceNotFound))
+	})
+
+	t.Run("Unwrap exposes the underlying BaseError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(testutil.MockErrorNotFound)
+		}))
+		defer server.Close()
+
+		client, err := luna.NewClient(
+			luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
+			luna.WithBaseURL(server.URL),
+		)
+		require.NoError(t, err)
+
+		_, err = client.Users().Get(context.Background(), "usr_nonexistent")
+
+		require.Error(t, err)
+		var notFoundErr *errors.NotFoundError
+		require.ErrorAs(t, err, &notFoundErr)
+		assert.Equal(t, notFoundErr.BaseError, stderrors.Unwrap(notFoundErr))
+	})
+}
+
+func TestAsRateLimitIsRetryableRetryAfter(t *testing.T) {
+	t.Run("AsRateLimit and RetryAfter extract the retry window", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(testutil.MockErrorRateLimit)
+		}))
+		defer server.Close()
+
+		client, err := luna.NewClient(
+			luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
+			luna.WithBaseURL(server.URL),
+		)
+		require.NoError(t, err)
+
+		_, err = client.Users().List(context.Background(), nil)
+		require.Error(t, err)
+
+		rl, ok := errors.AsRateLimit(err)
+		require.True(t, ok)
+		assert.Greater(t, rl.RetryAfter, 0)
+		assert.True(t, errors.IsRetryable(err))
+		assert.Equal(t, 60*time.Second, errors.RetryAfter(err))
+	})
+
+	t.Run("IsRetryable is false and AsRateLimit fails for a validation error", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(testutil.MockErrorValidation)
+		}))
+		defer server.Close()
+
+		client, err := luna.NewClient(
+			luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
+			luna.WithBaseURL(server.URL),
+		)
+		require.NoError(t, err)
+
+		_, err = client.Users().Create(context.Background(), luna.UserCreate{
+			Name:  "Test User",
+			Email: "test@example.com",
+		})
+		require.Error(t, err)
+
+		_, ok := errors.AsRateLimit(err)
+		assert.False(t, ok)
+		assert.False(t, errors.IsRetryable(err))
+		assert.Equal(t, time.Duration(0), errors.RetryAfter(err))
+	})
+}
+
+func TestFromHTTPResponse(t *testing.T) {
+	t.Run("parses an RFC 7807 problem+json body", func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusNotFound,
+			Header: http.Header{
+				"Content-Type": {"application/problem+json"},
+				"X-Request-Id": {"req_problem_1"},
+			},
+			Body: io.NopCloser(strings.NewReader(`{
+				"type": "resource-not-found",
+				"title": "Not Found",
+				"status": 404,
+				"detail": "user usr_1 not found",
+				"instance": "/users/usr_1",
+				"trace_id": "abc123"
+			}`)),
+		}
+
+		err := errors.FromHTTPResponse(resp)
+		require.Error(t, err)
+
+		var notFoundErr *errors.NotFoundError
+		require.ErrorAs(t, err, &notFoundErr)
+		assert.Equal(t, "resource-not-found", notFoundErr.BaseError.Code)
+		assert.Equal(t, "user usr_1 not found", notFoundErr.BaseError.Message)
+		assert.Equal(t, "req_problem_1", notFoundErr.BaseError.RequestID)
+		assert.Equal(t, "/users/usr_1", notFoundErr.BaseError.Details["instance"])
+		assert.Equal(t, "abc123", notFoundErr.BaseError.Details["trace_id"])
+	})
+
+	t.Run("falls back to the legacy error shape when not problem+json", func(t *testing.T) {
+		resp := &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Header: http.Header{
+				"Content-Type": {"application/json"},
+			},
+			Body: io.NopCloser(strings.NewReader(`{"code":"VALIDATION_FAILED","message":"email is required"}`)),
+		}
+
+		err := errors.FromHTTPResponse(resp)
+		require.Error(t, err)
+
+		var validationErr *errors.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "VALIDATION_FAILED", validationErr.BaseError.Code)
+		assert.Equal(t, "email is required", validationErr.BaseError.Message)
+	})
+}
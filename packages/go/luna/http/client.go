@@ -4,7 +4,9 @@ package http
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"io"
 	"math"
@@ -13,36 +15,517 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/analytics"
 	"github.com/eclipse-softworks/luna-sdk-go/luna/auth"
 	"github.com/eclipse-softworks/luna-sdk-go/luna/errors"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/messagebus"
 	"github.com/eclipse-softworks/luna-sdk-go/luna/telemetry"
 )
 
+// requestCompletedTopic is the messagebus topic Client.Request publishes
+// to once a logical call (all attempts) finishes, success or failure --
+// so a downstream service can watch request/response lifecycle events
+// instead of polling a resource's GetStatus.
+const requestCompletedTopic = "luna.http.request_completed"
+
 // ClientConfig holds HTTP client configuration
 type ClientConfig struct {
-	BaseURL      string
+	BaseURL string
+	// BaseURLs lists additional endpoints Client fails over to when
+	// BaseURL (or whichever endpoint last succeeded) returns a network
+	// error or a 5xx response, for HA against a regional API outage.
+	// BaseURL itself is always tried first on a fresh Client; nil means
+	// BaseURL is the only endpoint, matching Client's behavior before this
+	// field existed. See ClusterError and WithEndpointHealthCheck.
+	BaseURLs     []string
 	Timeout      int
 	MaxRetries   int
 	AuthProvider auth.Provider
 	Logger       telemetry.Logger
+	Analytics    *analytics.Client
+	// Otel emits the OpenTelemetry spans and metrics described in
+	// Client.Request's doc comment. Left nil, NewClient builds one from
+	// otel's globally configured (possibly no-op) providers.
+	Otel *telemetry.Otel
+	// Bus, when set, receives a requestCompletedTopic event for every
+	// logical call Client.Request makes (see publishRequestEvent). Nil is
+	// safe and is the default: no event is published.
+	Bus messagebus.MessageBus
+	// MetricsSink, when set, receives one RequestMetric per HTTP attempt
+	// Client.Request makes (including retries), for callers that want to
+	// wire their own Prometheus/OpenTelemetry counters alongside (or
+	// instead of) the OTel integration Otel already provides.
+	MetricsSink MetricsSink
+}
+
+// Usage reports AI token counts, parsed best-effort from a JSON response
+// body's top-level "usage" object or, failing that, x-usage-* response
+// headers. Most non-AI responses have neither, in which case
+// Response.Usage is nil.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// RequestMetric is one HTTP attempt's outcome, including retries, so a
+// MetricsSink can compute p99 latency and error rate per attempt rather
+// than only over each logical call's final result.
+type RequestMetric struct {
+	Resource  string
+	Method    string
+	Path      string
+	Status    int
+	Attempt   int
+	LatencyMs int64
+	// Err is the attempt's error message, or "" on success.
+	Err string
+}
+
+// MetricsSink receives one RequestMetric per HTTP attempt Client.Request
+// makes.
+type MetricsSink interface {
+	RecordRequest(RequestMetric)
 }
 
 // Client is the HTTP client for the Luna SDK
 type Client struct {
 	config     ClientConfig
 	httpClient *http.Client
+	endpoints  *endpointSet
+
+	retryPolicy        RetryPolicy
+	retryBudget        *RetryBudget
+	idempotencyKeyFunc func(*http.Request) string
+	onRetry            func(attempt int, err error)
+}
+
+// endpointSet holds the base URLs a Client fails over across, and which
+// one a fresh request should start from. It's modeled on etcd's
+// httpClusterClient: a successful request pins its endpoint so later
+// requests prefer it, and WithEndpointHealthCheck can reorder the whole
+// list in the background to prefer endpoints that are actually responding.
+type endpointSet struct {
+	mu     sync.Mutex
+	urls   []string
+	pinned int
+}
+
+// newEndpointSet builds an endpointSet from primary plus extra, dropping
+// empty values and duplicates while preserving order, so primary is always
+// tried first on a fresh Client.
+func newEndpointSet(primary string, extra []string) *endpointSet {
+	seen := make(map[string]bool, 1+len(extra))
+	urls := make([]string, 0, 1+len(extra))
+	for _, u := range append([]string{primary}, extra...) {
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		urls = append(urls, u)
+	}
+	return &endpointSet{urls: urls}
+}
+
+// ordered returns every endpoint exactly once, starting from whichever one
+// is currently pinned.
+func (s *endpointSet) ordered() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]string, len(s.urls))
+	for i := range s.urls {
+		out[i] = s.urls[(s.pinned+i)%len(s.urls)]
+	}
+	return out
+}
+
+// pin makes url the first endpoint ordered returns, so a request that
+// just succeeded against it doesn't force every later request to re-walk
+// the list from the top.
+func (s *endpointSet) pin(url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, u := range s.urls {
+		if u == url {
+			s.pinned = i
+			return
+		}
+	}
+}
+
+// all returns a copy of every configured endpoint, in no particular order.
+func (s *endpointSet) all() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]string, len(s.urls))
+	copy(out, s.urls)
+	return out
+}
+
+// reorder replaces the rotation order outright and resets the pin to the
+// new first entry. Used by the background health check to move endpoints
+// that are currently failing to the back of the list.
+func (s *endpointSet) reorder(urls []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.urls = urls
+	s.pinned = 0
+}
+
+// ClusterError accumulates one error per endpoint Client tried before
+// giving up on a request, named and shaped after etcd's error of the same
+// purpose. Request never returns one when only a single endpoint is
+// configured (the common case), to keep that behavior unchanged from
+// before multi-endpoint failover existed -- callers that only ever
+// configured BaseURL still see the bare underlying error.
+type ClusterError struct {
+	Errors []error
+}
+
+// Error joins every attempt's message, so a caller that doesn't unwrap
+// still gets a useful error string.
+func (e *ClusterError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("luna: all endpoints failed: %s", strings.Join(msgs, "; "))
+}
+
+// Unwrap lets errors.Is/errors.As see through ClusterError to any one of
+// the endpoint errors it accumulated.
+func (e *ClusterError) Unwrap() []error {
+	return e.Errors
+}
+
+// failoverEligible reports whether err is the kind of failure
+// executeWithFailover should try the next endpoint for: a network error
+// that never reached the server, or a 5xx response. Anything else (a 4xx,
+// say) is a property of the request itself, not the endpoint, so trying
+// another endpoint would just waste the rest of the list.
+func failoverEligible(err error) bool {
+	var netErr *errors.NetworkError
+	if stderrors.As(err, &netErr) {
+		return true
+	}
+	return statusFromError(err) >= 500
+}
+
+// ClientOption configures optional Client behavior not covered by
+// ClientConfig.
+type ClientOption func(*Client)
+
+// RetryClassifier reports whether a failed call to method against path
+// should be retried, overriding Client's default retry eligibility
+// (errors.IsRetryable plus RetryPolicy.RetryableStatuses/RetryableMethods)
+// entirely. Use this to opt a normally-unsafe endpoint (e.g. a
+// non-idempotent POST) in, or a normally-safe one out, per resource.
+type RetryClassifier func(method, path string, err error) bool
+
+// RetryPolicy controls which requests Client retries and how long it
+// waits between attempts. BaseDelay is doubled on each attempt (capped at
+// MaxDelay), then the actual sleep is chosen uniformly from [0, delay] --
+// "full jitter" -- so many concurrent callers backing off from the same
+// outage don't all wake up at once.
+type RetryPolicy struct {
+	// MaxAttempts caps the number of retry attempts beyond the initial
+	// try. Zero means "use ClientConfig.MaxRetries"; set this only when
+	// configuring retries as a whole via luna.WithRetryPolicy.
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+
+	// RetryableStatuses are HTTP status codes retried even when the
+	// response body carried no error code errors.IsRetryable recognizes
+	// (e.g. a 502 from an intermediary proxy with no JSON body at all).
+	// Nil means none -- status-code-based retry is opt-in, so configuring
+	// a RetryPolicy for MaxAttempts/BaseDelay/MaxDelay alone never changes
+	// which requests get retried. Pass DefaultRetryableStatuses() to opt
+	// into the common set (429, 500, 502, 503, 504).
+	RetryableStatuses map[int]bool
+	// RetryableMethods are the HTTP methods eligible for retry at all.
+	// Nil means every method is eligible, matching Client's behavior
+	// before this field existed.
+	RetryableMethods map[string]bool
+	// Classifier, when set, replaces the default retry-eligibility
+	// decision (RetryableStatuses/RetryableMethods/errors.IsRetryable)
+	// entirely.
+	Classifier RetryClassifier
+}
+
+// DefaultRetryableStatuses is the common set of transient HTTP statuses
+// worth retrying even when the response body carries no error code
+// errors.IsRetryable recognizes. Pass it (or a subset) as
+// RetryPolicy.RetryableStatuses via luna.WithRetryPolicy to opt in; it is
+// not applied automatically, so existing callers who never configure a
+// RetryPolicy keep their current retry-eligibility behavior unchanged.
+func DefaultRetryableStatuses() map[int]bool {
+	return map[int]bool{
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	}
+}
+
+// defaultRetryPolicy matches the fixed backoff Client used before
+// RetryPolicy existed, upgraded to full jitter. It leaves RetryableStatuses
+// nil so status-code-based retry stays opt-in (see DefaultRetryableStatuses).
+func defaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		BaseDelay: 500 * time.Millisecond,
+		MaxDelay:  30 * time.Second,
+	}
+}
+
+// delay returns how long to wait before the given retry attempt (0-indexed):
+// rand(0, min(MaxDelay, BaseDelay*2^attempt)).
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(attempt)))
+	if d > p.MaxDelay || d <= 0 {
+		d = p.MaxDelay
+	}
+	return time.Duration(rand.Float64() * float64(d))
+}
+
+// retryableMethod reports whether method is eligible for retry under p.
+func (p RetryPolicy) retryableMethod(method string) bool {
+	if p.RetryableMethods == nil {
+		return true
+	}
+	return p.RetryableMethods[method]
+}
+
+// retryableStatus reports whether status is retried under p even without
+// a recognized error code. Nil RetryableStatuses retries none, keeping
+// status-code-based retry strictly opt-in.
+func (p RetryPolicy) retryableStatus(status int) bool {
+	return p.RetryableStatuses[status]
+}
+
+// RetryBudget caps how many retries Client can spend across all requests,
+// independent of any single call's MaxRetries, so a degraded backend can't
+// be amplified into a bigger outage by every caller retrying at once. It's
+// a standard token bucket: tokens refill at qps and the bucket holds at
+// most burst of them.
+type RetryBudget struct {
+	mu         sync.Mutex
+	tokens     float64
+	max        float64
+	refillRate float64
+	last       time.Time
+}
+
+// NewRetryBudget creates a RetryBudget that allows qps retries per second,
+// bursting up to burst at once.
+func NewRetryBudget(qps float64, burst int) *RetryBudget {
+	return &RetryBudget{
+		tokens:     float64(burst),
+		max:        float64(burst),
+		refillRate: qps,
+		last:       time.Now(),
+	}
+}
+
+// take reports whether a retry may proceed, consuming one token if so.
+func (b *RetryBudget) take() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.refillRate
+	if b.tokens > b.max {
+		b.tokens = b.max
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// WithRetryPolicy overrides the default jitter-backoff delay used between
+// retry attempts.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithRetryBudget caps retries across every request this client makes to
+// qps per second, bursting up to burst at once.
+func WithRetryBudget(qps float64, burst int) ClientOption {
+	return func(c *Client) {
+		c.retryBudget = NewRetryBudget(qps, burst)
+	}
+}
+
+// WithIdempotencyKeyFunc overrides how the Idempotency-Key header is
+// derived for a retried POST/PUT/PATCH request. By default, Client reuses
+// the logical call's generated request ID, which is stable across all of
+// that call's attempts.
+func WithIdempotencyKeyFunc(fn func(*http.Request) string) ClientOption {
+	return func(c *Client) {
+		c.idempotencyKeyFunc = fn
+	}
+}
+
+// WithOnRetry registers a callback invoked just before Client sleeps ahead
+// of each retry attempt, for observability (metrics, logging) beyond what
+// the structured request_id logs already capture.
+func WithOnRetry(fn func(attempt int, err error)) ClientOption {
+	return func(c *Client) {
+		c.onRetry = fn
+	}
+}
+
+// WithTransport overrides the http.RoundTripper the client's underlying
+// http.Client uses, e.g. to splice in testutil/schema's ValidatingTransport
+// or a recording/mocking transport. Left unset, the client uses
+// http.DefaultTransport the same as a zero-value http.Client would, except
+// when ClientConfig.AuthProvider carries its own TLSConfig() (e.g.
+// auth.MTLSAuth), in which case that certificate is installed by default.
+// WithTransport always wins if both are present, so a transport.RoundTripper
+// passed here is responsible for carrying the AuthProvider's TLSConfig()
+// itself if both mTLS and a custom transport are needed.
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.httpClient.Transport = transport
+	}
+}
+
+// tlsConfigProvider is implemented by auth providers (e.g. auth.MTLSAuth)
+// that need to present a client certificate rather than, or in addition
+// to, GetHeaders' Authorization header.
+type tlsConfigProvider interface {
+	TLSConfig() *tls.Config
 }
 
 // NewClient creates a new HTTP client
-func NewClient(config ClientConfig) *Client {
-	return &Client{
+func NewClient(config ClientConfig, opts ...ClientOption) *Client {
+	if config.Otel == nil {
+		config.Otel = telemetry.NewOtel(nil, nil)
+	}
+
+	c := &Client{
 		config: config,
 		httpClient: &http.Client{
 			Timeout: time.Duration(config.Timeout) * time.Millisecond,
 		},
+		endpoints:   newEndpointSet(config.BaseURL, config.BaseURLs),
+		retryPolicy: defaultRetryPolicy(),
+	}
+
+	if tcp, ok := config.AuthProvider.(tlsConfigProvider); ok {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tcp.TLSConfig()
+		c.httpClient.Transport = transport
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// WithEndpointHealthCheck starts a background goroutine that GETs path
+// against every configured endpoint every interval, reordering the
+// rotation (see endpointSet.reorder) so endpoints currently passing the
+// check are preferred over ones that aren't. This complements, but
+// doesn't replace, the pinning Client.Request already does on its own
+// successes -- a health check catches an endpoint recovering (or failing)
+// between requests, not just during one. The goroutine runs for the
+// process lifetime; Client has no Close.
+func WithEndpointHealthCheck(interval time.Duration, path string) ClientOption {
+	return func(c *Client) {
+		go c.runEndpointHealthChecks(interval, path)
+	}
+}
+
+func (c *Client) runEndpointHealthChecks(interval time.Duration, path string) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		c.checkEndpointHealth(path)
+	}
+}
+
+// checkEndpointHealth GETs path against every configured endpoint and
+// reorders the rotation to put the ones that answered healthily first,
+// preserving each group's relative order.
+func (c *Client) checkEndpointHealth(path string) {
+	urls := c.endpoints.all()
+	ordered := make([]string, 0, len(urls))
+	unhealthy := make([]string, 0, len(urls))
+	for _, base := range urls {
+		if c.endpointHealthy(base, path) {
+			ordered = append(ordered, base)
+		} else {
+			unhealthy = append(unhealthy, base)
+		}
+	}
+	c.endpoints.reorder(append(ordered, unhealthy...))
+}
+
+func (c *Client) endpointHealthy(base, path string) bool {
+	req, err := http.NewRequest(http.MethodGet, buildURL(base, path, nil), nil)
+	if err != nil {
+		return false
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode < 500
+}
+
+// Track records an analytics event through the client's configured
+// analytics sink. It is a no-op when no sink has been configured, so
+// resources can call it unconditionally.
+func (c *Client) Track(ctx context.Context, event string, properties map[string]interface{}) {
+	if c == nil {
+		return
+	}
+	c.config.Analytics.Track(ctx, event, properties)
+}
+
+// requestEvent is the JSON payload published to requestCompletedTopic.
+type requestEvent struct {
+	RequestID  string `json:"request_id"`
+	Resource   string `json:"resource"`
+	Method     string `json:"method"`
+	Path       string `json:"path"`
+	Status     int    `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// publishRequestEvent publishes a requestCompletedTopic event through the
+// client's configured message bus. It is a no-op when no bus has been
+// configured, so Request can call it unconditionally, mirroring Track.
+func (c *Client) publishRequestEvent(ctx context.Context, event requestEvent) {
+	if c == nil || c.config.Bus == nil {
+		return
 	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	_ = c.config.Bus.Publish(ctx, requestCompletedTopic, payload)
 }
 
 // RequestConfig holds request configuration
@@ -52,6 +535,24 @@ type RequestConfig struct {
 	Query   url.Values
 	Body    interface{}
 	Timeout time.Duration
+	// Resource identifies the calling resource method for logging, e.g.
+	// "Users.Get" or "Messaging.SMS.Send".
+	Resource string
+
+	// BodyReader, when set, is sent as the raw request body instead of
+	// JSON-marshaling Body; ContentType should also be set since the
+	// default of application/json won't apply. If BodyReader implements
+	// io.ReadSeeker it's rewound with Seek(0, io.SeekStart) before each
+	// attempt, so retries resend the same bytes; a non-seekable
+	// BodyReader (e.g. the reader end of an io.Pipe) can only be read
+	// once, so Request treats any error from that attempt as final
+	// rather than risk replaying a drained reader.
+	BodyReader  io.Reader
+	ContentType string
+	// Headers are merged onto the request after Client's own headers
+	// (Content-Type, Accept, request/idempotency IDs, auth), for things
+	// RequestConfig has no dedicated field for, like Content-Range.
+	Headers map[string]string
 }
 
 // Response holds response data
@@ -60,71 +561,364 @@ type Response struct {
 	Status    int
 	Headers   http.Header
 	RequestID string
+	// LatencyMs is the wall time of this specific attempt: from just
+	// before httpClient.Do to just after the response body was fully
+	// read. Unlike the "duration_ms" logged alongside it, it doesn't
+	// include time spent on earlier retry attempts.
+	LatencyMs int64
+	// Usage is parsed best-effort from an AI endpoint's response; see
+	// extractUsage. Nil when the response carries no usage information.
+	Usage *Usage
 }
 
-// Request makes an HTTP request with retry logic
+// extractUsage looks for a top-level "usage" object in a JSON response
+// body, falling back to x-usage-* headers. It returns nil if neither is
+// present, which is the common case for non-AI endpoints.
+func extractUsage(data []byte, headers http.Header) *Usage {
+	var body struct {
+		Usage *Usage `json:"usage"`
+	}
+	if err := json.Unmarshal(data, &body); err == nil && body.Usage != nil {
+		return body.Usage
+	}
+
+	total := headers.Get("X-Usage-Total-Tokens")
+	if total == "" {
+		return nil
+	}
+	usage := &Usage{}
+	usage.TotalTokens, _ = strconv.Atoi(total)
+	usage.PromptTokens, _ = strconv.Atoi(headers.Get("X-Usage-Prompt-Tokens"))
+	usage.CompletionTokens, _ = strconv.Atoi(headers.Get("X-Usage-Completion-Tokens"))
+	return usage
+}
+
+// recordMetric reports m to the client's configured MetricsSink. It is a
+// no-op when no sink has been configured, so Request can call it
+// unconditionally.
+func (c *Client) recordMetric(m RequestMetric) {
+	if c == nil || c.config.MetricsSink == nil {
+		return
+	}
+	c.config.MetricsSink.RecordRequest(m)
+}
+
+// idempotentMethods are the methods Client stamps with an Idempotency-Key
+// header once a request is retried, so repeating them server-side is safe.
+var idempotentMethods = map[string]bool{
+	http.MethodPost:  true,
+	http.MethodPut:   true,
+	http.MethodPatch: true,
+}
+
+// Request makes an HTTP request with retry logic. One structured log event
+// is emitted per HTTP attempt plus a single summary event for the logical
+// call, all stamped with request_id/resource/method/path/status/
+// duration_ms/retry_attempt so they can be correlated across retries. The
+// whole call (all attempts) is also wrapped in a single OTel span named
+// after config.Resource, with request-count and latency metrics recorded
+// once it finishes; see telemetry.Otel.
 func (c *Client) Request(ctx context.Context, config RequestConfig) (*Response, error) {
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
 	reqURL := c.buildURL(config.Path, config.Query)
 	requestID := c.generateRequestID()
+	logger := telemetry.LoggerFromContextOr(ctx, c.config.Logger)
+	start := time.Now()
+
+	ctx, span := c.config.Otel.StartSpan(ctx, config.Resource,
+		attribute.String("http.method", config.Method),
+		attribute.String("http.url", reqURL),
+		attribute.String("luna.request_id", requestID),
+	)
+
+	baseFields := map[string]interface{}{
+		"request_id": requestID,
+		"resource":   config.Resource,
+		"method":     config.Method,
+		"path":       config.Path,
+	}
 
 	var lastErr error
+	lastStatus := 0
+
+	// A raw BodyReader that isn't seekable (e.g. the reader end of an
+	// io.Pipe) can only be consumed once, so it can't safely back a
+	// retried request.
+	bodyReplayable := true
+	if config.BodyReader != nil {
+		_, bodyReplayable = config.BodyReader.(io.ReadSeeker)
+	}
 
-	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
-		resp, err := c.executeRequest(ctx, reqURL, config, requestID)
+	maxAttempts := c.config.MaxRetries
+	if c.retryPolicy.MaxAttempts > 0 {
+		maxAttempts = c.retryPolicy.MaxAttempts
+	}
+
+	for attempt := 0; attempt <= maxAttempts; attempt++ {
+		attemptFields := mergeFields(baseFields, map[string]interface{}{"retry_attempt": attempt})
+
+		idempotencyKey := ""
+		if attempt > 0 && idempotentMethods[config.Method] {
+			idempotencyKey = requestID
+		}
+
+		attemptStart := time.Now()
+		resp, err := c.executeWithFailover(ctx, config, requestID, idempotencyKey)
+		attemptLatencyMs := time.Since(attemptStart).Milliseconds()
 		if err == nil {
-			c.config.Logger.Info("HTTP request completed", map[string]interface{}{
-				"request_id": requestID,
-				"method":     config.Method,
-				"path":       config.Path,
-				"status":     resp.Status,
+			resp.LatencyMs = attemptLatencyMs
+			resp.Usage = extractUsage(resp.Data, resp.Headers)
+
+			completedFields := map[string]interface{}{
+				"status":      resp.Status,
+				"duration_ms": time.Since(start).Milliseconds(),
+				"latency_ms":  resp.LatencyMs,
+			}
+			if resp.Usage != nil {
+				completedFields["prompt_tokens"] = resp.Usage.PromptTokens
+				completedFields["completion_tokens"] = resp.Usage.CompletionTokens
+				completedFields["total_tokens"] = resp.Usage.TotalTokens
+			}
+			logger.Info("HTTP request completed", mergeFields(attemptFields, completedFields))
+			logger.Info("SDK call completed", mergeFields(baseFields, map[string]interface{}{
+				"status":      resp.Status,
+				"duration_ms": time.Since(start).Milliseconds(),
+			}))
+			c.Track(ctx, "sdk.request", map[string]interface{}{
+				"method": config.Method,
+				"path":   config.Path,
+				"status": resp.Status,
+			})
+			c.config.Otel.RecordRequest(ctx, span, config.Resource, resp.Status, time.Since(start), "")
+			c.recordMetric(RequestMetric{
+				Resource:  config.Resource,
+				Method:    config.Method,
+				Path:      config.Path,
+				Status:    resp.Status,
+				Attempt:   attempt,
+				LatencyMs: resp.LatencyMs,
+			})
+			c.publishRequestEvent(ctx, requestEvent{
+				RequestID:  requestID,
+				Resource:   config.Resource,
+				Method:     config.Method,
+				Path:       config.Path,
+				Status:     resp.Status,
+				DurationMs: time.Since(start).Milliseconds(),
 			})
 			return resp, nil
 		}
 
 		lastErr = err
+		lastStatus = statusFromError(err)
+		c.recordMetric(RequestMetric{
+			Resource:  config.Resource,
+			Method:    config.Method,
+			Path:      config.Path,
+			Status:    lastStatus,
+			Attempt:   attempt,
+			LatencyMs: attemptLatencyMs,
+			Err:       err.Error(),
+		})
 
-		// Check if retryable
-		lunaErr, ok := err.(*errors.Error)
-		if !ok {
+		if !c.isRetryable(config.Method, config.Path, err) || attempt >= maxAttempts || !bodyReplayable {
+			logger.Error("SDK call failed", mergeFields(baseFields, map[string]interface{}{
+				"error":       err.Error(),
+				"duration_ms": time.Since(start).Milliseconds(),
+			}))
 			break
 		}
 
-		if !lunaErr.Retryable() || attempt >= c.config.MaxRetries {
-			c.config.Logger.Error("HTTP request failed", map[string]interface{}{
-				"request_id": requestID,
-				"method":     config.Method,
-				"path":       config.Path,
-				"error":      lunaErr.Code,
-				"attempt":    attempt,
-			})
+		if c.retryBudget != nil && !c.retryBudget.take() {
+			logger.Error("SDK call failed", mergeFields(baseFields, map[string]interface{}{
+				"error":       err.Error(),
+				"duration_ms": time.Since(start).Milliseconds(),
+				"reason":      "retry budget exhausted",
+			}))
 			break
 		}
 
-		c.config.Logger.Warn("HTTP request failed, retrying", map[string]interface{}{
-			"request_id": requestID,
-			"method":     config.Method,
-			"path":       config.Path,
-			"status":     lunaErr.Status,
-			"attempt":    attempt,
-		})
+		retryAfter := errors.RetryAfter(err)
+		if retryAfter > 0 {
+			logger.Debug("Rate limited, waiting before retry", mergeFields(attemptFields, map[string]interface{}{
+				"retry_after_ms": retryAfter.Milliseconds(),
+			}))
+			c.config.Otel.RecordRateLimit(ctx, config.Resource)
+		}
+
+		logger.Debug("HTTP request failed, retrying", mergeFields(attemptFields, map[string]interface{}{
+			"error": err.Error(),
+		}))
+
+		c.config.Otel.RecordRetry(ctx, config.Resource)
 
-		// Get retry delay
-		var retryAfter int
-		if rateLimitErr, ok := err.(*errors.RateLimitError); ok {
-			retryAfter = rateLimitErr.RetryAfter
+		if c.onRetry != nil {
+			c.onRetry(attempt, err)
 		}
 
 		c.waitForRetry(ctx, attempt, retryAfter)
 	}
 
+	c.config.Otel.RecordRequest(ctx, span, config.Resource, lastStatus, time.Since(start), errors.ClassName(lastErr))
+	c.publishRequestEvent(ctx, requestEvent{
+		RequestID:  requestID,
+		Resource:   config.Resource,
+		Method:     config.Method,
+		Path:       config.Path,
+		Status:     lastStatus,
+		Error:      lastErr.Error(),
+		DurationMs: time.Since(start).Milliseconds(),
+	})
+
 	return nil, lastErr
 }
 
-func (c *Client) executeRequest(ctx context.Context, reqURL string, config RequestConfig, requestID string) (*Response, error) {
+// StreamRequest makes a single HTTP attempt and returns the raw, unread
+// response body instead of buffering it the way Request's executeRequest
+// does -- for long-lived responses (server-sent events, chunked transfer)
+// that don't fit in memory and shouldn't be read before the caller is ready
+// to consume them. There is no retry: replaying a request mid-stream would
+// silently re-deliver events the caller already processed. The caller owns
+// the returned *http.Response and must Close its Body; cancelling ctx tears
+// down the underlying connection promptly.
+func (c *Client) StreamRequest(ctx context.Context, config RequestConfig) (*http.Response, error) {
+	requestID := c.generateRequestID()
+
+	authHeaders, err := c.config.AuthProvider.GetHeaders()
+	if err != nil {
+		return nil, &errors.NetworkError{BaseError: &errors.Error{
+			Code:      errors.CodeNetworkConnection,
+			Message:   "Failed to get auth headers",
+			RequestID: requestID,
+		}}
+	}
+
+	var bodyReader io.Reader
+	if config.Body != nil {
+		bodyBytes, err := json.Marshal(config.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	reqURL := c.buildURL(config.Path, config.Query)
+	req, err := http.NewRequestWithContext(ctx, config.Method, reqURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("X-Request-Id", requestID)
+	req.Header.Set("User-Agent", "luna-sdk-go/1.0.0")
+
+	for key, value := range authHeaders {
+		req.Header.Set(key, value)
+	}
+	for key, value := range config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	c.config.Otel.InjectHeaders(ctx, req.Header)
+
+	c.config.Logger.Debug("Sending streaming HTTP request", map[string]interface{}{
+		"request_id": requestID,
+		"method":     config.Method,
+		"url":        reqURL,
+	})
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, &errors.NetworkError{BaseError: &errors.Error{
+				Code:      errors.CodeNetworkTimeout,
+				Message:   "Request timeout",
+				RequestID: requestID,
+			}}
+		}
+		return nil, &errors.NetworkError{BaseError: &errors.Error{
+			Code:      errors.CodeNetworkConnection,
+			Message:   "Connection error",
+			RequestID: requestID,
+		}}
+	}
+
+	if resp.StatusCode >= 400 {
+		if resp.Header.Get("X-Request-Id") == "" {
+			resp.Header.Set("X-Request-Id", requestID)
+		}
+		return nil, errors.FromHTTPResponse(resp)
+	}
+
+	return resp, nil
+}
+
+// mergeFields returns a new map containing base overlaid with extra, leaving
+// both inputs untouched.
+func mergeFields(base, extra map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(base)+len(extra))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range extra {
+		out[k] = v
+	}
+	return out
+}
+
+// executeWithFailover tries config's request against each configured
+// endpoint in turn, starting from whichever one last succeeded (see
+// endpointSet), modeled on etcd's httpClusterClient.Do. A context
+// cancellation or deadline is returned immediately -- trying another
+// endpoint can't fix the caller giving up -- and any other failure not
+// eligible for failover (e.g. a 4xx) is also returned immediately. A
+// network error or 5xx response is accumulated and the next endpoint is
+// tried; once every endpoint has failed, the accumulated errors are
+// returned as a *ClusterError, or as the single underlying error when
+// only one endpoint is configured, so existing single-BaseURL callers see
+// no change in behavior. The first endpoint to succeed is pinned so later
+// attempts of this same call, and later calls, start there.
+func (c *Client) executeWithFailover(ctx context.Context, config RequestConfig, requestID, idempotencyKey string) (*Response, error) {
+	urls := c.endpoints.ordered()
+
+	var clusterErr ClusterError
+	for _, base := range urls {
+		resp, err := c.executeRequest(ctx, buildURL(base, config.Path, config.Query), config, requestID, idempotencyKey)
+		if err == nil {
+			c.endpoints.pin(base)
+			return resp, nil
+		}
+
+		if ctxErr := ctx.Err(); ctxErr != nil && stderrors.Is(err, ctxErr) {
+			return nil, err
+		}
+		if stderrors.Is(err, context.Canceled) || stderrors.Is(err, context.DeadlineExceeded) {
+			return nil, err
+		}
+
+		clusterErr.Errors = append(clusterErr.Errors, err)
+		if !failoverEligible(err) {
+			return nil, err
+		}
+	}
+
+	if len(clusterErr.Errors) == 1 {
+		return nil, clusterErr.Errors[0]
+	}
+	return nil, &clusterErr
+}
+
+func (c *Client) executeRequest(ctx context.Context, reqURL string, config RequestConfig, requestID, idempotencyKey string) (*Response, error) {
 	// Get auth headers
 	authHeaders, err := c.config.AuthProvider.GetHeaders()
 	if err != nil {
-		return nil, &errors.NetworkError{Error: &errors.Error{
+		return nil, &errors.NetworkError{BaseError: &errors.Error{
 			Code:      errors.CodeNetworkConnection,
 			Message:   "Failed to get auth headers",
 			RequestID: requestID,
@@ -133,7 +927,19 @@ func (c *Client) executeRequest(ctx context.Context, reqURL string, config Reque
 
 	// Create request body
 	var bodyReader io.Reader
-	if config.Body != nil {
+	contentType := "application/json"
+	switch {
+	case config.BodyReader != nil:
+		if seeker, ok := config.BodyReader.(io.ReadSeeker); ok {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+		}
+		bodyReader = config.BodyReader
+		if config.ContentType != "" {
+			contentType = config.ContentType
+		}
+	case config.Body != nil:
 		bodyBytes, err := json.Marshal(config.Body)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request body: %w", err)
@@ -148,15 +954,29 @@ func (c *Client) executeRequest(ctx context.Context, reqURL string, config Reque
 	}
 
 	// Set headers
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("X-Request-Id", requestID)
 	req.Header.Set("User-Agent", "luna-sdk-go/1.0.0")
 
+	if idempotencyKey != "" {
+		key := idempotencyKey
+		if c.idempotencyKeyFunc != nil {
+			key = c.idempotencyKeyFunc(req)
+		}
+		req.Header.Set("Idempotency-Key", key)
+	}
+
 	for key, value := range authHeaders {
 		req.Header.Set(key, value)
 	}
 
+	for key, value := range config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	c.config.Otel.InjectHeaders(ctx, req.Header)
+
 	c.config.Logger.Debug("Sending HTTP request", map[string]interface{}{
 		"request_id": requestID,
 		"method":     config.Method,
@@ -167,13 +987,13 @@ func (c *Client) executeRequest(ctx context.Context, reqURL string, config Reque
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		if ctx.Err() == context.DeadlineExceeded {
-			return nil, &errors.NetworkError{Error: &errors.Error{
+			return nil, &errors.NetworkError{BaseError: &errors.Error{
 				Code:      errors.CodeNetworkTimeout,
 				Message:   "Request timeout",
 				RequestID: requestID,
 			}}
 		}
-		return nil, &errors.NetworkError{Error: &errors.Error{
+		return nil, &errors.NetworkError{BaseError: &errors.Error{
 			Code:      errors.CodeNetworkConnection,
 			Message:   "Connection error",
 			RequestID: requestID,
@@ -194,26 +1014,13 @@ func (c *Client) executeRequest(ctx context.Context, reqURL string, config Reque
 
 	// Handle error responses
 	if resp.StatusCode >= 400 {
-		var errBody struct {
-			Code    string                 `json:"code"`
-			Message string                 `json:"message"`
-			Details map[string]interface{} `json:"details"`
-		}
-		json.Unmarshal(body, &errBody)
-
-		retryAfter := 0
-		if raHeader := resp.Header.Get("Retry-After"); raHeader != "" {
-			retryAfter, _ = strconv.Atoi(raHeader)
+		errResp := &http.Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       io.NopCloser(bytes.NewReader(body)),
 		}
-
-		return nil, errors.FromResponse(
-			resp.StatusCode,
-			errBody.Code,
-			errBody.Message,
-			serverRequestID,
-			errBody.Details,
-			retryAfter,
-		)
+		errResp.Header.Set("X-Request-Id", serverRequestID)
+		return nil, errors.FromHTTPResponse(errResp)
 	}
 
 	return &Response{
@@ -224,12 +1031,65 @@ func (c *Client) executeRequest(ctx context.Context, reqURL string, config Reque
 	}, nil
 }
 
+// isRetryable reports whether a failed call to method against path should
+// be retried, per c.retryPolicy's Classifier if set, falling back to
+// errors.IsRetryable (error-code based) plus RetryableStatuses (status-
+// code based, for errors with no recognized code) gated by
+// RetryableMethods.
+func (c *Client) isRetryable(method, path string, err error) bool {
+	if c.retryPolicy.Classifier != nil {
+		return c.retryPolicy.Classifier(method, path, err)
+	}
+	if !c.retryPolicy.retryableMethod(method) {
+		return false
+	}
+	if errors.IsRetryable(err) {
+		return true
+	}
+	return c.retryPolicy.retryableStatus(statusFromError(err))
+}
+
+// statusFromError extracts the HTTP status code carried by err's
+// underlying *errors.Error, or 0 if err doesn't wrap one (e.g. it failed
+// before a response was ever received).
+func statusFromError(err error) int {
+	var e *errors.Error
+	if stderrors.As(err, &e) {
+		return e.Status
+	}
+	return 0
+}
+
+// parseRetryAfter parses a Retry-After header value in either of its two
+// HTTP-spec forms -- a number of seconds, or an HTTP-date -- returning 0
+// if header is empty or neither form parses.
+func parseRetryAfter(header string) int {
+	if header == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		return secs
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := time.Until(t); d > 0 {
+			return int(d.Seconds())
+		}
+	}
+	return 0
+}
+
 func (c *Client) buildURL(path string, query url.Values) string {
+	return buildURL(c.config.BaseURL, path, query)
+}
+
+// buildURL joins base and path (ensuring exactly one slash between them)
+// and appends query if non-empty.
+func buildURL(base, path string, query url.Values) string {
 	if !strings.HasPrefix(path, "/") {
 		path = "/" + path
 	}
 
-	u := c.config.BaseURL + path
+	u := base + path
 
 	if len(query) > 0 {
 		u += "?" + query.Encode()
@@ -244,21 +1104,15 @@ func (c *Client) generateRequestID() string {
 	return fmt.Sprintf("req_%s%s", timestamp, random)
 }
 
-func (c *Client) waitForRetry(ctx context.Context, attempt int, retryAfter int) {
-	var delay time.Duration
-
-	if retryAfter > 0 {
-		delay = time.Duration(retryAfter) * time.Second
-	} else {
-		baseDelay := 500 * time.Millisecond
-		maxDelay := 30 * time.Second
-		delay = time.Duration(float64(baseDelay) * math.Pow(2, float64(attempt)))
-		if delay > maxDelay {
-			delay = maxDelay
-		}
-		// Add jitter
-		jitter := time.Duration(float64(delay) * 0.1 * (rand.Float64()*2 - 1))
-		delay += jitter
+func (c *Client) waitForRetry(ctx context.Context, attempt int, retryAfter time.Duration) {
+	delay := retryAfter
+	if delay <= 0 {
+		delay = c.retryPolicy.delay(attempt)
+	} else if max := c.retryPolicy.MaxDelay; max > 0 && delay > max {
+		// A server-supplied Retry-After still has to respect MaxDelay --
+		// otherwise a misbehaving server can hang every retrying call for
+		// however long it likes.
+		delay = max
 	}
 
 	select {
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/auth"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/telemetry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractUsageFromJSONBody(t *testing.T) {
+	usage := extractUsage([]byte(`{"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}`), http.Header{})
+	require.NotNil(t, usage)
+	assert.Equal(t, 10, usage.PromptTokens)
+	assert.Equal(t, 5, usage.CompletionTokens)
+	assert.Equal(t, 15, usage.TotalTokens)
+}
+
+func TestExtractUsageFromHeaders(t *testing.T) {
+	headers := http.Header{}
+	headers.Set("X-Usage-Total-Tokens", "30")
+	headers.Set("X-Usage-Prompt-Tokens", "20")
+	headers.Set("X-Usage-Completion-Tokens", "10")
+
+	usage := extractUsage([]byte(`{}`), headers)
+	require.NotNil(t, usage)
+	assert.Equal(t, 20, usage.PromptTokens)
+	assert.Equal(t, 10, usage.CompletionTokens)
+	assert.Equal(t, 30, usage.TotalTokens)
+}
+
+func TestExtractUsageReturnsNilWhenAbsent(t *testing.T) {
+	assert.Nil(t, extractUsage([]byte(`{"id":"abc"}`), http.Header{}))
+}
+
+type recordingMetricsSink struct {
+	metrics []RequestMetric
+}
+
+func (s *recordingMetricsSink) RecordRequest(m RequestMetric) {
+	s.metrics = append(s.metrics, m)
+}
+
+func TestRequestRecordsMetricAndLatencyOnSuccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"usage":{"prompt_tokens":1,"completion_tokens":2,"total_tokens":3}}`))
+	}))
+	defer server.Close()
+
+	authProvider, err := auth.NewAPIKeyAuth("lk_test_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+
+	sink := &recordingMetricsSink{}
+	client := NewClient(ClientConfig{
+		BaseURL:      server.URL,
+		Timeout:      5000,
+		AuthProvider: authProvider,
+		Logger:       telemetry.NewZerologLogger(telemetry.LogLevelError),
+		MetricsSink:  sink,
+	})
+
+	resp, err := client.Request(context.Background(), RequestConfig{
+		Method:   "GET",
+		Path:     "/v1/ai/chat/completions",
+		Resource: "AI.ChatCompletions",
+	})
+	require.NoError(t, err)
+
+	assert.GreaterOrEqual(t, resp.LatencyMs, int64(0))
+	require.NotNil(t, resp.Usage)
+	assert.Equal(t, 3, resp.Usage.TotalTokens)
+
+	require.Len(t, sink.metrics, 1)
+	assert.Equal(t, "AI.ChatCompletions", sink.metrics[0].Resource)
+	assert.Equal(t, 0, sink.metrics[0].Attempt)
+	assert.Equal(t, http.StatusOK, sink.metrics[0].Status)
+	assert.Empty(t, sink.metrics[0].Err)
+}
+
+func TestRequestRecordsMetricPerRetryAttempt(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	authProvider, err := auth.NewAPIKeyAuth("lk_test_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+
+	sink := &recordingMetricsSink{}
+	retryPolicy := RetryPolicy{
+		BaseDelay:         time.Millisecond,
+		MaxDelay:          time.Millisecond,
+		RetryableStatuses: DefaultRetryableStatuses(),
+	}
+	client := NewClient(ClientConfig{
+		BaseURL:      server.URL,
+		Timeout:      5000,
+		MaxRetries:   3,
+		AuthProvider: authProvider,
+		Logger:       telemetry.NewZerologLogger(telemetry.LogLevelError),
+		MetricsSink:  sink,
+	}, WithRetryPolicy(retryPolicy))
+
+	_, err = client.Request(context.Background(), RequestConfig{
+		Method:   "GET",
+		Path:     "/v1/users",
+		Resource: "Users.Get",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, sink.metrics, 3)
+	assert.Equal(t, 0, sink.metrics[0].Attempt)
+	assert.NotEmpty(t, sink.metrics[0].Err)
+	assert.Equal(t, 1, sink.metrics[1].Attempt)
+	assert.NotEmpty(t, sink.metrics[1].Err)
+	assert.Equal(t, 2, sink.metrics[2].Attempt)
+	assert.Empty(t, sink.metrics[2].Err)
+}
+
+func TestRequestFailsOverToSecondaryEndpoint(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer secondary.Close()
+
+	authProvider, err := auth.NewAPIKeyAuth("lk_test_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+
+	client := NewClient(ClientConfig{
+		BaseURL:      primary.URL,
+		BaseURLs:     []string{secondary.URL},
+		Timeout:      5000,
+		AuthProvider: authProvider,
+		Logger:       telemetry.NewZerologLogger(telemetry.LogLevelError),
+	})
+
+	resp, err := client.Request(context.Background(), RequestConfig{
+		Method:   "GET",
+		Path:     "/v1/users",
+		Resource: "Users.Get",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.Status)
+
+	// The pinned endpoint should now be secondary, so a follow-up request
+	// goes straight there without retrying primary first.
+	require.Len(t, client.endpoints.ordered(), 2)
+	assert.Equal(t, secondary.URL, client.endpoints.ordered()[0])
+}
+
+func TestRequestReturnsClusterErrorWhenAllEndpointsFail(t *testing.T) {
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+
+	secondary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer secondary.Close()
+
+	authProvider, err := auth.NewAPIKeyAuth("lk_test_aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	require.NoError(t, err)
+
+	client := NewClient(ClientConfig{
+		BaseURL:      primary.URL,
+		BaseURLs:     []string{secondary.URL},
+		Timeout:      5000,
+		AuthProvider: authProvider,
+		Logger:       telemetry.NewZerologLogger(telemetry.LogLevelError),
+	})
+
+	_, err = client.Request(context.Background(), RequestConfig{
+		Method:   "GET",
+		Path:     "/v1/users",
+		Resource: "Users.Get",
+	})
+	require.Error(t, err)
+
+	var clusterErr *ClusterError
+	require.ErrorAs(t, err, &clusterErr)
+	assert.Len(t, clusterErr.Errors, 2)
+}
@@ -0,0 +1,32 @@
+package messagebus
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// reconnectBackoff picks how long to wait before the next reconnect
+// attempt: BaseDelay doubled on each attempt and capped at MaxDelay, then
+// chosen uniformly from [0, delay] ("full jitter"), the same scheme
+// lunahttp.RetryPolicy uses for HTTP retries -- so a broker outage doesn't
+// make every disconnected bus client reconnect in lockstep.
+type reconnectBackoff struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+func defaultReconnectBackoff() reconnectBackoff {
+	return reconnectBackoff{
+		BaseDelay: 500 * time.Millisecond,
+		MaxDelay:  30 * time.Second,
+	}
+}
+
+func (b reconnectBackoff) delay(attempt int) time.Duration {
+	d := time.Duration(float64(b.BaseDelay) * math.Pow(2, float64(attempt)))
+	if d > b.MaxDelay || d <= 0 {
+		d = b.MaxDelay
+	}
+	return time.Duration(rand.Float64() * float64(d))
+}
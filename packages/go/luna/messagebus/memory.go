@@ -0,0 +1,78 @@
+package messagebus
+
+import (
+	"context"
+	"sync"
+)
+
+// Memory is a MessageBus implementation backed by nothing but in-process
+// maps and goroutines. It's the default choice for tests and for single-
+// process deployments that don't need delivery events to leave the
+// process; it has no reconnection logic because it has no connection to
+// lose.
+type Memory struct {
+	mu     sync.Mutex
+	subs   map[string]map[int]Handler
+	nextID int
+	closed bool
+}
+
+// NewMemory creates an empty in-process MessageBus.
+func NewMemory() *Memory {
+	return &Memory{subs: make(map[string]map[int]Handler)}
+}
+
+// Publish calls every handler currently subscribed to topic, each in its
+// own goroutine, so a slow or blocking handler can't delay Publish or
+// other subscribers. It always returns nil; a Memory bus has no failure
+// mode of its own.
+func (m *Memory) Publish(ctx context.Context, topic string, payload []byte) error {
+	m.mu.Lock()
+	handlers := make([]Handler, 0, len(m.subs[topic]))
+	for _, h := range m.subs[topic] {
+		handlers = append(handlers, h)
+	}
+	m.mu.Unlock()
+
+	for _, h := range handlers {
+		go h(ctx, payload)
+	}
+	return nil
+}
+
+// Subscribe registers handler for topic. The returned unsubscribe func is
+// idempotent.
+func (m *Memory) Subscribe(topic string, handler Handler) (func(), error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.subs[topic] == nil {
+		m.subs[topic] = make(map[int]Handler)
+	}
+	id := m.nextID
+	m.nextID++
+	m.subs[topic][id] = handler
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			delete(m.subs[topic], id)
+		})
+	}
+	return unsubscribe, nil
+}
+
+// Close discards all subscriptions. It never returns an error; it exists
+// so Memory satisfies MessageBus alongside implementations that do have
+// something to close.
+func (m *Memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.closed = true
+	m.subs = make(map[string]map[int]Handler)
+	return nil
+}
+
+var _ MessageBus = (*Memory)(nil)
@@ -0,0 +1,121 @@
+package messagebus_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/messagebus"
+)
+
+func TestMemoryPublishDeliversToSubscriber(t *testing.T) {
+	bus := messagebus.NewMemory()
+	defer bus.Close()
+
+	received := make(chan []byte, 1)
+	unsubscribe, err := bus.Subscribe("sms.inbound", func(ctx context.Context, payload []byte) {
+		received <- payload
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer unsubscribe()
+
+	if err := bus.Publish(context.Background(), "sms.inbound", []byte("hello")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case payload := <-received:
+		if string(payload) != "hello" {
+			t.Errorf("expected payload %q, got %q", "hello", payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for message")
+	}
+}
+
+func TestMemoryPublishIgnoresOtherTopics(t *testing.T) {
+	bus := messagebus.NewMemory()
+	defer bus.Close()
+
+	received := make(chan []byte, 1)
+	unsubscribe, err := bus.Subscribe("sms.inbound", func(ctx context.Context, payload []byte) {
+		received <- payload
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer unsubscribe()
+
+	if err := bus.Publish(context.Background(), "whatsapp.inbound", []byte("hello")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case payload := <-received:
+		t.Fatalf("expected no delivery, got %q", payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestMemoryUnsubscribeStopsDelivery(t *testing.T) {
+	bus := messagebus.NewMemory()
+	defer bus.Close()
+
+	var mu sync.Mutex
+	count := 0
+	unsubscribe, err := bus.Subscribe("events", func(ctx context.Context, payload []byte) {
+		mu.Lock()
+		count++
+		mu.Unlock()
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := bus.Publish(context.Background(), "events", []byte("one")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	unsubscribe()
+	unsubscribe() // must be safe to call twice
+
+	if err := bus.Publish(context.Background(), "events", []byte("two")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if count != 1 {
+		t.Errorf("expected 1 delivery before unsubscribe, got %d", count)
+	}
+}
+
+func TestMemoryCloseDiscardsSubscriptions(t *testing.T) {
+	bus := messagebus.NewMemory()
+
+	received := make(chan []byte, 1)
+	if _, err := bus.Subscribe("events", func(ctx context.Context, payload []byte) {
+		received <- payload
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := bus.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := bus.Publish(context.Background(), "events", []byte("hello")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case payload := <-received:
+		t.Fatalf("expected no delivery after Close, got %q", payload)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
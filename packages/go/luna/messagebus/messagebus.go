@@ -0,0 +1,34 @@
+// Package messagebus lets SDK resources publish delivery-status and
+// webhook events onto a caller-supplied message bus, so downstream
+// services can consume them asynchronously instead of polling endpoints
+// like Messaging.SMS.GetStatus. It ships an in-process implementation
+// (Memory) usable without any external dependency, plus NATS and RabbitMQ
+// implementations gated behind build tags (see nats.go, rabbitmq.go).
+package messagebus
+
+import "context"
+
+// Handler processes one message delivered on a subscribed topic. It
+// receives payload exactly as published; Subscribe implementations don't
+// interpret or mutate it.
+type Handler func(ctx context.Context, payload []byte)
+
+// MessageBus is a minimal publish/subscribe abstraction over a message
+// broker. Implementations must be safe for concurrent use.
+type MessageBus interface {
+	// Publish delivers payload to every current subscriber of topic.
+	// Implementations backed by a real broker (NATS, RabbitMQ) may return
+	// before the broker has acknowledged the message; callers that need a
+	// delivery guarantee should rely on the broker's own semantics.
+	Publish(ctx context.Context, topic string, payload []byte) error
+
+	// Subscribe registers handler to be called for every message
+	// published to topic from now on. The returned unsubscribe func stops
+	// further deliveries to handler; it is safe to call more than once.
+	Subscribe(topic string, handler Handler) (unsubscribe func(), err error)
+
+	// Close releases any resources held by the bus (connections,
+	// goroutines). Subscribers are not notified; callers that need a
+	// clean shutdown signal should arrange that themselves.
+	Close() error
+}
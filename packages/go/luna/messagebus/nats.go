@@ -0,0 +1,77 @@
+//go:build nats
+
+package messagebus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATS is a MessageBus backed by a NATS connection. Built only when the
+// caller's build includes the "nats" tag, so the SDK doesn't force a NATS
+// client dependency on users who don't want one.
+type NATS struct {
+	conn *nats.Conn
+}
+
+// NATSConfig configures a NATS-backed MessageBus.
+type NATSConfig struct {
+	URL string
+	// Backoff controls the delay between reconnect attempts once the
+	// connection drops. The zero value uses the same defaults as the
+	// HTTP client's RetryPolicy.
+	Backoff reconnectBackoff
+	// MaxReconnects caps how many times the underlying nats.Conn retries
+	// a dropped connection before giving up. 0 uses the nats.go default;
+	// pass -1 to retry forever.
+	MaxReconnects int
+}
+
+// NewNATS connects to a NATS server and returns a MessageBus backed by
+// the connection. Reconnection after the initial connect is handled by
+// nats.go itself, configured from config.Backoff/MaxReconnects.
+func NewNATS(config NATSConfig) (*NATS, error) {
+	backoff := config.Backoff
+	if backoff == (reconnectBackoff{}) {
+		backoff = defaultReconnectBackoff()
+	}
+
+	conn, err := nats.Connect(config.URL,
+		nats.MaxReconnects(config.MaxReconnects),
+		nats.ReconnectWait(backoff.BaseDelay),
+		nats.RetryOnFailedConnect(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("messagebus: connect to nats: %w", err)
+	}
+	return &NATS{conn: conn}, nil
+}
+
+// Publish publishes payload to the NATS subject named topic.
+func (n *NATS) Publish(ctx context.Context, topic string, payload []byte) error {
+	if err := n.conn.Publish(topic, payload); err != nil {
+		return fmt.Errorf("messagebus: nats publish: %w", err)
+	}
+	return nil
+}
+
+// Subscribe subscribes handler to the NATS subject named topic.
+func (n *NATS) Subscribe(topic string, handler Handler) (func(), error) {
+	sub, err := n.conn.Subscribe(topic, func(msg *nats.Msg) {
+		handler(context.Background(), msg.Data)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("messagebus: nats subscribe: %w", err)
+	}
+	return func() { _ = sub.Unsubscribe() }, nil
+}
+
+// Close drains and closes the underlying NATS connection.
+func (n *NATS) Close() error {
+	n.conn.Close()
+	return nil
+}
+
+var _ MessageBus = (*NATS)(nil)
@@ -0,0 +1,199 @@
+//go:build rabbitmq
+
+package messagebus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// RabbitMQ is a MessageBus backed by a RabbitMQ connection, mapping each
+// topic to a fanout exchange of the same name so every subscriber gets
+// its own exclusive queue. Built only when the caller's build includes
+// the "rabbitmq" tag, so the SDK doesn't force an AMQP client dependency
+// on users who don't want one.
+type RabbitMQ struct {
+	url     string
+	backoff reconnectBackoff
+
+	mu     sync.Mutex
+	conn   *amqp.Connection
+	ch     *amqp.Channel
+	closed bool
+}
+
+// RabbitMQConfig configures a RabbitMQ-backed MessageBus.
+type RabbitMQConfig struct {
+	URL string
+	// Backoff controls the delay between reconnect attempts after the
+	// connection drops -- amqp091-go, unlike nats.go, has no built-in
+	// reconnect, so RabbitMQ redials itself on the same full-jitter
+	// schedule the HTTP client uses for retries. The zero value uses
+	// RetryPolicy's defaults.
+	Backoff reconnectBackoff
+}
+
+// NewRabbitMQ dials config.URL and returns a MessageBus backed by the
+// connection, redialing with backoff whenever it drops.
+func NewRabbitMQ(config RabbitMQConfig) (*RabbitMQ, error) {
+	backoff := config.Backoff
+	if backoff == (reconnectBackoff{}) {
+		backoff = defaultReconnectBackoff()
+	}
+
+	r := &RabbitMQ{url: config.URL, backoff: backoff}
+	if err := r.connect(); err != nil {
+		return nil, err
+	}
+	go r.watchConnection()
+	return r, nil
+}
+
+func (r *RabbitMQ) connect() error {
+	conn, err := amqp.Dial(r.url)
+	if err != nil {
+		return fmt.Errorf("messagebus: dial rabbitmq: %w", err)
+	}
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("messagebus: open rabbitmq channel: %w", err)
+	}
+
+	r.mu.Lock()
+	r.conn = conn
+	r.ch = ch
+	r.mu.Unlock()
+	return nil
+}
+
+// watchConnection redials with full-jitter backoff every time the current
+// connection closes, until Close is called.
+func (r *RabbitMQ) watchConnection() {
+	for attempt := 0; ; {
+		r.mu.Lock()
+		conn := r.conn
+		closed := r.closed
+		r.mu.Unlock()
+		if closed {
+			return
+		}
+
+		closeErr := make(chan *amqp.Error, 1)
+		conn.NotifyClose(closeErr)
+		<-closeErr
+
+		r.mu.Lock()
+		stillClosed := r.closed
+		r.mu.Unlock()
+		if stillClosed {
+			return
+		}
+
+		time.Sleep(r.backoff.delay(attempt))
+		if err := r.connect(); err != nil {
+			attempt++
+			continue
+		}
+		attempt = 0
+	}
+}
+
+func (r *RabbitMQ) channel() (*amqp.Channel, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.closed {
+		return nil, fmt.Errorf("messagebus: rabbitmq bus is closed")
+	}
+	return r.ch, nil
+}
+
+func (r *RabbitMQ) declareExchange(ch *amqp.Channel, topic string) error {
+	return ch.ExchangeDeclare(topic, "fanout", true, false, false, false, nil)
+}
+
+// Publish declares topic's fanout exchange (idempotent) and publishes
+// payload to it.
+func (r *RabbitMQ) Publish(ctx context.Context, topic string, payload []byte) error {
+	ch, err := r.channel()
+	if err != nil {
+		return err
+	}
+	if err := r.declareExchange(ch, topic); err != nil {
+		return fmt.Errorf("messagebus: declare exchange %q: %w", topic, err)
+	}
+
+	err = ch.PublishWithContext(ctx, topic, "", false, false, amqp.Publishing{
+		ContentType: "application/octet-stream",
+		Body:        payload,
+	})
+	if err != nil {
+		return fmt.Errorf("messagebus: rabbitmq publish: %w", err)
+	}
+	return nil
+}
+
+// Subscribe declares topic's fanout exchange (idempotent), binds a fresh
+// exclusive queue to it, and delivers messages to handler until the
+// returned unsubscribe func is called.
+func (r *RabbitMQ) Subscribe(topic string, handler Handler) (func(), error) {
+	ch, err := r.channel()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.declareExchange(ch, topic); err != nil {
+		return nil, fmt.Errorf("messagebus: declare exchange %q: %w", topic, err)
+	}
+
+	queue, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("messagebus: declare queue for %q: %w", topic, err)
+	}
+	if err := ch.QueueBind(queue.Name, "", topic, false, nil); err != nil {
+		return nil, fmt.Errorf("messagebus: bind queue for %q: %w", topic, err)
+	}
+
+	deliveries, err := ch.Consume(queue.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("messagebus: consume %q: %w", topic, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case d, ok := <-deliveries:
+				if !ok {
+					return
+				}
+				handler(context.Background(), d.Body)
+			}
+		}
+	}()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() { close(done) })
+	}
+	return unsubscribe, nil
+}
+
+// Close stops reconnect attempts and closes the current connection.
+func (r *RabbitMQ) Close() error {
+	r.mu.Lock()
+	r.closed = true
+	conn := r.conn
+	r.mu.Unlock()
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
+
+var _ MessageBus = (*RabbitMQ)(nil)
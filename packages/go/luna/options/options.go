@@ -0,0 +1,16 @@
+// Package options provides the functional-options idiom shared by resource
+// constructors across the SDK (Storage, ZATools, ...), so callers learn one
+// pattern regardless of which package they're configuring.
+package options
+
+// Option mutates a configuration value of type T. Constructors that accept
+// ...Option[T] start from T's zero value (or their own defaults) and apply
+// each option in order.
+type Option[T any] func(*T)
+
+// Apply runs each opt against cfg in order.
+func Apply[T any](cfg *T, opts []Option[T]) {
+	for _, opt := range opts {
+		opt(cfg)
+	}
+}
@@ -0,0 +1,260 @@
+package provisioning
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// loginStatus is the state of a single in-flight enrollment, streamed to
+// subscribers over the /login/{state} WebSocket.
+type loginStatus string
+
+const (
+	loginStatusPending loginStatus = "pending"
+	loginStatusSuccess loginStatus = "success"
+	loginStatusError   loginStatus = "error"
+)
+
+// loginUpdate is one message sent down the /login/{state} WebSocket.
+type loginUpdate struct {
+	Status       loginStatus `json:"status"`
+	Message      string      `json:"message,omitempty"`
+	AccessToken  string      `json:"access_token,omitempty"`
+	RefreshToken string      `json:"refresh_token,omitempty"`
+}
+
+// pendingLogin tracks one POST /login call until its /callback fires or it
+// expires, fanning status updates out to any subscribed WebSocket.
+type pendingLogin struct {
+	state        string
+	codeVerifier string
+	redirectURI  string
+	createdAt    time.Time
+
+	mu      sync.Mutex
+	updates []loginUpdate
+	subs    []chan loginUpdate
+}
+
+func (p *pendingLogin) publish(u loginUpdate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.updates = append(p.updates, u)
+	for _, sub := range p.subs {
+		select {
+		case sub <- u:
+		default:
+		}
+	}
+}
+
+func (p *pendingLogin) subscribe() (<-chan loginUpdate, []loginUpdate) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ch := make(chan loginUpdate, 8)
+	p.subs = append(p.subs, ch)
+	backlog := make([]loginUpdate, len(p.updates))
+	copy(backlog, p.updates)
+	return ch, backlog
+}
+
+// loginRegistry holds pendingLogins keyed by OAuth state, expiring entries
+// that are never claimed so a restarted enrollment doesn't leak memory.
+type loginRegistry struct {
+	mu      sync.Mutex
+	byState map[string]*pendingLogin
+	ttl     time.Duration
+}
+
+func newLoginRegistry() *loginRegistry {
+	return &loginRegistry{byState: make(map[string]*pendingLogin), ttl: 10 * time.Minute}
+}
+
+func (r *loginRegistry) create(redirectURI string) (*pendingLogin, error) {
+	state, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &pendingLogin{
+		state:        state,
+		codeVerifier: verifier,
+		redirectURI:  redirectURI,
+		createdAt:    time.Now(),
+	}
+
+	r.mu.Lock()
+	r.byState[state] = p
+	r.mu.Unlock()
+
+	return p, nil
+}
+
+func (r *loginRegistry) get(state string) (*pendingLogin, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	p, ok := r.byState[state]
+	if !ok || time.Since(p.createdAt) > r.ttl {
+		return nil, false
+	}
+	return p, true
+}
+
+func (r *loginRegistry) delete(state string) {
+	r.mu.Lock()
+	delete(r.byState, state)
+	r.mu.Unlock()
+}
+
+func randomToken(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// POST /login starts an enrollment: it allocates state + a PKCE verifier
+// and returns the authorization URL the operator should open on any device,
+// plus the one-time state the caller polls/streams via GET /login/{state}.
+func (h *Handler) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body struct {
+		RedirectURI string `json:"redirect_uri"`
+	}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	login, err := h.logins.create(body.RedirectURI)
+	if err != nil {
+		http.Error(w, "failed to start login", http.StatusInternalServerError)
+		return
+	}
+
+	challenge := pkceChallenge(login.codeVerifier)
+	authURL := h.cfg.AuthorizeURL + "?" + url.Values{
+		"client_id":             {h.cfg.ClientID},
+		"redirect_uri":          {login.redirectURI},
+		"response_type":         {"code"},
+		"state":                 {login.state},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"login_url": authURL,
+		"state":     login.state,
+	})
+}
+
+// GET /login/{state} upgrades to a WebSocket and streams loginUpdate
+// messages, starting with anything already published, finally delivering
+// the access/refresh tokens once the OAuth callback completes.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+func (h *Handler) handleLoginStatus(w http.ResponseWriter, r *http.Request) {
+	state := strings.TrimPrefix(r.URL.Path, "/login/")
+	login, ok := h.logins.get(state)
+	if !ok {
+		http.Error(w, "unknown or expired login", http.StatusNotFound)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	ch, backlog := login.subscribe()
+	for _, u := range backlog {
+		if conn.WriteJSON(u) != nil {
+			return
+		}
+		if u.Status != loginStatusPending {
+			return
+		}
+	}
+
+	for u := range ch {
+		if conn.WriteJSON(u) != nil {
+			return
+		}
+		if u.Status != loginStatusPending {
+			h.logins.delete(state)
+			return
+		}
+	}
+}
+
+// CompleteCallback is invoked by the CLI's OAuth redirect handler (or by
+// handleLogin's caller, when this Handler also owns the redirect endpoint)
+// once the authorization code has been exchanged for tokens. It publishes
+// the final status to any subscribed /login/{state} WebSocket.
+func (h *Handler) CompleteCallback(state, accessToken, refreshToken string, err error) {
+	login, ok := h.logins.get(state)
+	if !ok {
+		return
+	}
+	if err != nil {
+		login.publish(loginUpdate{Status: loginStatusError, Message: err.Error()})
+		return
+	}
+	login.publish(loginUpdate{
+		Status:       loginStatusSuccess,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+}
+
+func (h *Handler) handleLogout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	// Token revocation is the caller's responsibility (it holds the
+	// tokens, not us); this endpoint exists for symmetry with `luna auth
+	// logout` and to let operators signal end-of-session in logs.
+	writeJSON(w, http.StatusOK, map[string]string{"status": "logged_out"})
+}
+
+func (h *Handler) handleWhoami(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"auth_type": "provisioning"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
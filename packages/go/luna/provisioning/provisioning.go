@@ -0,0 +1,115 @@
+// Package provisioning exposes an embeddable HTTP API that lets operators
+// enroll headless bots/services (no browser on the target host) without
+// going through the interactive `luna auth login` flow.
+package provisioning
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna"
+)
+
+// Config configures a provisioning Handler.
+type Config struct {
+	// Prefix is the path the handler is mounted under, e.g.
+	// "/luna/provision/v1". Defaults to config.Defaults.ProvisioningPrefix.
+	Prefix string
+	// Secret is the shared-secret bearer token callers must present in an
+	// `Authorization: Bearer <secret>` header.
+	Secret string
+	// AuthorizeURL is the OAuth 2.0 authorization endpoint used to start a
+	// login (see login.go). Defaults to https://auth.eclipse.dev/authorize.
+	AuthorizeURL string
+	// TokenURL is the OAuth 2.0 token endpoint used to exchange a code for
+	// tokens. Defaults to https://auth.eclipse.dev/oauth/token.
+	TokenURL string
+	// ClientID is the OAuth client_id sent with authorization/token
+	// requests. Defaults to "luna-cli".
+	ClientID string
+}
+
+// Handler is an http.Handler implementing the provisioning API described in
+// the package doc. Mount it at Config.Prefix on any *http.ServeMux.
+type Handler struct {
+	client *luna.Client
+	cfg    Config
+	mux    *http.ServeMux
+	logins *loginRegistry
+}
+
+// NewHandler creates a provisioning Handler backed by client. Requests
+// missing or mismatching the shared secret are rejected with 401 before
+// reaching any route.
+func NewHandler(client *luna.Client, cfg Config) *Handler {
+	if cfg.Prefix == "" {
+		cfg.Prefix = "/luna/provision/v1"
+	}
+	if cfg.AuthorizeURL == "" {
+		cfg.AuthorizeURL = "https://auth.eclipse.dev/authorize"
+	}
+	if cfg.TokenURL == "" {
+		cfg.TokenURL = "https://auth.eclipse.dev/oauth/token"
+	}
+	if cfg.ClientID == "" {
+		cfg.ClientID = "luna-cli"
+	}
+
+	h := &Handler{
+		client: client,
+		cfg:    cfg,
+		mux:    http.NewServeMux(),
+		logins: newLoginRegistry(),
+	}
+
+	h.mux.HandleFunc("/login", h.handleLogin)
+	h.mux.HandleFunc("/login/", h.handleLoginStatus)
+	h.mux.HandleFunc("/logout", h.handleLogout)
+	h.mux.HandleFunc("/whoami", h.handleWhoami)
+	h.mux.HandleFunc("/users", h.handleUsersCollection)
+	h.mux.HandleFunc("/users/", h.handleUsersItem)
+
+	return h
+}
+
+// MountPrefix returns the URL prefix this Handler expects to be mounted
+// under, for callers building their own *http.ServeMux routes.
+func (h *Handler) MountPrefix() string {
+	return h.cfg.Prefix
+}
+
+// ServeHTTP implements http.Handler, enforcing the shared-secret bearer
+// token and stripping Config.Prefix before dispatching to routes.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	trimmed := strings.TrimPrefix(r.URL.Path, h.cfg.Prefix)
+	if trimmed == r.URL.Path && h.cfg.Prefix != "" {
+		http.NotFound(w, r)
+		return
+	}
+	if trimmed == "" {
+		trimmed = "/"
+	}
+
+	r2 := r.Clone(r.Context())
+	r2.URL.Path = trimmed
+	h.mux.ServeHTTP(w, r2)
+}
+
+func (h *Handler) authorized(r *http.Request) bool {
+	if h.cfg.Secret == "" {
+		return false
+	}
+	const prefix = "Bearer "
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, prefix) {
+		return false
+	}
+	token := strings.TrimPrefix(header, prefix)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.cfg.Secret)) == 1
+}
@@ -0,0 +1,82 @@
+package provisioning
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna"
+)
+
+// GET/POST /users passes through to client.Users().List / Create so an
+// enrolled bot can manage users without its own copy of the SDK's
+// credentials.
+func (h *Handler) handleUsersCollection(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		users, err := h.client.Users().List(r.Context(), &luna.ListParams{})
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, users)
+	case http.MethodPost:
+		var data luna.UserCreate
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		user, err := h.client.Users().Create(r.Context(), data)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, user)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// GET/PATCH/DELETE /users/{id} passes through to client.Users().Get /
+// Update / Delete.
+func (h *Handler) handleUsersItem(w http.ResponseWriter, r *http.Request) {
+	userID := strings.TrimPrefix(r.URL.Path, "/users/")
+
+	switch r.Method {
+	case http.MethodGet:
+		user, err := h.client.Users().Get(r.Context(), userID)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, user)
+	case http.MethodPatch:
+		var data luna.UserUpdate
+		if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+		user, err := h.client.Users().Update(r.Context(), userID, data)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, user)
+	case http.MethodDelete:
+		if err := h.client.Users().Delete(r.Context(), userID); err != nil {
+			writeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	if lunaErr, ok := err.(*luna.Error); ok {
+		status = lunaErr.Status
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
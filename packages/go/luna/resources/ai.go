@@ -1,17 +1,51 @@
 package resources
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
 
 	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/options"
 )
 
 // AiResource provides access to AI operations
 type AiResource struct {
 	client   *lunahttp.Client
 	basePath string
+
+	promptStarterTTL time.Duration
+
+	mu          sync.Mutex
+	promptCache map[string]promptStarterCacheEntry
+}
+
+// promptStarterCacheEntry is one cached PromptStarters result.
+type promptStarterCacheEntry struct {
+	prompts   []string
+	fetchedAt time.Time
+}
+
+// aiOptions is AiResource's option target; NewAiResource starts from its
+// zero value (matching the resource's pre-options defaults) and applies
+// each AiOption in order.
+type aiOptions struct {
+	promptStarterTTL time.Duration
+}
+
+// AiOption configures NewAiResource.
+type AiOption = options.Option[aiOptions]
+
+// WithPromptStarterTTL overrides how long PromptStarters caches a result
+// per (AppName, Description) before regenerating it. Defaults to 1 hour.
+func WithPromptStarterTTL(ttl time.Duration) AiOption {
+	return func(o *aiOptions) { o.promptStarterTTL = ttl }
 }
 
 // ChatCompletions generates chat completions
@@ -33,10 +67,289 @@ func (r *AiResource) ChatCompletions(ctx context.Context, params *CompletionRequ
 	return &result, nil
 }
 
-// NewAiResource creates a new AI resource
-func NewAiResource(client *lunahttp.Client) *AiResource {
+// StreamOption configures ChatCompletionsStream.
+type StreamOption func(*streamConfig)
+
+type streamConfig struct {
+	onChunk func(CompletionChunk) error
+}
+
+// WithStreamCallback drives the stream to completion internally, invoking
+// onChunk for every chunk, for callers that want a push model instead of
+// driving Next/Current themselves. With this set, ChatCompletionsStream
+// blocks until the stream ends (or onChunk returns an error) and returns an
+// already-closed CompletionStream; its Err() still reflects how the stream
+// ended.
+func WithStreamCallback(onChunk func(CompletionChunk) error) StreamOption {
+	return func(c *streamConfig) {
+		c.onChunk = onChunk
+	}
+}
+
+// ChatCompletionsStream is ChatCompletions with stream: true set, returning
+// a CompletionStream the caller iterates with Next/Current instead of
+// waiting for the whole response. params is not mutated; a copy carries the
+// Stream flag.
+func (r *AiResource) ChatCompletionsStream(ctx context.Context, params *CompletionRequest, opts ...StreamOption) (*CompletionStream, error) {
+	var cfg streamConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	streamParams := *params
+	streamParams.Stream = true
+
+	resp, err := r.client.StreamRequest(ctx, lunahttp.RequestConfig{
+		Method:   "POST",
+		Path:     fmt.Sprintf("%s/chat/completions", r.basePath),
+		Body:     &streamParams,
+		Resource: "AI.ChatCompletionsStream",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stream := newCompletionStream(resp)
+	if cfg.onChunk == nil {
+		return stream, nil
+	}
+
+	defer stream.Close()
+	for stream.Next() {
+		if err := cfg.onChunk(stream.Current()); err != nil {
+			return stream, err
+		}
+	}
+	return stream, stream.Err()
+}
+
+// CompletionStream iterates the server-sent chunks of a streaming chat
+// completion. Call Next until it returns false, then check Err for
+// anything other than a clean end of stream (a "data: [DONE]" event or
+// EOF); Close releases the underlying connection and must be called even
+// if the caller stops iterating before Next returns false.
+type CompletionStream struct {
+	resp    *http.Response
+	scanner *bufio.Scanner
+	current CompletionChunk
+	err     error
+	done    bool
+}
+
+func newCompletionStream(resp *http.Response) *CompletionStream {
+	return &CompletionStream{resp: resp, scanner: bufio.NewScanner(resp.Body)}
+}
+
+// Next advances the stream to the next chunk, returning false once the
+// stream ends or fails; check Err to tell the two apart.
+func (s *CompletionStream) Next() bool {
+	if s.done {
+		return false
+	}
+
+	for s.scanner.Scan() {
+		line := s.scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "" {
+			continue
+		}
+		if data == "[DONE]" {
+			s.done = true
+			return false
+		}
+
+		var chunk CompletionChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			s.err = fmt.Errorf("failed to unmarshal completion chunk: %w", err)
+			s.done = true
+			return false
+		}
+		s.current = chunk
+		return true
+	}
+
+	s.err = s.scanner.Err()
+	s.done = true
+	return false
+}
+
+// Current returns the chunk most recently read by Next.
+func (s *CompletionStream) Current() CompletionChunk {
+	return s.current
+}
+
+// Err returns the first error encountered while iterating, or nil if the
+// stream ended cleanly (EOF or "data: [DONE]").
+func (s *CompletionStream) Err() error {
+	return s.err
+}
+
+// Close releases the underlying HTTP connection. Safe to call more than
+// once.
+func (s *CompletionStream) Close() error {
+	return s.resp.Body.Close()
+}
+
+// Accumulate drives the stream to completion, reducing its deltas back
+// into a single CompletionResponse, for callers that want a streaming
+// display but a normal response object to hand off once the model is done
+// (e.g. to append to chat history or feed into a tool-calling loop). It
+// closes the stream before returning.
+func (s *CompletionStream) Accumulate() (*CompletionResponse, error) {
+	defer s.Close()
+
+	resp := &CompletionResponse{}
+	choices := make(map[int]*Choice)
+	var order []int
+
+	for s.Next() {
+		chunk := s.Current()
+		if resp.ID == "" {
+			resp.ID = chunk.ID
+		}
+		if chunk.Usage != nil {
+			resp.Usage = chunk.Usage
+		}
+
+		for _, delta := range chunk.Choices {
+			choice, ok := choices[delta.Index]
+			if !ok {
+				choice = &Choice{Index: delta.Index, Message: Message{Role: delta.Delta.Role}}
+				choices[delta.Index] = choice
+				order = append(order, delta.Index)
+			}
+			choice.Message.Content += delta.Delta.Content
+			choice.Message.ToolCalls = accumulateToolCalls(choice.Message.ToolCalls, delta.Delta.ToolCalls)
+			if delta.FinishReason != "" {
+				choice.FinishReason = delta.FinishReason
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	resp.Choices = make([]Choice, 0, len(order))
+	for _, index := range order {
+		resp.Choices = append(resp.Choices, *choices[index])
+	}
+	return resp, nil
+}
+
+// accumulateToolCalls merges one delta's ToolCalls into the ones built up
+// so far, matched by ToolCall.Index. A streaming tool call typically
+// arrives as a first delta carrying ID/Type/Function.Name and subsequent
+// deltas that each append a few more characters to Function.Arguments, so
+// later deltas for the same Index only ever grow Arguments.
+func accumulateToolCalls(existing []ToolCall, deltas []ToolCall) []ToolCall {
+	byIndex := make(map[int]int, len(existing))
+	for i := range existing {
+		byIndex[existing[i].Index] = i
+	}
+
+	for _, delta := range deltas {
+		i, ok := byIndex[delta.Index]
+		if !ok {
+			byIndex[delta.Index] = len(existing)
+			existing = append(existing, delta)
+			continue
+		}
+		existing[i].Function.Arguments += delta.Function.Arguments
+		if delta.ID != "" {
+			existing[i].ID = delta.ID
+		}
+		if delta.Type != "" {
+			existing[i].Type = delta.Type
+		}
+		if delta.Function.Name != "" {
+			existing[i].Function.Name = delta.Function.Name
+		}
+	}
+	return existing
+}
+
+// Recv reads the next chunk. It returns io.EOF once the stream ends
+// cleanly (a "data: [DONE]" event or EOF) and any other error from Err
+// otherwise. Recv is a one-call convenience over driving Next/Current
+// directly; callers that want to distinguish "no more chunks" from
+// "stream failed" without an io.EOF check should use Next/Current/Err
+// instead.
+func (s *CompletionStream) Recv() (*CompletionChunk, error) {
+	if !s.Next() {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	chunk := s.current
+	return &chunk, nil
+}
+
+// NewAiResource creates a new AI resource. With no options, PromptStarters
+// caches results for an hour.
+func NewAiResource(client *lunahttp.Client, opts ...AiOption) *AiResource {
+	o := aiOptions{promptStarterTTL: time.Hour}
+	options.Apply(&o, opts)
+
 	return &AiResource{
-		client:   client,
-		basePath: "/v1/ai",
+		client:           client,
+		basePath:         "/v1/ai",
+		promptStarterTTL: o.promptStarterTTL,
+		promptCache:      make(map[string]promptStarterCacheEntry),
+	}
+}
+
+// PromptStarters returns up to req.Limit suggested opening prompts for an
+// application described by req.AppName/req.Description, calling
+// /ai/prompt-starters. Limit must be in [1, 9]. Results are cached per
+// (AppName, Description) for r.promptStarterTTL (see WithPromptStarterTTL)
+// so a UI that re-renders its starter list on every load doesn't
+// regenerate it from the model each time.
+func (r *AiResource) PromptStarters(ctx context.Context, req PromptStarterRequest) ([]string, error) {
+	if req.Limit < 1 || req.Limit >= 10 {
+		return nil, fmt.Errorf("prompt starter limit must be between 1 and 9, got %d", req.Limit)
+	}
+
+	key := req.AppName + "\x00" + req.Description
+
+	r.mu.Lock()
+	entry, ok := r.promptCache[key]
+	r.mu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < r.promptStarterTTL {
+		return truncatePrompts(entry.prompts, req.Limit), nil
+	}
+
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method:   "POST",
+		Path:     fmt.Sprintf("%s/prompt-starters", r.basePath),
+		Body:     req,
+		Resource: "AI.PromptStarters",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result promptStarterResponse
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	r.mu.Lock()
+	r.promptCache[key] = promptStarterCacheEntry{prompts: result.Prompts, fetchedAt: time.Now()}
+	r.mu.Unlock()
+
+	return truncatePrompts(result.Prompts, req.Limit), nil
+}
+
+// truncatePrompts caps prompts at limit entries, for a cached result whose
+// originating request asked for more than the caller currently wants.
+func truncatePrompts(prompts []string, limit int) []string {
+	if len(prompts) <= limit {
+		return prompts
 	}
+	return prompts[:limit]
 }
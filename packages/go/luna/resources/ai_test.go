@@ -0,0 +1,64 @@
+package resources_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAiResource_PromptStarters(t *testing.T) {
+	ms := testutil.NewMockServer()
+	defer ms.Close()
+
+	calls := 0
+	ms.Mux.HandleFunc("/v1/ai/prompt-starters", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"prompts": []string{"one", "two", "three", "four"},
+		})
+	})
+
+	client, err := luna.NewClient(
+		luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
+		luna.WithBaseURL(ms.URL()),
+	)
+	require.NoError(t, err)
+
+	req := luna.PromptStarterRequest{AppName: "demo", Description: "a demo app", Limit: 2}
+
+	prompts, err := client.AI().PromptStarters(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two"}, prompts)
+	assert.Equal(t, 1, calls)
+
+	// A second call with the same (AppName, Description) is served from
+	// cache, so the mock handler isn't hit again.
+	prompts, err = client.AI().PromptStarters(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"one", "two"}, prompts)
+	assert.Equal(t, 1, calls)
+}
+
+func TestAiResource_PromptStartersValidatesLimit(t *testing.T) {
+	ms := testutil.NewMockServer()
+	defer ms.Close()
+
+	client, err := luna.NewClient(
+		luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
+		luna.WithBaseURL(ms.URL()),
+	)
+	require.NoError(t, err)
+
+	_, err = client.AI().PromptStarters(context.Background(), luna.PromptStarterRequest{AppName: "demo", Limit: 0})
+	assert.Error(t, err)
+
+	_, err = client.AI().PromptStarters(context.Background(), luna.PromptStarterRequest{AppName: "demo", Limit: 10})
+	assert.Error(t, err)
+}
@@ -1,9 +1,16 @@
 package resources
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
 )
@@ -51,6 +58,258 @@ func (r *WorkflowsResource) Trigger(ctx context.Context, id string, params any)
 	return &result, nil
 }
 
+// GetRun retrieves the current status of a single workflow run
+func (r *WorkflowsResource) GetRun(ctx context.Context, workflowID, runID string) (*WorkflowRun, error) {
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s/runs/%s", r.basePath, workflowID, runID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result WorkflowRun
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ListRuns retrieves a workflow's past and current runs with pagination
+func (r *WorkflowsResource) ListRuns(ctx context.Context, workflowID string, params *ListParams) (*WorkflowRunList, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.Cursor != "" {
+			query.Set("cursor", params.Cursor)
+		}
+	}
+
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s/runs", r.basePath, workflowID),
+		Query:  query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result WorkflowRunList
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// CancelRun requests cancellation of an in-progress workflow run
+func (r *WorkflowsResource) CancelRun(ctx context.Context, workflowID, runID string) (*WorkflowRun, error) {
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "POST",
+		Path:   fmt.Sprintf("%s/%s/runs/%s/cancel", r.basePath, workflowID, runID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result WorkflowRun
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// terminalRunStatuses are the WorkflowRun.Status values WaitForRun stops
+// polling on by default.
+var terminalRunStatuses = map[string]bool{
+	"succeeded": true,
+	"failed":    true,
+	"cancelled": true,
+	"timed_out": true,
+}
+
+// defaultTerminal reports whether run is in one of terminalRunStatuses.
+func defaultTerminal(run *WorkflowRun) bool {
+	return terminalRunStatuses[run.Status]
+}
+
+// WaitOptions configures WaitForRun's polling behavior.
+type WaitOptions struct {
+	// InitialInterval is the delay before the second poll (the first
+	// happens immediately). Defaults to 1 second.
+	InitialInterval time.Duration
+	// MaxInterval caps the delay between polls regardless of backoff.
+	// Defaults to 30 seconds.
+	MaxInterval time.Duration
+	// BackoffFactor multiplies the interval after every poll that doesn't
+	// reach a terminal state. Defaults to 2.
+	BackoffFactor float64
+	// Jitter randomizes each interval within [interval*(1-Jitter),
+	// interval], so concurrent callers waiting on different runs don't
+	// all poll in lockstep. Zero means no jitter.
+	Jitter float64
+	// Terminal reports whether run should stop WaitForRun's polling loop.
+	// Defaults to checking run.Status against the standard terminal
+	// states (succeeded, failed, cancelled, timed_out).
+	Terminal func(run *WorkflowRun) bool
+	// OnUpdate, if set, is called with the result of every poll
+	// (including the first), terminal or not, for progress reporting.
+	OnUpdate func(run *WorkflowRun)
+}
+
+// WaitForRun polls GetRun until it reaches a terminal state (per
+// opts.Terminal) or ctx is cancelled, backing off between polls according
+// to opts.InitialInterval/MaxInterval/BackoffFactor/Jitter.
+func (r *WorkflowsResource) WaitForRun(ctx context.Context, workflowID, runID string, opts WaitOptions) (*WorkflowRun, error) {
+	interval := opts.InitialInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 30 * time.Second
+	}
+	backoffFactor := opts.BackoffFactor
+	if backoffFactor <= 0 {
+		backoffFactor = 2
+	}
+	terminal := opts.Terminal
+	if terminal == nil {
+		terminal = defaultTerminal
+	}
+
+	for {
+		run, err := r.GetRun(ctx, workflowID, runID)
+		if err != nil {
+			return nil, err
+		}
+		if opts.OnUpdate != nil {
+			opts.OnUpdate(run)
+		}
+		if terminal(run) {
+			return run, nil
+		}
+
+		wait := interval
+		if opts.Jitter > 0 {
+			wait = time.Duration(float64(wait) * (1 - opts.Jitter + opts.Jitter*rand.Float64()))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		interval = time.Duration(float64(interval) * backoffFactor)
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+// StreamLogs streams a workflow run's execution log over SSE, returning a
+// channel of LogEvent the caller ranges over until it closes. A dropped
+// connection is reconnected automatically, resuming after the last event
+// seen via a Last-Event-Id header, so a caller ranging over the channel
+// never has to notice or handle the reconnect itself. The channel closes
+// when the server signals the log is complete (a "data: [DONE]" event,
+// matching AiResource's streaming convention), ctx is cancelled, or
+// reconnecting fails.
+func (r *WorkflowsResource) StreamLogs(ctx context.Context, workflowID, runID string) (<-chan LogEvent, error) {
+	path := fmt.Sprintf("%s/%s/runs/%s/logs", r.basePath, workflowID, runID)
+
+	resp, err := r.client.StreamRequest(ctx, lunahttp.RequestConfig{
+		Method: "GET",
+		Path:   path,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan LogEvent)
+	go r.streamLogsLoop(ctx, path, resp, events)
+	return events, nil
+}
+
+func (r *WorkflowsResource) streamLogsLoop(ctx context.Context, path string, resp *http.Response, events chan<- LogEvent) {
+	defer close(events)
+
+	lastEventID := ""
+	for {
+		var done bool
+		lastEventID, done = r.drainLogEvents(ctx, resp, events, lastEventID)
+		resp.Body.Close()
+
+		if done || ctx.Err() != nil {
+			return
+		}
+
+		headers := map[string]string{}
+		if lastEventID != "" {
+			headers["Last-Event-Id"] = lastEventID
+		}
+		next, err := r.client.StreamRequest(ctx, lunahttp.RequestConfig{
+			Method:  "GET",
+			Path:    path,
+			Headers: headers,
+		})
+		if err != nil {
+			return
+		}
+		resp = next
+	}
+}
+
+// drainLogEvents reads SSE events from resp, forwarding each as a
+// LogEvent, until the connection drops or the server signals completion.
+// It returns the ID of the last event forwarded (for Last-Event-Id on
+// reconnect) and whether the server explicitly signaled the stream is
+// complete rather than just dropping the connection.
+func (r *WorkflowsResource) drainLogEvents(ctx context.Context, resp *http.Response, events chan<- LogEvent, lastEventID string) (newLastEventID string, done bool) {
+	scanner := bufio.NewScanner(resp.Body)
+	var pendingID string
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "id:"):
+			pendingID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "data:"):
+			data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			if data == "" {
+				continue
+			}
+			if data == "[DONE]" {
+				return lastEventID, true
+			}
+
+			var event LogEvent
+			if err := json.Unmarshal([]byte(data), &event); err != nil {
+				continue
+			}
+			if event.ID == "" {
+				event.ID = pendingID
+			}
+			if event.ID != "" {
+				lastEventID = event.ID
+			}
+
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return lastEventID, true
+			}
+		}
+	}
+
+	return lastEventID, false
+}
+
 // AutomationResource groups Automation service resources
 type AutomationResource struct {
 	Workflows *WorkflowsResource
@@ -0,0 +1,175 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/errors"
+	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+)
+
+// BatchOp identifies the kind of operation a BatchOperation performs.
+type BatchOp string
+
+const (
+	BatchOpCreate BatchOp = "create"
+	BatchOpUpdate BatchOp = "update"
+	BatchOpDelete BatchOp = "delete"
+)
+
+// BatchOperation is one create/update/delete request to a resource's
+// Batch/BatchAll methods, modeled on the git-lfs batch API: operations of
+// different kinds can be mixed in a single call and are executed
+// server-side as a single POST .../:batch request.
+type BatchOperation struct {
+	Op BatchOp
+	// ID is required for Update and Delete, ignored for Create.
+	ID string
+	// Data is the create/update payload (e.g. UserCreate, UserUpdate);
+	// ignored for Delete.
+	Data interface{}
+}
+
+// DefaultBatchChunkSize is how many operations BatchAll sends per request
+// when the caller passes chunkSize <= 0.
+const DefaultBatchChunkSize = 100
+
+// BatchResult is the outcome of one BatchOperation, at the same index as
+// the operation it came from. Exactly one of Item and Err is set; Item is
+// nil for a failed operation and for every Delete (the API returns no
+// body for those).
+type BatchResult[T any] struct {
+	Item *T
+	Err  error
+}
+
+// batchOperationWire is BatchOperation's JSON wire shape: {"op":"create",
+// "data":{...}} / {"op":"update","id":"...","data":{...}} /
+// {"op":"delete","id":"..."}.
+type batchOperationWire struct {
+	Op   BatchOp     `json:"op"`
+	ID   string      `json:"id,omitempty"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// batchResultWire is one entry of a batch response's "results" array.
+type batchResultWire struct {
+	Status int                 `json:"status"`
+	Data   json.RawMessage     `json:"data,omitempty"`
+	Error  *batchResultWireErr `json:"error,omitempty"`
+}
+
+type batchResultWireErr struct {
+	Code    string                 `json:"code"`
+	Message string                 `json:"message"`
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// validateBatchOperation applies validateID (e.g. validateUserID) to
+// operations that carry one, and checks that Create/Update carry Data.
+func validateBatchOperation(op BatchOperation, validateID func(string) error) error {
+	switch op.Op {
+	case BatchOpCreate:
+		if op.Data == nil {
+			return fmt.Errorf("create operation requires Data")
+		}
+	case BatchOpUpdate:
+		if err := validateID(op.ID); err != nil {
+			return err
+		}
+		if op.Data == nil {
+			return fmt.Errorf("update operation requires Data")
+		}
+	case BatchOpDelete:
+		if err := validateID(op.ID); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid batch operation %q, expected create/update/delete", op.Op)
+	}
+	return nil
+}
+
+// runBatch validates ops against validateID, issues a single POST path
+// request with the {"operations":[...]} body, and decodes each response
+// item into a BatchResult, preserving input order. An item-level error (a
+// non-nil "error" member of the response) is classified with
+// errors.FromResponse and reported in that item's BatchResult rather than
+// failing the whole call; only a transport failure or a malformed
+// response returns a top-level error.
+func runBatch[T any](ctx context.Context, client *lunahttp.Client, path string, ops []BatchOperation, validateID func(string) error) ([]BatchResult[T], error) {
+	for i, op := range ops {
+		if err := validateBatchOperation(op, validateID); err != nil {
+			return nil, fmt.Errorf("operation %d: %w", i, err)
+		}
+	}
+
+	wire := make([]batchOperationWire, len(ops))
+	for i, op := range ops {
+		wire[i] = batchOperationWire{Op: op.Op, ID: op.ID, Data: op.Data}
+	}
+
+	resp, err := client.Request(ctx, lunahttp.RequestConfig{
+		Method: "POST",
+		Path:   path,
+		Body:   map[string]interface{}{"operations": wire},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var raw struct {
+		Results []batchResultWire `json:"results"`
+	}
+	if err := json.Unmarshal(resp.Data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if len(raw.Results) != len(ops) {
+		return nil, fmt.Errorf("batch response had %d results for %d operations", len(raw.Results), len(ops))
+	}
+
+	results := make([]BatchResult[T], len(ops))
+	for i, item := range raw.Results {
+		if item.Error != nil {
+			results[i] = BatchResult[T]{Err: errors.FromResponse(item.Status, item.Error.Code, item.Error.Message, "", item.Error.Details, 0)}
+			continue
+		}
+		if len(item.Data) == 0 {
+			continue
+		}
+		var decoded T
+		if err := json.Unmarshal(item.Data, &decoded); err != nil {
+			results[i] = BatchResult[T]{Err: fmt.Errorf("result %d: failed to unmarshal: %w", i, err)}
+			continue
+		}
+		results[i] = BatchResult[T]{Item: &decoded}
+	}
+	return results, nil
+}
+
+// runBatchAll splits ops into chunks of at most chunkSize (falling back
+// to DefaultBatchChunkSize), runs runBatch on each in turn, and merges
+// the results in input order. It returns whatever results were gathered
+// before a transport-level failure alongside that error, mirroring
+// Paginator.Collect.
+func runBatchAll[T any](ctx context.Context, client *lunahttp.Client, path string, ops []BatchOperation, validateID func(string) error, chunkSize int) ([]BatchResult[T], error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultBatchChunkSize
+	}
+
+	results := make([]BatchResult[T], 0, len(ops))
+	for start := 0; start < len(ops); start += chunkSize {
+		end := start + chunkSize
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		chunkResults, err := runBatch[T](ctx, client, path, ops[start:end], validateID)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, chunkResults...)
+	}
+	return results, nil
+}
@@ -0,0 +1,111 @@
+package resources_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBatchTestClient(t *testing.T) (*testutil.MockServer, *luna.Client) {
+	t.Helper()
+	ms := testutil.NewMockServer()
+	t.Cleanup(ms.Close)
+
+	client, err := luna.NewClient(
+		luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
+		luna.WithBaseURL(ms.URL()),
+	)
+	require.NoError(t, err)
+	return ms, client
+}
+
+func TestUsersResource_Batch(t *testing.T) {
+	_, client := newBatchTestClient(t)
+
+	t.Run("preserves order across create/update/delete", func(t *testing.T) {
+		results, err := client.Users().Batch(context.Background(), []luna.BatchOperation{
+			{Op: luna.BatchOpCreate, Data: luna.UserCreate{Name: "New User", Email: "newuser@example.com"}},
+			{Op: luna.BatchOpUpdate, ID: "usr_123456789", Data: luna.UserUpdate{Name: stringPtr("Updated")}},
+			{Op: luna.BatchOpDelete, ID: "usr_987654321"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		assert.NoError(t, results[0].Err)
+		require.NotNil(t, results[0].Item)
+
+		assert.NoError(t, results[1].Err)
+		require.NotNil(t, results[1].Item)
+		assert.Equal(t, "usr_123456789", results[1].Item.ID)
+
+		assert.NoError(t, results[2].Err)
+		assert.Nil(t, results[2].Item)
+	})
+
+	t.Run("reports a per-item error without failing the call", func(t *testing.T) {
+		results, err := client.Users().Batch(context.Background(), []luna.BatchOperation{
+			{Op: luna.BatchOpUpdate, ID: "usr_123456789", Data: luna.UserUpdate{Name: stringPtr("Updated")}},
+			{Op: luna.BatchOpDelete, ID: "usr_nonexistent"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		assert.NoError(t, results[0].Err)
+		require.NotNil(t, results[0].Item)
+
+		require.Error(t, results[1].Err)
+		assert.Nil(t, results[1].Item)
+	})
+
+	t.Run("rejects an invalid operation before making a request", func(t *testing.T) {
+		_, err := client.Users().Batch(context.Background(), []luna.BatchOperation{
+			{Op: luna.BatchOpUpdate, ID: "not-a-user-id", Data: luna.UserUpdate{}},
+		})
+
+		require.Error(t, err)
+	})
+}
+
+func TestUsersResource_BatchAll(t *testing.T) {
+	_, client := newBatchTestClient(t)
+
+	t.Run("splits an oversize slice into chunks and merges results in order", func(t *testing.T) {
+		ops := make([]luna.BatchOperation, 0, 5)
+		for i := 0; i < 5; i++ {
+			ops = append(ops, luna.BatchOperation{Op: luna.BatchOpDelete, ID: "usr_123456789"})
+		}
+
+		results, err := client.Users().BatchAll(context.Background(), ops, 2)
+
+		require.NoError(t, err)
+		require.Len(t, results, 5)
+		for _, r := range results {
+			assert.NoError(t, r.Err)
+		}
+	})
+}
+
+func TestProjectsResource_Batch(t *testing.T) {
+	_, client := newBatchTestClient(t)
+
+	t.Run("reports a per-item error without failing the call", func(t *testing.T) {
+		results, err := client.Projects().Batch(context.Background(), []luna.BatchOperation{
+			{Op: luna.BatchOpCreate, Data: luna.ProjectCreate{Name: "New Project"}},
+			{Op: luna.BatchOpDelete, ID: "prj_nonexistent"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+
+		assert.NoError(t, results[0].Err)
+		require.NotNil(t, results[0].Item)
+
+		require.Error(t, results[1].Err)
+	})
+}
@@ -0,0 +1,164 @@
+package resources
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// earthRadiusKm is the mean Earth radius used by haversineKm, matching the
+// value ResidenceSearch's server side is documented to use for its own
+// Radius filter (so SearchNearby's client-side re-filter agrees with it at
+// the boundary).
+const earthRadiusKm = 6371.0088
+
+// haversineKm returns the great-circle distance in kilometers between two
+// lat/lon points.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	deltaPhi := (lat2 - lat1) * math.Pi / 180
+	deltaLambda := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Pow(math.Sin(deltaPhi/2), 2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Pow(math.Sin(deltaLambda/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
+
+// boundingBox derives the axis-aligned GeoBox enclosing a radiusKm circle
+// centered on origin, widening by radius/111.32 degrees of latitude (one
+// degree of latitude is ~111.32km everywhere) and radius/(111.32*cos(lat))
+// degrees of longitude (a degree of longitude shrinks toward the poles).
+// It over-covers the true circle at the corners, which is corrected for by
+// re-filtering with haversineKm once the server returns results.
+func boundingBox(origin ResidenceLocation, radiusKm float64) GeoBox {
+	latDelta := radiusKm / 111.32
+	lonDelta := radiusKm / (111.32 * math.Cos(origin.Latitude*math.Pi/180))
+
+	return GeoBox{
+		MinLat: origin.Latitude - latDelta,
+		MaxLat: origin.Latitude + latDelta,
+		MinLng: origin.Longitude - lonDelta,
+		MaxLng: origin.Longitude + lonDelta,
+	}
+}
+
+// SearchNearbyOption configures SearchNearby.
+type SearchNearbyOption func(*searchNearbyConfig)
+
+type searchNearbyConfig struct {
+	sortByDistance bool
+	polygon        []ResidenceLocation
+}
+
+// SortByDistance orders SearchNearby's results ascending by distance from
+// origin, closest first. Without it, results keep whatever order the
+// server (or filters.Sort) returned them in.
+func SortByDistance() SearchNearbyOption {
+	return func(c *searchNearbyConfig) { c.sortByDistance = true }
+}
+
+// WithinPolygon additionally restricts SearchNearby's results to those
+// falling inside polygon, tested with the ray-casting point-in-polygon
+// algorithm. polygon's points are taken in order as the polygon's
+// vertices; it does not need to be explicitly closed (the last vertex is
+// implicitly connected back to the first).
+func WithinPolygon(polygon []ResidenceLocation) SearchNearbyOption {
+	return func(c *searchNearbyConfig) { c.polygon = polygon }
+}
+
+// SearchNearby runs filters as a server-side bounding-box search around
+// origin (sized to radiusKm per boundingBox) and re-filters each returned
+// page client-side with the haversine formula, dropping the corner
+// residences a bounding box necessarily over-includes but a true circle
+// would not. filters.Location, filters.Radius, and filters.BoundingBox are
+// overwritten; set the rest of filters (Query, NSFAS, PriceBetween, ...)
+// as normal.
+func (r *ResidencesResource) SearchNearby(ctx context.Context, origin ResidenceLocation, radiusKm float64, filters ResidenceSearch, opts ...SearchNearbyOption) *Paginator[Residence] {
+	var cfg searchNearbyConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	box := boundingBox(origin, radiusKm)
+	base := filters
+	base.Location = nil
+	base.Radius = 0
+	base.BoundingBox = &box
+	base.Origin = &origin
+
+	return NewPaginator(ctx, func(ctx context.Context, cursor string) (*ListResponse[Residence], error) {
+		params := base
+		params.Cursor = cursor
+
+		resp, err := r.List(ctx, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		filtered := resp.Data[:0]
+		for _, residence := range resp.Data {
+			loc := residence.Location
+			if haversineKm(origin.Latitude, origin.Longitude, loc.Latitude, loc.Longitude) > radiusKm {
+				continue
+			}
+			if len(cfg.polygon) > 0 && !pointInPolygon(loc, cfg.polygon) {
+				continue
+			}
+			filtered = append(filtered, residence)
+		}
+
+		if cfg.sortByDistance {
+			sort.Slice(filtered, func(i, j int) bool {
+				di := haversineKm(origin.Latitude, origin.Longitude, filtered[i].Location.Latitude, filtered[i].Location.Longitude)
+				dj := haversineKm(origin.Latitude, origin.Longitude, filtered[j].Location.Latitude, filtered[j].Location.Longitude)
+				return di < dj
+			})
+		}
+
+		lr := resp.ListResponse
+		lr.Data = filtered
+		return &lr, nil
+	})
+}
+
+// pointInPolygon reports whether point falls inside polygon, using the
+// standard ray-casting test: count how many polygon edges a ray cast due
+// east from point crosses, and call it inside on an odd count.
+func pointInPolygon(point ResidenceLocation, polygon []ResidenceLocation) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		vi, vj := polygon[i], polygon[j]
+		crosses := (vi.Longitude > point.Longitude) != (vj.Longitude > point.Longitude)
+		if !crosses {
+			continue
+		}
+		xIntersect := (vj.Latitude-vi.Latitude)*(point.Longitude-vi.Longitude)/(vj.Longitude-vi.Longitude) + vi.Latitude
+		if point.Latitude < xIntersect {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// NearestCampus returns whichever of campuses is geographically closest to
+// residence, and its distance in kilometers. Each campus's coordinates are
+// cached by ID on first lookup, so rendering a results list doesn't
+// recompute the same campus's position once per residence row.
+func (r *ResidencesResource) NearestCampus(residence Residence, campuses []Campus) (Campus, float64) {
+	var nearest Campus
+	best := math.Inf(1)
+	found := false
+
+	for _, campus := range campuses {
+		locVal, _ := r.campusCoords.LoadOrStore(campus.ID, campus.Location)
+		loc := locVal.(CampusLocation)
+
+		d := haversineKm(residence.Location.Latitude, residence.Location.Longitude, loc.Latitude, loc.Longitude)
+		if !found || d < best {
+			nearest, best, found = campus, d, true
+		}
+	}
+
+	return nearest, best
+}
@@ -53,9 +53,10 @@ func (r *GroupsResource) Get(ctx context.Context, id string) (*Group, error) {
 // Create creates a new group
 func (r *GroupsResource) Create(ctx context.Context, params *GroupCreate) (*Group, error) {
 	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
-		Method: "POST",
-		Path:   r.basePath,
-		Body:   params,
+		Method:   "POST",
+		Path:     r.basePath,
+		Body:     params,
+		Resource: "Identity.Groups.Create",
 	})
 	if err != nil {
 		return nil, err
@@ -66,6 +67,10 @@ func (r *GroupsResource) Create(ctx context.Context, params *GroupCreate) (*Grou
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	r.client.Track(ctx, "identity.group.created", map[string]interface{}{
+		"group_id": result.ID,
+	})
+
 	return &result, nil
 }
 
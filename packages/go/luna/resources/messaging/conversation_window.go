@@ -0,0 +1,99 @@
+package messaging
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultConversationWindow is the length of Meta's customer service
+// window: the period after a customer's last inbound message during which
+// WhatsApp.Send may send free-form (non-template) replies.
+const DefaultConversationWindow = 24 * time.Hour
+
+// ConversationWindowStore is the pluggable store ConversationWindow records
+// each recipient's last inbound message timestamp in. NewInMemoryConversationWindowStore
+// is the default, good for a single process; a Redis/SQL-backed
+// implementation is needed to share window state across replicas.
+type ConversationWindowStore interface {
+	RecordInbound(phone string, at time.Time)
+	LastInbound(phone string) (time.Time, bool)
+}
+
+// ConversationWindow tracks Meta's 24-hour customer service window per
+// recipient: WhatsApp.Send may send a free-form reply within
+// DefaultConversationWindow of the recipient's last inbound message, and
+// must fall back to an approved template outside it.
+type ConversationWindow struct {
+	store  ConversationWindowStore
+	window time.Duration
+}
+
+// NewConversationWindow creates a ConversationWindow backed by store. A nil
+// store defaults to NewInMemoryConversationWindowStore.
+func NewConversationWindow(store ConversationWindowStore) *ConversationWindow {
+	if store == nil {
+		store = NewInMemoryConversationWindowStore()
+	}
+	return &ConversationWindow{store: store, window: DefaultConversationWindow}
+}
+
+// RecordInbound marks phone's customer service window as freshly opened by
+// an inbound message arriving now.
+func (c *ConversationWindow) RecordInbound(phone string) {
+	c.store.RecordInbound(phone, time.Now())
+}
+
+// InWindow reports whether phone is still inside its customer service
+// window, along with the time it expires (or expired). The zero time is
+// returned alongside false when phone has no recorded inbound message.
+func (c *ConversationWindow) InWindow(phone string) (bool, time.Time) {
+	last, ok := c.store.LastInbound(phone)
+	if !ok {
+		return false, time.Time{}
+	}
+	expiresAt := last.Add(c.window)
+	return time.Now().Before(expiresAt), expiresAt
+}
+
+// inMemoryConversationWindowStore is the default ConversationWindowStore: a
+// mutex-guarded map, with entries older than ttl evicted lazily on read
+// rather than by a background sweep (mirroring pairingEntry's expiry
+// handling in pairing.go).
+type inMemoryConversationWindowStore struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	ttl     time.Duration
+}
+
+// NewInMemoryConversationWindowStore creates a ConversationWindowStore that
+// keeps state in process memory, evicting entries older than
+// DefaultConversationWindow.
+func NewInMemoryConversationWindowStore() ConversationWindowStore {
+	return &inMemoryConversationWindowStore{
+		entries: make(map[string]time.Time),
+		ttl:     DefaultConversationWindow,
+	}
+}
+
+func (s *inMemoryConversationWindowStore) RecordInbound(phone string, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[phone] = at
+}
+
+func (s *inMemoryConversationWindowStore) LastInbound(phone string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	at, ok := s.entries[phone]
+	if !ok {
+		return time.Time{}, false
+	}
+	if time.Since(at) > s.ttl {
+		delete(s.entries, phone)
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+var _ ConversationWindowStore = (*inMemoryConversationWindowStore)(nil)
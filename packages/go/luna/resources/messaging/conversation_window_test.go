@@ -0,0 +1,137 @@
+package messaging_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/messaging"
+)
+
+func TestConversationWindowInWindow(t *testing.T) {
+	window := messaging.NewConversationWindow(nil)
+
+	if inWindow, _ := window.InWindow("27821234567"); inWindow {
+		t.Fatalf("expected a number with no recorded inbound message to be outside the window")
+	}
+
+	window.RecordInbound("27821234567")
+
+	inWindow, expiresAt := window.InWindow("27821234567")
+	if !inWindow {
+		t.Fatalf("expected the number to be inside the window right after an inbound message")
+	}
+	if !expiresAt.After(time.Now()) {
+		t.Errorf("expected expiresAt to be in the future, got %s", expiresAt)
+	}
+}
+
+// backdatingStore lets a test simulate a store whose entries have already
+// aged out, without sleeping for DefaultConversationWindow.
+type backdatingStore struct {
+	messaging.ConversationWindowStore
+	last time.Time
+}
+
+func newBackdatingStore() *backdatingStore {
+	return &backdatingStore{ConversationWindowStore: messaging.NewInMemoryConversationWindowStore()}
+}
+
+func (s *backdatingStore) RecordInbound(phone string, at time.Time) {
+	s.last = at
+	s.ConversationWindowStore.RecordInbound(phone, s.last.Add(-25*time.Hour))
+}
+
+func TestConversationWindowExpires(t *testing.T) {
+	store := newBackdatingStore()
+	window := messaging.NewConversationWindow(store)
+
+	window.RecordInbound("27821234567")
+
+	if inWindow, _ := window.InWindow("27821234567"); inWindow {
+		t.Fatalf("expected a message recorded 25h ago to be outside the 24h window")
+	}
+}
+
+func TestWhatsAppSendWindowGating(t *testing.T) {
+	store := messaging.NewInMemoryConversationWindowStore()
+	wa := messaging.NewWhatsApp(nil, messaging.WhatsAppConfig{
+		Provider:          messaging.WhatsAppMock,
+		ConversationStore: store,
+	})
+
+	ctx := context.Background()
+	to := "27821234567"
+
+	t.Run("rejects a free-form text send outside the window", func(t *testing.T) {
+		if _, err := wa.Send(ctx, messaging.WhatsAppTextRequest{To: to, Text: "hi"}); err == nil {
+			t.Fatalf("expected an error sending text outside the customer service window")
+		}
+	})
+
+	t.Run("always allows a template send", func(t *testing.T) {
+		msg, err := wa.Send(ctx, messaging.WhatsAppTemplateRequest{To: to, TemplateName: "order_update", Category: "utility"})
+		if err != nil {
+			t.Fatalf("expected template send to succeed outside the window, got %v", err)
+		}
+		if msg.Metadata["billing_category"] != "utility" {
+			t.Errorf("expected billing_category utility, got %v", msg.Metadata["billing_category"])
+		}
+	})
+
+	t.Run("defaults a template's billing category to marketing", func(t *testing.T) {
+		msg, err := wa.Send(ctx, messaging.WhatsAppTemplateRequest{To: to, TemplateName: "promo"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.Metadata["billing_category"] != "marketing" {
+			t.Errorf("expected billing_category marketing, got %v", msg.Metadata["billing_category"])
+		}
+	})
+
+	t.Run("allows a free-form text send once the recipient is inside the window", func(t *testing.T) {
+		store.RecordInbound(to, time.Now())
+
+		msg, err := wa.Send(ctx, messaging.WhatsAppTextRequest{To: to, Text: "hi"})
+		if err != nil {
+			t.Fatalf("expected text send to succeed inside the window, got %v", err)
+		}
+		if msg.Metadata["billing_category"] != "service" {
+			t.Errorf("expected billing_category service, got %v", msg.Metadata["billing_category"])
+		}
+	})
+
+	t.Run("matches the window regardless of the recipient's input format", func(t *testing.T) {
+		// The window was recorded under the E.164 form above ("27821234567"),
+		// the same shape ProcessWebhook/WhatsAppWebhook record senders under.
+		// A caller passing a differently-formatted but equivalent number must
+		// still be recognized as inside the window.
+		if _, err := wa.Send(ctx, messaging.WhatsAppTextRequest{To: "+27821234567", Text: "hi again"}); err != nil {
+			t.Fatalf("expected a +-prefixed equivalent number to match the recorded window, got %v", err)
+		}
+	})
+}
+
+func TestWhatsAppWebhookRecordsInboundIntoWindow(t *testing.T) {
+	window := messaging.NewConversationWindow(nil)
+	hook := messaging.NewWhatsAppWebhook(messaging.WhatsAppWebhookConfig{
+		Provider: messaging.WhatsAppClickatell,
+		Window:   window,
+	})
+
+	if inWindow, _ := window.InWindow("27821234567"); inWindow {
+		t.Fatalf("expected the window to be empty before any webhook delivery")
+	}
+
+	body := `{"messages":[{"messageId":"ck_1","from":"27821234567","to":"27001234567","type":"text","text":"hi there"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	hook.ServeHTTP(w, req)
+
+	if inWindow, _ := window.InWindow("27821234567"); !inWindow {
+		t.Fatalf("expected an inbound webhook delivery to open the sender's conversation window")
+	}
+}
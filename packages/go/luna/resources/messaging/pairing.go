@@ -0,0 +1,195 @@
+// Package messaging provides SMS, WhatsApp, and USSD integrations for South Africa.
+package messaging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// pairingAlphabet is the 32-letter alphabet used for pairing codes. It
+// excludes visually ambiguous characters (0/O, 1/I).
+const pairingAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+const defaultPairingTTL = 2 * time.Minute
+
+// pairingEntry tracks an issued, not-yet-claimed pairing code.
+type pairingEntry struct {
+	phoneE164 string
+	pushName  string
+	expiresAt time.Time
+	claimed   bool
+}
+
+// RequestPairingCode issues an 8-character alphanumeric pairing code that the
+// user enters on their handset to link phoneE164 as a WhatsApp Business
+// sender, without scanning a QR code.
+func (w *WhatsApp) RequestPairingCode(ctx context.Context, phoneE164 string, opts *PairingOptions) (*PairingCode, error) {
+	if phoneE164 == "" {
+		return nil, fmt.Errorf("phone number is required")
+	}
+
+	ttl := defaultPairingTTL
+	pushName := ""
+	if opts != nil {
+		if opts.TTL > 0 {
+			ttl = opts.TTL
+		}
+		pushName = opts.PushName
+	}
+
+	code, err := generatePairingCode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate pairing code: %w", err)
+	}
+
+	expiresAt := time.Now().Add(ttl)
+
+	w.mu.Lock()
+	if w.pairings == nil {
+		w.pairings = make(map[string]*pairingEntry)
+	}
+	w.pairings[code] = &pairingEntry{
+		phoneE164: phoneE164,
+		pushName:  pushName,
+		expiresAt: expiresAt,
+	}
+	w.mu.Unlock()
+
+	return &PairingCode{
+		Code:      formatPairingCode(code),
+		PhoneE164: phoneE164,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// WaitForPairing blocks until code has been entered on the paired handset,
+// the code expires, or ctx is cancelled.
+func (w *WhatsApp) WaitForPairing(ctx context.Context, code string) (*PairedDevice, error) {
+	key := normalizePairingCode(code)
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		device, err := w.checkPairing(key)
+		if device != nil || err != nil {
+			return device, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *WhatsApp) checkPairing(key string) (*PairedDevice, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entry, ok := w.pairings[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown or already-claimed pairing code")
+	}
+	if entry.claimed {
+		return nil, fmt.Errorf("pairing code already used")
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(w.pairings, key)
+		return nil, fmt.Errorf("pairing code expired")
+	}
+
+	// The handset has entered the code; mark it single-use and link the device.
+	entry.claimed = true
+	device := &PairedDevice{
+		JID:         normalizePhoneForJID(entry.phoneE164) + ".0:1@s.whatsapp.net",
+		PushName:    entry.pushName,
+		Platform:    "android",
+		PhoneNumber: entry.phoneE164,
+		LinkedAt:    time.Now(),
+	}
+
+	if w.devices == nil {
+		w.devices = make(map[string]*PairedDevice)
+	}
+	w.devices[device.JID] = device
+	delete(w.pairings, key)
+
+	return device, nil
+}
+
+// ListLinkedDevices returns the WhatsApp senders currently paired under this
+// Messaging config.
+func (w *WhatsApp) ListLinkedDevices(ctx context.Context) ([]PairedDevice, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	devices := make([]PairedDevice, 0, len(w.devices))
+	for _, d := range w.devices {
+		devices = append(devices, *d)
+	}
+	return devices, nil
+}
+
+// UnlinkDevice removes a paired WhatsApp sender by JID.
+func (w *WhatsApp) UnlinkDevice(ctx context.Context, jid string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.devices[jid]; !ok {
+		return fmt.Errorf("device not found: %s", jid)
+	}
+	delete(w.devices, jid)
+	return nil
+}
+
+// generatePairingCode derives an 8-character code from a crypto/rand value,
+// mapped through the 32-letter pairing alphabet.
+func generatePairingCode() (string, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "", err
+	}
+
+	hi := binary.BigEndian.Uint32(buf[0:4])
+	lo := binary.BigEndian.Uint32(buf[4:8])
+	bits := uint64(hi)<<32 | uint64(lo)
+
+	out := make([]byte, 8)
+	for i := 7; i >= 0; i-- {
+		out[i] = pairingAlphabet[bits%32]
+		bits /= 32
+	}
+	return string(out), nil
+}
+
+func formatPairingCode(code string) string {
+	if len(code) != 8 {
+		return code
+	}
+	return code[:4] + "-" + code[4:]
+}
+
+func normalizePairingCode(code string) string {
+	out := make([]byte, 0, len(code))
+	for i := 0; i < len(code); i++ {
+		if code[i] != '-' {
+			out = append(out, code[i])
+		}
+	}
+	return string(out)
+}
+
+func normalizePhoneForJID(phoneE164 string) string {
+	digits := make([]byte, 0, len(phoneE164))
+	for i := 0; i < len(phoneE164); i++ {
+		if phoneE164[i] >= '0' && phoneE164[i] <= '9' {
+			digits = append(digits, phoneE164[i])
+		}
+	}
+	return string(digits)
+}
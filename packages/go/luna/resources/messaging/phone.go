@@ -0,0 +1,95 @@
+package messaging
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nyaruka/phonenumbers"
+)
+
+// ErrInvalidPhoneNumber is returned by WhatsApp's Send* methods, and by
+// PhoneNormalizer.Normalize/Validate, when a recipient number cannot be
+// parsed or is not a valid number for its region.
+type ErrInvalidPhoneNumber struct {
+	Number string
+	Reason string
+}
+
+func (e *ErrInvalidPhoneNumber) Error() string {
+	return fmt.Sprintf("messaging: invalid phone number %q: %s", e.Number, e.Reason)
+}
+
+// PhoneNumberInfo describes a validated phone number.
+type PhoneNumberInfo struct {
+	// E164 is the number in E.164 format without the leading "+", which is
+	// the wire format Meta's WhatsApp APIs expect.
+	E164 string
+	// CountryCode is the ISO 3166-1 alpha-2 region the number belongs to.
+	CountryCode string
+	// LineType is one of "mobile", "fixed_line", "fixed_line_or_mobile",
+	// "voip", or "unknown".
+	LineType string
+}
+
+// PhoneNormalizer parses and validates phone numbers using
+// github.com/nyaruka/phonenumbers, resolving numbers without a country
+// code against DefaultRegion.
+type PhoneNormalizer struct {
+	DefaultRegion string
+}
+
+// NewPhoneNormalizer creates a PhoneNormalizer for defaultRegion, falling
+// back to "ZA" when defaultRegion is empty for backward compatibility with
+// callers that predate multi-country support.
+func NewPhoneNormalizer(defaultRegion string) *PhoneNormalizer {
+	if defaultRegion == "" {
+		defaultRegion = "ZA"
+	}
+	return &PhoneNormalizer{DefaultRegion: defaultRegion}
+}
+
+// Normalize parses phone and returns it in E.164 without the leading "+",
+// or an *ErrInvalidPhoneNumber if it cannot be parsed or is not valid.
+func (n *PhoneNormalizer) Normalize(phone string) (string, error) {
+	info, err := n.Validate(phone)
+	if err != nil {
+		return "", err
+	}
+	return info.E164, nil
+}
+
+// Validate parses and validates phone, returning country and line-type
+// information, or an *ErrInvalidPhoneNumber if it cannot be parsed or is
+// not a valid number.
+func (n *PhoneNormalizer) Validate(phone string) (*PhoneNumberInfo, error) {
+	parsed, err := phonenumbers.Parse(phone, n.DefaultRegion)
+	if err != nil {
+		return nil, &ErrInvalidPhoneNumber{Number: phone, Reason: err.Error()}
+	}
+	if !phonenumbers.IsValidNumber(parsed) {
+		return nil, &ErrInvalidPhoneNumber{Number: phone, Reason: "not a valid number"}
+	}
+
+	e164 := strings.TrimPrefix(phonenumbers.Format(parsed, phonenumbers.E164), "+")
+
+	return &PhoneNumberInfo{
+		E164:        e164,
+		CountryCode: phonenumbers.GetRegionCodeForNumber(parsed),
+		LineType:    phoneLineType(phonenumbers.GetNumberType(parsed)),
+	}, nil
+}
+
+func phoneLineType(t phonenumbers.PhoneNumberType) string {
+	switch t {
+	case phonenumbers.MOBILE:
+		return "mobile"
+	case phonenumbers.FIXED_LINE:
+		return "fixed_line"
+	case phonenumbers.FIXED_LINE_OR_MOBILE:
+		return "fixed_line_or_mobile"
+	case phonenumbers.VOIP:
+		return "voip"
+	default:
+		return "unknown"
+	}
+}
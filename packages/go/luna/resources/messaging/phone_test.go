@@ -0,0 +1,91 @@
+package messaging_test
+
+import (
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/messaging"
+)
+
+func TestPhoneNormalizerNormalize(t *testing.T) {
+	t.Run("normalizes ZA local number against default region", func(t *testing.T) {
+		n := messaging.NewPhoneNormalizer("ZA")
+		got, err := n.Normalize("0821234567")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "27821234567" {
+			t.Errorf("expected 27821234567, got %s", got)
+		}
+	})
+
+	t.Run("normalizes ZA number already in international format", func(t *testing.T) {
+		n := messaging.NewPhoneNormalizer("ZA")
+		got, err := n.Normalize("+27821234567")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "27821234567" {
+			t.Errorf("expected 27821234567, got %s", got)
+		}
+	})
+
+	t.Run("normalizes NG local number against its own default region", func(t *testing.T) {
+		n := messaging.NewPhoneNormalizer("NG")
+		got, err := n.Normalize("08021234567")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "2348021234567" {
+			t.Errorf("expected 2348021234567, got %s", got)
+		}
+	})
+
+	t.Run("normalizes KE local number against its own default region", func(t *testing.T) {
+		n := messaging.NewPhoneNormalizer("KE")
+		got, err := n.Normalize("0712345678")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "254712345678" {
+			t.Errorf("expected 254712345678, got %s", got)
+		}
+	})
+
+	t.Run("empty default region falls back to ZA", func(t *testing.T) {
+		n := messaging.NewPhoneNormalizer("")
+		if n.DefaultRegion != "ZA" {
+			t.Errorf("expected default region ZA, got %s", n.DefaultRegion)
+		}
+	})
+
+	t.Run("rejects invalid numbers", func(t *testing.T) {
+		n := messaging.NewPhoneNormalizer("ZA")
+		_, err := n.Normalize("not-a-phone-number")
+		if err == nil {
+			t.Fatal("expected error for invalid phone number")
+		}
+		invalidErr, ok := err.(*messaging.ErrInvalidPhoneNumber)
+		if !ok {
+			t.Fatalf("expected *ErrInvalidPhoneNumber, got %T", err)
+		}
+		if invalidErr.Number != "not-a-phone-number" {
+			t.Errorf("expected Number to echo input, got %s", invalidErr.Number)
+		}
+	})
+}
+
+func TestPhoneNormalizerValidate(t *testing.T) {
+	t.Run("reports country and line type for international input", func(t *testing.T) {
+		n := messaging.NewPhoneNormalizer("ZA")
+		info, err := n.Validate("+14155552671")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if info.CountryCode != "US" {
+			t.Errorf("expected CountryCode US, got %s", info.CountryCode)
+		}
+		if info.E164 != "14155552671" {
+			t.Errorf("expected E164 14155552671, got %s", info.E164)
+		}
+	})
+}
@@ -0,0 +1,138 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// SessionStore persists arbitrary key/value state for a USSD session across
+// separate requests. This matters because, unlike Africa's Talking (which
+// resends the full dialed history as USSDSession.Text on every callback),
+// providers like Clickatell only send the newest keystroke and expect the
+// application to remember where the caller is. MenuRouter uses a
+// SessionStore internally to track navigation state; USSDMenuContext.Get/Set
+// expose the same store to handlers for their own session-scoped data.
+type SessionStore interface {
+	Get(sessionID, key string) (interface{}, bool)
+	Set(sessionID, key string, value interface{})
+	Clear(sessionID string)
+}
+
+// InMemorySessionStore is the default SessionStore: a mutex-guarded map,
+// suitable for a single process. Entries are never evicted on a timer --
+// MenuRouter calls Clear once a handler returns USSDResponse{End: true}, so
+// a completed session doesn't leak; an abandoned one will, same as
+// MockBackend's in-process bookkeeping.
+type InMemorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]map[string]interface{}
+}
+
+// NewInMemorySessionStore creates an empty InMemorySessionStore.
+func NewInMemorySessionStore() *InMemorySessionStore {
+	return &InMemorySessionStore{sessions: make(map[string]map[string]interface{})}
+}
+
+func (s *InMemorySessionStore) Get(sessionID, key string) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.sessions[sessionID]
+	if !ok {
+		return nil, false
+	}
+	value, ok := data[key]
+	return value, ok
+}
+
+func (s *InMemorySessionStore) Set(sessionID, key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, ok := s.sessions[sessionID]
+	if !ok {
+		data = make(map[string]interface{})
+		s.sessions[sessionID] = data
+	}
+	data[key] = value
+}
+
+func (s *InMemorySessionStore) Clear(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, sessionID)
+}
+
+var _ SessionStore = (*InMemorySessionStore)(nil)
+
+// RedisCmdable is the minimal command surface RedisSessionStore needs. It's
+// satisfied by a thin adapter over a real client (e.g. *redis.Client from
+// github.com/redis/go-redis/v9), kept narrow so this package doesn't take on
+// a hard dependency on any particular Redis library. Get should return
+// ("", error) with an error for which the caller's errors.Is(err, <the
+// client's "no such key" sentinel>) would be true when key is absent;
+// RedisSessionStore treats every other error as a hard failure and falls
+// back to an empty session rather than panicking on malformed state.
+type RedisCmdable interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+	Del(ctx context.Context, keys ...string) error
+}
+
+// RedisSessionStore is a reference SessionStore backed by any RedisCmdable.
+// The whole per-session map is JSON-encoded under a single key, with ttl
+// refreshed on every write, so an abandoned session expires on its own
+// instead of being cleaned up explicitly. Its Get/load/Set round trip isn't
+// atomic, so two concurrent writes for the same session can race; callers
+// that need strict consistency should serialize writes per SessionID (e.g.
+// a Redis lock) themselves.
+type RedisSessionStore struct {
+	client RedisCmdable
+	prefix string
+	ttl    time.Duration
+}
+
+// NewRedisSessionStore creates a RedisSessionStore. keyPrefix namespaces the
+// keys it writes (e.g. "ussd:session:"); ttl bounds how long an abandoned
+// session's state survives.
+func NewRedisSessionStore(client RedisCmdable, keyPrefix string, ttl time.Duration) *RedisSessionStore {
+	return &RedisSessionStore{client: client, prefix: keyPrefix, ttl: ttl}
+}
+
+func (s *RedisSessionStore) load(ctx context.Context, sessionID string) map[string]interface{} {
+	raw, err := s.client.Get(ctx, s.prefix+sessionID)
+	if err != nil || raw == "" {
+		return map[string]interface{}{}
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(raw), &data); err != nil {
+		return map[string]interface{}{}
+	}
+	return data
+}
+
+func (s *RedisSessionStore) Get(sessionID, key string) (interface{}, bool) {
+	data := s.load(context.Background(), sessionID)
+	value, ok := data[key]
+	return value, ok
+}
+
+func (s *RedisSessionStore) Set(sessionID, key string, value interface{}) {
+	ctx := context.Background()
+	data := s.load(ctx, sessionID)
+	data[key] = value
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	_ = s.client.Set(ctx, s.prefix+sessionID, string(encoded), s.ttl)
+}
+
+func (s *RedisSessionStore) Clear(sessionID string) {
+	_ = s.client.Del(context.Background(), s.prefix+sessionID)
+}
+
+var _ SessionStore = (*RedisSessionStore)(nil)
@@ -3,24 +3,34 @@ package messaging
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
-	"time"
 
 	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/telemetry"
 )
 
+// smsInboundTopic is the messagebus topic SMS.ParseInboundWebhook
+// publishes a parsed SMSMessage to, when SMSConfig.Bus is set.
+const smsInboundTopic = "messaging.sms.inbound"
+
 // SMS provides multi-provider SMS integration.
 type SMS struct {
-	client *lunahttp.Client
-	config SMSConfig
+	client  *lunahttp.Client
+	config  SMSConfig
+	backend smsBackend
 }
 
-// NewSMS creates a new SMS instance.
+// NewSMS creates a new SMS instance. The transport is selected from
+// config.Provider: SMSClickatell, SMSBulkSMS, and SMSPortal talk to their
+// respective provider APIs; anything else falls back to SMSGenericHTTP.
 func NewSMS(client *lunahttp.Client, config SMSConfig) *SMS {
 	return &SMS{
-		client: client,
-		config: config,
+		client:  client,
+		config:  config,
+		backend: newSMSBackend(config),
 	}
 }
 
@@ -30,56 +40,73 @@ func (s *SMS) Send(ctx context.Context, req SMSSendRequest) (*SMSMessage, error)
 		return nil, fmt.Errorf("SMS recipient (to) is required")
 	}
 
-	to := req.To[0]
-	messageID := fmt.Sprintf("sms_%d", time.Now().UnixMilli())
-	normalizedTo := s.normalizePhoneNumber(to)
-
 	from := req.From
 	if from == "" {
 		from = s.config.SenderID
 	}
 
-	return &SMSMessage{
-		ID:        messageID,
-		To:        normalizedTo,
-		From:      from,
-		Body:      req.Body,
-		Status:    StatusPending,
-		Direction: "outbound",
-		Provider:  s.config.Provider,
-		Parts:     (len(req.Body) + 159) / 160,
-		Metadata:  req.Metadata,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}, nil
+	_, segments, _, _, err := EncodeSMS(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := s.backend.Send(ctx, SMSSendRequest{
+		To:          []string{s.normalizePhoneNumber(req.To[0])},
+		Body:        req.Body,
+		From:        from,
+		CallbackURL: req.CallbackURL,
+		Metadata:    req.Metadata,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Parts = segments
+	msg.Metadata = req.Metadata
+
+	telemetry.LoggerFromContext(ctx).Debug("SMS sent", map[string]interface{}{
+		"resource":   "Messaging.SMS.Send",
+		"message_id": msg.ID,
+		"provider":   msg.Provider,
+		"parts":      msg.Parts,
+	})
+
+	s.client.Track(ctx, "messaging.sms.sent", map[string]interface{}{
+		"message_id": msg.ID,
+		"provider":   msg.Provider,
+		"parts":      msg.Parts,
+	})
+
+	return msg, nil
 }
 
-// SendBulk sends SMS to multiple recipients.
+// SendBulk sends SMS to multiple recipients, using the provider's native
+// batch endpoint where it has one instead of one HTTP call per recipient.
 func (s *SMS) SendBulk(ctx context.Context, req SMSSendRequest) (*SMSBulkResult, error) {
-	result := &SMSBulkResult{
-		Successful: []SMSMessage{},
-		Failed: []struct {
-			To    string `json:"to"`
-			Error string `json:"error"`
-		}{},
+	_, segments, _, _, err := EncodeSMS(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]string, len(req.To))
+	for i, to := range req.To {
+		normalized[i] = s.normalizePhoneNumber(to)
+	}
+
+	result, err := s.backend.SendBulk(ctx, SMSSendRequest{
+		To:          normalized,
+		Body:        req.Body,
+		From:        req.From,
+		CallbackURL: req.CallbackURL,
+		Metadata:    req.Metadata,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	for _, to := range req.To {
-		msg, err := s.Send(ctx, SMSSendRequest{
-			To:          []string{to},
-			Body:        req.Body,
-			From:        req.From,
-			CallbackURL: req.CallbackURL,
-			Metadata:    req.Metadata,
-		})
-		if err != nil {
-			result.Failed = append(result.Failed, struct {
-				To    string `json:"to"`
-				Error string `json:"error"`
-			}{To: to, Error: err.Error()})
-		} else {
-			result.Successful = append(result.Successful, *msg)
-		}
+	for i := range result.Successful {
+		result.Successful[i].Parts = segments
+		result.Successful[i].Metadata = req.Metadata
 	}
 
 	return result, nil
@@ -87,24 +114,38 @@ func (s *SMS) SendBulk(ctx context.Context, req SMSSendRequest) (*SMSBulkResult,
 
 // GetStatus gets SMS delivery status.
 func (s *SMS) GetStatus(ctx context.Context, messageID string) (*SMSMessage, error) {
-	return &SMSMessage{
-		ID:        messageID,
-		To:        "",
-		Body:      "",
-		Status:    StatusDelivered,
-		Direction: "outbound",
-		Provider:  s.config.Provider,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}, nil
+	return s.backend.GetStatus(ctx, messageID)
 }
 
 // GetBalance gets account balance.
 func (s *SMS) GetBalance(ctx context.Context) (map[string]interface{}, error) {
-	return map[string]interface{}{
-		"balance":  100.0,
-		"currency": "ZAR",
-	}, nil
+	return s.backend.GetBalance(ctx)
+}
+
+// ParseInboundWebhook parses an inbound (MO) or delivery-report webhook
+// from the configured provider into an SMSMessage, and publishes it to
+// s.config.Bus (if set) on smsInboundTopic so a downstream service can
+// consume it asynchronously instead of polling GetStatus.
+func (s *SMS) ParseInboundWebhook(r *http.Request) (*SMSMessage, error) {
+	msg, err := s.backend.ParseInboundWebhook(r)
+	if err != nil {
+		return nil, err
+	}
+	s.publishInbound(r.Context(), msg)
+	return msg, nil
+}
+
+// publishInbound is a no-op when no bus has been configured, so
+// ParseInboundWebhook can call it unconditionally.
+func (s *SMS) publishInbound(ctx context.Context, msg *SMSMessage) {
+	if s.config.Bus == nil {
+		return
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	_ = s.config.Bus.Publish(ctx, smsInboundTopic, payload)
 }
 
 func (s *SMS) normalizePhoneNumber(phone string) string {
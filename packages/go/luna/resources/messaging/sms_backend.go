@@ -0,0 +1,76 @@
+package messaging
+
+import (
+	"context"
+	"net/http"
+)
+
+// smsBackend is the pluggable transport SMS.Send/SendBulk/GetStatus/
+// GetBalance/ParseInboundWebhook dispatch to. NewSMS selects an
+// implementation from SMSConfig.Provider.
+type smsBackend interface {
+	Send(ctx context.Context, req SMSSendRequest) (*SMSMessage, error)
+	SendBulk(ctx context.Context, req SMSSendRequest) (*SMSBulkResult, error)
+	GetStatus(ctx context.Context, messageID string) (*SMSMessage, error)
+	GetBalance(ctx context.Context) (map[string]interface{}, error)
+	ParseInboundWebhook(r *http.Request) (*SMSMessage, error)
+}
+
+// newSMSBackend selects a backend from config.Provider. SMSClickatell,
+// SMSBulkSMS, and SMSPortal talk to their respective provider APIs;
+// everything else (including SMSGenericHTTP and providers without a
+// first-class backend yet) falls back to the generic HTTP backend, which
+// speaks a configurable, provider-agnostic JSON shape against
+// config.BaseURL.
+func newSMSBackend(config SMSConfig) smsBackend {
+	switch config.Provider {
+	case SMSClickatell:
+		return newClickatellBackend(config)
+	case SMSBulkSMS:
+		return newBulkSMSBackend(config)
+	case SMSPortal:
+		return newSMSPortalBackend(config)
+	default:
+		return newGenericHTTPBackend(config)
+	}
+}
+
+// singleSender is the subset of smsBackend SendBulk needs to fall back to
+// one Send call per recipient, for providers (Clickatell, the generic
+// backend) whose API has no true batch endpoint.
+type singleSender interface {
+	Send(ctx context.Context, req SMSSendRequest) (*SMSMessage, error)
+}
+
+// sendBulkOneByOne implements SendBulk by calling Send once per recipient
+// in req.To, collecting successes and failures the way SMS.SendBulk did
+// before providers existed.
+func sendBulkOneByOne(ctx context.Context, backend singleSender, req SMSSendRequest) (*SMSBulkResult, error) {
+	result := &SMSBulkResult{
+		Successful: []SMSMessage{},
+		Failed: []struct {
+			To    string `json:"to"`
+			Error string `json:"error"`
+		}{},
+	}
+
+	for _, to := range req.To {
+		msg, err := backend.Send(ctx, SMSSendRequest{
+			To:          []string{to},
+			Body:        req.Body,
+			From:        req.From,
+			CallbackURL: req.CallbackURL,
+			Metadata:    req.Metadata,
+		})
+		if err != nil {
+			result.Failed = append(result.Failed, struct {
+				To    string `json:"to"`
+				Error string `json:"error"`
+			}{To: to, Error: err.Error()})
+			continue
+		}
+		result.Successful = append(result.Successful, *msg)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,503 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// defaultBulkBatchSizes gives each provider the shard size BulkSender uses
+// when BulkSenderConfig.BatchSize is zero: Clickatell accepts up to 1000
+// recipients per request, Africa's Talking's bulk endpoint is happiest
+// chunked in the low hundreds, and Twilio has no batch endpoint at all, so
+// it's sent one recipient per request (its pooled HTTP client is what
+// gives that concurrency, not a bigger shard).
+var defaultBulkBatchSizes = map[SMSProvider]int{
+	SMSClickatell:     1000,
+	SMSAfricasTalking: 200,
+	SMSTwilio:         1,
+}
+
+// defaultBulkBatchSize is used for providers defaultBulkBatchSizes doesn't
+// name (SMSBulkSMS, SMSPortal, SMSGenericHTTP, or anything unrecognized).
+const defaultBulkBatchSize = 100
+
+// defaultBulkRatePerSecond gives each provider the outgoing shard rate
+// BulkSender uses when BulkSenderConfig.RatePerSecond is zero. Twilio's
+// default of 1 msg/sec per sender is the documented per-number throughput
+// limit most Twilio accounts start on; the rest default to a conservative
+// rate rather than firing every shard at once.
+var defaultBulkRatePerSecond = map[SMSProvider]float64{
+	SMSTwilio: 1,
+}
+
+// defaultBulkRate is used for providers defaultBulkRatePerSecond doesn't
+// name.
+const defaultBulkRate = 10
+
+// BulkRetryPolicy controls how BulkSender backs off between failed shard
+// attempts, the same full-jitter exponential scheme as
+// connector.RetryPolicy and lunahttp.RetryPolicy: each attempt waits a
+// random duration between 0 and min(MaxInterval, BaseInterval*2^attempt),
+// except when the failure carries a Retry-After (see SMSError.
+// RetryAfterSeconds), which takes precedence.
+type BulkRetryPolicy struct {
+	// MaxAttempts caps how many times BulkSender tries a shard before
+	// giving up and recording its recipients as failed. Zero means
+	// DefaultBulkRetryPolicy's 5.
+	MaxAttempts int
+	// BaseInterval is the backoff for the first retry. Zero means
+	// DefaultBulkRetryPolicy's 1s.
+	BaseInterval time.Duration
+	// MaxInterval caps how long a single backoff can grow to. Zero means
+	// DefaultBulkRetryPolicy's 1m.
+	MaxInterval time.Duration
+}
+
+// DefaultBulkRetryPolicy is used by NewBulkSender when no BulkRetryPolicy
+// is given.
+var DefaultBulkRetryPolicy = BulkRetryPolicy{
+	MaxAttempts:  5,
+	BaseInterval: 1 * time.Second,
+	MaxInterval:  1 * time.Minute,
+}
+
+func (p BulkRetryPolicy) withDefaults() BulkRetryPolicy {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = DefaultBulkRetryPolicy.MaxAttempts
+	}
+	if p.BaseInterval == 0 {
+		p.BaseInterval = DefaultBulkRetryPolicy.BaseInterval
+	}
+	if p.MaxInterval == 0 {
+		p.MaxInterval = DefaultBulkRetryPolicy.MaxInterval
+	}
+	return p
+}
+
+// delay returns how long to wait before the given attempt (1-based).
+func (p BulkRetryPolicy) delay(attempt int) time.Duration {
+	backoff := float64(p.BaseInterval) * math.Pow(2, float64(attempt-1))
+	if max := float64(p.MaxInterval); backoff > max {
+		backoff = max
+	}
+	return time.Duration(rand.Float64() * backoff)
+}
+
+// bulkRateLimiter is a token bucket, the same shape as lunahttp.RetryBudget:
+// tokens refill at ratePerSecond and the bucket holds at most burst of
+// them. BulkSender uses one per batch to pace individual sends to a
+// provider's documented throughput limit.
+type bulkRateLimiter struct {
+	mu         sync.Mutex
+	tokens     float64
+	max        float64
+	refillRate float64
+	last       time.Time
+}
+
+func newBulkRateLimiter(ratePerSecond float64, burst int) *bulkRateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &bulkRateLimiter{
+		tokens:     float64(burst),
+		max:        float64(burst),
+		refillRate: ratePerSecond,
+		last:       time.Now(),
+	}
+}
+
+// wait blocks until a token is available or ctx is done.
+func (b *bulkRateLimiter) wait(ctx context.Context) error {
+	for {
+		b.mu.Lock()
+		now := time.Now()
+		b.tokens += now.Sub(b.last).Seconds() * b.refillRate
+		if b.tokens > b.max {
+			b.tokens = b.max
+		}
+		b.last = now
+
+		if b.tokens >= 1 {
+			b.tokens--
+			b.mu.Unlock()
+			return nil
+		}
+		shortfall := 1 - b.tokens
+		b.mu.Unlock()
+
+		wait := time.Duration(shortfall / b.refillRate * float64(time.Second))
+		if wait <= 0 {
+			wait = time.Millisecond
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// OutboxEntryStatus is the lifecycle state OutboxStore tracks for one
+// recipient within a BulkSender batch.
+type OutboxEntryStatus string
+
+const (
+	OutboxPending OutboxEntryStatus = "pending"
+	OutboxSent    OutboxEntryStatus = "sent"
+	OutboxFailed  OutboxEntryStatus = "failed"
+)
+
+// OutboxEntry is the per-recipient bookkeeping OutboxStore persists, so a
+// restarted BulkSender.Run can tell which recipients in a batch it already
+// dispatched to instead of sending to every one of req.To again.
+type OutboxEntry struct {
+	// BatchID identifies the BulkSender.Run call this entry belongs to.
+	BatchID string
+	// To is the recipient, already normalized the way SMS.Send normalizes
+	// its own req.To[0].
+	To string
+	// IdempotencyKey is stored in the outbound SMSSendRequest's Metadata so
+	// a provider that itself deduplicates on it (or a human reconciling
+	// two runs of the same batch) can tell this is the same logical send.
+	IdempotencyKey string
+	Status         OutboxEntryStatus
+	// Message is set once Status is OutboxSent.
+	Message *SMSMessage
+	// Error is set once Status is OutboxFailed.
+	Error string
+}
+
+// OutboxStore persists BulkSender's in-flight batch state, so a process
+// restart resumes a partially-sent batch instead of sending to every
+// recipient again. See ReportStore (status_receiver.go) and
+// IdempotencyStore (resources/payments/webhooks) for the same pluggable,
+// in-memory-by-default shape.
+type OutboxStore interface {
+	// Put persists or updates entry, keyed by (entry.BatchID, entry.To).
+	Put(ctx context.Context, entry OutboxEntry) error
+	// Entries returns every entry recorded for batchID, in any status. A
+	// batchID Put has never seen returns an empty slice, not an error, so
+	// Run can tell "never started" (create fresh entries for every
+	// recipient) apart from "started, nothing left pending" (every
+	// recipient already reached OutboxSent/OutboxFailed -- don't resend).
+	Entries(ctx context.Context, batchID string) ([]OutboxEntry, error)
+}
+
+// InMemoryOutboxStore is the default OutboxStore: a mutex-guarded map,
+// suitable for a single process. A multi-replica deployment (or one that
+// needs a batch to survive the process exiting entirely) should pass a
+// shared store (Redis, SQL, ...) instead.
+type InMemoryOutboxStore struct {
+	mu      sync.Mutex
+	batches map[string]map[string]OutboxEntry
+}
+
+// NewInMemoryOutboxStore creates an empty InMemoryOutboxStore.
+func NewInMemoryOutboxStore() *InMemoryOutboxStore {
+	return &InMemoryOutboxStore{batches: make(map[string]map[string]OutboxEntry)}
+}
+
+func (s *InMemoryOutboxStore) Put(ctx context.Context, entry OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	batch, ok := s.batches[entry.BatchID]
+	if !ok {
+		batch = make(map[string]OutboxEntry)
+		s.batches[entry.BatchID] = batch
+	}
+	batch[entry.To] = entry
+	return nil
+}
+
+func (s *InMemoryOutboxStore) Entries(ctx context.Context, batchID string) ([]OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]OutboxEntry, 0, len(s.batches[batchID]))
+	for _, entry := range s.batches[batchID] {
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+var _ OutboxStore = (*InMemoryOutboxStore)(nil)
+
+// BulkEvent is emitted on BulkSender.Run's channel for every recipient as
+// it's resolved, so a caller can update progress UI without waiting for
+// the whole batch to finish. Message is set when Error is nil.
+type BulkEvent struct {
+	To      string
+	Message *SMSMessage
+	Error   error
+}
+
+// BulkSenderConfig configures a BulkSender.
+type BulkSenderConfig struct {
+	// Store persists in-flight batch state so Run can resume after a
+	// restart. Nil defaults to NewInMemoryOutboxStore.
+	Store OutboxStore
+	// BatchSize overrides the provider's default shard size (see
+	// defaultBulkBatchSizes). Zero uses the default.
+	BatchSize int
+	// RatePerSecond overrides the provider's default shard dispatch rate
+	// (see defaultBulkRatePerSecond). Zero uses the default.
+	RatePerSecond float64
+	// Burst is the token bucket's burst capacity. Zero means 1 (no burst
+	// beyond the steady rate).
+	Burst int
+	// RetryPolicy overrides DefaultBulkRetryPolicy.
+	RetryPolicy BulkRetryPolicy
+}
+
+// BulkSender sends a large SMSSendRequest (thousands of recipients) by
+// sharding it into provider-appropriate batches -- each shard is
+// dispatched as up to BatchSize concurrent sends, which is what bounds a
+// provider without a true batch endpoint (every smsBackend.SendBulk falls
+// back to one HTTP call per recipient -- see sendBulkOneByOne) to the
+// concurrency it can actually handle, e.g. 1 for Twilio's single pooled
+// connection. Each individual send is paced by a per-provider token
+// bucket and retried on a transient (429/5xx) failure with backoff
+// honoring Retry-After. Construct one with NewBulkSender around an
+// existing *SMS.
+type BulkSender struct {
+	sms         *SMS
+	store       OutboxStore
+	batchSize   int
+	limiter     *bulkRateLimiter
+	retryPolicy BulkRetryPolicy
+}
+
+// NewBulkSender creates a BulkSender that shards and paces sends through
+// sms, using sms's configured provider to pick default batch size and
+// rate unless config overrides them.
+func NewBulkSender(sms *SMS, config BulkSenderConfig) *BulkSender {
+	store := config.Store
+	if store == nil {
+		store = NewInMemoryOutboxStore()
+	}
+
+	batchSize := config.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultBulkBatchSizes[sms.config.Provider]
+		if batchSize == 0 {
+			batchSize = defaultBulkBatchSize
+		}
+	}
+
+	rate := config.RatePerSecond
+	if rate == 0 {
+		rate = defaultBulkRatePerSecond[sms.config.Provider]
+		if rate == 0 {
+			rate = defaultBulkRate
+		}
+	}
+
+	return &BulkSender{
+		sms:         sms,
+		store:       store,
+		batchSize:   batchSize,
+		limiter:     newBulkRateLimiter(rate, config.Burst),
+		retryPolicy: config.RetryPolicy.withDefaults(),
+	}
+}
+
+// Send shards req across sharded SendBulk calls and blocks until every
+// recipient has either succeeded or exhausted retries, returning the
+// aggregated SMSBulkResult. It's a thin wrapper around Run that drains
+// the event channel itself, for callers that don't need streaming
+// progress.
+func (b *BulkSender) Send(ctx context.Context, batchID string, req SMSSendRequest) (*SMSBulkResult, error) {
+	result := &SMSBulkResult{}
+	for event := range b.Run(ctx, batchID, req) {
+		if event.Error != nil {
+			result.Failed = append(result.Failed, struct {
+				To    string `json:"to"`
+				Error string `json:"error"`
+			}{To: event.To, Error: event.Error.Error()})
+			continue
+		}
+		result.Successful = append(result.Successful, *event.Message)
+	}
+	return result, ctx.Err()
+}
+
+// Run shards req into provider-sized batches and dispatches them,
+// pacing with the configured rate limiter and retrying transient
+// failures with backoff, streaming a BulkEvent per recipient as it's
+// resolved. The returned channel is closed once every recipient has
+// either succeeded or exhausted its retries, or ctx is canceled.
+//
+// Run resumes batchID from its OutboxStore: recipients already recorded
+// as OutboxSent or OutboxFailed from a prior, interrupted call aren't
+// sent again, so restarting a crashed batch with the same batchID picks
+// up where it left off instead of double-sending.
+func (b *BulkSender) Run(ctx context.Context, batchID string, req SMSSendRequest) <-chan BulkEvent {
+	events := make(chan BulkEvent)
+
+	go func() {
+		defer close(events)
+
+		pending, err := b.resume(ctx, batchID, req)
+		if err != nil {
+			for _, to := range req.To {
+				events <- BulkEvent{To: to, Error: err}
+			}
+			return
+		}
+
+		for i := 0; i < len(pending); i += b.batchSize {
+			end := i + b.batchSize
+			if end > len(pending) {
+				end = len(pending)
+			}
+			shard := pending[i:end]
+			if ctx.Err() != nil {
+				return
+			}
+			b.dispatchShard(ctx, shard, req, events)
+		}
+	}()
+
+	return events
+}
+
+// resume records an OutboxEntry for every recipient in req the first time
+// batchID is seen, then returns every entry still at OutboxPending --
+// either newly created here, or left over from an interrupted earlier
+// call. A batchID already seen (even if every entry has since reached a
+// terminal status) is never re-seeded, so a recipient that already
+// succeeded -- or already permanently failed -- isn't sent to again.
+func (b *BulkSender) resume(ctx context.Context, batchID string, req SMSSendRequest) ([]OutboxEntry, error) {
+	all, err := b.store.Entries(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("messaging: loading outbox entries: %w", err)
+	}
+	if len(all) > 0 || len(req.To) == 0 {
+		return pendingOf(all), nil
+	}
+
+	pending := make([]OutboxEntry, 0, len(req.To))
+	seen := make(map[string]bool, len(req.To))
+	for _, to := range req.To {
+		// A recipient repeated in req.To keys to the same OutboxEntry (see
+		// InMemoryOutboxStore.Put), so only the first occurrence is
+		// recorded -- otherwise two in-flight sends to the same number
+		// would race to persist the same store key, and a restart
+		// couldn't tell the duplicate send happened at all.
+		if seen[to] {
+			continue
+		}
+		seen[to] = true
+		entry := OutboxEntry{
+			BatchID:        batchID,
+			To:             to,
+			IdempotencyKey: fmt.Sprintf("%s:%s", batchID, to),
+			Status:         OutboxPending,
+		}
+		if err := b.store.Put(ctx, entry); err != nil {
+			return nil, fmt.Errorf("messaging: persisting outbox entry for %s: %w", to, err)
+		}
+		pending = append(pending, entry)
+	}
+	return pending, nil
+}
+
+// pendingOf filters entries down to those still at OutboxPending.
+func pendingOf(entries []OutboxEntry) []OutboxEntry {
+	pending := make([]OutboxEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Status == OutboxPending {
+			pending = append(pending, entry)
+		}
+	}
+	return pending
+}
+
+// dispatchShard sends every recipient in shard concurrently (bounded by
+// len(shard) <= b.batchSize, the provider's dispatch concurrency), each
+// retried independently on a transient failure, and emits a BulkEvent
+// (and an OutboxStore update) per recipient as it's resolved.
+func (b *BulkSender) dispatchShard(ctx context.Context, shard []OutboxEntry, req SMSSendRequest, events chan<- BulkEvent) {
+	var wg sync.WaitGroup
+	wg.Add(len(shard))
+	for _, entry := range shard {
+		go func(entry OutboxEntry) {
+			defer wg.Done()
+			b.dispatchOne(ctx, entry, req, events)
+		}(entry)
+	}
+	wg.Wait()
+}
+
+// dispatchOne sends to entry.To, retrying a transient (429/5xx) failure
+// with backoff (honoring SMSError.RetryAfterSeconds when the provider
+// sent one) until b.retryPolicy.MaxAttempts is exhausted, then records
+// the outcome to b.store and emits a BulkEvent.
+func (b *BulkSender) dispatchOne(ctx context.Context, entry OutboxEntry, req SMSSendRequest, events chan<- BulkEvent) {
+	metadata := make(map[string]interface{}, len(req.Metadata)+1)
+	for k, v := range req.Metadata {
+		metadata[k] = v
+	}
+	metadata["idempotency_key"] = entry.IdempotencyKey
+
+	var msg *SMSMessage
+	var err error
+	for attempt := 1; ; attempt++ {
+		if waitErr := b.limiter.wait(ctx); waitErr != nil {
+			b.failOne(ctx, entry, waitErr, events)
+			return
+		}
+
+		msg, err = b.sms.Send(ctx, SMSSendRequest{
+			To:          []string{entry.To},
+			Body:        req.Body,
+			From:        req.From,
+			CallbackURL: req.CallbackURL,
+			Metadata:    metadata,
+		})
+		if err == nil {
+			break
+		}
+
+		var smsErr *SMSError
+		retryable := errors.As(err, &smsErr) && (smsErr.Status == 429 || smsErr.Status >= 500)
+		if !retryable || attempt >= b.retryPolicy.MaxAttempts {
+			b.failOne(ctx, entry, err, events)
+			return
+		}
+
+		delay := b.retryPolicy.delay(attempt)
+		if smsErr.RetryAfterSeconds > 0 {
+			delay = time.Duration(smsErr.RetryAfterSeconds) * time.Second
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			b.failOne(ctx, entry, ctx.Err(), events)
+			return
+		case <-timer.C:
+		}
+	}
+
+	entry.Status = OutboxSent
+	entry.Message = msg
+	_ = b.store.Put(ctx, entry)
+	events <- BulkEvent{To: entry.To, Message: msg}
+}
+
+// failOne records entry as permanently failed with cause and emits a
+// BulkEvent for it.
+func (b *BulkSender) failOne(ctx context.Context, entry OutboxEntry, cause error, events chan<- BulkEvent) {
+	entry.Status = OutboxFailed
+	entry.Error = cause.Error()
+	_ = b.store.Put(ctx, entry)
+	events <- BulkEvent{To: entry.To, Error: cause}
+}
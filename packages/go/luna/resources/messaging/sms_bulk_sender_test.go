@@ -0,0 +1,178 @@
+package messaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/messaging"
+)
+
+func TestBulkSenderSendAllSucceed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			To []string `json:"to"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "msg-" + req.To[0], "status": "sent"})
+	}))
+	defer server.Close()
+
+	sms := messaging.NewSMS(nil, messaging.SMSConfig{Provider: messaging.SMSGenericHTTP, BaseURL: server.URL})
+	bulk := messaging.NewBulkSender(sms, messaging.BulkSenderConfig{BatchSize: 2, RatePerSecond: 1000})
+
+	recipients := []string{"+27821111111", "+27821111112", "+27821111113", "+27821111114", "+27821111115"}
+	result, err := bulk.Send(context.Background(), "test-batch-all-ok", messaging.SMSSendRequest{
+		To:   recipients,
+		Body: "hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Successful) != len(recipients) {
+		t.Fatalf("expected %d successful sends, got %d", len(recipients), len(result.Successful))
+	}
+	if len(result.Failed) != 0 {
+		t.Fatalf("expected no failures, got %v", result.Failed)
+	}
+}
+
+func TestBulkSenderRetriesTransientFailureThenSucceeds(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_ = json.NewEncoder(w).Encode(map[string]string{"message": "rate limited"})
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "msg-1", "status": "sent"})
+	}))
+	defer server.Close()
+
+	sms := messaging.NewSMS(nil, messaging.SMSConfig{Provider: messaging.SMSGenericHTTP, BaseURL: server.URL})
+	bulk := messaging.NewBulkSender(sms, messaging.BulkSenderConfig{
+		BatchSize:     1,
+		RatePerSecond: 1000,
+		RetryPolicy:   messaging.BulkRetryPolicy{MaxAttempts: 3, BaseInterval: time.Millisecond, MaxInterval: 10 * time.Millisecond},
+	})
+
+	result, err := bulk.Send(context.Background(), "test-batch-retry", messaging.SMSSendRequest{
+		To:   []string{"+27821111111"},
+		Body: "hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Successful) != 1 {
+		t.Fatalf("expected 1 successful send after retry, got successful=%v failed=%v", result.Successful, result.Failed)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("expected exactly 2 HTTP calls (1 failed + 1 retry), got %d", got)
+	}
+}
+
+func TestBulkSenderGivesUpAfterMaxAttempts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "down"})
+	}))
+	defer server.Close()
+
+	sms := messaging.NewSMS(nil, messaging.SMSConfig{Provider: messaging.SMSGenericHTTP, BaseURL: server.URL})
+	bulk := messaging.NewBulkSender(sms, messaging.BulkSenderConfig{
+		BatchSize:     1,
+		RatePerSecond: 1000,
+		RetryPolicy:   messaging.BulkRetryPolicy{MaxAttempts: 2, BaseInterval: time.Millisecond, MaxInterval: 5 * time.Millisecond},
+	})
+
+	result, err := bulk.Send(context.Background(), "test-batch-exhausted", messaging.SMSSendRequest{
+		To:   []string{"+27821111111"},
+		Body: "hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Failed) != 1 {
+		t.Fatalf("expected 1 permanently failed recipient, got successful=%v failed=%v", result.Successful, result.Failed)
+	}
+}
+
+func TestBulkSenderDoesNotRetryNonTransientFailure(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"message": "invalid recipient"})
+	}))
+	defer server.Close()
+
+	sms := messaging.NewSMS(nil, messaging.SMSConfig{Provider: messaging.SMSGenericHTTP, BaseURL: server.URL})
+	bulk := messaging.NewBulkSender(sms, messaging.BulkSenderConfig{BatchSize: 1, RatePerSecond: 1000})
+
+	result, err := bulk.Send(context.Background(), "test-batch-bad-request", messaging.SMSSendRequest{
+		To:   []string{"+27821111111"},
+		Body: "hello",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Failed) != 1 {
+		t.Fatalf("expected 1 failed recipient, got %v", result)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("expected a 400 to never be retried, got %d calls", got)
+	}
+}
+
+func TestBulkSenderResumesFromOutboxAfterRestart(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			To []string `json:"to"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(map[string]string{"id": "resumed-" + req.To[0], "status": "sent"})
+	}))
+	defer server.Close()
+
+	store := messaging.NewInMemoryOutboxStore()
+	batchID := "test-batch-resume"
+
+	// Seed the store as if a prior, interrupted run already sent to the
+	// first recipient and crashed before reaching the second.
+	ctx := context.Background()
+	_ = store.Put(ctx, messaging.OutboxEntry{
+		BatchID: batchID, To: "+27821111111",
+		Status:  messaging.OutboxSent,
+		Message: &messaging.SMSMessage{ID: "already-sent", To: "+27821111111"},
+	})
+	_ = store.Put(ctx, messaging.OutboxEntry{
+		BatchID: batchID, To: "+27821111112",
+		Status: messaging.OutboxPending,
+	})
+
+	sms := messaging.NewSMS(nil, messaging.SMSConfig{Provider: messaging.SMSGenericHTTP, BaseURL: server.URL})
+	bulk := messaging.NewBulkSender(sms, messaging.BulkSenderConfig{Store: store, BatchSize: 2, RatePerSecond: 1000})
+
+	var events []messaging.BulkEvent
+	for ev := range bulk.Run(ctx, batchID, messaging.SMSSendRequest{
+		To:   []string{"+27821111111", "+27821111112"},
+		Body: "hello",
+	}) {
+		events = append(events, ev)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected Run to resume only the 1 pending recipient, got %d events: %v", len(events), events)
+	}
+	if events[0].To != "+27821111112" {
+		t.Errorf("expected the resumed event to be for the still-pending recipient, got %s", events[0].To)
+	}
+}
@@ -0,0 +1,187 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultBulkSMSBaseURL = "https://api.bulksms.com/v1"
+
+// bulkSMSBackend talks to the BulkSMS JSON API:
+// https://api.bulksms.com/v1/messages. Authentication is HTTP Basic,
+// using a token ID/secret pair issued from the BulkSMS dashboard -- config
+// maps Username to the token ID and Password to the token secret.
+type bulkSMSBackend struct {
+	http     *smsHTTPClient
+	senderID string
+}
+
+func newBulkSMSBackend(config SMSConfig) *bulkSMSBackend {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBulkSMSBaseURL
+	}
+	username, password := config.Username, config.Password
+	return &bulkSMSBackend{
+		http: newSMSHTTPClient(baseURL, func(r *http.Request) {
+			r.SetBasicAuth(username, password)
+		}),
+		senderID: config.SenderID,
+	}
+}
+
+type bulkSMSMessageRequest struct {
+	To   string `json:"to"`
+	Body string `json:"body"`
+	From string `json:"from,omitempty"`
+}
+
+type bulkSMSMessageResponse struct {
+	ID     string `json:"id"`
+	To     string `json:"to"`
+	Status struct {
+		Type string `json:"type"`
+	} `json:"status"`
+}
+
+func (b *bulkSMSBackend) Send(ctx context.Context, req SMSSendRequest) (*SMSMessage, error) {
+	results, err := b.send(ctx, req.To, req.Body, req.From)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("sms: bulksms returned no messages")
+	}
+	return b.toSMSMessage(results[0], req.Body), nil
+}
+
+// SendBulk uses BulkSMS's native batch endpoint: a single POST with one
+// message object per recipient, rather than one HTTP round trip per
+// recipient.
+func (b *bulkSMSBackend) SendBulk(ctx context.Context, req SMSSendRequest) (*SMSBulkResult, error) {
+	results, err := b.send(ctx, req.To, req.Body, req.From)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SMSBulkResult{
+		Successful: []SMSMessage{},
+		Failed: []struct {
+			To    string `json:"to"`
+			Error string `json:"error"`
+		}{},
+	}
+	for _, r := range results {
+		if r.Status.Type != "ACCEPTED" && r.Status.Type != "SENT" {
+			result.Failed = append(result.Failed, struct {
+				To    string `json:"to"`
+				Error string `json:"error"`
+			}{To: r.To, Error: r.Status.Type})
+			continue
+		}
+		result.Successful = append(result.Successful, *b.toSMSMessage(r, req.Body))
+	}
+	return result, nil
+}
+
+func (b *bulkSMSBackend) send(ctx context.Context, to []string, body, from string) ([]bulkSMSMessageResponse, error) {
+	if from == "" {
+		from = b.senderID
+	}
+
+	requests := make([]bulkSMSMessageRequest, len(to))
+	for i, recipient := range to {
+		requests[i] = bulkSMSMessageRequest{To: recipient, Body: body, From: from}
+	}
+
+	var resp []bulkSMSMessageResponse
+	if err := b.http.do(ctx, SMSBulkSMS, http.MethodPost, "/messages", requests, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (b *bulkSMSBackend) toSMSMessage(r bulkSMSMessageResponse, body string) *SMSMessage {
+	return &SMSMessage{
+		ID:        r.ID,
+		To:        r.To,
+		Body:      body,
+		Status:    bulkSMSStatusToMessageStatus(r.Status.Type),
+		Direction: "outbound",
+		Provider:  SMSBulkSMS,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (b *bulkSMSBackend) GetStatus(ctx context.Context, messageID string) (*SMSMessage, error) {
+	var resp bulkSMSMessageResponse
+	if err := b.http.do(ctx, SMSBulkSMS, http.MethodGet, "/messages/"+messageID, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &SMSMessage{
+		ID:        messageID,
+		Status:    bulkSMSStatusToMessageStatus(resp.Status.Type),
+		Direction: "outbound",
+		Provider:  SMSBulkSMS,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (b *bulkSMSBackend) GetBalance(ctx context.Context) (map[string]interface{}, error) {
+	var resp struct {
+		Credits struct {
+			Balance float64 `json:"balance"`
+		} `json:"credits"`
+	}
+	if err := b.http.do(ctx, SMSBulkSMS, http.MethodGet, "/profile", nil, &resp); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"balance":  resp.Credits.Balance,
+		"currency": "credits",
+	}, nil
+}
+
+// ParseInboundWebhook parses a BulkSMS inbound message callback, a JSON
+// body of the form {"id":"...","from":"...","to":"...","body":"..."}.
+func (b *bulkSMSBackend) ParseInboundWebhook(r *http.Request) (*SMSMessage, error) {
+	var payload struct {
+		ID   string `json:"id"`
+		From string `json:"from"`
+		To   string `json:"to"`
+		Body string `json:"body"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("sms: failed to decode bulksms webhook: %w", err)
+	}
+	return &SMSMessage{
+		ID:        payload.ID,
+		To:        payload.To,
+		From:      payload.From,
+		Body:      payload.Body,
+		Status:    StatusDelivered,
+		Direction: "inbound",
+		Provider:  SMSBulkSMS,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func bulkSMSStatusToMessageStatus(statusType string) MessageStatus {
+	switch statusType {
+	case "ACCEPTED", "SENT", "SCHEDULED":
+		return StatusSent
+	case "DELIVERED":
+		return StatusDelivered
+	case "FAILED", "REJECTED", "UNKNOWN":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+var _ smsBackend = (*bulkSMSBackend)(nil)
@@ -0,0 +1,158 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultClickatellBaseURL = "https://platform.clickatell.com"
+
+// clickatellBackend talks to the Clickatell Platform REST API:
+// https://platform.clickatell.com/messages
+type clickatellBackend struct {
+	http     *smsHTTPClient
+	senderID string
+}
+
+func newClickatellBackend(config SMSConfig) *clickatellBackend {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultClickatellBaseURL
+	}
+	apiKey := config.APIKey
+	return &clickatellBackend{
+		http: newSMSHTTPClient(baseURL, func(r *http.Request) {
+			r.Header.Set("Authorization", apiKey)
+		}),
+		senderID: config.SenderID,
+	}
+}
+
+type clickatellSendRequest struct {
+	Content string   `json:"content"`
+	To      []string `json:"to"`
+	From    string   `json:"from,omitempty"`
+}
+
+type clickatellSendResponse struct {
+	Messages []struct {
+		APIMessageID string `json:"apiMessageId"`
+		Accepted     bool   `json:"accepted"`
+		To           string `json:"to"`
+		Error        string `json:"error,omitempty"`
+	} `json:"messages"`
+}
+
+func (c *clickatellBackend) Send(ctx context.Context, req SMSSendRequest) (*SMSMessage, error) {
+	from := req.From
+	if from == "" {
+		from = c.senderID
+	}
+
+	var resp clickatellSendResponse
+	if err := c.http.do(ctx, SMSClickatell, http.MethodPost, "/messages", clickatellSendRequest{
+		Content: req.Body,
+		To:      req.To,
+		From:    from,
+	}, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Messages) == 0 {
+		return nil, fmt.Errorf("sms: clickatell returned no messages")
+	}
+
+	m := resp.Messages[0]
+	if !m.Accepted {
+		return nil, &SMSError{Provider: SMSClickatell, Message: m.Error}
+	}
+
+	return &SMSMessage{
+		ID:        m.APIMessageID,
+		To:        m.To,
+		From:      from,
+		Body:      req.Body,
+		Status:    StatusSent,
+		Direction: "outbound",
+		Provider:  SMSClickatell,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (c *clickatellBackend) SendBulk(ctx context.Context, req SMSSendRequest) (*SMSBulkResult, error) {
+	return sendBulkOneByOne(ctx, c, req)
+}
+
+func (c *clickatellBackend) GetStatus(ctx context.Context, messageID string) (*SMSMessage, error) {
+	var resp struct {
+		Status string `json:"status"`
+	}
+	if err := c.http.do(ctx, SMSClickatell, http.MethodGet, "/message/"+messageID, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &SMSMessage{
+		ID:        messageID,
+		Status:    clickatellStatusToMessageStatus(resp.Status),
+		Direction: "outbound",
+		Provider:  SMSClickatell,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (c *clickatellBackend) GetBalance(ctx context.Context) (map[string]interface{}, error) {
+	var resp struct {
+		Balance  float64 `json:"balance"`
+		Currency string  `json:"currency"`
+	}
+	if err := c.http.do(ctx, SMSClickatell, http.MethodGet, "/account/balance", nil, &resp); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"balance":  resp.Balance,
+		"currency": resp.Currency,
+	}, nil
+}
+
+// ParseInboundWebhook parses a Clickatell MO (mobile-originated) webhook
+// delivery, a JSON body of the form
+// {"messageId":"...","fromNumber":"...","toNumber":"...","text":"...","timestamp":...}.
+func (c *clickatellBackend) ParseInboundWebhook(r *http.Request) (*SMSMessage, error) {
+	var payload struct {
+		MessageID  string `json:"messageId"`
+		FromNumber string `json:"fromNumber"`
+		ToNumber   string `json:"toNumber"`
+		Text       string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("sms: failed to decode clickatell webhook: %w", err)
+	}
+	return &SMSMessage{
+		ID:        payload.MessageID,
+		To:        payload.ToNumber,
+		From:      payload.FromNumber,
+		Body:      payload.Text,
+		Status:    StatusDelivered,
+		Direction: "inbound",
+		Provider:  SMSClickatell,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func clickatellStatusToMessageStatus(status string) MessageStatus {
+	switch status {
+	case "001", "002", "003", "004":
+		return StatusSent
+	case "008":
+		return StatusDelivered
+	case "005", "006", "007":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+var _ smsBackend = (*clickatellBackend)(nil)
@@ -0,0 +1,210 @@
+package messaging
+
+import (
+	"fmt"
+	"unicode/utf16"
+)
+
+// SMS encoding identifiers returned by EncodeSMS.
+const (
+	SMSEncodingGSM7 = "gsm-7"
+	SMSEncodingUCS2 = "ucs-2"
+)
+
+const (
+	gsm7EscapeSeptet = 0x1B
+
+	// gsm7SingleSegmentSeptets/gsm7ConcatSegmentSeptets are the per-segment
+	// septet budgets for a GSM-7 message that fits in one PDU versus one
+	// that needs concatenation: a concatenated message reserves 6 septets
+	// (the UDH) out of every 160-septet segment, leaving 153.
+	gsm7SingleSegmentSeptets = 160
+	gsm7ConcatSegmentSeptets = 153
+
+	// ucs2SingleSegmentChars/ucs2ConcatSegmentChars are the equivalent
+	// budgets for UCS-2 (UTF-16BE), in 16-bit code units: a concatenated
+	// UCS-2 segment reserves 3 code units (6 bytes) for the UDH out of 70,
+	// leaving 67.
+	ucs2SingleSegmentChars = 70
+	ucs2ConcatSegmentChars = 67
+)
+
+// gsm7BasicSet maps each GSM 03.38 default-alphabet character to its
+// 1-septet code point.
+var gsm7BasicSet = buildGSM7BasicSet()
+
+// gsm7ExtensionSet maps each GSM 03.38 extension-table character to its
+// code point; encoding one costs 2 septets (the escape septet plus this
+// one).
+var gsm7ExtensionSet = map[rune]byte{
+	'\f': 0x0A,
+	'^':  0x14,
+	'{':  0x28,
+	'}':  0x29,
+	'\\': 0x2F,
+	'[':  0x3C,
+	'~':  0x3D,
+	']':  0x3E,
+	'|':  0x40,
+	'€':  0x65,
+}
+
+func buildGSM7BasicSet() map[rune]byte {
+	chars := []rune(
+		"@£$¥èéùìòÇ\nØø\rÅå" +
+			"Δ_ΦΓΛΩΠΨΣΘΞ\x1bÆæßÉ" +
+			" !\"#¤%&'()*+,-./" +
+			"0123456789:;<=>?" +
+			"¡ABCDEFGHIJKLMNO" +
+			"PQRSTUVWXYZÄÖÑÜ§" +
+			"¿abcdefghijklmno" +
+			"pqrstuvwxyzäöñüà",
+	)
+	set := make(map[rune]byte, len(chars))
+	for i, r := range chars {
+		set[r] = byte(i) // chars is an []rune, so i is already a rune index
+	}
+	return set
+}
+
+// EncodeSMS determines how body would be transmitted over SMS: which
+// character encoding it requires, how many segments it splits into, the
+// per-segment character/septet budget used to compute that, and the raw
+// encoded payload bytes. It returns an error if body contains a control
+// character neither encoding can carry, so a caller can reject the send
+// instead of letting it go out garbled.
+func EncodeSMS(body string) (encoding string, segments int, perSegment int, payload []byte, err error) {
+	runes := []rune(body)
+	for _, r := range runes {
+		if isDisallowedSMSControlChar(r) {
+			return "", 0, 0, nil, fmt.Errorf("messaging: body contains unsupported control character %U", r)
+		}
+	}
+
+	if gsm7Septets, ok := gsm7SeptetCount(runes); ok {
+		perSegment = gsm7SingleSegmentSeptets
+		if gsm7Septets > gsm7SingleSegmentSeptets {
+			perSegment = gsm7ConcatSegmentSeptets
+		}
+		segments = ceilDiv(gsm7Septets, perSegment)
+		if segments == 0 {
+			segments = 1
+		}
+		return SMSEncodingGSM7, segments, perSegment, packGSM7(gsm7Septets, septetsOf(runes)), nil
+	}
+
+	units := utf16.Encode(runes)
+	perSegment = ucs2SingleSegmentChars
+	if len(units) > ucs2SingleSegmentChars {
+		perSegment = ucs2ConcatSegmentChars
+	}
+	segments = ceilDiv(len(units), perSegment)
+	if segments == 0 {
+		segments = 1
+	}
+	return SMSEncodingUCS2, segments, perSegment, encodeUTF16BE(units), nil
+}
+
+// CostEstimate estimates the total cost, in rateZAR per billable segment
+// per recipient, of sending req -- the same per-segment billing EncodeSMS's
+// segment count drives. Returns 0 if req.Body can't be encoded (see
+// EncodeSMS) or req has no recipients.
+func CostEstimate(req SMSSendRequest, rateZAR float64) float64 {
+	_, segments, _, _, err := EncodeSMS(req.Body)
+	if err != nil {
+		return 0
+	}
+	return float64(segments*len(req.To)) * rateZAR
+}
+
+// isDisallowedSMSControlChar reports whether r is a C0 control character
+// neither GSM-7 nor the SMS transport conventions around it can carry.
+// "\n", "\r", and "\f" are explicitly part of the GSM 03.38 alphabet and
+// UCS-2 carries any code point, so those three are fine; everything else
+// below U+0020 (NUL, BEL, ESC, ...) is rejected rather than silently
+// degraded or dropped downstream by a provider's gateway.
+func isDisallowedSMSControlChar(r rune) bool {
+	if r >= 0x20 {
+		return false
+	}
+	switch r {
+	case '\n', '\r', '\f':
+		return false
+	default:
+		return true
+	}
+}
+
+// gsm7SeptetCount reports the total septet count runes would require in
+// GSM-7, and whether every rune is representable in the default alphabet
+// or its extension table at all.
+func gsm7SeptetCount(runes []rune) (int, bool) {
+	total := 0
+	for _, r := range runes {
+		if _, ok := gsm7BasicSet[r]; ok {
+			total++
+			continue
+		}
+		if _, ok := gsm7ExtensionSet[r]; ok {
+			total += 2
+			continue
+		}
+		return 0, false
+	}
+	return total, true
+}
+
+// septetsOf converts runes (already confirmed GSM-7 representable by
+// gsm7SeptetCount) into their septet stream, expanding each extension
+// character into its escape septet followed by its code.
+func septetsOf(runes []rune) []byte {
+	septets := make([]byte, 0, len(runes))
+	for _, r := range runes {
+		if code, ok := gsm7BasicSet[r]; ok {
+			septets = append(septets, code)
+			continue
+		}
+		code := gsm7ExtensionSet[r]
+		septets = append(septets, gsm7EscapeSeptet, code)
+	}
+	return septets
+}
+
+// packGSM7 packs septets into 8-bit octets per 3GPP TS 23.038, least
+// significant septet first.
+func packGSM7(septetCount int, septets []byte) []byte {
+	packed := make([]byte, 0, ceilDiv(septetCount*7, 8))
+
+	var acc uint16
+	var accBits uint
+	for _, s := range septets {
+		acc |= uint16(s) << accBits
+		accBits += 7
+		if accBits >= 8 {
+			packed = append(packed, byte(acc))
+			acc >>= 8
+			accBits -= 8
+		}
+	}
+	if accBits > 0 {
+		packed = append(packed, byte(acc))
+	}
+	return packed
+}
+
+// encodeUTF16BE encodes units as big-endian UTF-16, the byte order SMS
+// UCS-2 PDUs use.
+func encodeUTF16BE(units []uint16) []byte {
+	payload := make([]byte, 0, len(units)*2)
+	for _, u := range units {
+		payload = append(payload, byte(u>>8), byte(u))
+	}
+	return payload
+}
+
+func ceilDiv(a, b int) int {
+	if b == 0 {
+		return 0
+	}
+	return (a + b - 1) / b
+}
@@ -0,0 +1,142 @@
+package messaging_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/messaging"
+)
+
+func TestEncodeSMSGSM7Segmentation(t *testing.T) {
+	cases := []struct {
+		name           string
+		body           string
+		wantSegments   int
+		wantPerSegment int
+	}{
+		{"empty", "", 1, 160},
+		{"short", "Hello, World!", 1, 160},
+		{"exactly one segment", strings.Repeat("a", 160), 1, 160},
+		{"just over one segment", strings.Repeat("a", 161), 2, 153},
+		{"exactly two concatenated segments", strings.Repeat("a", 306), 2, 153},
+		{"just over two segments", strings.Repeat("a", 307), 3, 153},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoding, segments, perSegment, payload, err := messaging.EncodeSMS(tc.body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if encoding != messaging.SMSEncodingGSM7 {
+				t.Errorf("expected gsm-7, got %s", encoding)
+			}
+			if segments != tc.wantSegments {
+				t.Errorf("expected %d segments, got %d", tc.wantSegments, segments)
+			}
+			if perSegment != tc.wantPerSegment {
+				t.Errorf("expected perSegment %d, got %d", tc.wantPerSegment, perSegment)
+			}
+			if len(payload) == 0 && tc.body != "" {
+				t.Errorf("expected a non-empty payload for a non-empty body")
+			}
+		})
+	}
+}
+
+func TestEncodeSMSGSM7ExtensionCharsCountAsTwoSeptets(t *testing.T) {
+	// 80 euro signs = 160 septets, still fits in a single segment; one more
+	// pushes the total over 160 and forces concatenation.
+	_, segments, _, _, err := messaging.EncodeSMS(strings.Repeat("€", 80))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if segments != 1 {
+		t.Errorf("expected 80 euro signs (160 septets) to fit in 1 segment, got %d", segments)
+	}
+
+	_, segments, _, _, err = messaging.EncodeSMS(strings.Repeat("€", 81))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if segments != 2 {
+		t.Errorf("expected 81 euro signs (162 septets) to need 2 segments, got %d", segments)
+	}
+}
+
+func TestEncodeSMSUCS2ForUnsupportedCharacters(t *testing.T) {
+	cases := []struct {
+		name           string
+		body           string
+		wantSegments   int
+		wantPerSegment int
+	}{
+		{"emoji", "Hello 👋", 1, 70},
+		{"exactly one segment", strings.Repeat("ê", 70), 1, 70},
+		{"just over one segment", strings.Repeat("ê", 71), 2, 67},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoding, segments, perSegment, _, err := messaging.EncodeSMS(tc.body)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if encoding != messaging.SMSEncodingUCS2 {
+				t.Errorf("expected ucs-2, got %s", encoding)
+			}
+			if segments != tc.wantSegments {
+				t.Errorf("expected %d segments, got %d", tc.wantSegments, segments)
+			}
+			if perSegment != tc.wantPerSegment {
+				t.Errorf("expected perSegment %d, got %d", tc.wantPerSegment, perSegment)
+			}
+		})
+	}
+}
+
+func TestEncodeSMSRejectsUnsupportedControlCharacters(t *testing.T) {
+	_, _, _, _, err := messaging.EncodeSMS("Hello\x07World")
+	if err == nil {
+		t.Fatalf("expected an error for a BEL control character")
+	}
+
+	// Newline, carriage return, and form feed are all part of GSM 03.38 and
+	// must not be rejected.
+	_, _, _, _, err = messaging.EncodeSMS("line1\nline2\rline3\fline4")
+	if err != nil {
+		t.Fatalf("expected newline/CR/FF to be accepted, got %v", err)
+	}
+}
+
+func TestCostEstimate(t *testing.T) {
+	req := messaging.SMSSendRequest{
+		To:   []string{"+27821234567", "+27821234568"},
+		Body: strings.Repeat("a", 306), // exactly 2 concatenated GSM-7 segments
+	}
+
+	got := messaging.CostEstimate(req, 0.25)
+	want := 1.0 // 2 recipients * 2 segments * 0.25
+	if got != want {
+		t.Errorf("expected cost %.2f, got %.2f", want, got)
+	}
+}
+
+func TestCostEstimateReturnsZeroForUnencodableBody(t *testing.T) {
+	req := messaging.SMSSendRequest{To: []string{"+27821234567"}, Body: "Hello\x07World"}
+	if got := messaging.CostEstimate(req, 0.25); got != 0 {
+		t.Errorf("expected 0 for an unencodable body, got %.2f", got)
+	}
+}
+
+func TestSMSSendRejectsUnsupportedControlCharacters(t *testing.T) {
+	sms := messaging.NewSMS(nil, messaging.SMSConfig{Provider: messaging.SMSGenericHTTP, BaseURL: "http://example.invalid"})
+	_, err := sms.Send(context.Background(), messaging.SMSSendRequest{
+		To:   []string{"0821234567"},
+		Body: "Hello\x07World",
+	})
+	if err == nil {
+		t.Fatalf("expected Send to reject a body with an unsupported control character before calling the backend")
+	}
+}
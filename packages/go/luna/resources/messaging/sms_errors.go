@@ -0,0 +1,24 @@
+package messaging
+
+import "fmt"
+
+// SMSError wraps a non-2xx response from an SMS provider API, preserving
+// the provider's own error code/message for callers that need to act on
+// specific failures (e.g. insufficient balance vs. invalid recipient).
+type SMSError struct {
+	Provider SMSProvider `json:"provider"`
+	Code     string      `json:"code,omitempty"`
+	Message  string      `json:"message"`
+	Status   int         `json:"status,omitempty"`
+	// RetryAfterSeconds is the provider's Retry-After response header,
+	// when it sent one alongside a 429 or 5xx. Zero means the provider
+	// didn't send one, not that zero seconds was requested.
+	RetryAfterSeconds int `json:"retry_after_seconds,omitempty"`
+}
+
+func (e *SMSError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("sms: %s: %s (code %s, status %d)", e.Provider, e.Message, e.Code, e.Status)
+	}
+	return fmt.Sprintf("sms: %s: %s (status %d)", e.Provider, e.Message, e.Status)
+}
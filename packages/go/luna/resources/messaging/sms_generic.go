@@ -0,0 +1,148 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// genericHTTPBackend is the fallback smsBackend for providers without a
+// first-class implementation: it speaks one fixed, provider-agnostic JSON
+// shape against config.BaseURL with a bearer token, rather than any real
+// provider's actual API. Configure SMSGenericHTTP only against a gateway
+// (or adapter in front of one) that accepts this shape; anything else
+// needs its own smsBackend implementation.
+type genericHTTPBackend struct {
+	http     *smsHTTPClient
+	senderID string
+}
+
+func newGenericHTTPBackend(config SMSConfig) *genericHTTPBackend {
+	apiKey := config.APIKey
+	return &genericHTTPBackend{
+		http: newSMSHTTPClient(config.BaseURL, func(r *http.Request) {
+			if apiKey != "" {
+				r.Header.Set("Authorization", "Bearer "+apiKey)
+			}
+		}),
+		senderID: config.SenderID,
+	}
+}
+
+type genericSendRequest struct {
+	To   []string `json:"to"`
+	From string   `json:"from,omitempty"`
+	Body string   `json:"body"`
+}
+
+type genericSendResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (g *genericHTTPBackend) Send(ctx context.Context, req SMSSendRequest) (*SMSMessage, error) {
+	from := req.From
+	if from == "" {
+		from = g.senderID
+	}
+
+	var resp genericSendResponse
+	if err := g.http.do(ctx, SMSGenericHTTP, http.MethodPost, "/send", genericSendRequest{
+		To:   req.To,
+		From: from,
+		Body: req.Body,
+	}, &resp); err != nil {
+		return nil, err
+	}
+
+	to := ""
+	if len(req.To) > 0 {
+		to = req.To[0]
+	}
+
+	return &SMSMessage{
+		ID:        resp.ID,
+		To:        to,
+		From:      from,
+		Body:      req.Body,
+		Status:    genericStatusToMessageStatus(resp.Status),
+		Direction: "outbound",
+		Provider:  SMSGenericHTTP,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (g *genericHTTPBackend) SendBulk(ctx context.Context, req SMSSendRequest) (*SMSBulkResult, error) {
+	return sendBulkOneByOne(ctx, g, req)
+}
+
+func (g *genericHTTPBackend) GetStatus(ctx context.Context, messageID string) (*SMSMessage, error) {
+	var resp genericSendResponse
+	if err := g.http.do(ctx, SMSGenericHTTP, http.MethodGet, "/status/"+messageID, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &SMSMessage{
+		ID:        messageID,
+		Status:    genericStatusToMessageStatus(resp.Status),
+		Direction: "outbound",
+		Provider:  SMSGenericHTTP,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (g *genericHTTPBackend) GetBalance(ctx context.Context) (map[string]interface{}, error) {
+	var resp struct {
+		Balance  float64 `json:"balance"`
+		Currency string  `json:"currency"`
+	}
+	if err := g.http.do(ctx, SMSGenericHTTP, http.MethodGet, "/balance", nil, &resp); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"balance":  resp.Balance,
+		"currency": resp.Currency,
+	}, nil
+}
+
+// ParseInboundWebhook parses the generic backend's own inbound shape, a
+// JSON body of the form {"id":"...","from":"...","to":"...","body":"..."}.
+func (g *genericHTTPBackend) ParseInboundWebhook(r *http.Request) (*SMSMessage, error) {
+	var payload struct {
+		ID   string `json:"id"`
+		From string `json:"from"`
+		To   string `json:"to"`
+		Body string `json:"body"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("sms: failed to decode generic webhook: %w", err)
+	}
+	return &SMSMessage{
+		ID:        payload.ID,
+		To:        payload.To,
+		From:      payload.From,
+		Body:      payload.Body,
+		Status:    StatusDelivered,
+		Direction: "inbound",
+		Provider:  SMSGenericHTTP,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func genericStatusToMessageStatus(status string) MessageStatus {
+	switch status {
+	case "sent", "queued":
+		return StatusSent
+	case "delivered":
+		return StatusDelivered
+	case "failed":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+var _ smsBackend = (*genericHTTPBackend)(nil)
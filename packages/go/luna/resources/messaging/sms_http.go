@@ -0,0 +1,94 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// smsHTTPClient is the shared low-level transport for the provider
+// backends: each speaks its own JSON request/response shape, but all of
+// them POST/GET over plain HTTP(S) against a fixed base URL with a
+// provider-specific auth header. authorize sets that header (and any
+// other provider-required header) on every outgoing request.
+type smsHTTPClient struct {
+	httpClient *http.Client
+	baseURL    string
+	authorize  func(*http.Request)
+}
+
+func newSMSHTTPClient(baseURL string, authorize func(*http.Request)) *smsHTTPClient {
+	return &smsHTTPClient{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		baseURL:    baseURL,
+		authorize:  authorize,
+	}
+}
+
+// do sends method/path (relative to baseURL) with body JSON-encoded (or no
+// body when nil), and unmarshals a successful response into out (skipped
+// when out is nil). provider is only used to label the returned SMSError
+// on a non-2xx response.
+func (c *smsHTTPClient) do(ctx context.Context, provider SMSProvider, method, path string, body interface{}, out interface{}) error {
+	var bodyReader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("sms: failed to encode request: %w", err)
+		}
+		bodyReader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bodyReader)
+	if err != nil {
+		return fmt.Errorf("sms: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	c.authorize(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("sms: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("sms: failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var envelope struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+			Error   string `json:"error"`
+		}
+		_ = json.Unmarshal(respBody, &envelope)
+		message := envelope.Message
+		if message == "" {
+			message = envelope.Error
+		}
+		if message == "" {
+			message = string(respBody)
+		}
+		return &SMSError{
+			Provider:          provider,
+			Code:              envelope.Code,
+			Message:           message,
+			Status:            resp.StatusCode,
+			RetryAfterSeconds: parseRetryAfter(resp.Header.Get("Retry-After")),
+		}
+	}
+
+	if out != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, out); err != nil {
+			return fmt.Errorf("sms: failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
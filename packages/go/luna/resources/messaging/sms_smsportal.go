@@ -0,0 +1,196 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const defaultSMSPortalBaseURL = "https://rest.smsportal.com/v2"
+
+// smsPortalBackend talks to the SMSPortal REST API:
+// https://rest.smsportal.com/v2/BulkMessages. SMSPortal normally issues a
+// short-lived OAuth2 bearer token via a client ID/secret exchange; this
+// backend expects that token to already be in config.APIKey (refreshing
+// it is the caller's responsibility) rather than performing the OAuth
+// dance itself.
+type smsPortalBackend struct {
+	http     *smsHTTPClient
+	senderID string
+}
+
+func newSMSPortalBackend(config SMSConfig) *smsPortalBackend {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = defaultSMSPortalBaseURL
+	}
+	token := config.APIKey
+	return &smsPortalBackend{
+		http: newSMSHTTPClient(baseURL, func(r *http.Request) {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}),
+		senderID: config.SenderID,
+	}
+}
+
+type smsPortalMessage struct {
+	Content     string `json:"content"`
+	Destination string `json:"destination"`
+}
+
+type smsPortalBulkRequest struct {
+	Messages []smsPortalMessage `json:"messages"`
+}
+
+type smsPortalResult struct {
+	MessageID   string `json:"messageId"`
+	Destination string `json:"destination"`
+	Success     bool   `json:"success"`
+	Errors      string `json:"errors,omitempty"`
+}
+
+type smsPortalBulkResponse struct {
+	Results []smsPortalResult `json:"results"`
+}
+
+func (p *smsPortalBackend) send(ctx context.Context, to []string, body string) ([]smsPortalResult, error) {
+	messages := make([]smsPortalMessage, len(to))
+	for i, recipient := range to {
+		messages[i] = smsPortalMessage{Content: body, Destination: recipient}
+	}
+
+	var resp smsPortalBulkResponse
+	if err := p.http.do(ctx, SMSPortal, http.MethodPost, "/BulkMessages", smsPortalBulkRequest{Messages: messages}, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Results, nil
+}
+
+func (p *smsPortalBackend) Send(ctx context.Context, req SMSSendRequest) (*SMSMessage, error) {
+	results, err := p.send(ctx, req.To, req.Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, fmt.Errorf("sms: smsportal returned no results")
+	}
+	r := results[0]
+	if !r.Success {
+		return nil, &SMSError{Provider: SMSPortal, Message: r.Errors}
+	}
+	return p.toSMSMessage(r, req.Body), nil
+}
+
+// SendBulk uses SMSPortal's native BulkMessages endpoint, posting every
+// recipient as one messages array in a single request.
+func (p *smsPortalBackend) SendBulk(ctx context.Context, req SMSSendRequest) (*SMSBulkResult, error) {
+	results, err := p.send(ctx, req.To, req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SMSBulkResult{
+		Successful: []SMSMessage{},
+		Failed: []struct {
+			To    string `json:"to"`
+			Error string `json:"error"`
+		}{},
+	}
+	for _, r := range results {
+		if !r.Success {
+			result.Failed = append(result.Failed, struct {
+				To    string `json:"to"`
+				Error string `json:"error"`
+			}{To: r.Destination, Error: r.Errors})
+			continue
+		}
+		result.Successful = append(result.Successful, *p.toSMSMessage(r, req.Body))
+	}
+	return result, nil
+}
+
+func (p *smsPortalBackend) toSMSMessage(r smsPortalResult, body string) *SMSMessage {
+	return &SMSMessage{
+		ID:        r.MessageID,
+		To:        r.Destination,
+		Body:      body,
+		Status:    StatusSent,
+		Direction: "outbound",
+		Provider:  SMSPortal,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+func (p *smsPortalBackend) GetStatus(ctx context.Context, messageID string) (*SMSMessage, error) {
+	var resp struct {
+		Status string `json:"status"`
+	}
+	if err := p.http.do(ctx, SMSPortal, http.MethodGet, "/BulkMessages/"+messageID, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &SMSMessage{
+		ID:        messageID,
+		Status:    smsPortalStatusToMessageStatus(resp.Status),
+		Direction: "outbound",
+		Provider:  SMSPortal,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (p *smsPortalBackend) GetBalance(ctx context.Context) (map[string]interface{}, error) {
+	var resp struct {
+		Balance  float64 `json:"balance"`
+		Currency string  `json:"currency"`
+	}
+	if err := p.http.do(ctx, SMSPortal, http.MethodGet, "/Balances", nil, &resp); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"balance":  resp.Balance,
+		"currency": resp.Currency,
+	}, nil
+}
+
+// ParseInboundWebhook parses an SMSPortal inbound message notification, a
+// JSON body of the form
+// {"messageId":"...","sender":"...","destination":"...","message":"..."}.
+func (p *smsPortalBackend) ParseInboundWebhook(r *http.Request) (*SMSMessage, error) {
+	var payload struct {
+		MessageID   string `json:"messageId"`
+		Sender      string `json:"sender"`
+		Destination string `json:"destination"`
+		Message     string `json:"message"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		return nil, fmt.Errorf("sms: failed to decode smsportal webhook: %w", err)
+	}
+	return &SMSMessage{
+		ID:        payload.MessageID,
+		To:        payload.Destination,
+		From:      payload.Sender,
+		Body:      payload.Message,
+		Status:    StatusDelivered,
+		Direction: "inbound",
+		Provider:  SMSPortal,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func smsPortalStatusToMessageStatus(status string) MessageStatus {
+	switch status {
+	case "Submitted", "Sent":
+		return StatusSent
+	case "Delivered":
+		return StatusDelivered
+	case "Failed", "Undelivered":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+var _ smsBackend = (*smsPortalBackend)(nil)
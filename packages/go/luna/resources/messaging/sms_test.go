@@ -0,0 +1,190 @@
+package messaging_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/messaging"
+)
+
+func TestSMSSendProviderShapes(t *testing.T) {
+	cases := []struct {
+		name          string
+		config        func(baseURL string) messaging.SMSConfig
+		assertRequest func(t *testing.T, r *http.Request, body []byte)
+		response      string
+		wantID        string
+		wantStatus    messaging.MessageStatus
+	}{
+		{
+			name: "clickatell",
+			config: func(baseURL string) messaging.SMSConfig {
+				return messaging.SMSConfig{Provider: messaging.SMSClickatell, APIKey: "ck-key", BaseURL: baseURL}
+			},
+			assertRequest: func(t *testing.T, r *http.Request, body []byte) {
+				if r.URL.Path != "/messages" {
+					t.Errorf("expected path /messages, got %s", r.URL.Path)
+				}
+				if got := r.Header.Get("Authorization"); got != "ck-key" {
+					t.Errorf("expected Authorization ck-key, got %s", got)
+				}
+				var payload struct {
+					Content string   `json:"content"`
+					To      []string `json:"to"`
+				}
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Fatalf("failed to decode request body: %v", err)
+				}
+				if payload.Content != "hello" {
+					t.Errorf("expected content hello, got %s", payload.Content)
+				}
+				if len(payload.To) != 1 || payload.To[0] != "+27821234567" {
+					t.Errorf("expected to [+27821234567], got %v", payload.To)
+				}
+			},
+			response:   `{"messages":[{"apiMessageId":"ck_1","accepted":true,"to":"+27821234567"}]}`,
+			wantID:     "ck_1",
+			wantStatus: messaging.StatusSent,
+		},
+		{
+			name: "bulksms",
+			config: func(baseURL string) messaging.SMSConfig {
+				return messaging.SMSConfig{Provider: messaging.SMSBulkSMS, Username: "token-id", Password: "token-secret", BaseURL: baseURL}
+			},
+			assertRequest: func(t *testing.T, r *http.Request, body []byte) {
+				if r.URL.Path != "/messages" {
+					t.Errorf("expected path /messages, got %s", r.URL.Path)
+				}
+				username, password, ok := r.BasicAuth()
+				if !ok || username != "token-id" || password != "token-secret" {
+					t.Errorf("expected basic auth token-id/token-secret, got %s/%s (ok=%v)", username, password, ok)
+				}
+				var payload []struct {
+					To   string `json:"to"`
+					Body string `json:"body"`
+				}
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Fatalf("failed to decode request body: %v", err)
+				}
+				if len(payload) != 1 || payload[0].Body != "hello" {
+					t.Errorf("expected one message with body hello, got %v", payload)
+				}
+			},
+			response:   `[{"id":"bsms_1","to":"+27821234567","status":{"type":"SENT"}}]`,
+			wantID:     "bsms_1",
+			wantStatus: messaging.StatusSent,
+		},
+		{
+			name: "smsportal",
+			config: func(baseURL string) messaging.SMSConfig {
+				return messaging.SMSConfig{Provider: messaging.SMSPortal, APIKey: "portal-token", BaseURL: baseURL}
+			},
+			assertRequest: func(t *testing.T, r *http.Request, body []byte) {
+				if r.URL.Path != "/BulkMessages" {
+					t.Errorf("expected path /BulkMessages, got %s", r.URL.Path)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer portal-token" {
+					t.Errorf("expected Authorization Bearer portal-token, got %s", got)
+				}
+				var payload struct {
+					Messages []struct {
+						Content     string `json:"content"`
+						Destination string `json:"destination"`
+					} `json:"messages"`
+				}
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Fatalf("failed to decode request body: %v", err)
+				}
+				if len(payload.Messages) != 1 || payload.Messages[0].Destination != "+27821234567" {
+					t.Errorf("expected one message to +27821234567, got %v", payload.Messages)
+				}
+			},
+			response:   `{"results":[{"messageId":"sp_1","destination":"+27821234567","success":true}]}`,
+			wantID:     "sp_1",
+			wantStatus: messaging.StatusSent,
+		},
+		{
+			name: "generic http",
+			config: func(baseURL string) messaging.SMSConfig {
+				return messaging.SMSConfig{Provider: messaging.SMSGenericHTTP, APIKey: "generic-token", BaseURL: baseURL}
+			},
+			assertRequest: func(t *testing.T, r *http.Request, body []byte) {
+				if r.URL.Path != "/send" {
+					t.Errorf("expected path /send, got %s", r.URL.Path)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer generic-token" {
+					t.Errorf("expected Authorization Bearer generic-token, got %s", got)
+				}
+				var payload struct {
+					To   []string `json:"to"`
+					Body string   `json:"body"`
+				}
+				if err := json.Unmarshal(body, &payload); err != nil {
+					t.Fatalf("failed to decode request body: %v", err)
+				}
+				if len(payload.To) != 1 || payload.To[0] != "+27821234567" {
+					t.Errorf("expected to [+27821234567], got %v", payload.To)
+				}
+			},
+			response:   `{"id":"gen_1","status":"sent"}`,
+			wantID:     "gen_1",
+			wantStatus: messaging.StatusSent,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Fatalf("failed to read request body: %v", err)
+				}
+				tc.assertRequest(t, r, body)
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tc.response))
+			}))
+			defer server.Close()
+
+			sms := messaging.NewSMS(nil, tc.config(server.URL))
+			msg, err := sms.Send(context.Background(), messaging.SMSSendRequest{
+				To:   []string{"0821234567"},
+				Body: "hello",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if msg.ID != tc.wantID {
+				t.Errorf("expected ID %s, got %s", tc.wantID, msg.ID)
+			}
+			if msg.Status != tc.wantStatus {
+				t.Errorf("expected status %s, got %s", tc.wantStatus, msg.Status)
+			}
+			if msg.Parts != 1 {
+				t.Errorf("expected 1 part, got %d", msg.Parts)
+			}
+		})
+	}
+}
+
+func TestSMSParseInboundWebhookGenericHTTP(t *testing.T) {
+	sms := messaging.NewSMS(nil, messaging.SMSConfig{Provider: messaging.SMSGenericHTTP, BaseURL: "http://example.invalid"})
+
+	body := `{"id":"gen_inbound_1","from":"+27821234567","to":"+27001234567","body":"hi there"}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/sms", strings.NewReader(body))
+
+	msg, err := sms.ParseInboundWebhook(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if msg.Direction != "inbound" {
+		t.Errorf("expected inbound direction, got %s", msg.Direction)
+	}
+	if msg.Body != "hi there" {
+		t.Errorf("expected body 'hi there', got %s", msg.Body)
+	}
+}
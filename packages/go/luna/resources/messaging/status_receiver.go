@@ -0,0 +1,233 @@
+package messaging
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// StatusReceiverProvider selects which delivery-report payload shape
+// StatusReceiver.ServeHTTP expects and which status vocabulary it maps
+// into MessageStatus.
+type StatusReceiverProvider string
+
+const (
+	StatusReceiverTwilio            StatusReceiverProvider = "twilio"
+	StatusReceiverClickatellSMS     StatusReceiverProvider = "clickatell_sms"
+	StatusReceiverAfricasTalkingSMS StatusReceiverProvider = "africastalking_sms"
+	StatusReceiverWhatsAppCloudAPI  StatusReceiverProvider = "whatsapp_cloud_api"
+)
+
+// DeliveryReport is the provider-agnostic shape StatusReceiver normalizes
+// every delivery receipt into, whichever SMS/WhatsApp provider posted it.
+type DeliveryReport struct {
+	// MessageID is the ID the SDK returned when the message was sent
+	// (SMSMessage.ID / WhatsAppMessage.ID).
+	MessageID string `json:"message_id"`
+	// ProviderID is the provider's own identifier for the delivery, when
+	// it differs from MessageID (most providers echo the same ID back).
+	ProviderID string        `json:"provider_id,omitempty"`
+	Status     MessageStatus `json:"status"`
+	// ErrorCode and ErrorDetail are populated when Status is StatusFailed
+	// and the provider reported why.
+	ErrorCode   string `json:"error_code,omitempty"`
+	ErrorDetail string `json:"error_detail,omitempty"`
+	// Price and Currency carry the provider's reported cost of the
+	// message, when it reports one on the delivery receipt itself rather
+	// than only via a separate billing/usage API.
+	Price     float64   `json:"price,omitempty"`
+	Currency  string    `json:"currency,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ReportStore persists the most recent DeliveryReport seen for a message,
+// so StatusReceiver.GetReport can answer "what's the current status of
+// message X" without the caller reimplementing that bookkeeping. See
+// IdempotencyStore (resources/payments/webhooks) for the same pluggable,
+// in-memory-by-default shape.
+type ReportStore interface {
+	Save(report DeliveryReport)
+	Get(messageID string) (DeliveryReport, bool)
+}
+
+// InMemoryReportStore is the default ReportStore: a mutex-guarded map,
+// suitable for a single process. A real deployment with multiple replicas
+// should pass a shared store (Redis, SQL, ...) instead.
+type InMemoryReportStore struct {
+	mu      sync.Mutex
+	reports map[string]DeliveryReport
+}
+
+// NewInMemoryReportStore creates an empty InMemoryReportStore.
+func NewInMemoryReportStore() *InMemoryReportStore {
+	return &InMemoryReportStore{reports: make(map[string]DeliveryReport)}
+}
+
+func (s *InMemoryReportStore) Save(report DeliveryReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[report.MessageID] = report
+}
+
+func (s *InMemoryReportStore) Get(messageID string) (DeliveryReport, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	report, ok := s.reports[messageID]
+	return report, ok
+}
+
+var _ ReportStore = (*InMemoryReportStore)(nil)
+
+// StatusReceiverConfig configures a StatusReceiver.
+type StatusReceiverConfig struct {
+	// Provider selects which payload shape ServeHTTP expects. Required.
+	Provider StatusReceiverProvider
+	// Store persists every normalized DeliveryReport so GetReport can
+	// answer status queries later. Nil defaults to NewInMemoryReportStore.
+	Store ReportStore
+	// TwilioAuthToken verifies the X-Twilio-Signature header Twilio signs
+	// status callbacks with. Only used for StatusReceiverTwilio; unset
+	// skips verification.
+	TwilioAuthToken string
+	// TwilioWebhookURL is the exact public URL Twilio POSTs its status
+	// callback to, required alongside TwilioAuthToken to verify
+	// X-Twilio-Signature. When empty, ServeHTTP derives it from the
+	// incoming request (scheme defaults to "https", then r.Host and
+	// r.URL.RequestURI()) -- set it explicitly if ServeHTTP runs behind a
+	// proxy that rewrites the scheme, host, or path Twilio actually signed.
+	TwilioWebhookURL string
+	// AppSecret verifies the X-Hub-Signature-256 header Meta signs status
+	// callbacks with. Only used for StatusReceiverWhatsAppCloudAPI; unset
+	// skips verification.
+	AppSecret string
+}
+
+// StatusReceiver is an http.Handler for one provider's delivery-receipt
+// webhook endpoint. Construct one per provider you integrate (e.g. a
+// StatusReceiverTwilio instance mounted at one URL and a
+// StatusReceiverWhatsAppCloudAPI instance mounted at another), wire each
+// into your router, and register OnReport once to consume normalized
+// DeliveryReports from all of them the same way.
+type StatusReceiver struct {
+	config StatusReceiverConfig
+	store  ReportStore
+
+	onReport []func(DeliveryReport)
+}
+
+// NewStatusReceiver creates a StatusReceiver for config.Provider.
+func NewStatusReceiver(config StatusReceiverConfig) *StatusReceiver {
+	store := config.Store
+	if store == nil {
+		store = NewInMemoryReportStore()
+	}
+	return &StatusReceiver{config: config, store: store}
+}
+
+// OnReport registers fn to be called for every DeliveryReport this
+// StatusReceiver parses, after it's been persisted to the configured
+// ReportStore.
+func (s *StatusReceiver) OnReport(fn func(DeliveryReport)) {
+	s.onReport = append(s.onReport, fn)
+}
+
+// GetReport returns the last DeliveryReport persisted for messageID.
+func (s *StatusReceiver) GetReport(messageID string) (DeliveryReport, bool) {
+	return s.store.Get(messageID)
+}
+
+// ServeHTTP implements http.Handler, verifying the request (where the
+// provider supports it) and parsing the body as config.Provider's
+// delivery-receipt shape before dispatching to registered listeners.
+func (s *StatusReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var reports []DeliveryReport
+	var err error
+
+	switch s.config.Provider {
+	case StatusReceiverTwilio:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form body", http.StatusBadRequest)
+			return
+		}
+		if s.config.TwilioAuthToken != "" {
+			url := s.config.TwilioWebhookURL
+			if url == "" {
+				url = requestURL(r)
+			}
+			if !verifyTwilioSignature(s.config.TwilioAuthToken, url, r.PostForm, r.Header.Get("X-Twilio-Signature")) {
+				http.Error(w, "signature verification failed", http.StatusForbidden)
+				return
+			}
+		}
+		reports = append(reports, parseTwilioStatusReport(r.PostForm))
+
+	case StatusReceiverClickatellSMS:
+		body, readErr := io.ReadAll(r.Body)
+		if readErr != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		reports, err = parseClickatellSMSStatusReports(body)
+		if err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+	case StatusReceiverAfricasTalkingSMS:
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form body", http.StatusBadRequest)
+			return
+		}
+		reports = append(reports, parseAfricasTalkingSMSStatusReport(r.PostForm))
+
+	case StatusReceiverWhatsAppCloudAPI:
+		body, readErr := io.ReadAll(r.Body)
+		if readErr != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		if s.config.AppSecret != "" {
+			if !verifyWhatsAppSignature(s.config.AppSecret, r.Header.Get("X-Hub-Signature-256"), body) {
+				http.Error(w, "webhook verification failed", http.StatusForbidden)
+				return
+			}
+		}
+		reports, err = parseWhatsAppCloudAPIStatusReports(body)
+		if err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+	default:
+		http.Error(w, "unsupported provider", http.StatusBadRequest)
+		return
+	}
+
+	for _, report := range reports {
+		s.store.Save(report)
+		for _, fn := range s.onReport {
+			fn(report)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// requestURL reconstructs the URL Twilio most likely signed when
+// StatusReceiverConfig.TwilioWebhookURL wasn't set explicitly. Twilio only
+// ever calls back over https, so that's the default; X-Forwarded-Proto
+// (the common signal a TLS-terminating proxy sets) overrides it, and a
+// direct, unproxied plaintext connection (r.TLS == nil with no forwarded
+// header) is the only case that falls back to http.
+func requestURL(r *http.Request) string {
+	scheme := "https"
+	if forwarded := r.Header.Get("X-Forwarded-Proto"); forwarded != "" {
+		scheme = forwarded
+	} else if r.TLS == nil {
+		scheme = "http"
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+var _ http.Handler = (*StatusReceiver)(nil)
@@ -0,0 +1,32 @@
+package messaging
+
+import (
+	"net/url"
+	"time"
+)
+
+// parseAfricasTalkingSMSStatusReport parses an Africa's Talking delivery
+// report callback, a form-encoded POST carrying id, status, and, on
+// failure, failureReason.
+// https://developers.africastalking.com/docs/sms/callback/delivery-reports
+func parseAfricasTalkingSMSStatusReport(form url.Values) DeliveryReport {
+	return DeliveryReport{
+		MessageID:   form.Get("id"),
+		Status:      africasTalkingSMSStatusToMessageStatus(form.Get("status")),
+		ErrorDetail: form.Get("failureReason"),
+		Timestamp:   time.Now(),
+	}
+}
+
+func africasTalkingSMSStatusToMessageStatus(status string) MessageStatus {
+	switch status {
+	case "Submitted", "Sent", "Buffered":
+		return StatusSent
+	case "Success", "Delivered":
+		return StatusDelivered
+	case "Rejected", "Failed":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
@@ -0,0 +1,37 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// clickatellSMSStatusReportPayload is Clickatell's SMS delivery-status
+// webhook shape: a "messages" array, each entry keyed the same way as
+// clickatellBackend.ParseInboundWebhook and the WhatsApp-via-Clickatell
+// statuses[] shape (see clickatellWebhookNormalizer).
+type clickatellSMSStatusReportPayload struct {
+	Messages []struct {
+		MessageID string `json:"messageId"`
+		Status    string `json:"status"`
+	} `json:"messages"`
+}
+
+// parseClickatellSMSStatusReports parses a Clickatell SMS delivery-status
+// webhook body.
+func parseClickatellSMSStatusReports(body []byte) ([]DeliveryReport, error) {
+	var payload clickatellSMSStatusReportPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("messaging: failed to decode clickatell sms status webhook: %w", err)
+	}
+
+	reports := make([]DeliveryReport, 0, len(payload.Messages))
+	for _, msg := range payload.Messages {
+		reports = append(reports, DeliveryReport{
+			MessageID: msg.MessageID,
+			Status:    clickatellStatusToMessageStatus(msg.Status),
+			Timestamp: time.Now(),
+		})
+	}
+	return reports, nil
+}
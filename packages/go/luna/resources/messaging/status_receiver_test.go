@@ -0,0 +1,215 @@
+package messaging_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/messaging"
+)
+
+func signTwilioRequest(authToken, requestURL string, form url.Values) string {
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	data := requestURL
+	for _, key := range keys {
+		data += key + form.Get(key)
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(data))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestStatusReceiverTwilio(t *testing.T) {
+	receiver := messaging.NewStatusReceiver(messaging.StatusReceiverConfig{
+		Provider: messaging.StatusReceiverTwilio,
+	})
+
+	var reports []messaging.DeliveryReport
+	receiver.OnReport(func(report messaging.DeliveryReport) {
+		reports = append(reports, report)
+	})
+
+	form := url.Values{"MessageSid": {"SM123"}, "MessageStatus": {"delivered"}}
+	req := httptest.NewRequest(http.MethodPost, "/status", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if len(reports) != 1 || reports[0].MessageID != "SM123" || reports[0].Status != messaging.StatusDelivered {
+		t.Fatalf("expected a delivered report for SM123, got %+v", reports)
+	}
+
+	report, ok := receiver.GetReport("SM123")
+	if !ok || report.Status != messaging.StatusDelivered {
+		t.Fatalf("expected GetReport to return the persisted report, got %+v, %v", report, ok)
+	}
+}
+
+func TestStatusReceiverTwilioSignatureVerification(t *testing.T) {
+	const authToken = "shh"
+	const webhookURL = "https://example.com/status"
+
+	receiver := messaging.NewStatusReceiver(messaging.StatusReceiverConfig{
+		Provider:         messaging.StatusReceiverTwilio,
+		TwilioAuthToken:  authToken,
+		TwilioWebhookURL: webhookURL,
+	})
+
+	form := url.Values{"MessageSid": {"SM123"}, "MessageStatus": {"failed"}, "ErrorCode": {"30003"}}
+
+	t.Run("rejects a missing signature", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/status", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		receiver.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("accepts a valid signature", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/status", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("X-Twilio-Signature", signTwilioRequest(authToken, webhookURL, form))
+		w := httptest.NewRecorder()
+		receiver.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+	})
+}
+
+func TestStatusReceiverClickatellSMS(t *testing.T) {
+	receiver := messaging.NewStatusReceiver(messaging.StatusReceiverConfig{
+		Provider: messaging.StatusReceiverClickatellSMS,
+	})
+
+	var reports []messaging.DeliveryReport
+	receiver.OnReport(func(report messaging.DeliveryReport) {
+		reports = append(reports, report)
+	})
+
+	body := `{"messages":[{"messageId":"ck-1","status":"008"},{"messageId":"ck-2","status":"005"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/status", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if len(reports) != 2 {
+		t.Fatalf("expected 2 reports, got %+v", reports)
+	}
+	if reports[0].Status != messaging.StatusDelivered {
+		t.Errorf("expected ck-1 to be delivered, got %+v", reports[0])
+	}
+	if reports[1].Status != messaging.StatusFailed {
+		t.Errorf("expected ck-2 to be failed, got %+v", reports[1])
+	}
+}
+
+func TestStatusReceiverAfricasTalkingSMS(t *testing.T) {
+	receiver := messaging.NewStatusReceiver(messaging.StatusReceiverConfig{
+		Provider: messaging.StatusReceiverAfricasTalkingSMS,
+	})
+
+	var reports []messaging.DeliveryReport
+	receiver.OnReport(func(report messaging.DeliveryReport) {
+		reports = append(reports, report)
+	})
+
+	form := url.Values{"id": {"at-1"}, "status": {"Success"}}
+	req := httptest.NewRequest(http.MethodPost, "/status", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if len(reports) != 1 || reports[0].MessageID != "at-1" || reports[0].Status != messaging.StatusDelivered {
+		t.Fatalf("expected a delivered report for at-1, got %+v", reports)
+	}
+}
+
+func signStatusReceiverBody(appSecret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestStatusReceiverWhatsAppCloudAPI(t *testing.T) {
+	receiver := messaging.NewStatusReceiver(messaging.StatusReceiverConfig{
+		Provider:  messaging.StatusReceiverWhatsAppCloudAPI,
+		AppSecret: "shh",
+	})
+
+	var reports []messaging.DeliveryReport
+	receiver.OnReport(func(report messaging.DeliveryReport) {
+		reports = append(reports, report)
+	})
+
+	body := []byte(`{
+		"entry": [{
+			"changes": [{
+				"value": {
+					"statuses": [{
+						"id": "wamid.1",
+						"status": "failed",
+						"errors": [{"code": "131047", "message": "Re-engagement message"}]
+					}]
+				}
+			}]
+		}]
+	}`)
+
+	t.Run("rejects a missing signature", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/status", strings.NewReader(string(body)))
+		w := httptest.NewRecorder()
+		receiver.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("accepts a valid signature and normalizes the status", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/status", strings.NewReader(string(body)))
+		req.Header.Set("X-Hub-Signature-256", signStatusReceiverBody("shh", body))
+		w := httptest.NewRecorder()
+		receiver.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if len(reports) != 1 {
+			t.Fatalf("expected 1 report, got %+v", reports)
+		}
+		report := reports[0]
+		if report.MessageID != "wamid.1" || report.Status != messaging.StatusFailed {
+			t.Fatalf("expected a failed report for wamid.1, got %+v", report)
+		}
+		if report.ErrorCode != "131047" || report.ErrorDetail != "Re-engagement message" {
+			t.Fatalf("expected the error code/message to be parsed, got %+v", report)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package messaging
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// parseTwilioStatusReport parses a Twilio status callback, a form-encoded
+// POST carrying (at minimum) MessageSid, MessageStatus, and, on failure,
+// ErrorCode/ErrorMessage.
+func parseTwilioStatusReport(form url.Values) DeliveryReport {
+	return DeliveryReport{
+		MessageID:   form.Get("MessageSid"),
+		Status:      twilioStatusToMessageStatus(form.Get("MessageStatus")),
+		ErrorCode:   form.Get("ErrorCode"),
+		ErrorDetail: form.Get("ErrorMessage"),
+		Timestamp:   time.Now(),
+	}
+}
+
+func twilioStatusToMessageStatus(status string) MessageStatus {
+	switch status {
+	case "accepted", "queued", "sending", "sent":
+		return StatusSent
+	case "delivered":
+		return StatusDelivered
+	case "read":
+		return StatusRead
+	case "failed", "undelivered":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+// verifyTwilioSignature reports whether signature (the raw
+// X-Twilio-Signature header value) matches requestURL and form, per
+// Twilio's request validation scheme: HMAC-SHA1, keyed by authToken, over
+// requestURL with each form parameter's key and value appended in
+// alphabetical-by-key order (no separators), base64-encoded.
+// https://www.twilio.com/docs/usage/webhooks/webhooks-security
+func verifyTwilioSignature(authToken, requestURL string, form url.Values, signature string) bool {
+	if signature == "" {
+		return false
+	}
+
+	keys := make([]string, 0, len(form))
+	for key := range form {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	data := requestURL
+	for _, key := range keys {
+		data += key + form.Get(key)
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(data))
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
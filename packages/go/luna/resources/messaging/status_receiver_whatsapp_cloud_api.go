@@ -0,0 +1,83 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// parseWhatsAppCloudAPIStatusReports parses a WhatsApp Cloud API status
+// webhook body, the same entry[].changes[].value.statuses[] envelope
+// cloudAPIWebhookNormalizer.Normalize parses for WhatsAppWebhook, reused
+// here so a caller that only cares about delivery receipts (not inbound
+// messages or template review outcomes) doesn't need to stand up a full
+// WhatsAppWebhook to get them.
+func parseWhatsAppCloudAPIStatusReports(body []byte) ([]DeliveryReport, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("messaging: failed to decode whatsapp cloud api status webhook: %w", err)
+	}
+
+	var reports []DeliveryReport
+
+	entries, _ := payload["entry"].([]interface{})
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		changes, _ := entryMap["changes"].([]interface{})
+		for _, change := range changes {
+			changeMap, ok := change.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			value, ok := changeMap["value"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			statuses, _ := value["statuses"].([]interface{})
+			for _, status := range statuses {
+				if statusMap, ok := status.(map[string]interface{}); ok {
+					reports = append(reports, whatsAppCloudAPIStatusToDeliveryReport(statusMap))
+				}
+			}
+		}
+	}
+
+	return reports, nil
+}
+
+// whatsAppCloudAPIStatusToDeliveryReport converts one statuses[] entry into
+// a DeliveryReport, reusing the same status-vocabulary mapping and
+// timestamp/error parsing as cloudAPIParseStatusUpdate. Unlike Twilio or
+// Clickatell, Cloud API doesn't report a numeric amount on the delivery
+// receipt itself, so Price/Currency are left zero here; that's only
+// available via StatusMeta.PricingModel/PricingCategory/Billable.
+func whatsAppCloudAPIStatusToDeliveryReport(statusMap map[string]interface{}) DeliveryReport {
+	status := StatusPending
+	if s, ok := whatsAppStatusMap[jsonString(statusMap, "status")]; ok {
+		status = s
+	}
+
+	report := DeliveryReport{
+		MessageID: jsonString(statusMap, "id"),
+		Status:    status,
+		Timestamp: time.Now(),
+	}
+
+	if timestamp := jsonString(statusMap, "timestamp"); timestamp != "" {
+		if parsed, err := parseUnixSeconds(timestamp); err == nil {
+			report.Timestamp = parsed
+		}
+	}
+
+	if errs, ok := statusMap["errors"].([]interface{}); ok && len(errs) > 0 {
+		if errMap, ok := errs[0].(map[string]interface{}); ok {
+			report.ErrorCode = jsonString(errMap, "code")
+			report.ErrorDetail = jsonString(errMap, "message")
+		}
+	}
+
+	return report
+}
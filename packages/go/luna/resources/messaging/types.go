@@ -1,7 +1,11 @@
 // Package messaging provides SMS, WhatsApp, and USSD integrations for South Africa.
 package messaging
 
-import "time"
+import (
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/messagebus"
+)
 
 // Provider types
 type SMSProvider string
@@ -10,10 +14,15 @@ type USSDProvider string
 
 const (
 	SMSClickatell     SMSProvider = "clickatell"
+	SMSBulkSMS        SMSProvider = "bulksms"
+	SMSPortal         SMSProvider = "smsportal"
+	SMSGenericHTTP    SMSProvider = "http"
 	SMSAfricasTalking SMSProvider = "africastalking"
 	SMSTwilio         SMSProvider = "twilio"
 
 	WhatsAppCloudAPI   WhatsAppProvider = "cloud_api"
+	WhatsAppOnPremise  WhatsAppProvider = "on_premise"
+	WhatsAppMock       WhatsAppProvider = "mock"
 	WhatsAppClickatell WhatsAppProvider = "clickatell"
 	WhatsAppWati       WhatsAppProvider = "wati"
 	WhatsAppInfobip    WhatsAppProvider = "infobip"
@@ -50,6 +59,21 @@ type SMSConfig struct {
 	Username string      `json:"username,omitempty"`
 	SenderID string      `json:"sender_id,omitempty"`
 	Sandbox  bool        `json:"sandbox"`
+
+	// Password is required by providers that authenticate with a
+	// username/password or client ID/secret pair (SMSBulkSMS,
+	// SMSPortal). Unused by SMSClickatell and SMSGenericHTTP, which
+	// authenticate with APIKey alone.
+	Password string `json:"password,omitempty"`
+	// BaseURL overrides the provider's default API host. Required for
+	// SMSGenericHTTP; optional elsewhere, mainly for pointing a test
+	// server at something other than the provider's production host.
+	BaseURL string `json:"base_url,omitempty"`
+	// Bus, when set, receives an smsInboundTopic event for every message
+	// SMS.ParseInboundWebhook parses, so a downstream service can consume
+	// inbound/delivery-report traffic asynchronously instead of polling
+	// SMS.GetStatus. Nil is safe and is the default.
+	Bus messagebus.MessageBus `json:"-"`
 }
 
 // WhatsAppConfig holds WhatsApp configuration
@@ -59,6 +83,32 @@ type WhatsAppConfig struct {
 	PhoneNumberID string           `json:"phone_number_id,omitempty"`
 	WebhookToken  string           `json:"webhook_token,omitempty"`
 	Sandbox       bool             `json:"sandbox"`
+
+	// AccessToken is the Bearer token sent to the WhatsApp Business Cloud
+	// API / on-premise API. APIKey is kept for backward compatibility and
+	// used as a fallback when AccessToken is unset.
+	AccessToken string `json:"access_token,omitempty"`
+	// APIVersion is the Graph API version, e.g. "v19.0". Defaults to
+	// defaultGraphAPIVersion.
+	APIVersion string `json:"api_version,omitempty"`
+	// BaseURL overrides the provider's default API host, mainly for
+	// pointing an on-premise deployment or test server at something other
+	// than https://graph.facebook.com.
+	BaseURL string `json:"base_url,omitempty"`
+	// DefaultRegion is the ISO 3166-1 alpha-2 region used to resolve
+	// recipient numbers that don't carry a country code. Defaults to "ZA".
+	DefaultRegion string `json:"default_region,omitempty"`
+	// Bus, when set, receives a whatsAppInboundTopic event for every
+	// message WhatsApp.ProcessWebhook parses, so a downstream service can
+	// consume inbound/delivery-status traffic asynchronously instead of
+	// polling WhatsApp.GetStatus. Nil is safe and is the default.
+	Bus messagebus.MessageBus `json:"-"`
+	// ConversationStore backs the 24-hour customer service window WhatsApp.Send
+	// gates free-form replies on. Nil (the default) uses an in-memory store,
+	// which is fine for a single process but loses window state across
+	// restarts or between replicas; pass a shared store (Redis, SQL, ...) for
+	// multi-instance deployments.
+	ConversationStore ConversationWindowStore `json:"-"`
 }
 
 // USSDConfig holds USSD configuration
@@ -121,6 +171,11 @@ type WhatsAppTemplateRequest struct {
 	TemplateName   string                 `json:"template_name"`
 	TemplateParams map[string]interface{} `json:"template_params,omitempty"`
 	Language       string                 `json:"language,omitempty"`
+	// Category is the billing category Meta approved the template under:
+	// "marketing", "utility", or "authentication". Echoed onto the returned
+	// WhatsAppMessage.Metadata["billing_category"] so callers can track
+	// conversation-based costs. Defaults to "marketing" when unset.
+	Category string `json:"category,omitempty"`
 }
 
 // WhatsAppMediaRequest represents a WhatsApp media message request
@@ -136,6 +191,7 @@ type WhatsAppMessage struct {
 	ID             string                 `json:"id"`
 	To             string                 `json:"to"`
 	From           string                 `json:"from,omitempty"`
+	FromName       string                 `json:"from_name,omitempty"`
 	Type           string                 `json:"type"`
 	Text           string                 `json:"text,omitempty"`
 	TemplateName   string                 `json:"template_name,omitempty"`
@@ -146,6 +202,70 @@ type WhatsAppMessage struct {
 	Provider       WhatsAppProvider       `json:"provider,omitempty"`
 	CreatedAt      time.Time              `json:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at"`
+
+	// Interactive holds a button/list reply payload when Type is
+	// "interactive".
+	Interactive *WhatsAppInteractiveReply `json:"interactive,omitempty"`
+	// Reaction holds an emoji reaction payload when Type is "reaction".
+	Reaction *WhatsAppReaction `json:"reaction,omitempty"`
+	// Referral holds the ad/post a conversation originated from, when
+	// present on an inbound message.
+	Referral *WhatsAppReferral `json:"referral,omitempty"`
+	// Metadata carries send-time bookkeeping, currently just
+	// "billing_category" ("marketing"/"utility"/"authentication"/"service")
+	// set by WhatsApp.Send so callers can track conversation-based costs.
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// WhatsAppInteractiveReply represents a button_reply or list_reply
+// selection from an inbound interactive message.
+type WhatsAppInteractiveReply struct {
+	Kind  string `json:"kind"` // "button_reply" or "list_reply"
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// WhatsAppReaction represents an emoji reaction to a prior message.
+type WhatsAppReaction struct {
+	MessageID string `json:"message_id"`
+	Emoji     string `json:"emoji"`
+}
+
+// WhatsAppReferral represents the ad/post that a conversation was started
+// from (Click to WhatsApp ads, Facebook/Instagram posts).
+type WhatsAppReferral struct {
+	SourceURL  string `json:"source_url,omitempty"`
+	SourceType string `json:"source_type,omitempty"`
+	SourceID   string `json:"source_id,omitempty"`
+	Headline   string `json:"headline,omitempty"`
+	Body       string `json:"body,omitempty"`
+	MediaType  string `json:"media_type,omitempty"`
+}
+
+// StatusMeta carries the conversation/pricing metadata a status webhook
+// attaches to a delivered/read/failed update: which customer-service
+// conversation window billed it, under which pricing category, and
+// whether it was billable at all (free-tier service conversations
+// aren't). Zero value means the provider didn't report any of this.
+type StatusMeta struct {
+	ConversationID       string     `json:"conversation_id,omitempty"`
+	ConversationCategory string     `json:"conversation_category,omitempty"`
+	ConversationExpiry   *time.Time `json:"conversation_expiry,omitempty"`
+	PricingModel         string     `json:"pricing_model,omitempty"`
+	PricingCategory      string     `json:"pricing_category,omitempty"`
+	Billable             bool       `json:"billable,omitempty"`
+	Error                string     `json:"error,omitempty"`
+}
+
+// WhatsAppTemplateStatusUpdate represents a message template's review
+// outcome changing (approved, rejected, disabled, ...), delivered via the
+// message_template_status_update webhook field.
+type WhatsAppTemplateStatusUpdate struct {
+	TemplateID       string `json:"template_id"`
+	TemplateName     string `json:"template_name"`
+	TemplateLanguage string `json:"template_language,omitempty"`
+	Event            string `json:"event"`
+	Reason           string `json:"reason,omitempty"`
 }
 
 // USSDSession represents a USSD session
@@ -166,3 +286,29 @@ type USSDResponse struct {
 	Text string `json:"text"`
 	End  bool   `json:"end"`
 }
+
+// PairingOptions configures a WhatsApp pairing-code request.
+type PairingOptions struct {
+	// PushName is the display name advertised to the paired handset during linking.
+	PushName string `json:"push_name,omitempty"`
+	// TTL overrides the default code expiry. The server has the final say and
+	// may return a shorter TTL than requested.
+	TTL time.Duration `json:"-"`
+}
+
+// PairingCode represents an issued WhatsApp pairing code.
+type PairingCode struct {
+	// Code is formatted as two groups of 4, e.g. "ABCD-EFGH".
+	Code      string    `json:"code"`
+	PhoneE164 string    `json:"phone_e164"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// PairedDevice represents a WhatsApp sender linked via a pairing code.
+type PairedDevice struct {
+	JID         string    `json:"jid"`
+	PushName    string    `json:"push_name,omitempty"`
+	Platform    string    `json:"platform,omitempty"`
+	PhoneNumber string    `json:"phone_number"`
+	LinkedAt    time.Time `json:"linked_at"`
+}
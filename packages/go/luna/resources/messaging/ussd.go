@@ -14,9 +14,10 @@ type USSDHandler func(USSDSession) USSDResponse
 
 // USSD provides USSD service integration for South African networks.
 type USSD struct {
-	client   *lunahttp.Client
-	config   USSDConfig
-	handlers map[string]USSDHandler
+	client     *lunahttp.Client
+	config     USSDConfig
+	handlers   map[string]USSDHandler
+	menuRouter *MenuRouter
 }
 
 // NewUSSD creates a new USSD instance.
@@ -38,8 +39,22 @@ func (u *USSD) OnMenu(path string, handler USSDHandler) {
 	u.handlers[path] = handler
 }
 
-// ProcessRequest processes incoming USSD request.
+// UseMenu attaches a declarative Menu tree built with NewMenu, so
+// ProcessRequest routes through it instead of the flat Text-keyed
+// OnMenu/OnSession handlers. A nil store defaults to
+// NewInMemorySessionStore.
+func (u *USSD) UseMenu(root *USSDMenu, store SessionStore) {
+	u.menuRouter = NewMenuRouter(root, store)
+}
+
+// ProcessRequest processes incoming USSD request. If UseMenu has been
+// called, it delegates to the attached MenuRouter; otherwise it falls back
+// to the flat Text-keyed handlers registered via OnMenu/OnSession.
 func (u *USSD) ProcessRequest(session USSDSession) USSDResponse {
+	if u.menuRouter != nil {
+		return u.menuRouter.Handle(session)
+	}
+
 	handler, ok := u.handlers[session.Text]
 	if !ok {
 		handler, ok = u.handlers["default"]
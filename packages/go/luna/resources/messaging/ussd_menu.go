@@ -0,0 +1,160 @@
+package messaging
+
+import (
+	"fmt"
+	"strings"
+)
+
+// USSDInputValidator validates a free-text value collected by a Menu.Input
+// step, returning a user-facing error if the value is rejected. The caller
+// sees err.Error() prepended to the input's prompt and is asked again.
+type USSDInputValidator func(value string) error
+
+// USSDMenuHandler runs when a Menu option is selected, or when all of a
+// Menu's declared inputs have been collected. ctx exposes the inputs
+// gathered so far and a SessionStore-backed Get/Set for anything else the
+// handler needs to remember about the caller.
+type USSDMenuHandler func(ctx *USSDMenuContext) USSDResponse
+
+// USSDMenu is a declarative, multi-step USSD menu node, built with NewUSSDMenu():
+//
+//	sendPayment := NewUSSDMenu().
+//		Input("phone", "Enter phone number to send payment:", validatePhone).
+//		Input("amount", "Enter amount (ZAR):", validateAmount).
+//		Then(func(ctx *USSDMenuContext) USSDResponse {
+//			return USSDResponse{Text: fmt.Sprintf("Payment of R%s to %s initiated.", ctx.Input("amount"), ctx.Input("phone")), End: true}
+//		})
+//
+//	root := NewUSSDMenu().Title("Welcome to Luna SDK").
+//		Option("1", "Check Balance", checkBalance).
+//		Submenu("2", "Send Payment", sendPayment).
+//		Paginate(4)
+//
+// Attach the tree to a USSD with USSD.UseMenu; MenuRouter walks it directly
+// if you're not using the USSD type (e.g. from a custom HTTP handler).
+type USSDMenu struct {
+	title    string
+	options  []menuOption
+	inputs   []menuInput
+	handler  USSDMenuHandler
+	pageSize int
+}
+
+// menuOption is one numbered selection in a USSDMenu: exactly one of target
+// (descend into a submenu) or handler (run a terminal action) is set.
+type menuOption struct {
+	key     string
+	label   string
+	target  *USSDMenu
+	handler USSDMenuHandler
+}
+
+// menuInput is one free-text field a USSDMenu collects, in declaration order,
+// before invoking its handler.
+type menuInput struct {
+	name      string
+	prompt    string
+	validator USSDInputValidator
+}
+
+// NewUSSDMenu creates an empty USSDMenu. Chain Title/Option/Submenu/Input/
+// Then/Paginate to build it up.
+func NewUSSDMenu() *USSDMenu {
+	return &USSDMenu{}
+}
+
+// Title sets the heading line rendered above this menu's options.
+func (m *USSDMenu) Title(title string) *USSDMenu {
+	m.title = title
+	return m
+}
+
+// Option adds a numbered selection that runs handler directly. key is the
+// digit(s) the caller dials (conventionally "1".."9"; "0" and "00" are
+// reserved for back/home and never need to be added here -- MenuRouter
+// handles them itself).
+func (m *USSDMenu) Option(key, label string, handler USSDMenuHandler) *USSDMenu {
+	m.options = append(m.options, menuOption{key: key, label: label, handler: handler})
+	return m
+}
+
+// Submenu adds a numbered selection that descends into target instead of
+// running a handler directly.
+func (m *USSDMenu) Submenu(key, label string, target *USSDMenu) *USSDMenu {
+	m.options = append(m.options, menuOption{key: key, label: label, target: target})
+	return m
+}
+
+// Input declares the next free-text field this menu collects: once the
+// caller has no more options to pick from (i.e. every Option the caller
+// could still choose has already been superseded by an Input-only node),
+// MenuRouter prompts with prompt, validates the dialed text with validator
+// (nil skips validation), and stores it under name for USSDMenuContext.Input
+// to retrieve. Chain multiple calls to collect several fields in order.
+func (m *USSDMenu) Input(name, prompt string, validator USSDInputValidator) *USSDMenu {
+	m.inputs = append(m.inputs, menuInput{name: name, prompt: prompt, validator: validator})
+	return m
+}
+
+// Then sets the handler MenuRouter invokes once every declared Input has
+// been collected. A USSDMenu built purely from Input calls needs this to do
+// anything; a USSDMenu with Options doesn't, since each Option already
+// carries its own handler or submenu.
+func (m *USSDMenu) Then(handler USSDMenuHandler) *USSDMenu {
+	m.handler = handler
+	return m
+}
+
+// Paginate caps how many options are shown per screen to pageSize-1,
+// reserving the last line for "#. More" once the option list overflows a
+// single ~160-character USSD screen. Dialing "#" advances to the next page;
+// option keys remain globally matchable regardless of which page is
+// currently displayed, so pagination only changes what's rendered, never
+// how a selection is resolved.
+func (m *USSDMenu) Paginate(pageSize int) *USSDMenu {
+	m.pageSize = pageSize
+	return m
+}
+
+func (m *USSDMenu) findOption(key string) *menuOption {
+	for i := range m.options {
+		if m.options[i].key == key {
+			return &m.options[i]
+		}
+	}
+	return nil
+}
+
+// render produces the CON response listing this menu's title and options,
+// showing page (0-indexed) when Paginate has been set.
+func (m *USSDMenu) render(page int) USSDResponse {
+	var lines []string
+	if m.title != "" {
+		lines = append(lines, m.title, "")
+	}
+
+	opts := m.options
+	perPage := m.pageSize - 1
+	if m.pageSize > 0 && perPage >= 1 && len(opts) > perPage {
+		start := page * perPage
+		if start >= len(opts) {
+			start = 0
+		}
+		end := start + perPage
+		if end > len(opts) {
+			end = len(opts)
+		}
+		for _, opt := range opts[start:end] {
+			lines = append(lines, fmt.Sprintf("%s. %s", opt.key, opt.label))
+		}
+		if end < len(opts) {
+			lines = append(lines, "#. More")
+		}
+	} else {
+		for _, opt := range opts {
+			lines = append(lines, fmt.Sprintf("%s. %s", opt.key, opt.label))
+		}
+	}
+
+	return USSDResponse{Text: strings.Join(lines, "\n"), End: false}
+}
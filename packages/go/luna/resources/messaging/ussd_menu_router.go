@@ -0,0 +1,199 @@
+package messaging
+
+import "strings"
+
+const (
+	ussdStackSessionKey    = "__ussd_stack__"
+	ussdLastTextSessionKey = "__ussd_last_text__"
+)
+
+// USSDMenuContext is passed to a USSDMenuHandler, scoped to the current
+// session and menu node.
+type USSDMenuContext struct {
+	// Session is the request MenuRouter is currently handling.
+	Session USSDSession
+
+	store  SessionStore
+	inputs map[string]string
+}
+
+// Get reads session-scoped state previously stored with Set, surviving
+// across separate requests via the MenuRouter's SessionStore.
+func (c *USSDMenuContext) Get(key string) (interface{}, bool) {
+	return c.store.Get(c.Session.SessionID, key)
+}
+
+// Set persists session-scoped state under key via the MenuRouter's
+// SessionStore.
+func (c *USSDMenuContext) Set(key string, value interface{}) {
+	c.store.Set(c.Session.SessionID, key, value)
+}
+
+// Input returns the value a preceding Menu.Input step collected under name,
+// or "" if no such input was collected on the path to this handler.
+func (c *USSDMenuContext) Input(name string) string {
+	return c.inputs[name]
+}
+
+// MenuRouter walks a Menu tree built with NewMenu, resolving each request's
+// current node from the caller's dialed history and dispatching to the
+// matching USSDMenuHandler.
+type MenuRouter struct {
+	root  *USSDMenu
+	store SessionStore
+}
+
+// NewMenuRouter creates a MenuRouter for root. A nil store defaults to
+// NewInMemorySessionStore.
+func NewMenuRouter(root *USSDMenu, store SessionStore) *MenuRouter {
+	if store == nil {
+		store = NewInMemorySessionStore()
+	}
+	return &MenuRouter{root: root, store: store}
+}
+
+// Handle resolves session's current position in the menu tree and returns
+// the USSDResponse to send back. It clears the session's stored state once
+// a handler ends the session.
+func (r *MenuRouter) Handle(session USSDSession) USSDResponse {
+	stack := r.resolveStack(session)
+	resp := r.walk(session, stack)
+	if resp.End {
+		r.store.Clear(session.SessionID)
+	}
+	return resp
+}
+
+// resolveStack turns session.Text into the sequence of menu selections
+// dialed so far, applying "0"/"00" back/home semantics, and persists it for
+// next time. Africa's Talking resends the full cumulative history on every
+// callback; Clickatell and similar providers send only the newest
+// keystroke and rely on the application to remember the rest -- this is
+// detected by diffing against the previous request's raw text rather than
+// requiring the caller to configure it, so the same Menu works unmodified
+// against either.
+//
+// "0" and "00" are reserved network-wide for back/home (the same convention
+// Africa's Talking and Clickatell apps already rely on), so a Menu.Input
+// step can't collect them as literal values -- the same constraint the
+// underlying USSD networks themselves impose.
+func (r *MenuRouter) resolveStack(session USSDSession) []string {
+	lastTextRaw, _ := r.store.Get(session.SessionID, ussdLastTextSessionKey)
+	lastText, _ := lastTextRaw.(string)
+	r.store.Set(session.SessionID, ussdLastTextSessionKey, session.Text)
+
+	stackRaw, _ := r.store.Get(session.SessionID, ussdStackSessionKey)
+	stackText, _ := stackRaw.(string)
+	stack := splitUSSDStack(stackText)
+
+	// sawNewToken distinguishes "nothing dialed yet" (the very first request,
+	// where newToken is meaningless) from "the caller dialed the empty
+	// string" (a trailing "*" with nothing after it, e.g. "2*27821234567*"
+	// -- a legitimate, if unusual, new token that must still reach the
+	// current node's validator).
+	var newToken string
+	sawNewToken := session.Text != ""
+	switch {
+	case session.Text == "":
+	case lastText != "" && strings.HasPrefix(session.Text, lastText+"*"):
+		newToken = strings.TrimPrefix(session.Text, lastText+"*")
+	default:
+		newToken = session.Text
+	}
+
+	if sawNewToken {
+		switch newToken {
+		case "00":
+			stack = nil
+		case "0":
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		default:
+			stack = append(stack, newToken)
+		}
+	}
+
+	r.store.Set(session.SessionID, ussdStackSessionKey, joinUSSDStack(stack))
+	return stack
+}
+
+func splitUSSDStack(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "*")
+}
+
+func joinUSSDStack(stack []string) string {
+	return strings.Join(stack, "*")
+}
+
+// walk replays stack from the root of the tree, collecting each node's
+// declared inputs before matching an option, until it reaches a point that
+// needs more input from the caller or a handler to run.
+func (r *MenuRouter) walk(session USSDSession, stack []string) USSDResponse {
+	node := r.root
+	inputs := map[string]string{}
+	i := 0
+
+	for {
+		for _, in := range node.inputs {
+			if _, collected := inputs[in.name]; collected {
+				continue
+			}
+			for {
+				if i >= len(stack) {
+					return USSDResponse{Text: in.prompt, End: false}
+				}
+				value := stack[i]
+				i++
+				if in.validator != nil {
+					if err := in.validator(value); err != nil {
+						// A rejected value is baked permanently into the
+						// caller's cumulative dialed history and can't be
+						// un-dialed, so if a later token already supersedes
+						// it (the caller retried), skip it silently; only
+						// re-prompt when it's the newest thing dialed.
+						if i < len(stack) {
+							continue
+						}
+						return USSDResponse{Text: err.Error() + "\n" + in.prompt, End: false}
+					}
+				}
+				inputs[in.name] = value
+				break
+			}
+		}
+
+		if len(node.options) == 0 {
+			if node.handler == nil {
+				return USSDResponse{Text: "Service temporarily unavailable. Please try again later.", End: true}
+			}
+			return node.handler(&USSDMenuContext{Session: session, store: r.store, inputs: inputs})
+		}
+
+		page := 0
+		for i < len(stack) && stack[i] == "#" {
+			page++
+			i++
+		}
+
+		if i >= len(stack) {
+			return node.render(page)
+		}
+
+		token := stack[i]
+		opt := node.findOption(token)
+		if opt == nil {
+			return USSDResponse{Text: "Invalid selection. Please try again.", End: true}
+		}
+		i++
+
+		if opt.target != nil {
+			node = opt.target
+			continue
+		}
+		return opt.handler(&USSDMenuContext{Session: session, store: r.store, inputs: inputs})
+	}
+}
@@ -0,0 +1,233 @@
+package messaging_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/messaging"
+)
+
+func buildExampleMenu() *messaging.USSDMenu {
+	sendPayment := messaging.NewUSSDMenu().
+		Input("phone", "Enter phone number to send payment:", nil).
+		Input("amount", "Enter amount (ZAR):", func(value string) error {
+			if value == "" {
+				return errors.New("amount is required")
+			}
+			return nil
+		}).
+		Then(func(ctx *messaging.USSDMenuContext) messaging.USSDResponse {
+			return messaging.USSDResponse{
+				Text: fmt.Sprintf("Payment of R%s to %s initiated.", ctx.Input("amount"), ctx.Input("phone")),
+				End:  true,
+			}
+		})
+
+	return messaging.NewUSSDMenu().Title("Welcome to Luna SDK").
+		Option("1", "Check Balance", func(ctx *messaging.USSDMenuContext) messaging.USSDResponse {
+			return messaging.USSDResponse{Text: "Your balance is R1,234.56", End: true}
+		}).
+		Submenu("2", "Send Payment", sendPayment).
+		Option("3", "Exit", func(ctx *messaging.USSDMenuContext) messaging.USSDResponse {
+			return messaging.USSDResponse{Text: "Goodbye!", End: true}
+		})
+}
+
+func atSession(sessionID, text string) messaging.USSDSession {
+	return messaging.USSDSession{SessionID: sessionID, Text: text}
+}
+
+func TestMenuRouterRendersRootOnFirstRequest(t *testing.T) {
+	router := messaging.NewMenuRouter(buildExampleMenu(), nil)
+
+	resp := router.Handle(atSession("sess-1", ""))
+	if resp.End {
+		t.Fatalf("expected the root menu to not end the session")
+	}
+	if resp.Text == "" {
+		t.Fatalf("expected a non-empty rendered menu")
+	}
+}
+
+func TestMenuRouterImmediateOption(t *testing.T) {
+	router := messaging.NewMenuRouter(buildExampleMenu(), nil)
+
+	router.Handle(atSession("sess-1", ""))
+	resp := router.Handle(atSession("sess-1", "1"))
+	if !resp.End || resp.Text != "Your balance is R1,234.56" {
+		t.Fatalf("expected the balance response, got %+v", resp)
+	}
+}
+
+func TestMenuRouterMultiStepInputFlowAfricasTalkingCumulative(t *testing.T) {
+	router := messaging.NewMenuRouter(buildExampleMenu(), nil)
+
+	router.Handle(atSession("sess-2", ""))
+	resp := router.Handle(atSession("sess-2", "2"))
+	if resp.Text != "Enter phone number to send payment:" {
+		t.Fatalf("expected the phone prompt, got %+v", resp)
+	}
+
+	// Africa's Talking resends the full dialed history on every callback.
+	resp = router.Handle(atSession("sess-2", "2*27821234567"))
+	if resp.Text != "Enter amount (ZAR):" {
+		t.Fatalf("expected the amount prompt, got %+v", resp)
+	}
+
+	resp = router.Handle(atSession("sess-2", "2*27821234567*500"))
+	if !resp.End || resp.Text != "Payment of R500 to 27821234567 initiated." {
+		t.Fatalf("expected the payment confirmation, got %+v", resp)
+	}
+}
+
+func TestMenuRouterMultiStepInputFlowSingleTokenPerRequest(t *testing.T) {
+	router := messaging.NewMenuRouter(buildExampleMenu(), nil)
+
+	// Clickatell-style providers only ever send the newest keystroke.
+	router.Handle(atSession("sess-3", ""))
+	resp := router.Handle(atSession("sess-3", "2"))
+	if resp.Text != "Enter phone number to send payment:" {
+		t.Fatalf("expected the phone prompt, got %+v", resp)
+	}
+
+	resp = router.Handle(atSession("sess-3", "27831234567"))
+	if resp.Text != "Enter amount (ZAR):" {
+		t.Fatalf("expected the amount prompt, got %+v", resp)
+	}
+
+	resp = router.Handle(atSession("sess-3", "250"))
+	if !resp.End || resp.Text != "Payment of R250 to 27831234567 initiated." {
+		t.Fatalf("expected the payment confirmation, got %+v", resp)
+	}
+}
+
+func TestMenuRouterValidatorRejectsInvalidInput(t *testing.T) {
+	router := messaging.NewMenuRouter(buildExampleMenu(), nil)
+
+	router.Handle(atSession("sess-4", ""))
+	router.Handle(atSession("sess-4", "2"))
+	router.Handle(atSession("sess-4", "2*27821234567"))
+
+	resp := router.Handle(atSession("sess-4", "2*27821234567*"))
+	if resp.End {
+		t.Fatalf("expected a re-prompt for an invalid amount, not session end")
+	}
+	if resp.Text != "amount is required\nEnter amount (ZAR):" {
+		t.Fatalf("expected the validator error prefixed onto the prompt, got %q", resp.Text)
+	}
+
+	// Africa's Talking resends the rejected "" amount forever as part of the
+	// cumulative text; the retried "500" must still win.
+	resp = router.Handle(atSession("sess-4", "2*27821234567**500"))
+	if !resp.End || resp.Text != "Payment of R500 to 27821234567 initiated." {
+		t.Fatalf("expected the retried amount to supersede the rejected one, got %+v", resp)
+	}
+}
+
+func TestMenuRouterBackAndHomeNavigation(t *testing.T) {
+	router := messaging.NewMenuRouter(buildExampleMenu(), nil)
+
+	router.Handle(atSession("sess-5", ""))
+	resp := router.Handle(atSession("sess-5", "2"))
+	if resp.Text != "Enter phone number to send payment:" {
+		t.Fatalf("expected the phone prompt, got %+v", resp)
+	}
+
+	// "0" backs out of the Send Payment flow to the root menu.
+	resp = router.Handle(atSession("sess-5", "2*0"))
+	if resp.End || resp.Text == "" {
+		t.Fatalf("expected back navigation to redisplay the root menu, got %+v", resp)
+	}
+
+	// Re-enter Send Payment and partially fill it in, then go home with "00".
+	router.Handle(atSession("sess-5", "2*0*2"))
+	resp = router.Handle(atSession("sess-5", "2*0*2*27821234567"))
+	if resp.Text != "Enter amount (ZAR):" {
+		t.Fatalf("expected the amount prompt after re-entering, got %+v", resp)
+	}
+	resp = router.Handle(atSession("sess-5", "2*0*2*27821234567*00"))
+	if resp.End || resp.Text == "" {
+		t.Fatalf("expected home navigation to redisplay the root menu, got %+v", resp)
+	}
+}
+
+func TestMenuRouterPagination(t *testing.T) {
+	menu := messaging.NewUSSDMenu().Title("Pick a fruit").
+		Option("1", "Apple", func(ctx *messaging.USSDMenuContext) messaging.USSDResponse {
+			return messaging.USSDResponse{Text: "apple", End: true}
+		}).
+		Option("2", "Banana", func(ctx *messaging.USSDMenuContext) messaging.USSDResponse {
+			return messaging.USSDResponse{Text: "banana", End: true}
+		}).
+		Option("3", "Cherry", func(ctx *messaging.USSDMenuContext) messaging.USSDResponse {
+			return messaging.USSDResponse{Text: "cherry", End: true}
+		}).
+		Paginate(3)
+
+	router := messaging.NewMenuRouter(menu, nil)
+
+	first := router.Handle(atSession("sess-6", ""))
+	if !containsAll(first.Text, "1. Apple", "2. Banana", "#. More") {
+		t.Fatalf("expected page 1 with a More prompt, got %q", first.Text)
+	}
+	if containsAll(first.Text, "3. Cherry") {
+		t.Fatalf("expected page 1 to not show Cherry yet, got %q", first.Text)
+	}
+
+	second := router.Handle(atSession("sess-6", "#"))
+	if !containsAll(second.Text, "3. Cherry") {
+		t.Fatalf("expected page 2 to show Cherry, got %q", second.Text)
+	}
+
+	resp := router.Handle(atSession("sess-6", "#*3"))
+	if !resp.End || resp.Text != "cherry" {
+		t.Fatalf("expected selecting Cherry from page 2 to work, got %+v", resp)
+	}
+}
+
+func TestUSSDMenuContextGetSet(t *testing.T) {
+	var sawValue interface{}
+	menu := messaging.NewUSSDMenu().
+		Option("1", "Remember", func(ctx *messaging.USSDMenuContext) messaging.USSDResponse {
+			ctx.Set("visited", true)
+			return messaging.USSDResponse{Text: "ok", End: false}
+		}).
+		Option("2", "Recall", func(ctx *messaging.USSDMenuContext) messaging.USSDResponse {
+			sawValue, _ = ctx.Get("visited")
+			return messaging.USSDResponse{Text: "ok", End: true}
+		})
+
+	router := messaging.NewMenuRouter(menu, nil)
+	router.Handle(atSession("sess-7", ""))
+	router.Handle(atSession("sess-7", "1"))
+	// "00" returns to the root menu without ending the session, so the
+	// state Set by Remember must still be there once Recall runs.
+	router.Handle(atSession("sess-7", "1*00"))
+	router.Handle(atSession("sess-7", "1*00*2"))
+
+	if sawValue != true {
+		t.Fatalf("expected Get to see the value Set by a previous step, got %v", sawValue)
+	}
+}
+
+func TestUSSDUseMenuIntegratesWithProcessRequest(t *testing.T) {
+	client := messaging.NewUSSD(nil, messaging.USSDConfig{ServiceCode: "*123#"})
+	client.UseMenu(buildExampleMenu(), nil)
+
+	client.ProcessRequest(atSession("sess-8", ""))
+	resp := client.ProcessRequest(atSession("sess-8", "3"))
+	if !resp.End || resp.Text != "Goodbye!" {
+		t.Fatalf("expected ProcessRequest to route through the attached menu, got %+v", resp)
+	}
+}
+
+func containsAll(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if !strings.Contains(s, sub) {
+			return false
+		}
+	}
+	return true
+}
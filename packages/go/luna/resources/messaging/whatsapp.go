@@ -6,94 +6,107 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"fmt"
-	"regexp"
+	"encoding/json"
+	"sync"
 	"time"
 
 	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
 )
 
+// whatsAppInboundTopic is the messagebus topic ProcessWebhook publishes
+// each parsed WhatsAppMessage to, when WhatsAppConfig.Bus is set.
+const whatsAppInboundTopic = "messaging.whatsapp.inbound"
+
 // WhatsApp provides WhatsApp Business API integration.
 type WhatsApp struct {
-	client *lunahttp.Client
-	config WhatsAppConfig
+	client  *lunahttp.Client
+	config  WhatsAppConfig
+	backend whatsAppBackend
+	phones  *PhoneNormalizer
+	window  *ConversationWindow
+
+	mu       sync.Mutex
+	pairings map[string]*pairingEntry
+	devices  map[string]*PairedDevice
 }
 
-// NewWhatsApp creates a new WhatsApp instance.
+// NewWhatsApp creates a new WhatsApp instance. The transport is selected
+// from config.Provider: WhatsAppCloudAPI (default) talks to Meta's Cloud
+// API, WhatsAppOnPremise to a self-hosted deployment, and WhatsAppMock
+// fabricates responses for tests. Recipient numbers are parsed against
+// config.DefaultRegion (falling back to "ZA").
 func NewWhatsApp(client *lunahttp.Client, config WhatsAppConfig) *WhatsApp {
 	return &WhatsApp{
-		client: client,
-		config: config,
+		client:  client,
+		config:  config,
+		backend: newWhatsAppBackend(config),
+		phones:  NewPhoneNormalizer(config.DefaultRegion),
+		window:  NewConversationWindow(config.ConversationStore),
 	}
 }
 
+// InWindow reports whether phone is still inside its 24-hour customer
+// service window, along with the time it expires (or expired). Callers
+// only need this directly for diagnostics -- WhatsApp.Send already
+// enforces it.
+func (w *WhatsApp) InWindow(phone string) (bool, time.Time) {
+	return w.window.InWindow(phone)
+}
+
+// ValidatePhoneNumber parses and validates phone against the configured
+// default region, returning country and line-type information so callers
+// can gate WhatsApp vs SMS routing. It returns ErrInvalidPhoneNumber if
+// phone cannot be parsed or is not valid.
+func (w *WhatsApp) ValidatePhoneNumber(phone string) (*PhoneNumberInfo, error) {
+	return w.phones.Validate(phone)
+}
+
 // SendText sends a text message.
 func (w *WhatsApp) SendText(ctx context.Context, req WhatsAppTextRequest) (*WhatsAppMessage, error) {
-	messageID := fmt.Sprintf("wa_%d", time.Now().UnixMilli())
-	to := w.normalizePhoneNumber(req.To)
-
-	return &WhatsAppMessage{
-		ID:        messageID,
-		To:        to,
-		Type:      "text",
-		Text:      req.Text,
-		Status:    StatusPending,
-		Direction: "outbound",
-		Provider:  w.config.Provider,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}, nil
+	to, err := w.phones.Normalize(req.To)
+	if err != nil {
+		return nil, err
+	}
+	req.To = to
+	return w.backend.SendText(ctx, req)
 }
 
 // SendTemplate sends a template message.
 func (w *WhatsApp) SendTemplate(ctx context.Context, req WhatsAppTemplateRequest) (*WhatsAppMessage, error) {
-	messageID := fmt.Sprintf("wa_%d", time.Now().UnixMilli())
-	to := w.normalizePhoneNumber(req.To)
-
-	return &WhatsAppMessage{
-		ID:             messageID,
-		To:             to,
-		Type:           "template",
-		TemplateName:   req.TemplateName,
-		TemplateParams: req.TemplateParams,
-		Status:         StatusPending,
-		Direction:      "outbound",
-		Provider:       w.config.Provider,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
-	}, nil
+	to, err := w.phones.Normalize(req.To)
+	if err != nil {
+		return nil, err
+	}
+	req.To = to
+	return w.backend.SendTemplate(ctx, req)
 }
 
 // SendMedia sends a media message.
 func (w *WhatsApp) SendMedia(ctx context.Context, req WhatsAppMediaRequest) (*WhatsAppMessage, error) {
-	messageID := fmt.Sprintf("wa_%d", time.Now().UnixMilli())
-	to := w.normalizePhoneNumber(req.To)
-
-	return &WhatsAppMessage{
-		ID:        messageID,
-		To:        to,
-		Type:      req.Type,
-		MediaURL:  req.MediaURL,
-		Status:    StatusPending,
-		Direction: "outbound",
-		Provider:  w.config.Provider,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}, nil
+	to, err := w.phones.Normalize(req.To)
+	if err != nil {
+		return nil, err
+	}
+	req.To = to
+	return w.backend.SendMedia(ctx, req)
 }
 
 // GetStatus gets message status.
 func (w *WhatsApp) GetStatus(ctx context.Context, messageID string) (*WhatsAppMessage, error) {
-	return &WhatsAppMessage{
-		ID:        messageID,
-		To:        "",
-		Type:      "text",
-		Status:    StatusDelivered,
-		Direction: "outbound",
-		Provider:  w.config.Provider,
-		CreatedAt: time.Now(),
-		UpdatedAt: time.Now(),
-	}, nil
+	return w.backend.GetStatus(ctx, messageID)
+}
+
+// MarkRead marks an inbound message as read, which also clears the
+// "typing"/delivery indicators WhatsApp shows the sender.
+func (w *WhatsApp) MarkRead(ctx context.Context, messageID string) error {
+	return w.backend.MarkRead(ctx, messageID)
+}
+
+// DownloadMedia fetches the bytes and MIME type of an inbound media
+// message by its media ID (see WhatsAppMessage.MediaURL in ProcessWebhook
+// output, or the "id" field of an incoming media payload).
+func (w *WhatsApp) DownloadMedia(ctx context.Context, mediaID string) ([]byte, string, error) {
+	return w.backend.DownloadMedia(ctx, mediaID)
 }
 
 // VerifyWebhook verifies webhook signature.
@@ -108,7 +121,18 @@ func (w *WhatsApp) VerifyWebhook(payload, signature string) bool {
 	return expectedSignature == signature
 }
 
-// ProcessWebhook processes incoming webhook.
+var whatsAppStatusMap = map[string]MessageStatus{
+	"sent":      StatusSent,
+	"delivered": StatusDelivered,
+	"read":      StatusRead,
+	"failed":    StatusFailed,
+}
+
+// ProcessWebhook processes an incoming webhook delivery from the Graph API
+// (Cloud API and on-premise deployments share this payload shape). It
+// understands the v17+ shape: contact display names, plain text, media,
+// interactive button/list replies, reactions, referrals, and status
+// callbacks.
 func (w *WhatsApp) ProcessWebhook(payload map[string]interface{}) []WhatsAppMessage {
 	messages := []WhatsAppMessage{}
 
@@ -117,13 +141,6 @@ func (w *WhatsApp) ProcessWebhook(payload map[string]interface{}) []WhatsAppMess
 		return messages
 	}
 
-	statusMap := map[string]MessageStatus{
-		"sent":      StatusSent,
-		"delivered": StatusDelivered,
-		"read":      StatusRead,
-		"failed":    StatusFailed,
-	}
-
 	for _, entry := range entries {
 		entryMap, ok := entry.(map[string]interface{})
 		if !ok {
@@ -146,68 +163,176 @@ func (w *WhatsApp) ProcessWebhook(payload map[string]interface{}) []WhatsAppMess
 				continue
 			}
 
-			// Process incoming messages
+			contactNames := contactDisplayNames(value)
+
 			if msgs, ok := value["messages"].([]interface{}); ok {
 				for _, msg := range msgs {
-					msgMap, ok := msg.(map[string]interface{})
-					if !ok {
-						continue
+					if msgMap, ok := msg.(map[string]interface{}); ok {
+						messages = append(messages, w.parseInboundMessage(msgMap, contactNames))
 					}
-
-					messages = append(messages, WhatsAppMessage{
-						ID:        msgMap["id"].(string),
-						From:      msgMap["from"].(string),
-						Type:      msgMap["type"].(string),
-						Status:    StatusDelivered,
-						Direction: "inbound",
-						Provider:  w.config.Provider,
-						CreatedAt: time.Now(),
-						UpdatedAt: time.Now(),
-					})
 				}
 			}
 
-			// Process status updates
 			if statuses, ok := value["statuses"].([]interface{}); ok {
 				for _, status := range statuses {
-					statusMap2, ok := status.(map[string]interface{})
-					if !ok {
-						continue
-					}
-
-					messageStatus := StatusPending
-					if s, ok := statusMap[statusMap2["status"].(string)]; ok {
-						messageStatus = s
+					if statusMap, ok := status.(map[string]interface{}); ok {
+						messages = append(messages, w.parseStatusUpdate(statusMap))
 					}
-
-					messages = append(messages, WhatsAppMessage{
-						ID:        statusMap2["id"].(string),
-						To:        "",
-						Type:      "text",
-						Status:    messageStatus,
-						Direction: "outbound",
-						Provider:  w.config.Provider,
-						CreatedAt: time.Now(),
-						UpdatedAt: time.Now(),
-					})
 				}
 			}
 		}
 	}
 
+	for _, msg := range messages {
+		if msg.Direction == "inbound" && msg.From != "" {
+			w.window.RecordInbound(msg.From)
+		}
+		w.publishInbound(msg)
+	}
+
 	return messages
 }
 
-func (w *WhatsApp) normalizePhoneNumber(phone string) string {
-	digits := regexp.MustCompile(`\D`).ReplaceAllString(phone, "")
+// publishInbound is a no-op when no bus has been configured, so
+// ProcessWebhook can call it unconditionally.
+func (w *WhatsApp) publishInbound(msg WhatsAppMessage) {
+	if w.config.Bus == nil {
+		return
+	}
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	_ = w.config.Bus.Publish(context.Background(), whatsAppInboundTopic, payload)
+}
 
-	if len(digits) == 10 && digits[0] == '0' {
-		digits = "27" + digits[1:]
+// contactDisplayNames maps wa_id to the profile name WhatsApp attaches to
+// the "contacts" array alongside "messages".
+func contactDisplayNames(value map[string]interface{}) map[string]string {
+	names := map[string]string{}
+	contacts, ok := value["contacts"].([]interface{})
+	if !ok {
+		return names
+	}
+	for _, c := range contacts {
+		contactMap, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		waID := jsonString(contactMap, "wa_id")
+		profile, ok := contactMap["profile"].(map[string]interface{})
+		if !ok || waID == "" {
+			continue
+		}
+		if name := jsonString(profile, "name"); name != "" {
+			names[waID] = name
+		}
+	}
+	return names
+}
+
+func (w *WhatsApp) parseInboundMessage(msgMap map[string]interface{}, contactNames map[string]string) WhatsAppMessage {
+	return parseGraphInboundMessage(msgMap, contactNames, w.config.Provider)
+}
+
+// parseGraphInboundMessage parses one entry of a messages[] array from the
+// Graph API's entry[].changes[].value envelope. It's shared by
+// WhatsApp.parseInboundMessage and cloudAPIWebhookNormalizer, which consume
+// the identical payload shape from two different entry points (polling
+// GetStatus-adjacent flows vs. the WhatsAppWebhook HTTP handler).
+func parseGraphInboundMessage(msgMap map[string]interface{}, contactNames map[string]string, provider WhatsAppProvider) WhatsAppMessage {
+	from := jsonString(msgMap, "from")
+	msgType := jsonString(msgMap, "type")
+
+	out := WhatsAppMessage{
+		ID:        jsonString(msgMap, "id"),
+		From:      from,
+		FromName:  contactNames[from],
+		Type:      msgType,
+		Status:    StatusDelivered,
+		Direction: "inbound",
+		Provider:  provider,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
 	}
 
-	if len(digits) > 0 && digits[0] == '+' {
-		digits = digits[1:]
+	switch msgType {
+	case "text":
+		if text, ok := msgMap["text"].(map[string]interface{}); ok {
+			out.Text = jsonString(text, "body")
+		}
+	case "image", "document", "audio", "video", "sticker":
+		if media, ok := msgMap[msgType].(map[string]interface{}); ok {
+			out.MediaURL = jsonString(media, "id")
+		}
+	case "interactive":
+		if interactive, ok := msgMap["interactive"].(map[string]interface{}); ok {
+			out.Interactive = parseInteractiveReply(interactive)
+		}
+	case "reaction":
+		if reaction, ok := msgMap["reaction"].(map[string]interface{}); ok {
+			out.Reaction = &WhatsAppReaction{
+				MessageID: jsonString(reaction, "message_id"),
+				Emoji:     jsonString(reaction, "emoji"),
+			}
+		}
 	}
 
-	return digits
+	if referral, ok := msgMap["referral"].(map[string]interface{}); ok {
+		out.Referral = &WhatsAppReferral{
+			SourceURL:  jsonString(referral, "source_url"),
+			SourceType: jsonString(referral, "source_type"),
+			SourceID:   jsonString(referral, "source_id"),
+			Headline:   jsonString(referral, "headline"),
+			Body:       jsonString(referral, "body"),
+			MediaType:  jsonString(referral, "media_type"),
+		}
+	}
+
+	return out
+}
+
+func parseInteractiveReply(interactive map[string]interface{}) *WhatsAppInteractiveReply {
+	if reply, ok := interactive["button_reply"].(map[string]interface{}); ok {
+		return &WhatsAppInteractiveReply{
+			Kind:  "button_reply",
+			ID:    jsonString(reply, "id"),
+			Title: jsonString(reply, "title"),
+		}
+	}
+	if reply, ok := interactive["list_reply"].(map[string]interface{}); ok {
+		return &WhatsAppInteractiveReply{
+			Kind:  "list_reply",
+			ID:    jsonString(reply, "id"),
+			Title: jsonString(reply, "title"),
+		}
+	}
+	return nil
+}
+
+func (w *WhatsApp) parseStatusUpdate(statusMap map[string]interface{}) WhatsAppMessage {
+	messageStatus := StatusPending
+	if s, ok := whatsAppStatusMap[jsonString(statusMap, "status")]; ok {
+		messageStatus = s
+	}
+
+	return WhatsAppMessage{
+		ID:        jsonString(statusMap, "id"),
+		To:        jsonString(statusMap, "recipient_id"),
+		Type:      "text",
+		Status:    messageStatus,
+		Direction: "outbound",
+		Provider:  w.config.Provider,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// jsonString reads a string field from a decoded JSON object map, returning
+// "" if the key is absent or not a string rather than panicking — webhook
+// payloads are attacker-influenced input and must never be type-asserted
+// blindly.
+func jsonString(m map[string]interface{}, key string) string {
+	s, _ := m[key].(string)
+	return s
 }
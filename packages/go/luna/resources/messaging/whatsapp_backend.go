@@ -0,0 +1,156 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const defaultGraphAPIVersion = "v19.0"
+
+// whatsAppBackend is the pluggable transport Send*/GetStatus/MarkRead/
+// DownloadMedia dispatch to. NewWhatsApp selects an implementation from
+// WhatsAppConfig.Provider ("cloud_api", "on_premise", "mock").
+type whatsAppBackend interface {
+	SendText(ctx context.Context, req WhatsAppTextRequest) (*WhatsAppMessage, error)
+	SendTemplate(ctx context.Context, req WhatsAppTemplateRequest) (*WhatsAppMessage, error)
+	SendMedia(ctx context.Context, req WhatsAppMediaRequest) (*WhatsAppMessage, error)
+	GetStatus(ctx context.Context, messageID string) (*WhatsAppMessage, error)
+	MarkRead(ctx context.Context, messageID string) error
+	DownloadMedia(ctx context.Context, mediaID string) ([]byte, string, error)
+}
+
+func newWhatsAppBackend(config WhatsAppConfig) whatsAppBackend {
+	switch config.Provider {
+	case WhatsAppOnPremise:
+		return newOnPremiseBackend(config)
+	case WhatsAppMock:
+		return NewMockBackend()
+	default:
+		return newCloudAPIBackend(config)
+	}
+}
+
+// graphHTTPClient is shared by the cloud_api and on_premise backends: both
+// speak the same Graph-API-shaped REST protocol (messaging_product/
+// messages payloads, {"error": {...}} envelopes), just against different
+// hosts and auth tokens.
+type graphHTTPClient struct {
+	httpClient  *http.Client
+	baseURL     string
+	accessToken string
+	maxRetries  int
+}
+
+func newGraphHTTPClient(baseURL, accessToken string) *graphHTTPClient {
+	return &graphHTTPClient{
+		httpClient:  &http.Client{Timeout: 30 * time.Second},
+		baseURL:     baseURL,
+		accessToken: accessToken,
+		maxRetries:  3,
+	}
+}
+
+// do sends method/path (relative to baseURL) with body JSON-encoded (or no
+// body when nil), retrying 429/5xx with exponential backoff honoring
+// Retry-After, and unmarshals a successful response into out (skipped when
+// out is nil).
+func (g *graphHTTPClient) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var lastErr error
+
+	for attempt := 0; attempt <= g.maxRetries; attempt++ {
+		status, retryAfter, respBody, err := g.attempt(ctx, method, path, body)
+		if err == nil {
+			if out != nil && len(respBody) > 0 {
+				if err := json.Unmarshal(respBody, out); err != nil {
+					return fmt.Errorf("whatsapp: failed to decode response: %w", err)
+				}
+			}
+			return nil
+		}
+
+		lastErr = err
+
+		_, ok := err.(*WhatsAppError)
+		retryable := status == http.StatusTooManyRequests || status >= 500
+		if !ok || !retryable || attempt >= g.maxRetries {
+			return err
+		}
+
+		g.wait(ctx, attempt, retryAfter)
+	}
+
+	return lastErr
+}
+
+func (g *graphHTTPClient) attempt(ctx context.Context, method, path string, body interface{}) (int, int, []byte, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("whatsapp: failed to encode request: %w", err)
+		}
+		bodyReader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, g.baseURL+path, bodyReader)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("whatsapp: failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+g.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("whatsapp: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, retryAfter, nil, fmt.Errorf("whatsapp: failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var envelope graphErrorEnvelope
+		_ = json.Unmarshal(respBody, &envelope)
+		return resp.StatusCode, retryAfter, respBody, &WhatsAppError{
+			Code:      envelope.Error.Code,
+			Subcode:   envelope.Error.Subcode,
+			Message:   envelope.Error.Message,
+			FBTraceID: envelope.Error.FBTraceID,
+		}
+	}
+
+	return resp.StatusCode, retryAfter, respBody, nil
+}
+
+func (g *graphHTTPClient) wait(ctx context.Context, attempt int, retryAfterSeconds int) {
+	var delay time.Duration
+	if retryAfterSeconds > 0 {
+		delay = time.Duration(retryAfterSeconds) * time.Second
+	} else {
+		delay = time.Duration(float64(500*time.Millisecond) * math.Pow(2, float64(attempt)))
+		jitter := time.Duration(float64(delay) * 0.1 * (rand.Float64()*2 - 1))
+		delay += jitter
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(delay):
+	}
+}
+
+func parseRetryAfter(header string) int {
+	seconds, _ := strconv.Atoi(header)
+	return seconds
+}
@@ -0,0 +1,262 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// cloudAPIBackend talks to Meta's WhatsApp Business Cloud API:
+// https://graph.facebook.com/{version}/{phone_number_id}/messages
+type cloudAPIBackend struct {
+	http          *graphHTTPClient
+	phoneNumberID string
+}
+
+func newCloudAPIBackend(config WhatsAppConfig) *cloudAPIBackend {
+	version := config.APIVersion
+	if version == "" {
+		version = defaultGraphAPIVersion
+	}
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://graph.facebook.com/" + version
+	}
+
+	accessToken := config.AccessToken
+	if accessToken == "" {
+		accessToken = config.APIKey
+	}
+
+	return &cloudAPIBackend{
+		http:          newGraphHTTPClient(baseURL, accessToken),
+		phoneNumberID: config.PhoneNumberID,
+	}
+}
+
+type graphMessageRequest struct {
+	MessagingProduct string                `json:"messaging_product"`
+	RecipientType    string                `json:"recipient_type,omitempty"`
+	To               string                `json:"to"`
+	Type             string                `json:"type"`
+	Text             *graphTextPayload     `json:"text,omitempty"`
+	Template         *graphTemplatePayload `json:"template,omitempty"`
+	Image            *graphMediaPayload    `json:"image,omitempty"`
+	Document         *graphMediaPayload    `json:"document,omitempty"`
+	Audio            *graphMediaPayload    `json:"audio,omitempty"`
+	Video            *graphMediaPayload    `json:"video,omitempty"`
+	Status           string                `json:"status,omitempty"`
+	MessageID        string                `json:"message_id,omitempty"`
+}
+
+type graphTextPayload struct {
+	Body string `json:"body"`
+}
+
+type graphTemplatePayload struct {
+	Name       string                   `json:"name"`
+	Language   graphTemplateLanguage    `json:"language"`
+	Components []graphTemplateComponent `json:"components,omitempty"`
+}
+
+type graphTemplateLanguage struct {
+	Code string `json:"code"`
+}
+
+type graphTemplateComponent struct {
+	Type       string                   `json:"type"`
+	Parameters []map[string]interface{} `json:"parameters"`
+}
+
+type graphMediaPayload struct {
+	Link    string `json:"link"`
+	Caption string `json:"caption,omitempty"`
+}
+
+type graphMessageResponse struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+}
+
+func (c *cloudAPIBackend) sendMessageID(ctx context.Context, req graphMessageRequest) (string, error) {
+	var resp graphMessageResponse
+	if err := c.http.do(ctx, http.MethodPost, "/"+c.phoneNumberID+"/messages", req, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Messages) == 0 {
+		return "", fmt.Errorf("whatsapp: cloud API returned no message id")
+	}
+	return resp.Messages[0].ID, nil
+}
+
+func (c *cloudAPIBackend) SendText(ctx context.Context, req WhatsAppTextRequest) (*WhatsAppMessage, error) {
+	to := req.To
+
+	id, err := c.sendMessageID(ctx, graphMessageRequest{
+		MessagingProduct: "whatsapp",
+		To:               to,
+		Type:             "text",
+		Text:             &graphTextPayload{Body: req.Text},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &WhatsAppMessage{
+		ID:        id,
+		To:        to,
+		Type:      "text",
+		Text:      req.Text,
+		Status:    StatusSent,
+		Direction: "outbound",
+		Provider:  WhatsAppCloudAPI,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (c *cloudAPIBackend) SendTemplate(ctx context.Context, req WhatsAppTemplateRequest) (*WhatsAppMessage, error) {
+	to := req.To
+	language := req.Language
+	if language == "" {
+		language = "en_US"
+	}
+
+	id, err := c.sendMessageID(ctx, graphMessageRequest{
+		MessagingProduct: "whatsapp",
+		To:               to,
+		Type:             "template",
+		Template: &graphTemplatePayload{
+			Name:     req.TemplateName,
+			Language: graphTemplateLanguage{Code: language},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &WhatsAppMessage{
+		ID:             id,
+		To:             to,
+		Type:           "template",
+		TemplateName:   req.TemplateName,
+		TemplateParams: req.TemplateParams,
+		Status:         StatusSent,
+		Direction:      "outbound",
+		Provider:       WhatsAppCloudAPI,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}, nil
+}
+
+func (c *cloudAPIBackend) SendMedia(ctx context.Context, req WhatsAppMediaRequest) (*WhatsAppMessage, error) {
+	to := req.To
+	payload := &graphMediaPayload{Link: req.MediaURL, Caption: req.Caption}
+
+	graphReq := graphMessageRequest{
+		MessagingProduct: "whatsapp",
+		To:               to,
+		Type:             req.Type,
+	}
+	switch req.Type {
+	case "image":
+		graphReq.Image = payload
+	case "document":
+		graphReq.Document = payload
+	case "audio":
+		graphReq.Audio = payload
+	case "video":
+		graphReq.Video = payload
+	default:
+		return nil, fmt.Errorf("whatsapp: unsupported media type %q", req.Type)
+	}
+
+	id, err := c.sendMessageID(ctx, graphReq)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WhatsAppMessage{
+		ID:        id,
+		To:        to,
+		Type:      req.Type,
+		MediaURL:  req.MediaURL,
+		Status:    StatusSent,
+		Direction: "outbound",
+		Provider:  WhatsAppCloudAPI,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (c *cloudAPIBackend) GetStatus(ctx context.Context, messageID string) (*WhatsAppMessage, error) {
+	var resp struct {
+		Status string `json:"status"`
+	}
+	if err := c.http.do(ctx, http.MethodGet, "/"+messageID, nil, &resp); err != nil {
+		return nil, err
+	}
+
+	return &WhatsAppMessage{
+		ID:        messageID,
+		Status:    graphStatusToMessageStatus(resp.Status),
+		Direction: "outbound",
+		Provider:  WhatsAppCloudAPI,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (c *cloudAPIBackend) MarkRead(ctx context.Context, messageID string) error {
+	return c.http.do(ctx, http.MethodPost, "/"+c.phoneNumberID+"/messages", graphMessageRequest{
+		MessagingProduct: "whatsapp",
+		Status:           "read",
+		MessageID:        messageID,
+	}, nil)
+}
+
+func (c *cloudAPIBackend) DownloadMedia(ctx context.Context, mediaID string) ([]byte, string, error) {
+	var meta struct {
+		URL      string `json:"url"`
+		MimeType string `json:"mime_type"`
+	}
+	if err := c.http.do(ctx, http.MethodGet, "/"+mediaID, nil, &meta); err != nil {
+		return nil, "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, meta.URL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("whatsapp: failed to build media download request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.http.accessToken)
+
+	resp, err := c.http.httpClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("whatsapp: media download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("whatsapp: failed to read media download: %w", err)
+	}
+
+	return data, meta.MimeType, nil
+}
+
+func graphStatusToMessageStatus(status string) MessageStatus {
+	switch status {
+	case "sent":
+		return StatusSent
+	case "delivered":
+		return StatusDelivered
+	case "read":
+		return StatusRead
+	case "failed":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
@@ -0,0 +1,33 @@
+package messaging
+
+import "fmt"
+
+// WhatsAppError wraps an error returned by the Meta Graph API (or an
+// on-premise deployment's compatible error shape), preserving the fields
+// needed to act on specific failures (e.g. Code 131056 = rate limited,
+// Code 190 = expired access token) and to hand Meta support an FBTraceID.
+type WhatsAppError struct {
+	Code      int    `json:"code"`
+	Subcode   int    `json:"error_subcode,omitempty"`
+	Message   string `json:"message"`
+	FBTraceID string `json:"fbtrace_id,omitempty"`
+}
+
+func (e *WhatsAppError) Error() string {
+	if e.Subcode != 0 {
+		return fmt.Sprintf("whatsapp: %s (code %d, subcode %d, fbtrace_id %s)", e.Message, e.Code, e.Subcode, e.FBTraceID)
+	}
+	return fmt.Sprintf("whatsapp: %s (code %d, fbtrace_id %s)", e.Message, e.Code, e.FBTraceID)
+}
+
+// graphErrorEnvelope matches the Graph API's `{"error": {...}}` response
+// shape for non-2xx responses.
+type graphErrorEnvelope struct {
+	Error struct {
+		Message   string `json:"message"`
+		Type      string `json:"type"`
+		Code      int    `json:"code"`
+		Subcode   int    `json:"error_subcode"`
+		FBTraceID string `json:"fbtrace_id"`
+	} `json:"error"`
+}
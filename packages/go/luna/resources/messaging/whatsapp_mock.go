@@ -0,0 +1,103 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MockBackend is a whatsAppBackend that fabricates responses without
+// touching the network, matching what every Send* method did before real
+// HTTP calls were added. Use it via WhatsAppConfig{Provider: WhatsAppMock}
+// in tests.
+type MockBackend struct {
+	mu       sync.Mutex
+	Sent     []WhatsAppMessage
+	Statuses map[string]MessageStatus
+}
+
+// NewMockBackend creates an empty MockBackend.
+func NewMockBackend() *MockBackend {
+	return &MockBackend{Statuses: make(map[string]MessageStatus)}
+}
+
+func (m *MockBackend) record(msg WhatsAppMessage) *WhatsAppMessage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Sent = append(m.Sent, msg)
+	m.Statuses[msg.ID] = msg.Status
+	return &msg
+}
+
+func (m *MockBackend) SendText(ctx context.Context, req WhatsAppTextRequest) (*WhatsAppMessage, error) {
+	return m.record(WhatsAppMessage{
+		ID:        fmt.Sprintf("wamock_%d", time.Now().UnixNano()),
+		To:        req.To,
+		Type:      "text",
+		Text:      req.Text,
+		Status:    StatusSent,
+		Direction: "outbound",
+		Provider:  WhatsAppMock,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}), nil
+}
+
+func (m *MockBackend) SendTemplate(ctx context.Context, req WhatsAppTemplateRequest) (*WhatsAppMessage, error) {
+	return m.record(WhatsAppMessage{
+		ID:             fmt.Sprintf("wamock_%d", time.Now().UnixNano()),
+		To:             req.To,
+		Type:           "template",
+		TemplateName:   req.TemplateName,
+		TemplateParams: req.TemplateParams,
+		Status:         StatusSent,
+		Direction:      "outbound",
+		Provider:       WhatsAppMock,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}), nil
+}
+
+func (m *MockBackend) SendMedia(ctx context.Context, req WhatsAppMediaRequest) (*WhatsAppMessage, error) {
+	return m.record(WhatsAppMessage{
+		ID:        fmt.Sprintf("wamock_%d", time.Now().UnixNano()),
+		To:        req.To,
+		Type:      req.Type,
+		MediaURL:  req.MediaURL,
+		Status:    StatusSent,
+		Direction: "outbound",
+		Provider:  WhatsAppMock,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}), nil
+}
+
+func (m *MockBackend) GetStatus(ctx context.Context, messageID string) (*WhatsAppMessage, error) {
+	m.mu.Lock()
+	status, ok := m.Statuses[messageID]
+	m.mu.Unlock()
+	if !ok {
+		status = StatusDelivered
+	}
+	return &WhatsAppMessage{
+		ID:        messageID,
+		Status:    status,
+		Direction: "outbound",
+		Provider:  WhatsAppMock,
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (m *MockBackend) MarkRead(ctx context.Context, messageID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Statuses[messageID] = StatusRead
+	return nil
+}
+
+func (m *MockBackend) DownloadMedia(ctx context.Context, mediaID string) ([]byte, string, error) {
+	return []byte("mock-media-" + mediaID), "application/octet-stream", nil
+}
+
+var _ whatsAppBackend = (*MockBackend)(nil)
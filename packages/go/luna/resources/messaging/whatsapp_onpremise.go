@@ -0,0 +1,137 @@
+package messaging
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// onPremiseBackend talks to a self-hosted WhatsApp Business API deployment.
+// It speaks the same message envelope as the Cloud API but is addressed by
+// BaseURL rather than graph.facebook.com, and has no phone_number_id path
+// segment (on-premise deployments are already scoped to one number).
+type onPremiseBackend struct {
+	http *graphHTTPClient
+}
+
+func newOnPremiseBackend(config WhatsAppConfig) *onPremiseBackend {
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://localhost:9090"
+	}
+
+	accessToken := config.AccessToken
+	if accessToken == "" {
+		accessToken = config.APIKey
+	}
+
+	return &onPremiseBackend{http: newGraphHTTPClient(baseURL, accessToken)}
+}
+
+func (o *onPremiseBackend) sendMessageID(ctx context.Context, req graphMessageRequest) (string, error) {
+	var resp graphMessageResponse
+	if err := o.http.do(ctx, http.MethodPost, "/v1/messages", req, &resp); err != nil {
+		return "", err
+	}
+	if len(resp.Messages) == 0 {
+		return "", &WhatsAppError{Message: "on-premise API returned no message id"}
+	}
+	return resp.Messages[0].ID, nil
+}
+
+func (o *onPremiseBackend) SendText(ctx context.Context, req WhatsAppTextRequest) (*WhatsAppMessage, error) {
+	to := req.To
+	id, err := o.sendMessageID(ctx, graphMessageRequest{
+		MessagingProduct: "whatsapp",
+		To:               to,
+		Type:             "text",
+		Text:             &graphTextPayload{Body: req.Text},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WhatsAppMessage{
+		ID: id, To: to, Type: "text", Text: req.Text,
+		Status: StatusSent, Direction: "outbound", Provider: WhatsAppOnPremise,
+		CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (o *onPremiseBackend) SendTemplate(ctx context.Context, req WhatsAppTemplateRequest) (*WhatsAppMessage, error) {
+	to := req.To
+	language := req.Language
+	if language == "" {
+		language = "en_US"
+	}
+
+	id, err := o.sendMessageID(ctx, graphMessageRequest{
+		MessagingProduct: "whatsapp",
+		To:               to,
+		Type:             "template",
+		Template:         &graphTemplatePayload{Name: req.TemplateName, Language: graphTemplateLanguage{Code: language}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &WhatsAppMessage{
+		ID: id, To: to, Type: "template", TemplateName: req.TemplateName, TemplateParams: req.TemplateParams,
+		Status: StatusSent, Direction: "outbound", Provider: WhatsAppOnPremise,
+		CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (o *onPremiseBackend) SendMedia(ctx context.Context, req WhatsAppMediaRequest) (*WhatsAppMessage, error) {
+	to := req.To
+	payload := &graphMediaPayload{Link: req.MediaURL, Caption: req.Caption}
+	graphReq := graphMessageRequest{MessagingProduct: "whatsapp", To: to, Type: req.Type}
+	switch req.Type {
+	case "image":
+		graphReq.Image = payload
+	case "document":
+		graphReq.Document = payload
+	case "audio":
+		graphReq.Audio = payload
+	case "video":
+		graphReq.Video = payload
+	default:
+		return nil, &WhatsAppError{Message: "unsupported media type " + req.Type}
+	}
+
+	id, err := o.sendMessageID(ctx, graphReq)
+	if err != nil {
+		return nil, err
+	}
+	return &WhatsAppMessage{
+		ID: id, To: to, Type: req.Type, MediaURL: req.MediaURL,
+		Status: StatusSent, Direction: "outbound", Provider: WhatsAppOnPremise,
+		CreatedAt: time.Now(), UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (o *onPremiseBackend) GetStatus(ctx context.Context, messageID string) (*WhatsAppMessage, error) {
+	var resp struct {
+		Status string `json:"status"`
+	}
+	if err := o.http.do(ctx, http.MethodGet, "/v1/messages/"+messageID, nil, &resp); err != nil {
+		return nil, err
+	}
+	return &WhatsAppMessage{
+		ID: messageID, Status: graphStatusToMessageStatus(resp.Status),
+		Direction: "outbound", Provider: WhatsAppOnPremise, UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (o *onPremiseBackend) MarkRead(ctx context.Context, messageID string) error {
+	return o.http.do(ctx, http.MethodPut, "/v1/messages/"+messageID, map[string]string{"status": "read"}, nil)
+}
+
+func (o *onPremiseBackend) DownloadMedia(ctx context.Context, mediaID string) ([]byte, string, error) {
+	var resp struct {
+		Data     []byte `json:"data"`
+		MimeType string `json:"mime_type"`
+	}
+	if err := o.http.do(ctx, http.MethodGet, "/v1/media/"+mediaID, nil, &resp); err != nil {
+		return nil, "", err
+	}
+	return resp.Data, resp.MimeType, nil
+}
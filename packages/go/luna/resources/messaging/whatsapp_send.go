@@ -0,0 +1,91 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+)
+
+// WhatsAppSendRequest is implemented by WhatsAppTextRequest,
+// WhatsAppMediaRequest, and WhatsAppTemplateRequest -- the three requests
+// WhatsApp.Send accepts.
+type WhatsAppSendRequest interface {
+	whatsAppRecipient() string
+}
+
+func (r WhatsAppTextRequest) whatsAppRecipient() string     { return r.To }
+func (r WhatsAppMediaRequest) whatsAppRecipient() string    { return r.To }
+func (r WhatsAppTemplateRequest) whatsAppRecipient() string { return r.To }
+
+// Send routes req to SendText/SendMedia/SendTemplate, enforcing Meta's
+// 24-hour customer service window: a WhatsAppTextRequest or
+// WhatsAppMediaRequest is only allowed while the recipient is inside the
+// window (they messaged in the last 24h); outside it, only a
+// WhatsAppTemplateRequest -- an approved, pre-categorized template -- may
+// be sent. The returned WhatsAppMessage's Metadata["billing_category"]
+// records which of Meta's conversation categories the send falls into.
+func (w *WhatsApp) Send(ctx context.Context, req WhatsAppSendRequest) (*WhatsAppMessage, error) {
+	switch r := req.(type) {
+	case WhatsAppTemplateRequest:
+		msg, err := w.SendTemplate(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		category := r.Category
+		if category == "" {
+			category = "marketing"
+		}
+		setBillingCategory(msg, category)
+		return msg, nil
+
+	case WhatsAppTextRequest:
+		if err := w.requireWindow(r.To); err != nil {
+			return nil, err
+		}
+		msg, err := w.SendText(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		setBillingCategory(msg, "service")
+		return msg, nil
+
+	case WhatsAppMediaRequest:
+		if err := w.requireWindow(r.To); err != nil {
+			return nil, err
+		}
+		msg, err := w.SendMedia(ctx, r)
+		if err != nil {
+			return nil, err
+		}
+		setBillingCategory(msg, "service")
+		return msg, nil
+
+	default:
+		return nil, fmt.Errorf("whatsapp: unsupported send request type %T", req)
+	}
+}
+
+// requireWindow returns an error if phone is outside its 24-hour customer
+// service window, naming the template requirement so the caller knows how
+// to proceed. phone is normalized first since the window is always recorded
+// under the E.164 form ProcessWebhook/WhatsAppWebhook parse inbound senders
+// into, which may not match whatever format the caller passed in.
+func (w *WhatsApp) requireWindow(phone string) error {
+	normalized, err := w.phones.Normalize(phone)
+	if err != nil {
+		return err
+	}
+	if inWindow, _ := w.window.InWindow(normalized); !inWindow {
+		return fmt.Errorf("whatsapp: %s is outside the 24h customer service window; send a WhatsAppTemplateRequest instead", normalized)
+	}
+	return nil
+}
+
+func setBillingCategory(msg *WhatsAppMessage, category string) {
+	if msg == nil {
+		return
+	}
+	if msg.Metadata == nil {
+		msg.Metadata = map[string]interface{}{}
+	}
+	msg.Metadata["billing_category"] = category
+}
@@ -0,0 +1,212 @@
+package messaging
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+)
+
+// WhatsAppWebhookConfig configures a WhatsAppWebhook.
+type WhatsAppWebhookConfig struct {
+	// Provider selects which payload shape ServeHTTP expects: WhatsAppCloudAPI
+	// and WhatsAppOnPremise share the Graph API envelope; WhatsAppClickatell,
+	// WhatsAppWati, and WhatsAppInfobip each have their own. Defaults to
+	// WhatsAppCloudAPI.
+	Provider WhatsAppProvider
+	// VerifyToken is compared against hub.verify_token on the GET handshake
+	// Meta performs when a webhook URL is first configured. Required for
+	// WhatsAppCloudAPI/WhatsAppOnPremise; the other providers don't use the
+	// Cloud API verification handshake and ignore it.
+	VerifyToken string
+	// AppSecret signs inbound POST deliveries; when set, ServeHTTP verifies
+	// the X-Hub-Signature-256 header (Cloud API/on-premise) before dispatch.
+	// Providers without a signing scheme ignore it.
+	AppSecret string
+	// Window, when set, has every inbound message dispatched here record its
+	// sender's 24-hour customer service window, so a WhatsApp built with the
+	// same ConversationWindow can gate WhatsApp.Send off webhook deliveries
+	// instead of only off WhatsApp.ProcessWebhook's legacy polling path.
+	Window *ConversationWindow
+}
+
+// WhatsAppWebhook is an http.Handler for a WhatsApp inbound-webhook
+// endpoint. Unlike WhatsApp.ProcessWebhook, which expects an
+// already-decoded Cloud API payload, WhatsAppWebhook owns the full HTTP
+// contract -- the Cloud API GET verification handshake, POST signature
+// verification, and normalizing whichever of Cloud API/on-premise/
+// Clickatell/Wati/Infobip's payload shapes config.Provider selects into
+// WhatsAppMessage -- before dispatching to registered listeners.
+type WhatsAppWebhook struct {
+	config     WhatsAppWebhookConfig
+	normalizer whatsAppWebhookNormalizer
+
+	onMessage              []func(WhatsAppMessage)
+	onStatus               []func(id string, status MessageStatus, meta StatusMeta)
+	onTemplateStatusUpdate []func(WhatsAppTemplateStatusUpdate)
+}
+
+// NewWhatsAppWebhook creates a WhatsAppWebhook for config.Provider.
+func NewWhatsAppWebhook(config WhatsAppWebhookConfig) *WhatsAppWebhook {
+	return &WhatsAppWebhook{
+		config:     config,
+		normalizer: newWhatsAppWebhookNormalizer(config.Provider),
+	}
+}
+
+// OnMessage registers fn to be called for every inbound message this
+// WhatsAppWebhook dispatches.
+func (h *WhatsAppWebhook) OnMessage(fn func(WhatsAppMessage)) {
+	h.onMessage = append(h.onMessage, fn)
+}
+
+// OnStatus registers fn to be called for every delivery status update
+// (sent/delivered/read/failed) this WhatsAppWebhook dispatches.
+func (h *WhatsAppWebhook) OnStatus(fn func(id string, status MessageStatus, meta StatusMeta)) {
+	h.onStatus = append(h.onStatus, fn)
+}
+
+// OnTemplateStatusUpdate registers fn to be called for every message
+// template review outcome (approved/rejected/disabled/...) this
+// WhatsAppWebhook dispatches.
+func (h *WhatsAppWebhook) OnTemplateStatusUpdate(fn func(WhatsAppTemplateStatusUpdate)) {
+	h.onTemplateStatusUpdate = append(h.onTemplateStatusUpdate, fn)
+}
+
+// ServeHTTP implements http.Handler. GET requests are handled as the Cloud
+// API's subscription verification handshake; POST requests are verified
+// (when config.AppSecret is set) and dispatched to registered listeners.
+func (h *WhatsAppWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		h.serveVerification(w, r)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if h.usesHubSignature() && h.config.AppSecret != "" {
+		if !verifyWhatsAppSignature(h.config.AppSecret, r.Header.Get("X-Hub-Signature-256"), body) {
+			http.Error(w, "webhook verification failed", http.StatusForbidden)
+			return
+		}
+	}
+
+	result, err := h.normalizer.Normalize(body)
+	if err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	h.dispatch(result)
+	w.WriteHeader(http.StatusOK)
+}
+
+// usesHubSignature reports whether config.Provider signs deliveries with
+// X-Hub-Signature-256, the Cloud API/on-premise convention. Clickatell,
+// Wati, and Infobip authenticate their webhook endpoints some other way
+// (a URL token, a separate header, IP allowlisting) and never send this
+// header, so ServeHTTP must not require it from them even if AppSecret
+// happens to be set.
+func (h *WhatsAppWebhook) usesHubSignature() bool {
+	switch h.config.Provider {
+	case WhatsAppClickatell, WhatsAppWati, WhatsAppInfobip:
+		return false
+	default:
+		return true
+	}
+}
+
+// serveVerification handles the Cloud API's GET subscription handshake:
+// a hub.mode=subscribe request carrying the correct hub.verify_token gets
+// hub.challenge echoed back verbatim; anything else is rejected.
+func (h *WhatsAppWebhook) serveVerification(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("hub.mode") != "subscribe" || query.Get("hub.verify_token") != h.config.VerifyToken {
+		http.Error(w, "verification failed", http.StatusForbidden)
+		return
+	}
+	w.Write([]byte(query.Get("hub.challenge")))
+}
+
+// dispatch runs every registered listener over a normalized payload. Unlike
+// webhooks.Handler.dispatch, a listener error doesn't abort dispatch or
+// fail the delivery -- WhatsApp retries deliveries on non-2xx responses,
+// and a transient listener failure shouldn't cause Meta to redeliver a
+// whole batch of otherwise-successfully-processed messages.
+func (h *WhatsAppWebhook) dispatch(result whatsAppWebhookNormalizeResult) {
+	for _, msg := range result.Messages {
+		if h.config.Window != nil && msg.From != "" {
+			h.config.Window.RecordInbound(msg.From)
+		}
+		for _, fn := range h.onMessage {
+			fn(msg)
+		}
+	}
+	for _, status := range result.Statuses {
+		for _, fn := range h.onStatus {
+			fn(status.ID, status.Status, status.Meta)
+		}
+	}
+	for _, update := range result.TemplateStatusUpdates {
+		for _, fn := range h.onTemplateStatusUpdate {
+			fn(update)
+		}
+	}
+}
+
+// verifyWhatsAppSignature reports whether signature (the raw
+// X-Hub-Signature-256 header value, "sha256=<hex>") matches the
+// HMAC-SHA256 of body keyed by appSecret.
+func verifyWhatsAppSignature(appSecret, signature string, body []byte) bool {
+	if signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// whatsAppStatusUpdate is one normalized entry of
+// whatsAppWebhookNormalizeResult.Statuses.
+type whatsAppStatusUpdate struct {
+	ID     string
+	Status MessageStatus
+	Meta   StatusMeta
+}
+
+// whatsAppWebhookNormalizeResult is the provider-agnostic shape every
+// whatsAppWebhookNormalizer parses a raw webhook body into.
+type whatsAppWebhookNormalizeResult struct {
+	Messages              []WhatsAppMessage
+	Statuses              []whatsAppStatusUpdate
+	TemplateStatusUpdates []WhatsAppTemplateStatusUpdate
+}
+
+// whatsAppWebhookNormalizer parses one provider's raw webhook body into the
+// SDK's provider-agnostic message/status/template-status types.
+type whatsAppWebhookNormalizer interface {
+	Normalize(body []byte) (whatsAppWebhookNormalizeResult, error)
+}
+
+// newWhatsAppWebhookNormalizer selects a whatsAppWebhookNormalizer from
+// provider, mirroring newWhatsAppBackend's provider switch.
+func newWhatsAppWebhookNormalizer(provider WhatsAppProvider) whatsAppWebhookNormalizer {
+	switch provider {
+	case WhatsAppClickatell:
+		return &clickatellWebhookNormalizer{}
+	case WhatsAppWati:
+		return &watiWebhookNormalizer{}
+	case WhatsAppInfobip:
+		return &infobipWebhookNormalizer{}
+	default:
+		return &cloudAPIWebhookNormalizer{}
+	}
+}
+
+var _ http.Handler = (*WhatsAppWebhook)(nil)
@@ -0,0 +1,62 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// clickatellWebhookNormalizer normalizes Clickatell's WhatsApp delivery
+// webhook, a flatter shape than the Cloud API's: a JSON body carrying
+// "messages" and/or "statuses" arrays, each entry keyed the same way as
+// Clickatell's SMS webhooks (see clickatellBackend.ParseInboundWebhook).
+type clickatellWebhookNormalizer struct{}
+
+type clickatellWhatsAppWebhookPayload struct {
+	Messages []struct {
+		MessageID string `json:"messageId"`
+		From      string `json:"from"`
+		To        string `json:"to"`
+		Type      string `json:"type"`
+		Text      string `json:"text,omitempty"`
+	} `json:"messages,omitempty"`
+	Statuses []struct {
+		MessageID string `json:"messageId"`
+		Status    string `json:"status"`
+	} `json:"statuses,omitempty"`
+}
+
+func (n *clickatellWebhookNormalizer) Normalize(body []byte) (whatsAppWebhookNormalizeResult, error) {
+	var payload clickatellWhatsAppWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return whatsAppWebhookNormalizeResult{}, fmt.Errorf("whatsapp: failed to decode clickatell webhook: %w", err)
+	}
+
+	var result whatsAppWebhookNormalizeResult
+
+	for _, msg := range payload.Messages {
+		result.Messages = append(result.Messages, WhatsAppMessage{
+			ID:        msg.MessageID,
+			To:        msg.To,
+			From:      msg.From,
+			Type:      msg.Type,
+			Text:      msg.Text,
+			Status:    StatusDelivered,
+			Direction: "inbound",
+			Provider:  WhatsAppClickatell,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+
+	for _, status := range payload.Statuses {
+		result.Statuses = append(result.Statuses, whatsAppStatusUpdate{
+			ID:     status.MessageID,
+			Status: clickatellStatusToMessageStatus(status.Status),
+		})
+	}
+
+	return result, nil
+}
+
+var _ whatsAppWebhookNormalizer = (*clickatellWebhookNormalizer)(nil)
@@ -0,0 +1,133 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// cloudAPIWebhookNormalizer normalizes the entry[].changes[].value envelope
+// shared by the Cloud API and on-premise providers. It reuses the same
+// contactDisplayNames/parseInteractiveReply/jsonString/whatsAppStatusMap
+// helpers WhatsApp.ProcessWebhook already relies on, extended to also
+// surface each status update's conversation/pricing metadata and the
+// message_template_status_update field ProcessWebhook doesn't parse.
+type cloudAPIWebhookNormalizer struct{}
+
+func (n *cloudAPIWebhookNormalizer) Normalize(body []byte) (whatsAppWebhookNormalizeResult, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return whatsAppWebhookNormalizeResult{}, fmt.Errorf("whatsapp: failed to decode cloud api webhook: %w", err)
+	}
+
+	var result whatsAppWebhookNormalizeResult
+
+	entries, _ := payload["entry"].([]interface{})
+	for _, entry := range entries {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		changes, _ := entryMap["changes"].([]interface{})
+		for _, change := range changes {
+			changeMap, ok := change.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			value, ok := changeMap["value"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			contactNames := contactDisplayNames(value)
+
+			if msgs, ok := value["messages"].([]interface{}); ok {
+				for _, msg := range msgs {
+					if msgMap, ok := msg.(map[string]interface{}); ok {
+						result.Messages = append(result.Messages, parseGraphInboundMessage(msgMap, contactNames, WhatsAppCloudAPI))
+					}
+				}
+			}
+
+			if statuses, ok := value["statuses"].([]interface{}); ok {
+				for _, status := range statuses {
+					if statusMap, ok := status.(map[string]interface{}); ok {
+						result.Statuses = append(result.Statuses, cloudAPIParseStatusUpdate(statusMap))
+					}
+				}
+			}
+
+			if update, ok := value["message_template_status_update"].(map[string]interface{}); ok {
+				result.TemplateStatusUpdates = append(result.TemplateStatusUpdates, WhatsAppTemplateStatusUpdate{
+					TemplateID:       jsonString(update, "message_template_id"),
+					TemplateName:     jsonString(update, "message_template_name"),
+					TemplateLanguage: jsonString(update, "message_template_language"),
+					Event:            jsonString(update, "event"),
+					Reason:           jsonString(update, "reason"),
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// cloudAPIParseStatusUpdate parses one entry of a statuses[] delivery, to
+// include the conversation/pricing metadata ProcessWebhook's equivalent
+// parseStatusUpdate doesn't surface. Account-level errors reported outside
+// any specific status entry (value.errors, rather than
+// statuses[].errors) aren't represented here -- there's no message ID to
+// attach them to.
+func cloudAPIParseStatusUpdate(statusMap map[string]interface{}) whatsAppStatusUpdate {
+	status := StatusPending
+	if s, ok := whatsAppStatusMap[jsonString(statusMap, "status")]; ok {
+		status = s
+	}
+
+	var meta StatusMeta
+
+	if conversation, ok := statusMap["conversation"].(map[string]interface{}); ok {
+		meta.ConversationID = jsonString(conversation, "id")
+		if origin, ok := conversation["origin"].(map[string]interface{}); ok {
+			meta.ConversationCategory = jsonString(origin, "type")
+		}
+		if expiry := jsonString(conversation, "expiration_timestamp"); expiry != "" {
+			if seconds, err := parseUnixSeconds(expiry); err == nil {
+				meta.ConversationExpiry = &seconds
+			}
+		}
+	}
+
+	if pricing, ok := statusMap["pricing"].(map[string]interface{}); ok {
+		meta.PricingModel = jsonString(pricing, "pricing_model")
+		meta.PricingCategory = jsonString(pricing, "category")
+		meta.Billable, _ = pricing["billable"].(bool)
+	}
+
+	if errs, ok := statusMap["errors"].([]interface{}); ok && len(errs) > 0 {
+		if errMap, ok := errs[0].(map[string]interface{}); ok {
+			meta.Error = jsonString(errMap, "message")
+		}
+	}
+
+	return whatsAppStatusUpdate{
+		ID:     jsonString(statusMap, "id"),
+		Status: status,
+		Meta:   meta,
+	}
+}
+
+// parseUnixSeconds parses a Unix epoch-seconds string, the form Cloud API
+// sends expiration_timestamp in.
+func parseUnixSeconds(s string) (time.Time, error) {
+	seconds, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+var _ whatsAppWebhookNormalizer = (*cloudAPIWebhookNormalizer)(nil)
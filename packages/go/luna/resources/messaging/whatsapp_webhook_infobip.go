@@ -0,0 +1,90 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// infobipWebhookNormalizer normalizes an Infobip WhatsApp webhook delivery:
+// a "results" array where each entry is either an inbound message (carrying
+// a "message" object) or a delivery report (carrying a "status" object).
+type infobipWebhookNormalizer struct{}
+
+type infobipWebhookPayload struct {
+	Results []struct {
+		MessageID string `json:"messageId"`
+		From      string `json:"from,omitempty"`
+		To        string `json:"to,omitempty"`
+		Message   *struct {
+			Type string `json:"type"`
+			Text string `json:"text,omitempty"`
+		} `json:"message,omitempty"`
+		Status *struct {
+			GroupName string `json:"groupName"`
+		} `json:"status,omitempty"`
+		Price *struct {
+			PricePerMessage float64 `json:"pricePerMessage"`
+			Currency        string  `json:"currency"`
+		} `json:"price,omitempty"`
+	} `json:"results"`
+}
+
+func (n *infobipWebhookNormalizer) Normalize(body []byte) (whatsAppWebhookNormalizeResult, error) {
+	var payload infobipWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return whatsAppWebhookNormalizeResult{}, fmt.Errorf("whatsapp: failed to decode infobip webhook: %w", err)
+	}
+
+	var result whatsAppWebhookNormalizeResult
+
+	for _, r := range payload.Results {
+		switch {
+		case r.Message != nil:
+			result.Messages = append(result.Messages, WhatsAppMessage{
+				ID:        r.MessageID,
+				From:      r.From,
+				To:        r.To,
+				Type:      r.Message.Type,
+				Text:      r.Message.Text,
+				Status:    StatusDelivered,
+				Direction: "inbound",
+				Provider:  WhatsAppInfobip,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			})
+		case r.Status != nil:
+			var meta StatusMeta
+			if r.Price != nil {
+				// Infobip's delivery report carries a price, not a pricing
+				// category (Cloud API's business_initiated/user_initiated
+				// concept doesn't exist in Infobip's model).
+				meta.Billable = r.Price.PricePerMessage > 0
+			}
+			result.Statuses = append(result.Statuses, whatsAppStatusUpdate{
+				ID:     r.MessageID,
+				Status: infobipStatusToMessageStatus(r.Status.GroupName),
+				Meta:   meta,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+func infobipStatusToMessageStatus(groupName string) MessageStatus {
+	switch groupName {
+	case "PENDING", "PENDING_ENROUTE":
+		return StatusSent
+	case "DELIVERED":
+		return StatusDelivered
+	case "READ", "SEEN":
+		return StatusRead
+	case "REJECTED", "UNDELIVERABLE", "EXPIRED":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+var _ whatsAppWebhookNormalizer = (*infobipWebhookNormalizer)(nil)
@@ -0,0 +1,158 @@
+package messaging_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/messaging"
+)
+
+func signWhatsAppBody(appSecret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(appSecret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWhatsAppWebhookVerification(t *testing.T) {
+	hook := messaging.NewWhatsAppWebhook(messaging.WhatsAppWebhookConfig{VerifyToken: "verify-me"})
+
+	t.Run("echoes the challenge for a matching verify token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/webhook?hub.mode=subscribe&hub.verify_token=verify-me&hub.challenge=12345", nil)
+		w := httptest.NewRecorder()
+		hook.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+		if w.Body.String() != "12345" {
+			t.Errorf("expected challenge 12345, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("rejects a mismatched verify token", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/webhook?hub.mode=subscribe&hub.verify_token=wrong&hub.challenge=12345", nil)
+		w := httptest.NewRecorder()
+		hook.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", w.Code)
+		}
+	})
+}
+
+func TestWhatsAppWebhookSignatureVerification(t *testing.T) {
+	hook := messaging.NewWhatsAppWebhook(messaging.WhatsAppWebhookConfig{AppSecret: "shh"})
+
+	body := []byte(`{"entry":[]}`)
+
+	t.Run("rejects a missing signature", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(string(body)))
+		w := httptest.NewRecorder()
+		hook.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Fatalf("expected 403, got %d", w.Code)
+		}
+	})
+
+	t.Run("accepts a correctly signed payload", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(string(body)))
+		req.Header.Set("X-Hub-Signature-256", signWhatsAppBody("shh", body))
+		w := httptest.NewRecorder()
+		hook.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", w.Code)
+		}
+	})
+}
+
+func TestWhatsAppWebhookCloudAPIDispatch(t *testing.T) {
+	hook := messaging.NewWhatsAppWebhook(messaging.WhatsAppWebhookConfig{Provider: messaging.WhatsAppCloudAPI})
+
+	var gotMessages []messaging.WhatsAppMessage
+	var gotStatuses []struct {
+		ID     string
+		Status messaging.MessageStatus
+		Meta   messaging.StatusMeta
+	}
+	hook.OnMessage(func(msg messaging.WhatsAppMessage) {
+		gotMessages = append(gotMessages, msg)
+	})
+	hook.OnStatus(func(id string, status messaging.MessageStatus, meta messaging.StatusMeta) {
+		gotStatuses = append(gotStatuses, struct {
+			ID     string
+			Status messaging.MessageStatus
+			Meta   messaging.StatusMeta
+		}{id, status, meta})
+	})
+
+	body := `{
+		"entry": [{
+			"changes": [{
+				"value": {
+					"messages": [{"id": "wamid.1", "from": "27821234567", "type": "text", "text": {"body": "hi"}}],
+					"statuses": [{
+						"id": "wamid.2",
+						"status": "delivered",
+						"recipient_id": "27821234567",
+						"conversation": {"id": "conv-1", "origin": {"type": "service"}},
+						"pricing": {"pricing_model": "CBP", "category": "service", "billable": false}
+					}]
+				}
+			}]
+		}]
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	hook.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if len(gotMessages) != 1 || gotMessages[0].Text != "hi" {
+		t.Fatalf("expected one text message 'hi', got %v", gotMessages)
+	}
+	if len(gotStatuses) != 1 {
+		t.Fatalf("expected one status update, got %d", len(gotStatuses))
+	}
+	if gotStatuses[0].Status != messaging.StatusDelivered {
+		t.Errorf("expected status delivered, got %s", gotStatuses[0].Status)
+	}
+	if gotStatuses[0].Meta.ConversationID != "conv-1" {
+		t.Errorf("expected conversation ID conv-1, got %s", gotStatuses[0].Meta.ConversationID)
+	}
+	if gotStatuses[0].Meta.PricingCategory != "service" {
+		t.Errorf("expected pricing category service, got %s", gotStatuses[0].Meta.PricingCategory)
+	}
+}
+
+func TestWhatsAppWebhookClickatellDispatch(t *testing.T) {
+	hook := messaging.NewWhatsAppWebhook(messaging.WhatsAppWebhookConfig{Provider: messaging.WhatsAppClickatell})
+
+	var gotMessages []messaging.WhatsAppMessage
+	hook.OnMessage(func(msg messaging.WhatsAppMessage) {
+		gotMessages = append(gotMessages, msg)
+	})
+
+	body := `{"messages":[{"messageId":"ck_1","from":"27821234567","to":"27001234567","type":"text","text":"hi there"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	hook.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if len(gotMessages) != 1 || gotMessages[0].Text != "hi there" {
+		t.Fatalf("expected one message 'hi there', got %v", gotMessages)
+	}
+	if gotMessages[0].Provider != messaging.WhatsAppClickatell {
+		t.Errorf("expected provider clickatell, got %s", gotMessages[0].Provider)
+	}
+}
@@ -0,0 +1,71 @@
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// watiWebhookNormalizer normalizes a Wati webhook delivery: one event per
+// request, distinguished by eventType ("message" for an inbound message,
+// "status" for a delivery status change).
+type watiWebhookNormalizer struct{}
+
+type watiWebhookPayload struct {
+	ID         string `json:"id"`
+	EventType  string `json:"eventType"`
+	WaID       string `json:"waId"`
+	SenderName string `json:"senderName,omitempty"`
+	Type       string `json:"type,omitempty"`
+	Text       string `json:"text,omitempty"`
+	Status     string `json:"status,omitempty"`
+}
+
+func (n *watiWebhookNormalizer) Normalize(body []byte) (whatsAppWebhookNormalizeResult, error) {
+	var payload watiWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return whatsAppWebhookNormalizeResult{}, fmt.Errorf("whatsapp: failed to decode wati webhook: %w", err)
+	}
+
+	var result whatsAppWebhookNormalizeResult
+
+	switch payload.EventType {
+	case "status":
+		result.Statuses = append(result.Statuses, whatsAppStatusUpdate{
+			ID:     payload.ID,
+			Status: watiStatusToMessageStatus(payload.Status),
+		})
+	default:
+		result.Messages = append(result.Messages, WhatsAppMessage{
+			ID:        payload.ID,
+			From:      payload.WaID,
+			FromName:  payload.SenderName,
+			Type:      payload.Type,
+			Text:      payload.Text,
+			Status:    StatusDelivered,
+			Direction: "inbound",
+			Provider:  WhatsAppWati,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+	}
+
+	return result, nil
+}
+
+func watiStatusToMessageStatus(status string) MessageStatus {
+	switch status {
+	case "SENT":
+		return StatusSent
+	case "DELIVERED":
+		return StatusDelivered
+	case "READ":
+		return StatusRead
+	case "FAILED":
+		return StatusFailed
+	default:
+		return StatusPending
+	}
+}
+
+var _ whatsAppWebhookNormalizer = (*watiWebhookNormalizer)(nil)
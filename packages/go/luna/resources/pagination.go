@@ -2,67 +2,201 @@ package resources
 
 import (
 	"context"
-)
+	"iter"
+	"sync"
 
-// Paginator provides an iterator interface for pagination
-type Paginator[T any] struct {
-	fetchNext func(context.Context, string) (*ListResponse[T], error)
-	ctx       context.Context
-	buffer    []T
-	cursor    string
-	hasMore   bool
-	done      bool
-	err       error
-}
+	"github.com/eclipse-softworks/luna-sdk-go/luna/telemetry"
+)
 
+// ListResponse is the common envelope returned by every cursor-paginated
+// list endpoint.
 type ListResponse[T any] struct {
 	Data       []T     `json:"data"`
 	HasMore    bool    `json:"has_more"`
 	NextCursor *string `json:"next_cursor"`
+	// TotalCount is the total number of items across all pages, when the
+	// backend reports one. Not every list endpoint does, so it's nil
+	// unless populated.
+	TotalCount *int `json:"total_count,omitempty"`
+}
+
+// PageInfo describes a Paginator's current position in a result set, for
+// callers that want to report progress or checkpoint a long-running scan.
+type PageInfo struct {
+	// Cursor resumes iteration immediately after the last item handed to
+	// the caller; pass it to Seek to continue from here later.
+	Cursor string
+	// HasMore reports whether another page is known to exist.
+	HasMore bool
+	// Total is the total item count across all pages, or nil if the
+	// backend didn't report one.
+	Total *int
+}
+
+// page is what the background fetch loop hands to the consumer.
+type page[T any] struct {
+	items   []T
+	cursor  string
+	hasMore bool
+	total   *int
+	err     error
 }
 
+// Paginator provides an iterator interface for pagination. While the
+// caller consumes the items of the current page, Paginator prefetches the
+// next ones in the background over a buffered channel of pages (depth 1
+// by default; see NewPaginatorWithPrefetch), so a call to Next() that
+// crosses a page boundary usually doesn't have to wait on network I/O.
+type Paginator[T any] struct {
+	fetchNext func(context.Context, string) (*ListResponse[T], error)
+	rootCtx   context.Context
+	prefetch  int
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	pages  chan page[T]
+
+	buffer  []T
+	cursor  string
+	hasMore bool
+	total   *int
+	done    bool
+	err     error
+}
+
+// NewPaginator creates a Paginator that calls fetchNext to retrieve each
+// page, starting from the beginning of the result set.
 func NewPaginator[T any](ctx context.Context, fetchNext func(context.Context, string) (*ListResponse[T], error)) *Paginator[T] {
-	return &Paginator[T]{
+	return NewPaginatorWithPrefetch(ctx, fetchNext, 1)
+}
+
+// NewPaginatorWithPrefetch creates a Paginator like NewPaginator, but
+// eagerly fetches up to prefetchPages pages ahead of what the caller has
+// consumed so far (1 if prefetchPages <= 0, matching NewPaginator's
+// default), trading memory for reduced latency on workloads where
+// consuming a page is slower than the backend is to produce the next one.
+func NewPaginatorWithPrefetch[T any](ctx context.Context, fetchNext func(context.Context, string) (*ListResponse[T], error), prefetchPages int) *Paginator[T] {
+	if prefetchPages <= 0 {
+		prefetchPages = 1
+	}
+	p := &Paginator[T]{
 		fetchNext: fetchNext,
-		ctx:       ctx,
-		hasMore:   true, // Start with true to allow first fetch
+		rootCtx:   ctx,
+		prefetch:  prefetchPages,
 	}
+	p.resetLocked("")
+	return p
 }
 
-// Next advances the iterator to the next item
-func (p *Paginator[T]) Next() bool {
-	if len(p.buffer) > 0 {
-		return true
+// resetLocked cancels any in-flight prefetch loop and launches a new one
+// starting at cursor. Callers must hold p.mu.
+func (p *Paginator[T]) resetLocked(cursor string) {
+	if p.cancel != nil {
+		p.cancel()
 	}
 
-	if p.done || !p.hasMore {
-		return false
-	}
+	ctx, cancel := context.WithCancel(p.rootCtx)
+	p.cancel = cancel
+	p.pages = make(chan page[T], p.prefetch)
+	p.buffer = nil
+	p.cursor = cursor
+	p.hasMore = true
+	p.total = nil
+	p.done = false
+	p.err = nil
 
-	page, err := p.fetchNext(p.ctx, p.cursor)
-	if err != nil {
-		p.err = err
-		p.done = true
-		return false
-	}
+	go p.produce(ctx, cursor)
+}
+
+// produce fetches pages starting at cursor and sends them to p.pages one
+// at a time, stopping once the result set is exhausted, fetchNext errors,
+// or ctx is cancelled (by Seek, Close, or the caller's own context).
+func (p *Paginator[T]) produce(ctx context.Context, cursor string) {
+	defer close(p.pages)
+
+	for {
+		resp, err := p.fetchNext(ctx, cursor)
+		if err != nil {
+			select {
+			case p.pages <- page[T]{err: err}:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		next := ""
+		if resp.NextCursor != nil {
+			next = *resp.NextCursor
+		}
 
-	if len(page.Data) == 0 {
-		p.done = true
-		return false
+		telemetry.LoggerFromContext(ctx).Debug("Paginator fetched page", map[string]interface{}{
+			"page_size": len(resp.Data),
+			"has_more":  resp.HasMore,
+			"cursor":    next,
+		})
+
+		select {
+		case p.pages <- page[T]{items: resp.Data, cursor: next, hasMore: resp.HasMore, total: resp.TotalCount}:
+		case <-ctx.Done():
+			return
+		}
+
+		if !resp.HasMore {
+			return
+		}
+		cursor = next
 	}
+}
+
+// Next advances the iterator, fetching the next page in the background if
+// the current one has been fully consumed. It returns false once the
+// result set is exhausted or an error occurs; check Err() to tell them
+// apart. A page with no items but HasMore still true (a backend returning
+// a transiently-filtered empty page mid-scan) doesn't end iteration --
+// Next keeps pulling pages until one has items or HasMore is false.
+func (p *Paginator[T]) Next() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.buffer) == 0 {
+		if p.done {
+			return false
+		}
+
+		pg, ok := <-p.pages
+		if !ok {
+			p.done = true
+			return false
+		}
+		if pg.err != nil {
+			p.err = pg.err
+			p.done = true
+			return false
+		}
+
+		p.cursor = pg.cursor
+		p.hasMore = pg.hasMore
+		p.total = pg.total
 
-	p.buffer = page.Data
-	p.hasMore = page.HasMore
-	if page.NextCursor != nil {
-		p.cursor = *page.NextCursor
-	} else {
-		p.cursor = ""
+		if len(pg.items) == 0 {
+			if !pg.hasMore {
+				p.done = true
+				return false
+			}
+			continue
+		}
+
+		p.buffer = pg.items
 	}
 	return true
 }
 
-// Value returns the current item
-func (p *Paginator[T]) Value() T {
+// Current returns the item Next() just advanced to, consuming it from the
+// internal buffer.
+func (p *Paginator[T]) Current() T {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if len(p.buffer) == 0 {
 		var zero T
 		return zero
@@ -72,7 +206,172 @@ func (p *Paginator[T]) Value() T {
 	return item
 }
 
-// Err returns any error occurred during iteration
+// Err returns any error encountered during iteration.
 func (p *Paginator[T]) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	return p.err
 }
+
+// PageInfo reports the paginator's current cursor, whether more items are
+// known to remain, and the backend-reported total, if any.
+func (p *Paginator[T]) PageInfo() PageInfo {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return PageInfo{Cursor: p.cursor, HasMore: p.hasMore, Total: p.total}
+}
+
+// Seek abandons any in-flight prefetch and resumes iteration from cursor —
+// typically one saved earlier via PageInfo().Cursor — discarding any
+// buffered items left over from the previous position. Useful for
+// resuming a bulk ETL job after a crash without rescanning from the start.
+func (p *Paginator[T]) Seek(cursor string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.resetLocked(cursor)
+}
+
+// Close cancels the background prefetch loop. Callers that stop consuming
+// a Paginator before it's exhausted should call Close to release the
+// loop's goroutine; it is safe to call more than once.
+func (p *Paginator[T]) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.cancel != nil {
+		p.cancel()
+	}
+}
+
+// All returns a range-func iterator over every item in the result set,
+// for use with Go's `for ... range` over a function:
+//
+//	for item, err := range paginator.All() {
+//	    if err != nil {
+//	        // the last value yielded, once Next is exhausted or errors
+//	        break
+//	    }
+//	    use(item)
+//	}
+//
+// A non-nil error is yielded at most once, as the final value, the same
+// point a manual `for p.Next() {}` loop would find it via Err().
+func (p *Paginator[T]) All() iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for p.Next() {
+			if !yield(p.Current(), nil) {
+				return
+			}
+		}
+		if err := p.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// Channel drains the paginator into a channel of items, buffered to
+// bufSize, and a channel that receives at most one error -- Err(), if
+// non-nil -- once the result set is exhausted. Both channels close when
+// draining finishes or the Paginator's root context is cancelled,
+// whichever comes first; useful for consuming a Paginator alongside other
+// channel-based work in a select loop.
+func (p *Paginator[T]) Channel(bufSize int) (<-chan T, <-chan error) {
+	items := make(chan T, bufSize)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(items)
+		defer close(errs)
+
+		for p.Next() {
+			select {
+			case items <- p.Current():
+			case <-p.rootCtx.Done():
+				return
+			}
+		}
+		if err := p.Err(); err != nil {
+			errs <- err
+		}
+	}()
+
+	return items, errs
+}
+
+// Collect drains up to max items into a slice (max<=0 means no limit),
+// stopping early if ctx is cancelled or the result set is exhausted.
+func (p *Paginator[T]) Collect(ctx context.Context, max int) ([]T, error) {
+	var out []T
+	for p.Next() {
+		out = append(out, p.Current())
+		if max > 0 && len(out) >= max {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return out, ctx.Err()
+		default:
+		}
+	}
+	if err := p.Err(); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// Parallel drains the paginator across workers goroutines (at least one),
+// calling fn for each item. It returns the first error returned by fn or
+// encountered during pagination; once one occurs, ctx is cancelled so fn
+// can use it to abandon remaining in-flight work, and no further items are
+// dispatched. Intended for bulk ETL jobs that need per-item fan-out
+// without losing first-error semantics.
+func (p *Paginator[T]) Parallel(ctx context.Context, workers int, fn func(T) error) error {
+	if workers <= 0 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	items := make(chan T)
+	errs := make(chan error, workers)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for item := range items {
+				if err := fn(item); err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+feed:
+	for p.Next() {
+		select {
+		case items <- p.Current():
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(items)
+	wg.Wait()
+
+	if err := p.Err(); err != nil {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+	close(errs)
+
+	return <-errs
+}
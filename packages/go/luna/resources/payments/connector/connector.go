@@ -0,0 +1,109 @@
+// Package connector provides a provider-agnostic asynchronous task
+// surface for the payments package, modeled on the connector pattern used
+// by ledger systems like Formance: each payment provider plugs in a
+// Connector that resolves a small set of typed Task kinds, instead of
+// exposing its own ad-hoc struct and method set the way payments.Ozow and
+// payments.PayShap did before this package existed. TaskScheduler persists
+// and retries tasks a Connector couldn't finish synchronously (polling
+// Ozow for a settlement, say), and Engine gives callers a single entry
+// point that doesn't need to know which Connector handles which provider.
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments/webhooks"
+)
+
+// TaskKind identifies the kind of asynchronous operation a TaskDescriptor
+// describes.
+type TaskKind string
+
+const (
+	TaskCreatePayment             TaskKind = "create_payment"
+	TaskPollStatus                TaskKind = "poll_status"
+	TaskCreateExternalBankAccount TaskKind = "create_external_bank_account"
+	TaskRefund                    TaskKind = "refund"
+	TaskFetchTransactions         TaskKind = "fetch_transactions"
+)
+
+// TaskDescriptor is one asynchronous operation for a Connector to resolve.
+// Payload is the Kind-specific request, JSON-encoded so a TaskDescriptor
+// can round-trip through a Store without either side needing to know every
+// Connector's request types.
+type TaskDescriptor struct {
+	ID       string
+	Provider payments.PaymentProvider
+	Kind     TaskKind
+	Payload  json.RawMessage
+}
+
+// NewCreatePaymentTask builds a TaskCreatePayment TaskDescriptor from req.
+func NewCreatePaymentTask(id string, provider payments.PaymentProvider, req payments.PaymentRequest) (TaskDescriptor, error) {
+	return newTask(id, provider, TaskCreatePayment, req)
+}
+
+// NewPollStatusTask builds a TaskPollStatus TaskDescriptor for paymentID.
+func NewPollStatusTask(id string, provider payments.PaymentProvider, paymentID string) (TaskDescriptor, error) {
+	return newTask(id, provider, TaskPollStatus, pollStatusPayload{PaymentID: paymentID})
+}
+
+// NewRefundTask builds a TaskRefund TaskDescriptor from req.
+func NewRefundTask(id string, provider payments.PaymentProvider, req payments.RefundRequest) (TaskDescriptor, error) {
+	return newTask(id, provider, TaskRefund, req)
+}
+
+// pollStatusPayload is TaskPollStatus's Payload shape.
+type pollStatusPayload struct {
+	PaymentID string `json:"payment_id"`
+}
+
+func newTask(id string, provider payments.PaymentProvider, kind TaskKind, payload any) (TaskDescriptor, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return TaskDescriptor{}, fmt.Errorf("connector: encoding %s payload: %w", kind, err)
+	}
+	return TaskDescriptor{ID: id, Provider: provider, Kind: kind, Payload: raw}, nil
+}
+
+// TaskResult is what Resolve returns once a TaskDescriptor completes.
+type TaskResult struct {
+	// Payment is the Payment the task produced or advanced, if any --
+	// every Kind except TaskFetchTransactions and
+	// TaskCreateExternalBankAccount sets it.
+	Payment *payments.Payment
+	// NextTasks are follow-on tasks the caller (typically Engine) should
+	// hand to a TaskScheduler -- e.g. a TaskCreatePayment whose provider
+	// settles asynchronously returns a TaskPollStatus here.
+	NextTasks []TaskDescriptor
+}
+
+// Connector is the surface every payment provider integration implements
+// to plug into Engine and TaskScheduler. Install/Uninstall hook whatever
+// one-time setup a provider needs (registering a notify URL, creating a
+// webhook subscription); most SA gateways need neither and can no-op.
+type Connector interface {
+	// Provider identifies which payment provider this Connector talks to.
+	Provider() payments.PaymentProvider
+	// Install performs one-time setup before the connector is first used.
+	Install(ctx context.Context) error
+	// Uninstall reverses whatever Install did, when the connector is
+	// being removed from service.
+	Uninstall(ctx context.Context) error
+	// Resolve carries out task, returning the Payment it produced (if any)
+	// and any follow-on tasks to schedule. A returned error is retried by
+	// TaskScheduler per its RetryPolicy; Resolve should return
+	// payments.ErrUnsupported for a Kind this provider's flow has no
+	// equivalent for, which TaskScheduler does not retry.
+	Resolve(ctx context.Context, task TaskDescriptor) (TaskResult, error)
+	// HandleWebhook verifies and parses an inbound webhook delivery for
+	// this provider into the normalized webhooks.Event shape.
+	HandleWebhook(r *http.Request) (webhooks.Event, error)
+	// Capabilities reports which of the optional Gateway operations this
+	// connector's underlying provider supports.
+	Capabilities() payments.Capabilities
+}
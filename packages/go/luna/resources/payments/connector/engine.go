@@ -0,0 +1,106 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+)
+
+// Engine fans out payment operations to the Connector registered for
+// whichever provider the caller asks for, so callers that deal with
+// several providers don't need a payments.Gateway (or a Ozow/PayShap
+// struct) per provider lying around -- one Engine, built from a
+// connector.Config per provider, replaces all of them.
+type Engine struct {
+	connectors map[payments.PaymentProvider]Connector
+	scheduler  *TaskScheduler
+}
+
+// NewEngine creates an Engine dispatching across connectors, scheduling
+// any follow-on work (polling, refund retries) through scheduler.
+func NewEngine(scheduler *TaskScheduler, connectors ...Connector) *Engine {
+	byProvider := make(map[payments.PaymentProvider]Connector, len(connectors))
+	for _, c := range connectors {
+		byProvider[c.Provider()] = c
+	}
+	return &Engine{connectors: byProvider, scheduler: scheduler}
+}
+
+// connectorFor returns the Connector registered for provider, or an error
+// naming it if none is.
+func (e *Engine) connectorFor(provider payments.PaymentProvider) (Connector, error) {
+	c, ok := e.connectors[provider]
+	if !ok {
+		return nil, fmt.Errorf("connector: no connector configured for provider %q", provider)
+	}
+	return c, nil
+}
+
+// CreatePayment resolves a TaskCreatePayment for req against provider's
+// Connector, scheduling any follow-on tasks (e.g. TaskPollStatus for a
+// provider that settles asynchronously) the Connector returns.
+func (e *Engine) CreatePayment(ctx context.Context, provider payments.PaymentProvider, id string, req payments.PaymentRequest) (*payments.Payment, error) {
+	c, err := e.connectorFor(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := NewCreatePaymentTask(id, provider, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.Resolve(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, next := range result.NextTasks {
+		if err := e.scheduler.Schedule(ctx, next); err != nil {
+			return result.Payment, fmt.Errorf("connector: scheduling follow-on task for payment %s: %w", id, err)
+		}
+	}
+	return result.Payment, nil
+}
+
+// Refund resolves a TaskRefund for req against provider's Connector.
+func (e *Engine) Refund(ctx context.Context, provider payments.PaymentProvider, id string, req payments.RefundRequest) (*payments.Payment, error) {
+	c, err := e.connectorFor(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := NewRefundTask(id, provider, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.Resolve(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	for _, next := range result.NextTasks {
+		if err := e.scheduler.Schedule(ctx, next); err != nil {
+			return result.Payment, fmt.Errorf("connector: scheduling follow-on task for refund %s: %w", id, err)
+		}
+	}
+	return result.Payment, nil
+}
+
+// Capabilities reports the Capabilities of provider's Connector.
+func (e *Engine) Capabilities(provider payments.PaymentProvider) (payments.Capabilities, error) {
+	c, err := e.connectorFor(provider)
+	if err != nil {
+		return payments.Capabilities{}, err
+	}
+	return c.Capabilities(), nil
+}
+
+// Connectors returns every Connector registered with this Engine, keyed by
+// provider. Used by NewWebhookReceiver to wire each one into a
+// webhooks.Receiver without the caller repeating the provider/verifier
+// pairing it already gave NewEngine.
+func (e *Engine) Connectors() map[payments.PaymentProvider]Connector {
+	return e.connectors
+}
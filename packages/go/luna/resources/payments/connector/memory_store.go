@@ -0,0 +1,74 @@
+package connector
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore is an in-process Store, suitable for a single-instance
+// deployment or tests. Pending tasks don't survive a process restart; a
+// multi-instance deployment should use SQLStore instead so every instance
+// dispatches from the same pending set.
+type MemoryStore struct {
+	mu    sync.Mutex
+	tasks map[string]StoredTask
+}
+
+// NewMemoryStore creates an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tasks: make(map[string]StoredTask)}
+}
+
+// Enqueue implements Store.
+func (s *MemoryStore) Enqueue(ctx context.Context, task StoredTask) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tasks[task.ID] = task
+	return nil
+}
+
+// Due implements Store.
+func (s *MemoryStore) Due(ctx context.Context, now time.Time, limit int) ([]StoredTask, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := make([]StoredTask, 0, limit)
+	for _, task := range s.tasks {
+		if !task.NextAttempt.After(now) {
+			due = append(due, task)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].NextAttempt.Before(due[j].NextAttempt) })
+	if limit > 0 && len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// MarkDone implements Store.
+func (s *MemoryStore) MarkDone(ctx context.Context, taskID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.tasks, taskID)
+	return nil
+}
+
+// MarkFailed implements Store.
+func (s *MemoryStore) MarkFailed(ctx context.Context, taskID string, attempts int, nextAttempt time.Time, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	task, ok := s.tasks[taskID]
+	if !ok {
+		return nil
+	}
+	task.Attempts = attempts
+	task.NextAttempt = nextAttempt
+	task.LastError = lastError
+	s.tasks[taskID] = task
+	return nil
+}
+
+var _ Store = (*MemoryStore)(nil)
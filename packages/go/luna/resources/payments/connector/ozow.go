@@ -0,0 +1,96 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments/webhooks"
+)
+
+func init() {
+	RegisterConnector(string(payments.ProviderOzow), newOzowConnector)
+}
+
+// ozowConnector adapts payments.Ozow to Connector. Ozow EFT payments
+// settle immediately, so Resolve never returns a NextTasks poll -- it only
+// exists to plug Ozow into Engine/TaskScheduler alongside providers that
+// do settle asynchronously.
+type ozowConnector struct {
+	gateway  *payments.Ozow
+	verifier *webhooks.OzowVerifier
+}
+
+func newOzowConnector(cfg Config) (Connector, error) {
+	config, ok := cfg.GatewayConfig.(payments.OzowConfig)
+	if !ok {
+		return nil, fmt.Errorf("connector: ozow: GatewayConfig must be a payments.OzowConfig, got %T", cfg.GatewayConfig)
+	}
+	return &ozowConnector{
+		gateway:  payments.NewOzow(cfg.Client, config),
+		verifier: webhooks.NewOzowVerifier(config.PrivateKey),
+	}, nil
+}
+
+// Provider implements Connector.
+func (c *ozowConnector) Provider() payments.PaymentProvider { return payments.ProviderOzow }
+
+// Install implements Connector. Ozow needs no one-time setup.
+func (c *ozowConnector) Install(ctx context.Context) error { return nil }
+
+// Uninstall implements Connector. Ozow needs no one-time teardown.
+func (c *ozowConnector) Uninstall(ctx context.Context) error { return nil }
+
+// Resolve implements Connector.
+func (c *ozowConnector) Resolve(ctx context.Context, task TaskDescriptor) (TaskResult, error) {
+	switch task.Kind {
+	case TaskCreatePayment:
+		var req payments.PaymentRequest
+		if err := json.Unmarshal(task.Payload, &req); err != nil {
+			return TaskResult{}, fmt.Errorf("connector: ozow: decoding create payment payload: %w", err)
+		}
+		payment, err := c.gateway.CreatePayment(ctx, req)
+		if err != nil {
+			return TaskResult{}, err
+		}
+		return TaskResult{Payment: payment}, nil
+
+	case TaskPollStatus:
+		var payload pollStatusPayload
+		if err := json.Unmarshal(task.Payload, &payload); err != nil {
+			return TaskResult{}, fmt.Errorf("connector: ozow: decoding poll status payload: %w", err)
+		}
+		payment, err := c.gateway.GetPayment(ctx, payload.PaymentID)
+		if err != nil {
+			return TaskResult{}, err
+		}
+		return TaskResult{Payment: payment}, nil
+
+	case TaskRefund:
+		var req payments.RefundRequest
+		if err := json.Unmarshal(task.Payload, &req); err != nil {
+			return TaskResult{}, fmt.Errorf("connector: ozow: decoding refund payload: %w", err)
+		}
+		if _, err := c.gateway.Refund(ctx, req); err != nil {
+			return TaskResult{}, err
+		}
+		return TaskResult{}, nil
+
+	default:
+		return TaskResult{}, payments.ErrUnsupported
+	}
+}
+
+// HandleWebhook implements Connector.
+func (c *ozowConnector) HandleWebhook(r *http.Request) (webhooks.Event, error) {
+	return c.verifier.Verify(r)
+}
+
+// Capabilities implements Connector.
+func (c *ozowConnector) Capabilities() payments.Capabilities {
+	return c.gateway.Capabilities()
+}
+
+var _ Connector = (*ozowConnector)(nil)
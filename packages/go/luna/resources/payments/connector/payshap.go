@@ -0,0 +1,122 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments/webhooks"
+)
+
+func init() {
+	RegisterConnector(string(payments.ProviderPayShap), newPayShapConnector)
+}
+
+// payshapConnector adapts payments.PayShap to Connector. PayShap predates
+// the Gateway interface and uses its own PayShapPaymentRequest/PayShapPayment
+// shapes rather than PaymentRequest/Payment, so Resolve translates between
+// them at the boundary instead of changing PayShap's existing surface.
+type payshapConnector struct {
+	gateway  *payments.PayShap
+	verifier *webhooks.PayShapVerifier
+}
+
+func newPayShapConnector(cfg Config) (Connector, error) {
+	config, ok := cfg.GatewayConfig.(payments.PayShapConfig)
+	if !ok {
+		return nil, fmt.Errorf("connector: payshap: GatewayConfig must be a payments.PayShapConfig, got %T", cfg.GatewayConfig)
+	}
+	return &payshapConnector{
+		gateway:  payments.NewPayShap(cfg.Client, config),
+		verifier: webhooks.NewPayShapVerifier(cfg.WebhookSecret),
+	}, nil
+}
+
+// Provider implements Connector.
+func (c *payshapConnector) Provider() payments.PaymentProvider { return payments.ProviderPayShap }
+
+// Install implements Connector. PayShap needs no one-time setup.
+func (c *payshapConnector) Install(ctx context.Context) error { return nil }
+
+// Uninstall implements Connector. PayShap needs no one-time teardown.
+func (c *payshapConnector) Uninstall(ctx context.Context) error { return nil }
+
+// Resolve implements Connector.
+func (c *payshapConnector) Resolve(ctx context.Context, task TaskDescriptor) (TaskResult, error) {
+	switch task.Kind {
+	case TaskCreatePayment:
+		var req payments.PaymentRequest
+		if err := json.Unmarshal(task.Payload, &req); err != nil {
+			return TaskResult{}, fmt.Errorf("connector: payshap: decoding create payment payload: %w", err)
+		}
+		payment, err := c.gateway.CreatePayment(ctx, toPayShapPaymentRequest(req))
+		if err != nil {
+			return TaskResult{}, err
+		}
+
+		pollTask, err := NewPollStatusTask(task.ID+":poll", payments.ProviderPayShap, payment.ID)
+		if err != nil {
+			return TaskResult{}, err
+		}
+		return TaskResult{Payment: fromPayShapPayment(payment), NextTasks: []TaskDescriptor{pollTask}}, nil
+
+	case TaskPollStatus:
+		var payload pollStatusPayload
+		if err := json.Unmarshal(task.Payload, &payload); err != nil {
+			return TaskResult{}, fmt.Errorf("connector: payshap: decoding poll status payload: %w", err)
+		}
+		payment, err := c.gateway.GetPayment(ctx, payload.PaymentID)
+		if err != nil {
+			return TaskResult{}, err
+		}
+		return TaskResult{Payment: fromPayShapPayment(payment)}, nil
+
+	default:
+		return TaskResult{}, payments.ErrUnsupported
+	}
+}
+
+// HandleWebhook implements Connector.
+func (c *payshapConnector) HandleWebhook(r *http.Request) (webhooks.Event, error) {
+	return c.verifier.Verify(r)
+}
+
+// Capabilities implements Connector. PayShap has no Gateway adapter to
+// report Capabilities from, since it predates that interface -- it
+// supports GetPayment and nothing else Capabilities tracks.
+func (c *payshapConnector) Capabilities() payments.Capabilities {
+	return payments.Capabilities{SupportsGetPayment: true}
+}
+
+// toPayShapPaymentRequest narrows the provider-agnostic PaymentRequest down
+// to the fields PayShapPaymentRequest has room for.
+func toPayShapPaymentRequest(req payments.PaymentRequest) payments.PayShapPaymentRequest {
+	return payments.PayShapPaymentRequest{
+		Amount:    req.Amount,
+		Reference: req.Reference,
+	}
+}
+
+// fromPayShapPayment normalizes a PayShapPayment into the provider-agnostic
+// Payment shape, keeping PayShap-specific fields (ShapID, QRCode,
+// ExpiresAt) in Raw.
+func fromPayShapPayment(payment *payments.PayShapPayment) *payments.Payment {
+	return &payments.Payment{
+		ID:        payment.ID,
+		Provider:  payments.ProviderPayShap,
+		Amount:    payment.Amount,
+		Status:    payment.Status,
+		Reference: payment.Reference,
+		Raw: map[string]interface{}{
+			"shap_id":    payment.ShapID,
+			"qr_code":    payment.QRCode,
+			"expires_at": payment.ExpiresAt,
+		},
+		CreatedAt: payment.CreatedAt,
+		UpdatedAt: payment.UpdatedAt,
+	}
+}
+
+var _ Connector = (*payshapConnector)(nil)
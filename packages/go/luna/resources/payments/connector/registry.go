@@ -0,0 +1,73 @@
+package connector
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+)
+
+// Config is what a registered Factory receives to build its Connector:
+// the HTTP client every gateway adapter is already constructed with
+// (payments.NewOzow, payments.NewPayShap, ...), the provider-specific
+// config type that gateway expects, and a webhook signing secret, for
+// providers (PayShap) whose GatewayConfig carries no secret of its own to
+// verify deliveries with.
+type Config struct {
+	Client        *lunahttp.Client
+	GatewayConfig any
+	WebhookSecret string
+}
+
+// Factory builds a Connector from cfg. Third-party gateways register one
+// with RegisterConnector under whatever name they want Engine/New callers
+// to select them by -- it doesn't need to match a payments.PaymentProvider
+// constant, though the built-in connectors (see ozow.go, payshap.go) use
+// theirs for consistency.
+type Factory func(cfg Config) (Connector, error)
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]Factory)
+)
+
+// RegisterConnector adds factory to the registry under name, so New(name,
+// ...) can build it later. Intended to be called from a connector
+// package's init(), the way database/sql drivers register themselves;
+// panics if name is already registered, since that means two packages (or
+// two imports of the same one) are fighting over the same name.
+func RegisterConnector(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[name]; exists {
+		panic(fmt.Sprintf("connector: RegisterConnector called twice for %q", name))
+	}
+	registry[name] = factory
+}
+
+// New builds the Connector registered under name with cfg.
+func New(name string, cfg Config) (Connector, error) {
+	registryMu.Lock()
+	factory, ok := registry[name]
+	registryMu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("connector: no connector registered for %q (registered: %v)", name, Names())
+	}
+	return factory(cfg)
+}
+
+// Names lists every registered connector name, sorted.
+func Names() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
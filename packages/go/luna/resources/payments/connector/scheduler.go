@@ -0,0 +1,172 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+)
+
+// RetryPolicy controls how TaskScheduler backs off between failed attempts
+// at a task, full-jitter exponential the same scheme lunahttp.RetryPolicy
+// uses: each attempt waits a random duration between 0 and
+// min(MaxInterval, BaseInterval*2^attempt).
+type RetryPolicy struct {
+	// MaxAttempts is how many times TaskScheduler calls Resolve for a task
+	// before giving up on it. Zero means DefaultRetryPolicy's 8.
+	MaxAttempts int
+	// BaseInterval is the backoff for the first retry. Zero means
+	// DefaultRetryPolicy's 2s.
+	BaseInterval time.Duration
+	// MaxInterval caps how long a single backoff can grow to. Zero means
+	// DefaultRetryPolicy's 15m.
+	MaxInterval time.Duration
+}
+
+// DefaultRetryPolicy is used by NewScheduler when no RetryPolicy is given.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:  8,
+	BaseInterval: 2 * time.Second,
+	MaxInterval:  15 * time.Minute,
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if p.BaseInterval == 0 {
+		p.BaseInterval = DefaultRetryPolicy.BaseInterval
+	}
+	if p.MaxInterval == 0 {
+		p.MaxInterval = DefaultRetryPolicy.MaxInterval
+	}
+	return p
+}
+
+// delay returns how long to wait before the given attempt (1-based).
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	backoff := float64(p.BaseInterval) * math.Pow(2, float64(attempt-1))
+	if max := float64(p.MaxInterval); backoff > max {
+		backoff = max
+	}
+	return time.Duration(rand.Float64() * backoff)
+}
+
+// TaskScheduler persists tasks to a Store and dispatches them to the
+// Connector registered for their Provider, retrying failures with backoff
+// until RetryPolicy.MaxAttempts is exhausted.
+type TaskScheduler struct {
+	store       Store
+	connectors  map[payments.PaymentProvider]Connector
+	retryPolicy RetryPolicy
+	logger      *slog.Logger
+}
+
+// SchedulerOption configures NewScheduler.
+type SchedulerOption func(*TaskScheduler)
+
+// WithRetryPolicy overrides DefaultRetryPolicy.
+func WithRetryPolicy(policy RetryPolicy) SchedulerOption {
+	return func(s *TaskScheduler) { s.retryPolicy = policy.withDefaults() }
+}
+
+// WithLogger overrides the slog.Logger TaskScheduler logs dispatch
+// failures to. Defaults to slog.Default().
+func WithLogger(logger *slog.Logger) SchedulerOption {
+	return func(s *TaskScheduler) { s.logger = logger }
+}
+
+// NewScheduler creates a TaskScheduler backed by store, dispatching to
+// connectors keyed by Connector.Provider().
+func NewScheduler(store Store, connectors []Connector, opts ...SchedulerOption) *TaskScheduler {
+	byProvider := make(map[payments.PaymentProvider]Connector, len(connectors))
+	for _, c := range connectors {
+		byProvider[c.Provider()] = c
+	}
+
+	s := &TaskScheduler{
+		store:       store,
+		connectors:  byProvider,
+		retryPolicy: DefaultRetryPolicy,
+		logger:      slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Schedule enqueues task for dispatch, to run as soon as Run's poll loop
+// next picks it up.
+func (s *TaskScheduler) Schedule(ctx context.Context, task TaskDescriptor) error {
+	return s.store.Enqueue(ctx, StoredTask{TaskDescriptor: task, NextAttempt: time.Now()})
+}
+
+// Run polls store for due tasks every interval and dispatches them,
+// blocking until ctx is canceled.
+func (s *TaskScheduler) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := s.dispatchDue(ctx); err != nil {
+				s.logger.Error("connector: dispatching due tasks", "error", err)
+			}
+		}
+	}
+}
+
+func (s *TaskScheduler) dispatchDue(ctx context.Context) error {
+	due, err := s.store.Due(ctx, time.Now(), 100)
+	if err != nil {
+		return fmt.Errorf("connector: listing due tasks: %w", err)
+	}
+	for _, task := range due {
+		s.dispatchOne(ctx, task)
+	}
+	return nil
+}
+
+func (s *TaskScheduler) dispatchOne(ctx context.Context, task StoredTask) {
+	connector, ok := s.connectors[task.Provider]
+	if !ok {
+		s.logger.Error("connector: no connector registered for provider", "provider", task.Provider, "task", task.ID)
+		return
+	}
+
+	result, err := connector.Resolve(ctx, task.TaskDescriptor)
+	if err == nil {
+		if markErr := s.store.MarkDone(ctx, task.ID); markErr != nil {
+			s.logger.Error("connector: marking task done", "task", task.ID, "error", markErr)
+		}
+		for _, next := range result.NextTasks {
+			if scheduleErr := s.Schedule(ctx, next); scheduleErr != nil {
+				s.logger.Error("connector: scheduling follow-on task", "task", next.ID, "error", scheduleErr)
+			}
+		}
+		return
+	}
+
+	if errors.Is(err, payments.ErrUnsupported) || task.Attempts+1 >= s.retryPolicy.MaxAttempts {
+		s.logger.Error("connector: task failed permanently", "task", task.ID, "attempts", task.Attempts+1, "error", err)
+		if markErr := s.store.MarkDone(ctx, task.ID); markErr != nil {
+			s.logger.Error("connector: marking exhausted task done", "task", task.ID, "error", markErr)
+		}
+		return
+	}
+
+	attempts := task.Attempts + 1
+	nextAttempt := time.Now().Add(s.retryPolicy.delay(attempts))
+	if markErr := s.store.MarkFailed(ctx, task.ID, attempts, nextAttempt, err.Error()); markErr != nil {
+		s.logger.Error("connector: marking task failed", "task", task.ID, "error", markErr)
+	}
+}
@@ -0,0 +1,159 @@
+package connector
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+)
+
+// SQLStore is a database/sql-backed Store, for a multi-instance deployment
+// where every instance's TaskScheduler needs to see the same pending
+// tasks. It issues plain parameterized SQL through the standard
+// database/sql interface, so it works with any driver (sqlite3, mysql,
+// pgx, ...) registered under db's driver name.
+type SQLStore struct {
+	db    *sql.DB
+	table string
+	// placeholder renders the nth (1-based) bind parameter in a query, "?"
+	// for MySQL/SQLite or "$1"-style for Postgres. "?" if unset.
+	placeholder func(n int) string
+}
+
+// SQLStoreOption configures NewSQLStore.
+type SQLStoreOption func(*SQLStore)
+
+// WithTable overrides the default table name ("luna_payment_tasks").
+func WithTable(table string) SQLStoreOption {
+	return func(s *SQLStore) { s.table = table }
+}
+
+// WithPositionalPlaceholders switches SQLStore to Postgres-style "$1",
+// "$2", ... bind parameters instead of the "?" every other database/sql
+// driver uses.
+func WithPositionalPlaceholders() SQLStoreOption {
+	return func(s *SQLStore) {
+		s.placeholder = func(n int) string { return "$" + strconv.Itoa(n) }
+	}
+}
+
+// NewSQLStore creates a SQLStore over db. Call EnsureSchema once at
+// startup to create its table if it doesn't already exist.
+func NewSQLStore(db *sql.DB, opts ...SQLStoreOption) *SQLStore {
+	s := &SQLStore{
+		db:          db,
+		table:       "luna_payment_tasks",
+		placeholder: func(n int) string { return "?" },
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// EnsureSchema creates SQLStore's table if it doesn't already exist, using
+// portable-enough SQL for SQLite, MySQL, and Postgres alike.
+func (s *SQLStore) EnsureSchema(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+CREATE TABLE IF NOT EXISTS %s (
+	id TEXT PRIMARY KEY,
+	provider TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	next_attempt TIMESTAMP NOT NULL,
+	last_error TEXT
+)`, s.table))
+	if err != nil {
+		return fmt.Errorf("connector: creating %s: %w", s.table, err)
+	}
+	return nil
+}
+
+func (s *SQLStore) ph(n int) string { return s.placeholder(n) }
+
+// Enqueue implements Store.
+func (s *SQLStore) Enqueue(ctx context.Context, task StoredTask) error {
+	query := fmt.Sprintf(`
+INSERT INTO %s (id, provider, kind, payload, attempts, next_attempt, last_error)
+VALUES (%s, %s, %s, %s, %s, %s, %s)
+ON CONFLICT (id) DO UPDATE SET
+	provider = excluded.provider,
+	kind = excluded.kind,
+	payload = excluded.payload,
+	attempts = excluded.attempts,
+	next_attempt = excluded.next_attempt,
+	last_error = excluded.last_error`,
+		s.table, s.ph(1), s.ph(2), s.ph(3), s.ph(4), s.ph(5), s.ph(6), s.ph(7))
+
+	_, err := s.db.ExecContext(ctx, query,
+		task.ID, string(task.Provider), string(task.Kind), string(task.Payload),
+		task.Attempts, task.NextAttempt, task.LastError)
+	if err != nil {
+		return fmt.Errorf("connector: enqueuing task %s: %w", task.ID, err)
+	}
+	return nil
+}
+
+// Due implements Store.
+func (s *SQLStore) Due(ctx context.Context, now time.Time, limit int) ([]StoredTask, error) {
+	query := fmt.Sprintf(`
+SELECT id, provider, kind, payload, attempts, next_attempt, last_error
+FROM %s WHERE next_attempt <= %s ORDER BY next_attempt ASC LIMIT %s`,
+		s.table, s.ph(1), s.ph(2))
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("connector: querying due tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var due []StoredTask
+	for rows.Next() {
+		var (
+			task      StoredTask
+			provider  string
+			kind      string
+			payload   string
+			lastError sql.NullString
+		)
+		if err := rows.Scan(&task.ID, &provider, &kind, &payload, &task.Attempts, &task.NextAttempt, &lastError); err != nil {
+			return nil, fmt.Errorf("connector: scanning due task: %w", err)
+		}
+		task.Provider = payments.PaymentProvider(provider)
+		task.Kind = TaskKind(kind)
+		task.Payload = []byte(payload)
+		task.LastError = lastError.String
+		due = append(due, task)
+	}
+	return due, rows.Err()
+}
+
+// MarkDone implements Store.
+func (s *SQLStore) MarkDone(ctx context.Context, taskID string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = %s`, s.table, s.ph(1))
+	_, err := s.db.ExecContext(ctx, query, taskID)
+	if err != nil {
+		return fmt.Errorf("connector: marking task %s done: %w", taskID, err)
+	}
+	return nil
+}
+
+// MarkFailed implements Store.
+func (s *SQLStore) MarkFailed(ctx context.Context, taskID string, attempts int, nextAttempt time.Time, lastError string) error {
+	query := fmt.Sprintf(`UPDATE %s SET attempts = %s, next_attempt = %s, last_error = %s WHERE id = %s`,
+		s.table, s.ph(1), s.ph(2), s.ph(3), s.ph(4))
+	_, err := s.db.ExecContext(ctx, query, attempts, nextAttempt, lastError, taskID)
+	if err != nil {
+		return fmt.Errorf("connector: marking task %s failed: %w", taskID, err)
+	}
+	return nil
+}
+
+var _ Store = (*SQLStore)(nil)
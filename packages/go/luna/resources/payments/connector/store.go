@@ -0,0 +1,41 @@
+package connector
+
+import (
+	"context"
+	"time"
+)
+
+// StoredTask is a TaskDescriptor plus the scheduling bookkeeping
+// TaskScheduler needs to retry it with backoff.
+type StoredTask struct {
+	TaskDescriptor
+	// Attempts counts how many times TaskScheduler has called Resolve for
+	// this task, including the current one.
+	Attempts int
+	// NextAttempt is when this task is next eligible to run. Store
+	// implementations should index on it so Due can find the work without
+	// scanning every pending task.
+	NextAttempt time.Time
+	// LastError is the error Resolve returned on the most recent failed
+	// attempt, kept for diagnostics; empty until the first failure.
+	LastError string
+}
+
+// Store persists TaskScheduler's pending tasks, so a scheduled
+// TaskCreatePayment or TaskPollStatus survives a process restart instead
+// of being lost with it. Implementations must be safe for concurrent use.
+type Store interface {
+	// Enqueue persists task for later dispatch.
+	Enqueue(ctx context.Context, task StoredTask) error
+	// Due returns up to limit tasks whose NextAttempt has passed as of
+	// now, for TaskScheduler's poll loop to pick up and dispatch.
+	Due(ctx context.Context, now time.Time, limit int) ([]StoredTask, error)
+	// MarkDone removes taskID from the store after Connector.Resolve
+	// succeeds for it.
+	MarkDone(ctx context.Context, taskID string) error
+	// MarkFailed records a failed attempt for taskID, rescheduling it for
+	// nextAttempt. TaskScheduler calls this itself -- Store doesn't decide
+	// whether a task has exhausted its retries, only persists what it's
+	// told.
+	MarkFailed(ctx context.Context, taskID string, attempts int, nextAttempt time.Time, lastError string) error
+}
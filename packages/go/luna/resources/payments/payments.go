@@ -7,12 +7,15 @@ import (
 
 // Payments provides unified access to SA payment gateways.
 type Payments struct {
-	client  *lunahttp.Client
-	config  *Config
-	payfast *PayFast
-	ozow    *Ozow
-	yoco    *Yoco
-	payshap *PayShap
+	client   *lunahttp.Client
+	config   *Config
+	payfast  *PayFast
+	ozow     *Ozow
+	yoco     *Yoco
+	payshap  *PayShap
+	paygate  *PayGate
+	peach    *Peach
+	registry *Registry
 }
 
 // NewPayments creates a new Payments resource.
@@ -70,6 +73,54 @@ func (p *Payments) PayShap() *PayShap {
 	return p.payshap
 }
 
+// PayGate returns the PayGate gateway instance.
+func (p *Payments) PayGate() *PayGate {
+	if p.paygate == nil {
+		if p.config.PayGate == nil {
+			panic("PayGate not configured. Provide PayGateConfig when initializing LunaClient.")
+		}
+		p.paygate = NewPayGate(p.client, *p.config.PayGate)
+	}
+	return p.paygate
+}
+
+// Peach returns the Peach gateway instance.
+func (p *Payments) Peach() *Peach {
+	if p.peach == nil {
+		if p.config.Peach == nil {
+			panic("Peach not configured. Provide PeachConfig when initializing LunaClient.")
+		}
+		p.peach = NewPeach(p.client, *p.config.Peach)
+	}
+	return p.peach
+}
+
+// Gateway returns the registered Gateway for name (one of "payfast",
+// "ozow", "yoco", "paygate", "peach"), built lazily from Config the first
+// time it's requested. It panics if name has no corresponding
+// configuration, matching the per-provider accessors above.
+func (p *Payments) Gateway(name string) Gateway {
+	if p.registry == nil {
+		p.registry = NewRegistry()
+		if p.config.PayFast != nil {
+			p.registry.Register(string(ProviderPayFast), p.PayFast())
+		}
+		if p.config.Ozow != nil {
+			p.registry.Register(string(ProviderOzow), p.Ozow())
+		}
+		if p.config.Yoco != nil {
+			p.registry.Register(string(ProviderYoco), p.Yoco())
+		}
+		if p.config.PayGate != nil {
+			p.registry.Register(string(ProviderPayGate), p.PayGate())
+		}
+		if p.config.Peach != nil {
+			p.registry.Register(string(ProviderPeach), p.Peach())
+		}
+	}
+	return p.registry.Gateway(name)
+}
+
 // List returns available payment gateways.
 func (p *Payments) List() []string {
 	var available []string
@@ -85,5 +136,11 @@ func (p *Payments) List() []string {
 	if p.config.PayShap != nil {
 		available = append(available, "payshap")
 	}
+	if p.config.PayGate != nil {
+		available = append(available, "paygate")
+	}
+	if p.config.Peach != nil {
+		available = append(available, "peach")
+	}
 	return available
 }
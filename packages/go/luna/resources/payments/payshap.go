@@ -7,9 +7,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"sync"
 	"time"
 
 	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/options"
 )
 
 // SABank represents a South African bank
@@ -31,13 +33,26 @@ const (
 type PayShap struct {
 	client *lunahttp.Client
 	config PayShapConfig
+
+	bankAccountStore BankAccountStore
+	auditLog         AuditLogger
+
+	payoutTotalsMu sync.Mutex
+	payoutTotals   map[string]int
 }
 
-// NewPayShap creates a new PayShap instance.
-func NewPayShap(client *lunahttp.Client, config PayShapConfig) *PayShap {
+// NewPayShap creates a new PayShap instance. With no options the bank
+// account vault (RegisterBankAccount, Payout) uses an in-memory store and
+// a no-op AuditLogger.
+func NewPayShap(client *lunahttp.Client, config PayShapConfig, opts ...PayShapOption) *PayShap {
+	o := payShapVaultOptions{}
+	options.Apply(&o, opts)
+
 	return &PayShap{
-		client: client,
-		config: config,
+		client:           client,
+		config:           config,
+		bankAccountStore: o.store,
+		auditLog:         o.auditLog,
 	}
 }
 
@@ -96,6 +111,40 @@ func (p *PayShap) GetPayment(ctx context.Context, paymentID string) (*PayShapPay
 	}, nil
 }
 
+// PollUntilTerminal repeatedly calls GetPayment until the returned
+// PayShapPayment reaches a terminal status (Completed, Failed, Cancelled,
+// Refunded) or ctx/opts.Timeout is exceeded, returning the last
+// PayShapPayment seen in either case. PayShap's QR-based flow has no
+// redirect to return on, so a caller without a webhook receiver needs this
+// to learn when the customer's bank app has completed the payment.
+func (p *PayShap) PollUntilTerminal(ctx context.Context, paymentID string, opts PollOptions) (*PayShapPayment, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	for {
+		payment, err := p.GetPayment(ctx, paymentID)
+		if err != nil {
+			return nil, err
+		}
+		if terminalStatuses[payment.Status] {
+			return payment, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return payment, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
 // CancelPayment cancels a pending payment.
 func (p *PayShap) CancelPayment(ctx context.Context, paymentID string) (*PayShapPayment, error) {
 	payment, _ := p.GetPayment(ctx, paymentID)
@@ -0,0 +1,542 @@
+package payments
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/options"
+)
+
+// ErrBankAccountNotFound is returned when a BankAccountToken doesn't
+// resolve to a vaulted record, whether it never existed or was
+// tombstoned.
+var ErrBankAccountNotFound = errors.New("payments: bank account token not found")
+
+// ErrDailyLimitExceeded is returned by Payout when completing it would
+// push ToBankAccountToken's bank past its per-bank daily payout limit.
+var ErrDailyLimitExceeded = errors.New("payments: payout would exceed the receiving bank's daily limit")
+
+// BankAccountRegistration is what RegisterBankAccount vaults. Either
+// ShapID or AccountNumber+BankID must be set: a ShapID-bound account is
+// verified through LookupShapID, a raw account number through a mocked
+// penny-drop.
+type BankAccountRegistration struct {
+	MerchantID        string `json:"merchant_id"`
+	AccountHolderName string `json:"account_holder_name"`
+	ShapID            string `json:"shap_id,omitempty"`
+	AccountNumber     string `json:"account_number,omitempty"`
+	BankID            SABank `json:"bank_id,omitempty"`
+}
+
+// BankAccountToken is what RegisterBankAccount returns: an opaque
+// reference a caller stores and later passes to Payout, instead of
+// handling the underlying account number itself.
+type BankAccountToken struct {
+	Token     string    `json:"token"`
+	BankID    SABank    `json:"bank_id"`
+	Last4     string    `json:"last4,omitempty"`
+	ShapID    string    `json:"shap_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// bankAccountRecord is the plaintext shape encrypted into
+// EncryptedBankAccountRecord.Ciphertext. Unexported: it never leaves this
+// file except through AES-GCM.
+type bankAccountRecord struct {
+	MerchantID        string `json:"merchant_id"`
+	AccountHolderName string `json:"account_holder_name"`
+	AccountNumber     string `json:"account_number,omitempty"`
+	ShapID            string `json:"shap_id,omitempty"`
+	BankID            SABank `json:"bank_id"`
+}
+
+// EncryptedBankAccountRecord is what BankAccountStore persists: the
+// AES-GCM ciphertext of a bankAccountRecord, never the plaintext account
+// number itself.
+type EncryptedBankAccountRecord struct {
+	Token      string
+	Ciphertext []byte
+	Nonce      []byte
+	// KeyVersion identifies which PayShapConfig.EncryptionKeyVersion
+	// encrypted Ciphertext, so RotateEncryptionKey can tell records still
+	// on the old key from ones already rotated.
+	KeyVersion int
+	BankID     SABank
+	CreatedAt  time.Time
+	// Tombstoned marks a record as revoked without erasing it outright,
+	// preserving the audit trail while refusing any further Payout
+	// against its token.
+	Tombstoned bool
+}
+
+// BankAccountStore persists EncryptedBankAccountRecords. Implementations
+// must be safe for concurrent use.
+type BankAccountStore interface {
+	// Put stores record under record.Token, overwriting any previous
+	// record for that token (used by RotateEncryptionKey to re-save a
+	// record re-encrypted under the new key).
+	Put(ctx context.Context, record EncryptedBankAccountRecord) error
+	// Get retrieves the record for token, including tombstoned ones --
+	// callers that care about Tombstoned check it themselves, since
+	// RotateEncryptionKey still needs to read and rewrite tombstoned
+	// records.
+	Get(ctx context.Context, token string) (EncryptedBankAccountRecord, error)
+	// Tombstone marks token's record as revoked without deleting it.
+	Tombstone(ctx context.Context, token string) error
+	// All iterates every non-tombstoned record, for RotateEncryptionKey to
+	// walk the whole vault.
+	All(ctx context.Context) ([]EncryptedBankAccountRecord, error)
+}
+
+// MemoryBankAccountStore is an in-process BankAccountStore, suitable for a
+// single-instance deployment or tests.
+type MemoryBankAccountStore struct {
+	mu      sync.Mutex
+	records map[string]EncryptedBankAccountRecord
+}
+
+// NewMemoryBankAccountStore creates an empty MemoryBankAccountStore.
+func NewMemoryBankAccountStore() *MemoryBankAccountStore {
+	return &MemoryBankAccountStore{records: make(map[string]EncryptedBankAccountRecord)}
+}
+
+// Put implements BankAccountStore.
+func (s *MemoryBankAccountStore) Put(ctx context.Context, record EncryptedBankAccountRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[record.Token] = record
+	return nil
+}
+
+// Get implements BankAccountStore.
+func (s *MemoryBankAccountStore) Get(ctx context.Context, token string) (EncryptedBankAccountRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[token]
+	if !ok {
+		return EncryptedBankAccountRecord{}, ErrBankAccountNotFound
+	}
+	return record, nil
+}
+
+// Tombstone implements BankAccountStore.
+func (s *MemoryBankAccountStore) Tombstone(ctx context.Context, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[token]
+	if !ok {
+		return ErrBankAccountNotFound
+	}
+	record.Tombstoned = true
+	s.records[token] = record
+	return nil
+}
+
+// All implements BankAccountStore.
+func (s *MemoryBankAccountStore) All(ctx context.Context) ([]EncryptedBankAccountRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	records := make([]EncryptedBankAccountRecord, 0, len(s.records))
+	for _, record := range s.records {
+		if !record.Tombstoned {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+var _ BankAccountStore = (*MemoryBankAccountStore)(nil)
+
+// AuditEvent is one entry AuditLogger records for the bank account vault.
+type AuditEvent struct {
+	Action     string
+	Token      string
+	MerchantID string
+	At         time.Time
+}
+
+// AuditLogger receives an AuditEvent for every vault-affecting operation
+// (registration, payout, tombstone, key rotation), for a caller that needs
+// a compliance trail independent of this package's own logging.
+type AuditLogger interface {
+	Log(ctx context.Context, event AuditEvent)
+}
+
+// discardAuditLogger is the default AuditLogger: it drops every event.
+type discardAuditLogger struct{}
+
+func (discardAuditLogger) Log(ctx context.Context, event AuditEvent) {}
+
+// PayoutRequest requests a payout from FromMerchant to the bank account
+// behind ToBankAccountToken.
+type PayoutRequest struct {
+	FromMerchant       string  `json:"from_merchant"`
+	ToBankAccountToken string  `json:"to_bank_account_token"`
+	Amount             float64 `json:"amount"`
+	Reference          string  `json:"reference,omitempty"`
+}
+
+// PayoutInstruction is the RPP-ready instruction Payout builds, suitable
+// for submission to PayShap's rapid payments programme rails.
+type PayoutInstruction struct {
+	ID        string    `json:"id"`
+	ShapID    string    `json:"shap_id,omitempty"`
+	BankID    SABank    `json:"bank_id"`
+	Amount    Amount    `json:"amount"`
+	Reference string    `json:"reference,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// dailyPayoutLimits are the per-bank daily payout ceilings (in cents)
+// Payout enforces, set conservatively below each bank's published
+// interbank RPP limit.
+var dailyPayoutLimits = map[SABank]int{
+	BankABSA:      5_000_000,
+	BankCapitec:   3_000_000,
+	BankFNB:       5_000_000,
+	BankNedbank:   5_000_000,
+	BankStandard:  5_000_000,
+	BankInvestec:  10_000_000,
+	BankDiscovery: 2_000_000,
+	BankTymeBank:  1_000_000,
+	BankAfrican:   2_000_000,
+}
+
+// payShapVaultOptions is PayShap's bank-account-vault option target.
+type payShapVaultOptions struct {
+	store    BankAccountStore
+	auditLog AuditLogger
+}
+
+// PayShapOption configures the bank account vault NewPayShap builds.
+type PayShapOption = options.Option[payShapVaultOptions]
+
+// WithBankAccountStore overrides the default MemoryBankAccountStore, for a
+// multi-instance deployment that needs every instance to see the same
+// vaulted accounts.
+func WithBankAccountStore(store BankAccountStore) PayShapOption {
+	return func(o *payShapVaultOptions) { o.store = store }
+}
+
+// WithAuditLogger overrides the default no-op AuditLogger.
+func WithAuditLogger(logger AuditLogger) PayShapOption {
+	return func(o *payShapVaultOptions) { o.auditLog = logger }
+}
+
+// RegisterBankAccount validates and vaults reg, returning an opaque
+// BankAccountToken for later use with Payout. A ShapID-bound registration
+// is verified through LookupShapID; a raw account number goes through a
+// mocked penny-drop (PayShap's sandbox has no real penny-drop endpoint, so
+// this simulates one the same shape a production integration would call).
+func (p *PayShap) RegisterBankAccount(ctx context.Context, reg BankAccountRegistration) (*BankAccountToken, error) {
+	if reg.ShapID == "" && reg.AccountNumber == "" {
+		return nil, fmt.Errorf("payments: RegisterBankAccount requires a ShapID or an AccountNumber")
+	}
+
+	if reg.AccountNumber != "" {
+		if !p.ValidateBankAccount(reg.AccountNumber, reg.BankID) {
+			return nil, fmt.Errorf("payments: invalid account number for bank %q", reg.BankID)
+		}
+		if err := p.pennyDrop(ctx, reg); err != nil {
+			return nil, fmt.Errorf("payments: penny-drop verification failed: %w", err)
+		}
+	} else {
+		result, err := p.LookupShapID(ctx, reg.ShapID)
+		if err != nil {
+			return nil, err
+		}
+		if valid, _ := result["valid"].(bool); !valid {
+			return nil, fmt.Errorf("payments: ShapID %q did not resolve to a valid account", reg.ShapID)
+		}
+	}
+
+	ciphertext, nonce, err := p.encryptBankAccountRecord(bankAccountRecord{
+		MerchantID:        reg.MerchantID,
+		AccountHolderName: reg.AccountHolderName,
+		AccountNumber:     reg.AccountNumber,
+		ShapID:            reg.ShapID,
+		BankID:            reg.BankID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token := fmt.Sprintf("ba_%d", time.Now().UnixNano())
+	record := EncryptedBankAccountRecord{
+		Token:      token,
+		Ciphertext: ciphertext,
+		Nonce:      nonce,
+		KeyVersion: p.config.EncryptionKeyVersion,
+		BankID:     reg.BankID,
+		CreatedAt:  time.Now(),
+	}
+	if err := p.bankAccounts().Put(ctx, record); err != nil {
+		return nil, err
+	}
+
+	p.auditLogger().Log(ctx, AuditEvent{Action: "register_bank_account", Token: token, MerchantID: reg.MerchantID, At: time.Now()})
+
+	return &BankAccountToken{
+		Token:     token,
+		BankID:    reg.BankID,
+		Last4:     last4(reg.AccountNumber),
+		ShapID:    reg.ShapID,
+		CreatedAt: record.CreatedAt,
+	}, nil
+}
+
+// pennyDrop simulates PayShap's AVS-style small-value deposit
+// verification for a raw account number: production integrations submit
+// a < R1 test payment and confirm the account holder name the bank
+// returns matches; this sandbox stub just confirms the inputs are
+// well-formed, since there's no real rail to submit the test payment to.
+func (p *PayShap) pennyDrop(ctx context.Context, reg BankAccountRegistration) error {
+	if reg.AccountHolderName == "" {
+		return fmt.Errorf("account holder name is required for penny-drop verification")
+	}
+	return nil
+}
+
+// TombstoneBankAccount revokes token, refusing any further Payout against
+// it while preserving the record for audit purposes.
+func (p *PayShap) TombstoneBankAccount(ctx context.Context, token string) error {
+	if err := p.bankAccounts().Tombstone(ctx, token); err != nil {
+		return err
+	}
+	p.auditLogger().Log(ctx, AuditEvent{Action: "tombstone_bank_account", Token: token, At: time.Now()})
+	return nil
+}
+
+// RotateEncryptionKey re-encrypts every vaulted record currently under
+// oldKeyVersion with newKey/newKeyVersion, so a KMS key rotation doesn't
+// require re-vaulting (and re-verifying) every account. Callers should
+// update PayShapConfig.EncryptionKey/EncryptionKeyVersion to newKey's
+// values once this returns, so future RegisterBankAccount calls encrypt
+// under the new key too.
+func (p *PayShap) RotateEncryptionKey(ctx context.Context, oldKeyVersion int, newKey string, newKeyVersion int) error {
+	newKeyBytes, err := decodeEncryptionKey(newKey)
+	if err != nil {
+		return err
+	}
+
+	records, err := p.bankAccounts().All(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range records {
+		if record.KeyVersion != oldKeyVersion {
+			continue
+		}
+
+		decrypted, err := p.decryptBankAccountRecord(record)
+		if err != nil {
+			return fmt.Errorf("payments: rotating key for token %s: %w", record.Token, err)
+		}
+
+		plaintext, err := json.Marshal(decrypted)
+		if err != nil {
+			return fmt.Errorf("payments: re-encoding token %s: %w", record.Token, err)
+		}
+
+		ciphertext, nonce, err := encryptWithKey(newKeyBytes, plaintext)
+		if err != nil {
+			return fmt.Errorf("payments: re-encrypting token %s: %w", record.Token, err)
+		}
+
+		record.Ciphertext = ciphertext
+		record.Nonce = nonce
+		record.KeyVersion = newKeyVersion
+		if err := p.bankAccounts().Put(ctx, record); err != nil {
+			return err
+		}
+
+		p.auditLogger().Log(ctx, AuditEvent{Action: "rotate_encryption_key", Token: record.Token, At: time.Now()})
+	}
+
+	return nil
+}
+
+// Payout dereferences req.ToBankAccountToken and builds a PayoutInstruction
+// for PayShap RPP submission, enforcing the receiving bank's daily payout
+// limit. It does not itself submit the instruction to PayShap's rails --
+// the caller (or a connector.Connector) does that with the result.
+func (p *PayShap) Payout(ctx context.Context, req PayoutRequest) (*PayoutInstruction, error) {
+	record, err := p.bankAccounts().Get(ctx, req.ToBankAccountToken)
+	if err != nil {
+		return nil, err
+	}
+	if record.Tombstoned {
+		return nil, ErrBankAccountNotFound
+	}
+
+	amountCents := int(req.Amount * 100)
+	if err := p.checkDailyLimit(record.BankID, amountCents); err != nil {
+		return nil, err
+	}
+
+	plaintext, err := p.decryptBankAccountRecord(record)
+	if err != nil {
+		return nil, fmt.Errorf("payments: decrypting bank account for payout: %w", err)
+	}
+
+	instruction := &PayoutInstruction{
+		ID:        fmt.Sprintf("po_%d", time.Now().UnixNano()),
+		ShapID:    plaintext.ShapID,
+		BankID:    plaintext.BankID,
+		Amount:    Amount{Value: amountCents, Currency: "ZAR"},
+		Reference: req.Reference,
+		CreatedAt: time.Now(),
+	}
+
+	p.recordDailyPayout(record.BankID, amountCents)
+	p.auditLogger().Log(ctx, AuditEvent{Action: "payout", Token: req.ToBankAccountToken, MerchantID: req.FromMerchant, At: time.Now()})
+
+	return instruction, nil
+}
+
+// checkDailyLimit reports ErrDailyLimitExceeded if adding amountCents to
+// bankID's running total for today would exceed dailyPayoutLimits[bankID].
+func (p *PayShap) checkDailyLimit(bankID SABank, amountCents int) error {
+	limit, ok := dailyPayoutLimits[bankID]
+	if !ok {
+		return nil
+	}
+
+	p.payoutTotalsMu.Lock()
+	defer p.payoutTotalsMu.Unlock()
+
+	key := payoutLimitKey(bankID)
+	if p.payoutTotals[key]+amountCents > limit {
+		return ErrDailyLimitExceeded
+	}
+	return nil
+}
+
+// recordDailyPayout adds amountCents to bankID's running total for today.
+func (p *PayShap) recordDailyPayout(bankID SABank, amountCents int) {
+	p.payoutTotalsMu.Lock()
+	defer p.payoutTotalsMu.Unlock()
+
+	if p.payoutTotals == nil {
+		p.payoutTotals = make(map[string]int)
+	}
+	p.payoutTotals[payoutLimitKey(bankID)] += amountCents
+}
+
+// payoutLimitKey scopes a bank's running daily total to the current UTC
+// calendar day, so the limit resets at midnight rather than rolling.
+func payoutLimitKey(bankID SABank) string {
+	return fmt.Sprintf("%s:%s", bankID, time.Now().UTC().Format("2006-01-02"))
+}
+
+// bankAccounts lazily creates the default MemoryBankAccountStore if
+// WithBankAccountStore was never applied.
+func (p *PayShap) bankAccounts() BankAccountStore {
+	if p.bankAccountStore == nil {
+		p.bankAccountStore = NewMemoryBankAccountStore()
+	}
+	return p.bankAccountStore
+}
+
+// auditLogger lazily creates the default discardAuditLogger if
+// WithAuditLogger was never applied.
+func (p *PayShap) auditLogger() AuditLogger {
+	if p.auditLog == nil {
+		p.auditLog = discardAuditLogger{}
+	}
+	return p.auditLog
+}
+
+// encryptBankAccountRecord JSON-encodes record and seals it with
+// p.config.EncryptionKey.
+func (p *PayShap) encryptBankAccountRecord(record bankAccountRecord) (ciphertext, nonce []byte, err error) {
+	key, err := decodeEncryptionKey(p.config.EncryptionKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	plaintext, err := json.Marshal(record)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encryptWithKey(key, plaintext)
+}
+
+// decryptBankAccountRecord opens record.Ciphertext with
+// p.config.EncryptionKey and decodes it back into a bankAccountRecord.
+func (p *PayShap) decryptBankAccountRecord(record EncryptedBankAccountRecord) (bankAccountRecord, error) {
+	key, err := decodeEncryptionKey(p.config.EncryptionKey)
+	if err != nil {
+		return bankAccountRecord{}, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return bankAccountRecord{}, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return bankAccountRecord{}, err
+	}
+	plaintext, err := gcm.Open(nil, record.Nonce, record.Ciphertext, nil)
+	if err != nil {
+		return bankAccountRecord{}, err
+	}
+
+	var decoded bankAccountRecord
+	if err := json.Unmarshal(plaintext, &decoded); err != nil {
+		return bankAccountRecord{}, err
+	}
+	return decoded, nil
+}
+
+// decodeEncryptionKey base64-decodes key into the 32 bytes AES-256-GCM
+// requires.
+func decodeEncryptionKey(key string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return nil, fmt.Errorf("payments: EncryptionKey must be base64: %w", err)
+	}
+	if len(decoded) != 32 {
+		return nil, fmt.Errorf("payments: EncryptionKey must decode to 32 bytes (AES-256), got %d", len(decoded))
+	}
+	return decoded, nil
+}
+
+// encryptWithKey seals plaintext under key with a freshly-generated
+// nonce, returning both.
+func encryptWithKey(key, plaintext []byte) (ciphertext, nonce []byte, err error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, err
+	}
+	return gcm.Seal(nil, nonce, plaintext, nil), nonce, nil
+}
+
+// last4 returns the last 4 characters of accountNumber, or accountNumber
+// itself if shorter -- used to build a display-safe BankAccountToken
+// without the full account number.
+func last4(accountNumber string) string {
+	digits := regexp.MustCompile(`\D`).ReplaceAllString(accountNumber, "")
+	if len(digits) <= 4 {
+		return digits
+	}
+	return digits[len(digits)-4:]
+}
@@ -0,0 +1,259 @@
+// Package payments provides South African payment gateway integrations.
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+)
+
+// Peach provides Peach Payments integration (cards and alternative payment
+// methods via Peach's Checkout API). Unlike the redirect gateways, Peach
+// supports an authorize-then-settle flow, so Capture and Void are real
+// operations here rather than ErrUnsupported.
+type Peach struct {
+	client *lunahttp.Client
+	config PeachConfig
+}
+
+// NewPeach creates a new Peach instance.
+func NewPeach(client *lunahttp.Client, config PeachConfig) *Peach {
+	return &Peach{
+		client: client,
+		config: config,
+	}
+}
+
+// Provider implements Gateway.
+func (p *Peach) Provider() PaymentProvider { return ProviderPeach }
+
+// Capabilities implements Gateway. Peach's authorize/capture/reverse flow
+// supports the full capture and void lifecycle, plus GetPayment lookups.
+func (p *Peach) Capabilities() Capabilities {
+	return Capabilities{SupportsGetPayment: true, SupportsCapture: true, SupportsVoid: true}
+}
+
+// CreatePayment creates a checkout and returns the redirect URL.
+func (p *Peach) CreatePayment(ctx context.Context, req PaymentRequest) (*Payment, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = "ZAR"
+	}
+
+	body := map[string]interface{}{
+		"entityId":              p.config.EntityID,
+		"amount":                fmt.Sprintf("%.2f", req.Amount),
+		"currency":              currency,
+		"paymentType":           "DB",
+		"merchantTransactionId": req.Reference,
+		"shopperResultUrl":      req.SuccessURL,
+	}
+
+	resp, err := p.client.Request(ctx, lunahttp.RequestConfig{
+		Method:   "POST",
+		Path:     "/v1/payments/peach/checkouts",
+		Body:     body,
+		Resource: "Payments.Peach.CreatePayment",
+		Headers:  idempotencyHeaders(req.IdempotencyKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result peachCheckoutResult
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, err
+	}
+
+	return &Payment{
+		ID:          result.ID,
+		Provider:    ProviderPeach,
+		Amount:      Amount{Value: int(req.Amount * 100), Currency: currency},
+		Status:      peachResultStatus(result.Result.Code),
+		Reference:   req.Reference,
+		Description: req.Description,
+		PaymentURL:  result.RedirectURL,
+		NextAction:  redirectAction(result.RedirectURL),
+		Raw:         map[string]interface{}{"result_code": result.Result.Code, "result_description": result.Result.Description},
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// GetPayment retrieves a checkout's current state.
+func (p *Peach) GetPayment(ctx context.Context, paymentID string) (*Payment, error) {
+	resp, err := p.client.Request(ctx, lunahttp.RequestConfig{
+		Method:   "GET",
+		Path:     fmt.Sprintf("/v1/payments/peach/checkouts/%s", paymentID),
+		Query:    url.Values{"entityId": {p.config.EntityID}},
+		Resource: "Payments.Peach.GetPayment",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result peachCheckoutResult
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, err
+	}
+
+	return &Payment{
+		ID:        result.ID,
+		Provider:  ProviderPeach,
+		Amount:    Amount{Value: int(result.Amount * 100), Currency: result.Currency},
+		Status:    peachResultStatus(result.Result.Code),
+		Reference: result.MerchantTransactionID,
+		Raw:       map[string]interface{}{"result_code": result.Result.Code, "result_description": result.Result.Description},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// Capture captures a previously authorized (paymentType PA) payment.
+func (p *Peach) Capture(ctx context.Context, paymentID string) (*Payment, error) {
+	return p.referencedTransaction(ctx, paymentID, "CP", "Payments.Peach.Capture", "")
+}
+
+// Void reverses a previously authorized, uncaptured payment.
+func (p *Peach) Void(ctx context.Context, paymentID string) (*Payment, error) {
+	return p.referencedTransaction(ctx, paymentID, "RV", "Payments.Peach.Void", "")
+}
+
+// Refund reverses a captured payment back to the customer.
+func (p *Peach) Refund(ctx context.Context, req RefundRequest) (*Refund, error) {
+	refundResult, err := p.referencedTransaction(ctx, req.PaymentID, "RF", "Payments.Peach.Refund", req.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := refundResult.Amount.Value
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+
+	return &Refund{
+		ID:        refundResult.ID,
+		PaymentID: req.PaymentID,
+		Amount:    Amount{Value: amount, Currency: refundResult.Amount.Currency},
+		Status:    string(refundResult.Status),
+		Reason:    req.Reason,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+// referencedTransaction posts a follow-up transaction (capture, reversal,
+// or refund) that references a prior checkout by ID. idempotencyKey is
+// sent as an Idempotency-Key header when non-empty; Capture/Void pass ""
+// since they aren't driven by a caller-supplied request struct.
+func (p *Peach) referencedTransaction(ctx context.Context, paymentID, paymentType, resource, idempotencyKey string) (*Payment, error) {
+	resp, err := p.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "POST",
+		Path:   fmt.Sprintf("/v1/payments/peach/checkouts/%s/referenced", paymentID),
+		Body: map[string]interface{}{
+			"entityId":    p.config.EntityID,
+			"paymentType": paymentType,
+		},
+		Resource: resource,
+		Headers:  idempotencyHeaders(idempotencyKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result peachCheckoutResult
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, err
+	}
+
+	return &Payment{
+		ID:        result.ID,
+		Provider:  ProviderPeach,
+		Amount:    Amount{Value: int(result.Amount * 100), Currency: result.Currency},
+		Status:    peachResultStatus(result.Result.Code),
+		Reference: result.MerchantTransactionID,
+		Raw:       map[string]interface{}{"result_code": result.Result.Code, "result_description": result.Result.Description},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// VerifyWebhook verifies the notification signature. payload must carry
+// the raw JSON body under "raw_body" and the signature header value under
+// "signature".
+func (p *Peach) VerifyWebhook(payload map[string]interface{}) bool {
+	mac := hmac.New(sha256.New, []byte(p.config.WebhookSecret))
+	mac.Write([]byte(stringField(payload, "raw_body")))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(stringField(payload, "signature")), []byte(expected))
+}
+
+// ProcessWebhook processes a notification and returns the payment it
+// describes.
+func (p *Peach) ProcessWebhook(payload map[string]interface{}) (*Payment, error) {
+	amount := 0.0
+	fmt.Sscanf(stringField(payload, "amount"), "%f", &amount)
+
+	currency := stringField(payload, "currency")
+	if currency == "" {
+		currency = "ZAR"
+	}
+
+	resultCode := stringField(payload, "result_code")
+
+	return &Payment{
+		ID:        stringField(payload, "id"),
+		Provider:  ProviderPeach,
+		Amount:    Amount{Value: int(amount * 100), Currency: currency},
+		Status:    peachResultStatus(resultCode),
+		Reference: stringField(payload, "merchant_transaction_id"),
+		Raw:       map[string]interface{}{"result_code": resultCode},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+type peachCheckoutResult struct {
+	ID                    string  `json:"id"`
+	Amount                float64 `json:"amount,string"`
+	Currency              string  `json:"currency"`
+	RedirectURL           string  `json:"redirectUrl"`
+	MerchantTransactionID string  `json:"merchantTransactionId"`
+	Result                struct {
+		Code        string `json:"code"`
+		Description string `json:"description"`
+	} `json:"result"`
+	// ThreeDSecure is set on card checkouts that went through 3-D Secure
+	// authentication (see peach_threeds.go); empty for every other payment
+	// method Peach supports.
+	ThreeDSecure struct {
+		HTML                string `json:"html"`
+		DSTransID           string `json:"dsTransId"`
+		ECI                 string `json:"eci"`
+		AuthenticationValue string `json:"authenticationValue"`
+	} `json:"threeDSecure"`
+}
+
+// peachResultStatus maps a Peach result code to a PaymentStatus. Peach's
+// codes are dot-delimited with successful transactions starting
+// "000.000." or "000.100.1"; everything else is treated as pending until
+// a terminal code (000 prefix success, otherwise failed) is known.
+func peachResultStatus(code string) PaymentStatus {
+	switch {
+	case code == "":
+		return StatusPending
+	case strings.HasPrefix(code, "000.000.") || strings.HasPrefix(code, "000.100.1"):
+		return StatusCompleted
+	case strings.HasPrefix(code, "000."):
+		return StatusProcessing
+	default:
+		return StatusFailed
+	}
+}
@@ -0,0 +1,109 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+)
+
+// threeDSTokenValidity is how long Init3DSPayment's session token remains
+// valid, well past any reasonable cardholder challenge interaction.
+const threeDSTokenValidity = 15 * time.Minute
+
+// Init3DSPayment implements CardsGateway. It initiates a card checkout
+// with Peach's challengeIndicator set, branching on whether the issuer
+// authenticated the cardholder frictionlessly (ThreeDSecure.HTML empty,
+// payment already authorized) or requires a challenge (HTML holds the
+// ACS's challenge form to mount).
+func (p *Peach) Init3DSPayment(ctx context.Context, req ThreeDSInitRequest) (*ThreeDSInitResponse, error) {
+	currency := req.Currency
+	if currency == "" {
+		currency = "ZAR"
+	}
+
+	token := NewThreeDSSessionToken(p.config.ThreeDSSessionSecret)
+	expiresAt := time.Now().Add(threeDSTokenValidity)
+
+	body := map[string]interface{}{
+		"entityId":                        p.config.EntityID,
+		"amount":                          fmt.Sprintf("%.2f", req.Amount),
+		"currency":                        currency,
+		"paymentType":                     "DB",
+		"merchantTransactionId":           req.Reference,
+		"shopperResultUrl":                req.ReturnURL,
+		"threeDSecure.challengeIndicator": "01",
+	}
+
+	resp, err := p.client.Request(ctx, lunahttp.RequestConfig{
+		Method:   "POST",
+		Path:     "/v1/payments/peach/checkouts",
+		Body:     body,
+		Resource: "Payments.Peach.Init3DSPayment",
+		Headers:  idempotencyHeaders(req.IdempotencyKey),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result peachCheckoutResult
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, err
+	}
+
+	init := &ThreeDSInitResponse{
+		PaymentID:        result.ID,
+		ACSTransactionID: result.ThreeDSecure.DSTransID,
+		Frictionless:     result.ThreeDSecure.HTML == "" && result.RedirectURL == "",
+		HTMLContent:      result.ThreeDSecure.HTML,
+		SessionToken:     token.Sign(result.ID, expiresAt),
+	}
+	if !init.Frictionless && result.ThreeDSecure.HTML == "" {
+		init.RedirectURL = result.RedirectURL
+	}
+	return init, nil
+}
+
+// Complete3DSPayment implements CardsGateway. It submits the ACS's
+// authentication result (cres) back to Peach to finish authorizing
+// paymentID, propagating the resulting authenticationValue/eci/dsTransID
+// into Payment.Raw for the caller's records.
+func (p *Peach) Complete3DSPayment(ctx context.Context, paymentID string, cres string) (*Payment, error) {
+	resp, err := p.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "POST",
+		Path:   fmt.Sprintf("/v1/payments/peach/checkouts/%s/3ds", paymentID),
+		Body: map[string]interface{}{
+			"entityId": p.config.EntityID,
+			"cres":     cres,
+		},
+		Resource: "Payments.Peach.Complete3DSPayment",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result peachCheckoutResult
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, err
+	}
+
+	return &Payment{
+		ID:        result.ID,
+		Provider:  ProviderPeach,
+		Amount:    Amount{Value: int(result.Amount * 100), Currency: result.Currency},
+		Status:    peachResultStatus(result.Result.Code),
+		Reference: result.MerchantTransactionID,
+		Raw: map[string]interface{}{
+			"result_code":          result.Result.Code,
+			"eci":                  result.ThreeDSecure.ECI,
+			"authentication_value": result.ThreeDSecure.AuthenticationValue,
+			"ds_trans_id":          result.ThreeDSecure.DSTransID,
+		},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+var _ CardsGateway = (*Peach)(nil)
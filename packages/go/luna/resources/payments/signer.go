@@ -0,0 +1,38 @@
+package payments
+
+import (
+	"crypto/md5"
+	"crypto/sha512"
+	"encoding/hex"
+)
+
+// Signer hashes a gateway's canonical signing string into the
+// signature/checksum format that gateway expects. Each gateway builds its
+// own canonical string (PayFast and PayGate sort and join "key=value"
+// pairs, Ozow concatenates fields in a fixed order) but delegates the
+// final hash to a shared Signer, so the hashing primitive isn't
+// duplicated across adapters.
+type Signer interface {
+	// Sign returns the hex-encoded digest of canonical.
+	Sign(canonical string) string
+}
+
+// MD5Signer hashes with MD5, the scheme PayFast and PayGate use for their
+// request signatures and webhook/query checksums.
+type MD5Signer struct{}
+
+// Sign implements Signer.
+func (MD5Signer) Sign(canonical string) string {
+	sum := md5.Sum([]byte(canonical))
+	return hex.EncodeToString(sum[:])
+}
+
+// SHA512Signer hashes with SHA-512, the scheme Ozow uses for its
+// HashCheck field.
+type SHA512Signer struct{}
+
+// Sign implements Signer.
+func (SHA512Signer) Sign(canonical string) string {
+	sum := sha512.Sum512([]byte(canonical))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,166 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ThreeDSInitRequest is a PaymentRequest plus the return URL the issuer's
+// Access Control Server (ACS) redirects or posts back to once the
+// cardholder has completed (or abandoned) authentication.
+type ThreeDSInitRequest struct {
+	PaymentRequest
+	// ReturnURL is where the ACS sends the cardholder after authentication,
+	// typically a ThreeDSCallbackHandler's URL. Init3DSPayment appends a
+	// signed session token to it as a query parameter.
+	ReturnURL string `json:"return_url"`
+}
+
+// ThreeDSInitResponse is what Init3DSPayment returns: either a
+// frictionless result (Frictionless true, no further action needed) or a
+// challenge for the caller to mount, following the Init3DSPaymentResponse
+// shape used by acquirer SDKs like Craftgate's.
+type ThreeDSInitResponse struct {
+	// PaymentID identifies the payment Complete3DSPayment finalizes once
+	// the challenge (if any) completes.
+	PaymentID string `json:"payment_id"`
+	// Frictionless reports whether the issuer authenticated the
+	// cardholder without a challenge, in which case HTMLContent and
+	// RedirectURL are both empty and the payment is already settled.
+	Frictionless bool `json:"frictionless"`
+	// HTMLContent is the ACS's challenge form, to render in an iframe the
+	// cardholder interacts with directly. Empty when Frictionless or when
+	// the ACS uses a redirect instead.
+	HTMLContent string `json:"html_content,omitempty"`
+	// RedirectURL is where to send the cardholder for ACS's that redirect
+	// rather than post a challenge form. Empty when Frictionless or when
+	// the ACS uses HTMLContent instead.
+	RedirectURL string `json:"redirect_url,omitempty"`
+	// ACSTransactionID is the ACS's transaction ID (dsTransID) for this
+	// authentication, propagated into the authorization call alongside
+	// authenticationValue/eci once the challenge completes.
+	ACSTransactionID string `json:"acs_transaction_id,omitempty"`
+	// SessionToken is the signed token ThreeDSCallbackHandler expects back
+	// from the ACS callback. Callers building their own return URL (rather
+	// than using RedirectURL/HTMLContent as-is) must append it themselves.
+	SessionToken string `json:"session_token,omitempty"`
+}
+
+// CardsGateway is implemented by card-acquiring Gateways that authenticate
+// cardholders through issuer 3-D Secure, distinct from the EFT (Ozow) and
+// real-time payment (PayShap) providers that never reach an ACS.
+type CardsGateway interface {
+	Gateway
+	// Init3DSPayment begins card authentication for req, returning either
+	// a frictionless result or a challenge for the caller to mount.
+	Init3DSPayment(ctx context.Context, req ThreeDSInitRequest) (*ThreeDSInitResponse, error)
+	// Complete3DSPayment finalizes paymentID once the cardholder's
+	// challenge has completed, authorizing with the ACS's authentication
+	// result (cres, the issuer's CRes/PARes payload).
+	Complete3DSPayment(ctx context.Context, paymentID string, cres string) (*Payment, error)
+}
+
+// ThreeDSSessionToken signs and verifies the token Init3DSPayment binds to
+// a payment ID and embeds in the ACS return URL, so
+// ThreeDSCallbackHandler can reject a callback whose payment ID was
+// tampered with or replayed past its validity window.
+type ThreeDSSessionToken struct {
+	secret string
+}
+
+// NewThreeDSSessionToken creates a ThreeDSSessionToken signing with secret.
+func NewThreeDSSessionToken(secret string) ThreeDSSessionToken {
+	return ThreeDSSessionToken{secret: secret}
+}
+
+// Sign returns a token binding paymentID to validUntil, in the form
+// "<paymentID>:<unix expiry>:<hex hmac>".
+func (t ThreeDSSessionToken) Sign(paymentID string, validUntil time.Time) string {
+	payload := paymentID + ":" + strconv.FormatInt(validUntil.Unix(), 10)
+	return payload + ":" + t.mac(payload)
+}
+
+// Verify reports whether token is an unexpired signature over paymentID.
+func (t ThreeDSSessionToken) Verify(paymentID, token string) bool {
+	parts := strings.SplitN(token, ":", 3)
+	if len(parts) != 3 || parts[0] != paymentID {
+		return false
+	}
+
+	payload := parts[0] + ":" + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(t.mac(payload))) {
+		return false
+	}
+
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return false
+	}
+	return time.Now().Unix() <= expiry
+}
+
+func (t ThreeDSSessionToken) mac(payload string) string {
+	mac := hmac.New(sha256.New, []byte(t.secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ThreeDSCallbackHandler ingests the issuer ACS's PARes/CRes POST to the
+// merchant's return URL and finalizes the payment through Gateway's
+// Complete3DSPayment, verifying Token first so a client can't complete an
+// arbitrary payment ID by posting directly to this endpoint.
+type ThreeDSCallbackHandler struct {
+	Gateway CardsGateway
+	Token   ThreeDSSessionToken
+	// OnComplete, if set, is called with the finalized Payment (or the
+	// error Complete3DSPayment returned) after every callback, for a
+	// caller that wants to notify the customer out-of-band rather than
+	// relying on the handler's own response.
+	OnComplete func(payment *Payment, err error)
+}
+
+// NewThreeDSCallbackHandler creates a ThreeDSCallbackHandler for gateway,
+// verifying callbacks with a ThreeDSSessionToken signed by sessionSecret
+// (the same value as that gateway's ThreeDSSessionSecret config field).
+func NewThreeDSCallbackHandler(gateway CardsGateway, sessionSecret string) *ThreeDSCallbackHandler {
+	return &ThreeDSCallbackHandler{Gateway: gateway, Token: NewThreeDSSessionToken(sessionSecret)}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *ThreeDSCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid 3-D Secure callback payload", http.StatusBadRequest)
+		return
+	}
+
+	paymentID := r.PostForm.Get("paymentId")
+	if !h.Token.Verify(paymentID, r.PostForm.Get("token")) {
+		http.Error(w, "invalid or expired session token", http.StatusForbidden)
+		return
+	}
+
+	cres := r.PostForm.Get("cres")
+	if cres == "" {
+		cres = r.PostForm.Get("PaRes")
+	}
+
+	payment, err := h.Gateway.Complete3DSPayment(r.Context(), paymentID, cres)
+	if h.OnComplete != nil {
+		h.OnComplete(payment, err)
+	}
+	if err != nil {
+		http.Error(w, fmt.Sprintf("3-D Secure completion failed: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+var _ http.Handler = (*ThreeDSCallbackHandler)(nil)
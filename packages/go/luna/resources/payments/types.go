@@ -13,6 +13,8 @@ const (
 	ProviderOzow    PaymentProvider = "ozow"
 	ProviderYoco    PaymentProvider = "yoco"
 	ProviderPayShap PaymentProvider = "payshap"
+	ProviderPayGate PaymentProvider = "paygate"
+	ProviderPeach   PaymentProvider = "peach"
 )
 
 // PaymentStatus represents the status of a payment
@@ -62,112 +64,124 @@ type PayShapConfig struct {
 	BankID     string `json:"bank_id"`
 	APIKey     string `json:"api_key,omitempty"`
 	Sandbox    bool   `json:"sandbox"`
-}
-
-// Config holds all payments configuration
+	// EncryptionKey is the base64-encoded 32-byte AES-256 key
+	// RegisterBankAccount encrypts vaulted bank account details with,
+	// typically sourced from a KMS rather than stored in plaintext
+	// config. See WithEncryptionKeyRing for rotating it without
+	// re-vaulting every account.
+	EncryptionKey string `json:"encryption_key,omitempty"`
+	// EncryptionKeyVersion identifies EncryptionKey among a
+	// WithEncryptionKeyRing's versions, so RotateEncryptionKey knows which
+	// key a given vaulted record is still encrypted under.
+	EncryptionKeyVersion int `json:"encryption_key_version,omitempty"`
+}
+
+// PayGateConfig holds PayGate PayWeb3 configuration
+type PayGateConfig struct {
+	PayGateID     string `json:"pay_gate_id"`
+	PayGateSecret string `json:"pay_gate_secret"`
+	Sandbox       bool   `json:"sandbox"`
+}
+
+// PeachConfig holds Peach Payments configuration
+type PeachConfig struct {
+	EntityID      string `json:"entity_id"`
+	AccessToken   string `json:"access_token"`
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+	// ThreeDSSessionSecret signs the session token Init3DSPayment embeds
+	// in the issuer ACS callback, so ThreeDSCallbackHandler can tell a
+	// genuine callback from a forged POST to the return URL. Required for
+	// card payments that go through 3-D Secure; unused otherwise.
+	ThreeDSSessionSecret string `json:"three_ds_session_secret,omitempty"`
+	Sandbox              bool   `json:"sandbox"`
+}
+
+// Config holds all payments configuration. Retry timing (attempts,
+// backoff, jitter) isn't configured here -- every provider shares the
+// lunahttp.Client passed to NewPayments, so it's tuned once for all of
+// them via lunahttp.WithRetryPolicy when that client is built.
 type Config struct {
 	PayFast *PayFastConfig `json:"payfast,omitempty"`
 	Ozow    *OzowConfig    `json:"ozow,omitempty"`
 	Yoco    *YocoConfig    `json:"yoco,omitempty"`
 	PayShap *PayShapConfig `json:"payshap,omitempty"`
-}
-
-// PayFastPaymentRequest represents a PayFast payment request
-type PayFastPaymentRequest struct {
-	Amount          float64 `json:"amount"`
-	ItemName        string  `json:"item_name"`
-	ReturnURL       string  `json:"return_url"`
-	CancelURL       string  `json:"cancel_url"`
-	NotifyURL       string  `json:"notify_url"`
-	ItemDescription string  `json:"item_description,omitempty"`
-	Currency        string  `json:"currency,omitempty"`
-	EmailAddress    string  `json:"email_address,omitempty"`
-	CellNumber      string  `json:"cell_number,omitempty"`
-	PaymentMethod   string  `json:"payment_method,omitempty"`
-	CustomStr1      string  `json:"custom_str1,omitempty"`
-	CustomStr2      string  `json:"custom_str2,omitempty"`
-	CustomStr3      string  `json:"custom_str3,omitempty"`
-	CustomInt1      int     `json:"custom_int1,omitempty"`
-	CustomInt2      int     `json:"custom_int2,omitempty"`
-}
-
-// PayFastPayment represents a PayFast payment
-type PayFastPayment struct {
-	ID          string        `json:"id"`
-	Provider    string        `json:"provider"`
-	Amount      Amount        `json:"amount"`
-	Status      PaymentStatus `json:"status"`
-	Reference   string        `json:"reference,omitempty"`
-	Description string        `json:"description,omitempty"`
-	PaymentURL  string        `json:"payment_url"`
-	Signature   string        `json:"signature,omitempty"`
-	PFPaymentID string        `json:"pf_payment_id,omitempty"`
-	CreatedAt   time.Time     `json:"created_at"`
-	UpdatedAt   time.Time     `json:"updated_at"`
-}
-
-// OzowPaymentRequest represents an Ozow payment request
-type OzowPaymentRequest struct {
-	Amount               float64 `json:"amount"`
-	TransactionReference string  `json:"transaction_reference"`
-	BankReference        string  `json:"bank_reference"`
-	SuccessURL           string  `json:"success_url"`
-	CancelURL            string  `json:"cancel_url"`
-	ErrorURL             string  `json:"error_url"`
-	NotifyURL            string  `json:"notify_url"`
-	IsTest               *bool   `json:"is_test,omitempty"`
-	CustomerFirstName    string  `json:"customer_first_name,omitempty"`
-	CustomerLastName     string  `json:"customer_last_name,omitempty"`
-	CustomerEmail        string  `json:"customer_email,omitempty"`
-	CustomerPhone        string  `json:"customer_phone,omitempty"`
-}
-
-// OzowPayment represents an Ozow payment
-type OzowPayment struct {
-	ID            string        `json:"id"`
-	Provider      string        `json:"provider"`
-	Amount        Amount        `json:"amount"`
-	Status        PaymentStatus `json:"status"`
-	Reference     string        `json:"reference,omitempty"`
-	Description   string        `json:"description,omitempty"`
-	PaymentURL    string        `json:"payment_url"`
-	TransactionID string        `json:"transaction_id,omitempty"`
-	CreatedAt     time.Time     `json:"created_at"`
-	UpdatedAt     time.Time     `json:"updated_at"`
-}
-
-// YocoPaymentRequest represents a Yoco payment request
-type YocoPaymentRequest struct {
-	Amount     int                    `json:"amount"` // In cents
-	SuccessURL string                 `json:"success_url"`
-	CancelURL  string                 `json:"cancel_url"`
-	FailureURL string                 `json:"failure_url,omitempty"`
-	Currency   string                 `json:"currency,omitempty"`
-	Metadata   map[string]interface{} `json:"metadata,omitempty"`
-	LineItems  []YocoLineItem         `json:"line_items,omitempty"`
-}
-
-// YocoLineItem represents a line item in Yoco checkout
-type YocoLineItem struct {
-	DisplayName    string `json:"displayName"`
-	Quantity       int    `json:"quantity"`
-	PricingDetails struct {
-		Price int `json:"price"`
-	} `json:"pricingDetails"`
-}
-
-// YocoPayment represents a Yoco payment
-type YocoPayment struct {
+	PayGate *PayGateConfig `json:"paygate,omitempty"`
+	Peach   *PeachConfig   `json:"peach,omitempty"`
+}
+
+// PaymentRequest is the provider-agnostic shape accepted by every Gateway's
+// CreatePayment. Fields a particular provider doesn't have a dedicated slot
+// for (PayFast's custom_str1-3, Yoco's line items, ...) are read out of
+// Metadata, keeping the common case (amount, reference, redirect URLs)
+// free of provider-specific noise.
+type PaymentRequest struct {
+	Amount            float64                `json:"amount"`
+	Currency          string                 `json:"currency,omitempty"`
+	Reference         string                 `json:"reference,omitempty"`
+	Description       string                 `json:"description,omitempty"`
+	SuccessURL        string                 `json:"success_url,omitempty"`
+	CancelURL         string                 `json:"cancel_url,omitempty"`
+	ErrorURL          string                 `json:"error_url,omitempty"`
+	NotifyURL         string                 `json:"notify_url,omitempty"`
+	CustomerFirstName string                 `json:"customer_first_name,omitempty"`
+	CustomerLastName  string                 `json:"customer_last_name,omitempty"`
+	CustomerEmail     string                 `json:"customer_email,omitempty"`
+	CustomerPhone     string                 `json:"customer_phone,omitempty"`
+	Metadata          map[string]interface{} `json:"metadata,omitempty"`
+	// IdempotencyKey, if set, is sent as the Idempotency-Key header on the
+	// underlying CreatePayment request, including every retry of it, so a
+	// caller that crashes or times out waiting for a response can safely
+	// resubmit the same PaymentRequest without risking a duplicate charge.
+	IdempotencyKey string `json:"-"`
+}
+
+// Payment is the provider-agnostic representation of a payment returned by
+// every Gateway implementation. Fields specific to one provider (PayFast's
+// signature, Ozow's transaction ID, Yoco's checkout ID, ...) live in Raw so
+// application code written against Gateway stays portable across providers.
+type Payment struct {
 	ID          string                 `json:"id"`
-	Provider    string                 `json:"provider"`
-	CheckoutID  string                 `json:"checkout_id"`
+	Provider    PaymentProvider        `json:"provider"`
 	Amount      Amount                 `json:"amount"`
 	Status      PaymentStatus          `json:"status"`
 	Reference   string                 `json:"reference,omitempty"`
-	RedirectURL string                 `json:"redirect_url"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
+	Description string                 `json:"description,omitempty"`
+	PaymentURL  string                 `json:"payment_url,omitempty"`
+	Raw         map[string]interface{} `json:"raw,omitempty"`
+	// NextAction, when set, is what the caller must do before Status can
+	// progress out of Pending/Processing -- redirect the customer, mount a
+	// 3DS challenge, display a QR code, or simply poll (see
+	// PollUntilTerminal). Nil once a payment has reached a terminal status.
+	NextAction *PaymentAction `json:"next_action,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// PaymentActionType identifies the kind of step a PaymentAction describes.
+type PaymentActionType string
+
+const (
+	ActionRedirect     PaymentActionType = "redirect"
+	Action3DSChallenge PaymentActionType = "3ds_challenge"
+	ActionDisplayQR    PaymentActionType = "display_qr"
+	ActionPoll         PaymentActionType = "poll"
+)
+
+// PaymentAction describes a next step a caller must complete before a
+// payment can reach a terminal status: following a redirect, mounting a
+// 3DS challenge iframe, displaying a QR code (PayShap), or polling
+// GetPayment until the backend settles it (Yoco's async webhook
+// confirmation).
+type PaymentAction struct {
+	Type   PaymentActionType `json:"type"`
+	URL    string            `json:"url,omitempty"`
+	Method string            `json:"method,omitempty"`
+	// ExpiresAt is when this action is no longer valid, e.g. a PayShap QR
+	// code's expiry. Nil if the provider doesn't report one.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Params carries provider-specific fields a caller's UI needs but that
+	// don't fit Type/URL/Method (e.g. a 3DS challenge's MD/PaReq fields).
+	Params map[string]interface{} `json:"params,omitempty"`
 }
 
 // PayShapPaymentRequest represents a PayShap payment request
@@ -199,6 +213,11 @@ type RefundRequest struct {
 	PaymentID string `json:"payment_id"`
 	Amount    *int   `json:"amount,omitempty"` // Partial refund in cents
 	Reason    string `json:"reason,omitempty"`
+	// IdempotencyKey, if set, is sent as the Idempotency-Key header on the
+	// underlying Refund request, including every retry of it, so a caller
+	// that crashes or times out waiting for a response can safely resubmit
+	// the same RefundRequest without risking a duplicate refund.
+	IdempotencyKey string `json:"-"`
 }
 
 // Refund represents a refund
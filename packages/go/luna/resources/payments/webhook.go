@@ -0,0 +1,39 @@
+package payments
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnsupported is returned by a Gateway method a provider's payment flow
+// has no equivalent for (e.g. Capture/Void on a gateway that only ever
+// does an immediate, single-step redirect payment).
+var ErrUnsupported = errors.New("payments: operation not supported by this provider")
+
+// idempotencyHeaders returns the Headers map to pass through
+// lunahttp.RequestConfig so key, if non-empty, is sent as an
+// Idempotency-Key header on every attempt of the request -- including
+// retries, which reuse the same RequestConfig and so the same key. Returns
+// nil when key is empty, so callers can assign it straight into
+// RequestConfig.Headers without a conditional.
+func idempotencyHeaders(key string) map[string]string {
+	if key == "" {
+		return nil
+	}
+	return map[string]string{"Idempotency-Key": key}
+}
+
+// stringField safely reads a string-valued field out of a webhook payload
+// decoded from JSON or assembled from form-encoded POST values. Webhook
+// bodies are attacker-influenced input, so this never panics on a missing
+// key or an unexpected type — it just returns "".
+func stringField(payload map[string]interface{}, key string) string {
+	v, ok := payload[key]
+	if !ok {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,77 @@
+// Package webhooks provides server-side verification and dispatch for the
+// payment gateways in the parent payments package. Each gateway signs its
+// webhook deliveries differently (PayFast an MD5 param signature plus a
+// source-IP allowlist, Ozow a SHA-512 field concatenation, Yoco an
+// HMAC-SHA256 over the raw body, PayShap an ISO 20022 message signature),
+// so there is one Verifier per gateway; Handler is gateway-agnostic and
+// dispatches the typed Event each Verifier produces to registered
+// listeners, deduplicating replays via IdempotencyStore.
+package webhooks
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+)
+
+// EventType identifies what happened to a payment, independent of which
+// gateway reported it.
+type EventType string
+
+const (
+	PaymentSucceeded EventType = "payment.succeeded"
+	PaymentFailed    EventType = "payment.failed"
+	Refunded         EventType = "refunded"
+	Chargeback       EventType = "chargeback"
+)
+
+// Event is the provider-agnostic shape a Verifier produces from a single
+// webhook delivery, mirroring how payments.Payment stays portable across
+// Gateway implementations.
+type Event struct {
+	// ID identifies this specific delivery for idempotency purposes. It is
+	// derived from whatever the gateway includes that's stable across
+	// retried deliveries of the same event (a transaction ID, payment ID,
+	// or gateway-issued delivery ID) -- never generated fresh per request.
+	ID       string
+	Type     EventType
+	Provider payments.PaymentProvider
+	// Payment is the parsed payment the event describes, when the gateway's
+	// payload carries enough information to build one.
+	Payment *payments.Payment
+	// Raw is the gateway's original payload, for auditing or fields Payment
+	// and the typed Event fields don't surface.
+	Raw        map[string]interface{}
+	ReceivedAt time.Time
+	// Timestamp is when the gateway itself says the event occurred, read
+	// from whatever header or field that gateway's delivery carries (Yoco's
+	// webhook-timestamp header). Nil for gateways whose payload carries no
+	// such timestamp (PayFast, Ozow, PayShap), in which case Receiver's
+	// Tolerance window can't be enforced and is skipped.
+	Timestamp *time.Time
+}
+
+// Verifier authenticates a webhook delivery and, if valid, parses it into
+// an Event. Each gateway's Verifier constructor (NewPayFastVerifier,
+// NewOzowVerifier, ...) takes whatever that gateway's signature scheme
+// actually requires, rather than forcing a one-size-fits-all signature.
+type Verifier interface {
+	Verify(r *http.Request) (Event, error)
+}
+
+// stringField safely reads a string-valued field out of a webhook payload
+// decoded from JSON or assembled from form-encoded POST values. Webhook
+// bodies are attacker-influenced input, so this never panics on a missing
+// key or an unexpected type -- it just returns "".
+func stringField(payload map[string]interface{}, key string) string {
+	v, ok := payload[key]
+	if !ok {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", v)
+}
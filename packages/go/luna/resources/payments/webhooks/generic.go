@@ -0,0 +1,102 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+)
+
+// ErrHMACBadSignature is returned by HMACVerifier.Verify when the
+// delivery's signature header doesn't match the body's computed HMAC.
+var ErrHMACBadSignature = errors.New("webhooks: HMAC signature mismatch")
+
+// HMACVerifier verifies a webhook delivery the way most non-South-African
+// payment APIs (Adyen, Stripe, and similar) do it: a single hex-encoded
+// HMAC-SHA256 digest of the raw request body, carried in one header.
+// Use it for a gateway this package has no dedicated Verifier for yet,
+// rather than leaving that integration unverified; PayFast, Ozow, Yoco,
+// and PayShap each have their own Verifier because their signature
+// schemes don't fit this shape.
+type HMACVerifier struct {
+	Secret string
+	// Provider is stamped onto every Event this Verifier produces.
+	Provider payments.PaymentProvider
+	// SignatureHeader names the header carrying the hex-encoded digest.
+	// Empty means "X-Webhook-Signature".
+	SignatureHeader string
+	// EventTypeField is the top-level JSON field naming the event type.
+	// Empty means "eventType".
+	EventTypeField string
+	// EventTypeMap maps the gateway's own event type strings to this
+	// package's EventType. An unrecognized or missing value maps to
+	// PaymentFailed, matching YocoVerifier's fallback.
+	EventTypeMap map[string]EventType
+	// IDField is the top-level JSON field used, prefixed with Provider, to
+	// build Event.ID for idempotency purposes. Empty means "id".
+	IDField string
+}
+
+// NewHMACVerifier creates an HMACVerifier bound to secret for provider,
+// using this struct's defaults for every other field. Set the exported
+// fields directly afterwards to override them.
+func NewHMACVerifier(provider payments.PaymentProvider, secret string) *HMACVerifier {
+	return &HMACVerifier{Secret: secret, Provider: provider}
+}
+
+// Verify implements Verifier.
+func (v *HMACVerifier) Verify(r *http.Request) (Event, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return Event{}, fmt.Errorf("webhooks: failed to read %s webhook body: %w", v.Provider, err)
+	}
+
+	header := v.SignatureHeader
+	if header == "" {
+		header = "X-Webhook-Signature"
+	}
+	signature := r.Header.Get(header)
+
+	mac := hmac.New(sha256.New, []byte(v.Secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	decoded, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(decoded, expected) {
+		return Event{}, ErrHMACBadSignature
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return Event{}, fmt.Errorf("webhooks: invalid %s webhook body: %w", v.Provider, err)
+	}
+
+	idField := v.IDField
+	if idField == "" {
+		idField = "id"
+	}
+	eventTypeField := v.EventTypeField
+	if eventTypeField == "" {
+		eventTypeField = "eventType"
+	}
+
+	eventType := PaymentFailed
+	if t, ok := v.EventTypeMap[stringField(payload, eventTypeField)]; ok {
+		eventType = t
+	}
+
+	return Event{
+		ID:       fmt.Sprintf("%s:%s", v.Provider, stringField(payload, idField)),
+		Type:     eventType,
+		Provider: v.Provider,
+		Raw:      payload,
+	}, nil
+}
+
+var _ Verifier = (*HMACVerifier)(nil)
@@ -0,0 +1,82 @@
+package webhooks
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Listener is called once per dispatched Event, after Handler has verified
+// the delivery and confirmed (via IdempotencyStore) it hasn't already been
+// processed.
+type Listener func(Event)
+
+// Handler is an http.Handler for a single gateway's webhook route. Mount
+// one Handler per gateway (PayFast's notify_url, Ozow's NotifyUrl, Yoco's
+// webhook endpoint, PayShap's callback URL), since each needs that
+// gateway's own Verifier; register listeners per EventType with On.
+type Handler struct {
+	verifier Verifier
+	store    IdempotencyStore
+
+	mu        sync.RWMutex
+	listeners map[EventType][]Listener
+}
+
+// NewHandler creates a Handler that authenticates deliveries with verifier
+// and dedupes them with store. A nil store defaults to a
+// MemoryIdempotencyStore with a 24-hour window, matching how most gateways
+// stop retrying a failed delivery well within a day.
+func NewHandler(verifier Verifier, store IdempotencyStore) *Handler {
+	if store == nil {
+		store = NewMemoryIdempotencyStore(24 * time.Hour)
+	}
+	return &Handler{
+		verifier:  verifier,
+		store:     store,
+		listeners: make(map[EventType][]Listener),
+	}
+}
+
+// On registers listener to be called for every Event of eventType this
+// Handler dispatches.
+func (h *Handler) On(eventType EventType, listener Listener) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.listeners[eventType] = append(h.listeners[eventType], listener)
+}
+
+// ServeHTTP implements http.Handler. A verification failure responds 403;
+// a duplicate delivery (per IdempotencyStore) responds 200 without
+// re-dispatching, since that's still a successful delivery as far as the
+// gateway's retry logic is concerned.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	event, err := h.verifier.Verify(r)
+	if err != nil {
+		http.Error(w, "webhook verification failed", http.StatusForbidden)
+		return
+	}
+	event.ReceivedAt = time.Now()
+
+	seen, err := h.store.SeenAndMark(r.Context(), event.ID)
+	if err != nil {
+		http.Error(w, "idempotency check failed", http.StatusInternalServerError)
+		return
+	}
+	if seen {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	h.mu.RLock()
+	listeners := append([]Listener(nil), h.listeners[event.Type]...)
+	h.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener(event)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+var _ http.Handler = (*Handler)(nil)
@@ -0,0 +1,61 @@
+package webhooks
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// IdempotencyStore records which webhook deliveries Handler has already
+// dispatched, so a gateway's at-least-once retry policy (every gateway in
+// this package retries a notify_url that doesn't return 2xx) can't replay
+// the same event to listeners twice. Implementations backed by Redis or a
+// database should make SeenAndMark atomic (e.g. SETNX) to stay correct
+// under concurrent deliveries of the same event.
+type IdempotencyStore interface {
+	// SeenAndMark reports whether key has already been marked, and marks it
+	// if not. The first call for a given key returns false (not seen yet);
+	// every call after that returns true.
+	SeenAndMark(ctx context.Context, key string) (bool, error)
+}
+
+// MemoryIdempotencyStore is an in-process IdempotencyStore, suitable for a
+// single-instance deployment or tests. Entries older than ttl are evicted
+// lazily on the next SeenAndMark call, mirroring payfastIPCache's
+// refresh-on-access pattern in the parent package.
+type MemoryIdempotencyStore struct {
+	ttl time.Duration
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewMemoryIdempotencyStore creates a MemoryIdempotencyStore that forgets a
+// key once ttl has elapsed since it was first seen.
+func NewMemoryIdempotencyStore(ttl time.Duration) *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{
+		ttl:  ttl,
+		seen: make(map[string]time.Time),
+	}
+}
+
+// SeenAndMark implements IdempotencyStore.
+func (s *MemoryIdempotencyStore) SeenAndMark(ctx context.Context, key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	for k, seenAt := range s.seen {
+		if now.Sub(seenAt) > s.ttl {
+			delete(s.seen, k)
+		}
+	}
+
+	if seenAt, ok := s.seen[key]; ok && now.Sub(seenAt) <= s.ttl {
+		return true, nil
+	}
+	s.seen[key] = now
+	return false, nil
+}
+
+var _ IdempotencyStore = (*MemoryIdempotencyStore)(nil)
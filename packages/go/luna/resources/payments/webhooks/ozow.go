@@ -0,0 +1,109 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+)
+
+// ErrOzowBadSignature is returned when an Ozow webhook's Hash field doesn't
+// match the recomputed hash.
+var ErrOzowBadSignature = errors.New("webhooks: Ozow hash mismatch")
+
+// ozowHashFieldOrder mirrors the field order Ozow's own webhook hash uses,
+// per its notification payload (distinct from the request-hash field order
+// used when initiating a payment).
+var ozowHashFieldOrder = []string{
+	"SiteCode", "TransactionId", "TransactionReference", "Amount",
+	"Status", "Optional1", "Optional2", "Optional3", "Optional4", "Optional5",
+	"CurrencyCode", "IsTest", "StatusMessage",
+}
+
+var ozowStatusMap = map[string]EventType{
+	"Complete":  PaymentSucceeded,
+	"Cancelled": PaymentFailed,
+	"Error":     PaymentFailed,
+	"Abandoned": PaymentFailed,
+}
+
+var ozowPaymentStatus = map[EventType]payments.PaymentStatus{
+	PaymentSucceeded: payments.StatusCompleted,
+	PaymentFailed:    payments.StatusFailed,
+}
+
+// OzowVerifier verifies an Ozow webhook delivery's SHA-512 Hash field,
+// computed over a fixed, ordered field concatenation plus the merchant's
+// private key.
+type OzowVerifier struct {
+	PrivateKey string
+}
+
+// NewOzowVerifier creates an OzowVerifier bound to privateKey (the same
+// value configured on the Ozow gateway, payments.OzowConfig.PrivateKey).
+func NewOzowVerifier(privateKey string) *OzowVerifier {
+	return &OzowVerifier{PrivateKey: privateKey}
+}
+
+// Verify implements Verifier.
+func (v *OzowVerifier) Verify(r *http.Request) (Event, error) {
+	if err := r.ParseForm(); err != nil {
+		return Event{}, fmt.Errorf("webhooks: invalid Ozow payload: %w", err)
+	}
+
+	payload := make(map[string]interface{}, len(r.PostForm))
+	for k := range r.PostForm {
+		payload[k] = r.PostForm.Get(k)
+	}
+
+	receivedHash := strings.ToLower(stringField(payload, "Hash"))
+	if !hmac.Equal([]byte(receivedHash), []byte(strings.ToLower(ozowHash(payload, v.PrivateKey)))) {
+		return Event{}, ErrOzowBadSignature
+	}
+
+	return ozowEvent(payload), nil
+}
+
+// ozowHash rebuilds Ozow's ordered field concatenation (lower-cased, with
+// the private key appended) and SHA-512-hashes it.
+func ozowHash(payload map[string]interface{}, privateKey string) string {
+	var parts []string
+	for _, field := range ozowHashFieldOrder {
+		parts = append(parts, stringField(payload, field))
+	}
+	canonical := strings.ToLower(strings.Join(parts, "")) + strings.ToLower(privateKey)
+	sum := sha512.Sum512([]byte(canonical))
+	return hex.EncodeToString(sum[:])
+}
+
+func ozowEvent(payload map[string]interface{}) Event {
+	eventType := PaymentFailed
+	if t, ok := ozowStatusMap[stringField(payload, "Status")]; ok {
+		eventType = t
+	}
+
+	amount := 0.0
+	fmt.Sscanf(stringField(payload, "Amount"), "%f", &amount)
+
+	return Event{
+		ID:       fmt.Sprintf("ozow:%s", stringField(payload, "TransactionId")),
+		Type:     eventType,
+		Provider: payments.ProviderOzow,
+		Payment: &payments.Payment{
+			ID:        stringField(payload, "TransactionReference"),
+			Provider:  payments.ProviderOzow,
+			Amount:    payments.Amount{Value: int(amount * 100), Currency: "ZAR"},
+			Status:    ozowPaymentStatus[eventType],
+			Reference: stringField(payload, "TransactionReference"),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+		Raw: payload,
+	}
+}
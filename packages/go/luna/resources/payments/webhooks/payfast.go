@@ -0,0 +1,161 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+)
+
+// Typed PayFast verification failures, each satisfying errors.Is against
+// the exact sentinel (including through fmt.Errorf("%w: ...", ...) wrapping).
+var (
+	ErrPayFastBadSignature = errors.New("webhooks: PayFast signature mismatch")
+	ErrPayFastBadSource    = errors.New("webhooks: PayFast ITN request did not originate from an allowed IP")
+)
+
+var payfastStatusMap = map[string]EventType{
+	"COMPLETE":  PaymentSucceeded,
+	"FAILED":    PaymentFailed,
+	"CANCELLED": PaymentFailed,
+}
+
+var payfastPaymentStatus = map[EventType]payments.PaymentStatus{
+	PaymentSucceeded: payments.StatusCompleted,
+	PaymentFailed:    payments.StatusFailed,
+}
+
+// PayFastVerifier verifies a PayFast ITN delivery: the MD5 signature of its
+// sorted pf_* parameters (plus the configured passphrase, if any), and
+// optionally the source IP it arrived from. This is the signature-only
+// check; applications needing PayFast's full four-step ITN validation
+// (signature, source IP, amount, and a server-to-server postback to
+// PayFast) should use payments.PayFastITNVerifier instead and wrap it with
+// a Verifier adapter.
+type PayFastVerifier struct {
+	Passphrase string
+	// AllowedIPs restricts accepted source IPs to this allowlist. Nil
+	// disables the check.
+	AllowedIPs []net.IP
+}
+
+// NewPayFastVerifier creates a PayFastVerifier. passphrase is the same
+// value configured on the PayFast gateway (payments.PayFastConfig.Passphrase);
+// allowedIPs is typically resolved once at startup from PayFast's published
+// ITN hostnames.
+func NewPayFastVerifier(passphrase string, allowedIPs []net.IP) *PayFastVerifier {
+	return &PayFastVerifier{Passphrase: passphrase, AllowedIPs: allowedIPs}
+}
+
+// Verify implements Verifier.
+func (v *PayFastVerifier) Verify(r *http.Request) (Event, error) {
+	if err := r.ParseForm(); err != nil {
+		return Event{}, fmt.Errorf("webhooks: invalid PayFast ITN payload: %w", err)
+	}
+
+	payload := make(map[string]interface{}, len(r.PostForm))
+	for k := range r.PostForm {
+		payload[k] = r.PostForm.Get(k)
+	}
+
+	if len(v.AllowedIPs) > 0 && !ipAllowed(sourceIP(r), v.AllowedIPs) {
+		return Event{}, ErrPayFastBadSource
+	}
+
+	signature := stringField(payload, "signature")
+	if !hmac.Equal([]byte(signature), []byte(payfastSignature(payload, v.Passphrase))) {
+		return Event{}, ErrPayFastBadSignature
+	}
+
+	return payfastEvent(payload), nil
+}
+
+// payfastSignature rebuilds PayFast's sorted "key=urlencoded(value)"
+// canonical string (skipping the signature field itself, and the
+// passphrase if none is configured) and MD5-hashes it.
+func payfastSignature(payload map[string]interface{}, passphrase string) string {
+	keys := make([]string, 0, len(payload))
+	for k := range payload {
+		if k == "signature" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		v := stringField(payload, k)
+		if v != "" {
+			encoded := strings.ReplaceAll(url.QueryEscape(v), "+", "%20")
+			parts = append(parts, fmt.Sprintf("%s=%s", k, encoded))
+		}
+	}
+	paramString := strings.Join(parts, "&")
+
+	if passphrase != "" {
+		paramString += "&passphrase=" + url.QueryEscape(passphrase)
+	}
+
+	sum := md5.Sum([]byte(paramString))
+	return hex.EncodeToString(sum[:])
+}
+
+// payfastEvent parses an already-verified ITN payload into an Event.
+func payfastEvent(payload map[string]interface{}) Event {
+	eventType := PaymentFailed
+	if t, ok := payfastStatusMap[stringField(payload, "payment_status")]; ok {
+		eventType = t
+	}
+
+	amountGross := 0.0
+	fmt.Sscanf(stringField(payload, "amount_gross"), "%f", &amountGross)
+
+	return Event{
+		ID:       fmt.Sprintf("payfast:%s:%s", stringField(payload, "pf_payment_id"), stringField(payload, "payment_status")),
+		Type:     eventType,
+		Provider: payments.ProviderPayFast,
+		Payment: &payments.Payment{
+			ID:          stringField(payload, "m_payment_id"),
+			Provider:    payments.ProviderPayFast,
+			Amount:      payments.Amount{Value: int(amountGross * 100), Currency: "ZAR"},
+			Status:      payfastPaymentStatus[eventType],
+			Reference:   stringField(payload, "m_payment_id"),
+			Description: stringField(payload, "item_name"),
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		},
+		Raw: payload,
+	}
+}
+
+// sourceIP extracts the caller's IP from r.RemoteAddr, which is
+// "host:port" unless a reverse proxy rewrote it to a bare host.
+func sourceIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return net.ParseIP(host)
+}
+
+func ipAllowed(ip net.IP, allowed []net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, a := range allowed {
+		if a.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+)
+
+// ErrPayShapBadSignature is returned when a PayShap webhook's embedded
+// signature doesn't match the recomputed one.
+var ErrPayShapBadSignature = errors.New("webhooks: PayShap signature mismatch")
+
+// payshapMessage is the subset of an ISO 20022 pain.002 (payment status
+// report) PayShap's callback carries that this package cares about. A full
+// ISO 20022 message has many more optional elements; unmarshal only reads
+// the ones needed to build an Event, by design.
+type payshapMessage struct {
+	XMLName xml.Name `xml:"Document"`
+	Body    struct {
+		OrgnlInstrID string `xml:"OrgnlGrpInfAndSts>OrgnlInstrId"`
+		TxSts        string `xml:"OrgnlGrpInfAndSts>GrpSts"`
+		Amount       string `xml:"OrgnlGrpInfAndSts>OrgnlMsgInfAndSts>Amt"`
+	} `xml:"CstmrPmtStsRpt"`
+	Signature string `xml:"Sgntr"`
+}
+
+var payshapStatusMap = map[string]EventType{
+	"ACCP": PaymentSucceeded,
+	"ACSC": PaymentSucceeded,
+	"RJCT": PaymentFailed,
+}
+
+var payshapPaymentStatus = map[EventType]payments.PaymentStatus{
+	PaymentSucceeded: payments.StatusCompleted,
+	PaymentFailed:    payments.StatusFailed,
+}
+
+// PayShapVerifier verifies a PayShap callback's ISO 20022 message. PayShap
+// participants authenticate over mutually-authenticated TLS and sign
+// messages with bank-issued certificates in production; lacking a shared
+// PKI library, this verifies an HMAC-SHA256 digest over the message body
+// (with the <Sgntr> element itself excluded) against a pre-shared secret,
+// which is the scheme PayShap's sandbox callback uses. Participants that
+// need full XMLDSig certificate verification should wrap this Verifier's
+// output, swapping in their own signature check.
+type PayShapVerifier struct {
+	Secret string
+}
+
+// NewPayShapVerifier creates a PayShapVerifier bound to secret.
+func NewPayShapVerifier(secret string) *PayShapVerifier {
+	return &PayShapVerifier{Secret: secret}
+}
+
+// Verify implements Verifier.
+func (v *PayShapVerifier) Verify(r *http.Request) (Event, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return Event{}, fmt.Errorf("webhooks: failed to read PayShap callback body: %w", err)
+	}
+
+	var msg payshapMessage
+	if err := xml.Unmarshal(body, &msg); err != nil {
+		return Event{}, fmt.Errorf("webhooks: invalid PayShap ISO 20022 payload: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(v.Secret))
+	mac.Write(signedBody(body))
+	expected := hex.EncodeToString(mac.Sum(nil))
+	if !hmac.Equal([]byte(msg.Signature), []byte(expected)) {
+		return Event{}, ErrPayShapBadSignature
+	}
+
+	return payshapEvent(msg), nil
+}
+
+// signedBody strips the <Sgntr>...</Sgntr> element PayShap appends after
+// signing, since the signature necessarily can't cover itself.
+func signedBody(body []byte) []byte {
+	const open, close = "<Sgntr>", "</Sgntr>"
+	start := indexOf(body, open)
+	if start < 0 {
+		return body
+	}
+	end := indexOf(body[start:], close)
+	if end < 0 {
+		return body
+	}
+	end += start + len(close)
+	stripped := make([]byte, 0, len(body)-(end-start))
+	stripped = append(stripped, body[:start]...)
+	stripped = append(stripped, body[end:]...)
+	return stripped
+}
+
+func indexOf(body []byte, substr string) int {
+	for i := 0; i+len(substr) <= len(body); i++ {
+		if string(body[i:i+len(substr)]) == substr {
+			return i
+		}
+	}
+	return -1
+}
+
+func payshapEvent(msg payshapMessage) Event {
+	eventType := PaymentFailed
+	if t, ok := payshapStatusMap[msg.Body.TxSts]; ok {
+		eventType = t
+	}
+
+	amount := 0.0
+	fmt.Sscanf(msg.Body.Amount, "%f", &amount)
+
+	return Event{
+		ID:       fmt.Sprintf("payshap:%s", msg.Body.OrgnlInstrID),
+		Type:     eventType,
+		Provider: payments.ProviderPayShap,
+		Payment: &payments.Payment{
+			ID:        msg.Body.OrgnlInstrID,
+			Provider:  payments.ProviderPayShap,
+			Amount:    payments.Amount{Value: int(amount * 100), Currency: "ZAR"},
+			Status:    payshapPaymentStatus[eventType],
+			Reference: msg.Body.OrgnlInstrID,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+}
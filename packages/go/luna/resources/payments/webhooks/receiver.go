@@ -0,0 +1,323 @@
+package webhooks
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources/payments"
+)
+
+// EventHandler processes one dispatched Event. Unlike Listener, it takes a
+// ctx (cancelled if the delivering request's connection drops) and can
+// return an error, which Receiver retries per RetryPolicy before giving up
+// and handing the Event to DeadLetterSink.
+type EventHandler func(ctx context.Context, event *Event) error
+
+// RetryPolicy controls how many times Receiver retries a failing
+// EventHandler and how long it waits between attempts, using the same
+// full-jitter exponential backoff as lunahttp.RetryPolicy and
+// messagebus's reconnect backoff.
+type RetryPolicy struct {
+	// MaxAttempts caps retries after the initial call. Zero means 2.
+	MaxAttempts int
+	// BaseDelay and MaxDelay bound the backoff. Zero means 200ms and 5s.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts == 0 {
+		p.MaxAttempts = 2
+	}
+	if p.BaseDelay == 0 {
+		p.BaseDelay = 200 * time.Millisecond
+	}
+	if p.MaxDelay == 0 {
+		p.MaxDelay = 5 * time.Second
+	}
+	return p
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := time.Duration(float64(p.BaseDelay) * math.Pow(2, float64(attempt)))
+	if d > p.MaxDelay || d <= 0 {
+		d = p.MaxDelay
+	}
+	return time.Duration(rand.Float64() * float64(d))
+}
+
+// DeadLetterSink receives an Event whose EventHandler kept failing through
+// every attempt RetryPolicy allowed, so an application can persist it for
+// manual replay instead of losing it silently.
+type DeadLetterSink interface {
+	DeadLetter(ctx context.Context, event Event, handlerErr error)
+}
+
+// DeadLetterFunc adapts a plain function to a DeadLetterSink.
+type DeadLetterFunc func(ctx context.Context, event Event, handlerErr error)
+
+// DeadLetter implements DeadLetterSink.
+func (f DeadLetterFunc) DeadLetter(ctx context.Context, event Event, handlerErr error) {
+	f(ctx, event, handlerErr)
+}
+
+// discardDeadLetterSink is the zero-value DeadLetterSink: it drops the
+// event, matching Handler's pre-Receiver behavior of not retrying listener
+// errors at all.
+type discardDeadLetterSink struct{}
+
+func (discardDeadLetterSink) DeadLetter(ctx context.Context, event Event, handlerErr error) {}
+
+// EventStore deduplicates deliveries Receiver has already dispatched. It
+// generalizes IdempotencyStore's seen-or-not check to the full Event, so a
+// store that wants to keep a record of what it dropped (for a dead-letter
+// replay tool, say) has enough to work with. A Redis-backed
+// implementation should make SeenAndMark atomic (SET key NX) to stay
+// correct under concurrent deliveries of the same event.
+type EventStore interface {
+	// SeenAndMark reports whether event has already been marked, and
+	// marks it if not.
+	SeenAndMark(ctx context.Context, event Event) (bool, error)
+}
+
+// NewLRUEventStore creates an in-memory EventStore bounded to at most size
+// entries, evicting the least recently marked event once full. This is
+// Receiver's default EventStore -- suitable for a single-instance
+// deployment or tests; a multi-instance deployment should supply a
+// Redis-backed EventStore instead so every instance sees the same dedup
+// state.
+func NewLRUEventStore(size int) *LRUEventStore {
+	if size <= 0 {
+		size = 1024
+	}
+	return &LRUEventStore{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element, size),
+	}
+}
+
+// LRUEventStore is a fixed-capacity, in-process EventStore.
+type LRUEventStore struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+// SeenAndMark implements EventStore.
+func (s *LRUEventStore) SeenAndMark(ctx context.Context, event Event) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.entries[event.ID]; ok {
+		s.order.MoveToFront(el)
+		return true, nil
+	}
+
+	el := s.order.PushFront(event.ID)
+	s.entries[event.ID] = el
+	for s.order.Len() > s.size {
+		oldest := s.order.Back()
+		if oldest == nil {
+			break
+		}
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(string))
+	}
+	return false, nil
+}
+
+var _ EventStore = (*LRUEventStore)(nil)
+
+// eventStoreAdapter lets Receiver accept the IdempotencyStore
+// implementations Handler already shipped with (MemoryIdempotencyStore,
+// and any caller's Redis one written against that interface) without
+// forcing a rewrite to EventStore.
+type eventStoreAdapter struct{ store IdempotencyStore }
+
+func (a eventStoreAdapter) SeenAndMark(ctx context.Context, event Event) (bool, error) {
+	return a.store.SeenAndMark(ctx, event.ID)
+}
+
+// EventStoreFromIdempotencyStore adapts store to an EventStore.
+func EventStoreFromIdempotencyStore(store IdempotencyStore) EventStore {
+	return eventStoreAdapter{store: store}
+}
+
+// Receiver fans incoming webhook deliveries from any of the four South
+// African gateways out to typed EventType handlers, adding the
+// cross-cutting concerns Handler leaves each application to build itself:
+// a Tolerance window on whatever timestamp each gateway's payload or
+// headers carry (defeating replay of an intercepted-but-still-validly-
+// signed delivery), deduplication via a pluggable EventStore, and
+// automatic retry with a DeadLetterSink for handlers that keep failing.
+// Construct one with NewReceiver and mount Receiver.Handler() at a single
+// route; it dispatches each request by the {provider} path segment
+// (.../yoco, .../payfast, .../ozow, .../payshap).
+type Receiver struct {
+	verifiers  map[payments.PaymentProvider]Verifier
+	store      EventStore
+	tolerance  time.Duration
+	retry      RetryPolicy
+	deadLetter DeadLetterSink
+
+	mu       sync.RWMutex
+	handlers map[EventType][]EventHandler
+}
+
+// ReceiverOption configures a Receiver, following the functional-options
+// idiom the storage and zatools resources use for the same reason: most
+// callers only need to override one or two of several optional knobs.
+type ReceiverOption func(*Receiver)
+
+// WithVerifier registers verifier as the one Receiver uses for deliveries
+// routed to provider. Call it once per gateway the Receiver should accept.
+func WithVerifier(provider payments.PaymentProvider, verifier Verifier) ReceiverOption {
+	return func(r *Receiver) { r.verifiers[provider] = verifier }
+}
+
+// WithEventStore overrides the default LRUEventStore.
+func WithEventStore(store EventStore) ReceiverOption {
+	return func(r *Receiver) { r.store = store }
+}
+
+// WithTolerance overrides how far a delivery's timestamp may drift from
+// now before Receiver rejects it as a possible replay. Zero (the default)
+// disables the check, matching how PayFast and PayShap deliveries carry
+// no timestamp of their own to check.
+func WithTolerance(d time.Duration) ReceiverOption {
+	return func(r *Receiver) { r.tolerance = d }
+}
+
+// WithRetryPolicy overrides the default RetryPolicy applied to failing
+// EventHandlers.
+func WithRetryPolicy(policy RetryPolicy) ReceiverOption {
+	return func(r *Receiver) { r.retry = policy.withDefaults() }
+}
+
+// WithDeadLetterSink overrides the default no-op DeadLetterSink.
+func WithDeadLetterSink(sink DeadLetterSink) ReceiverOption {
+	return func(r *Receiver) { r.deadLetter = sink }
+}
+
+// NewReceiver creates a Receiver with no registered verifiers; use
+// WithVerifier to add the gateways it should accept deliveries from.
+func NewReceiver(opts ...ReceiverOption) *Receiver {
+	r := &Receiver{
+		verifiers:  make(map[payments.PaymentProvider]Verifier),
+		store:      NewLRUEventStore(1024),
+		retry:      RetryPolicy{}.withDefaults(),
+		deadLetter: discardDeadLetterSink{},
+		handlers:   make(map[EventType][]EventHandler),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// On registers handler to be called for every Event of eventType Receiver
+// dispatches. A handler error is retried per RetryPolicy before the Event
+// is handed to DeadLetterSink.
+func (r *Receiver) On(eventType EventType, handler EventHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers[eventType] = append(r.handlers[eventType], handler)
+}
+
+// Handler returns an http.Handler that verifies, deduplicates, and
+// dispatches deliveries from every gateway registered via WithVerifier. It
+// picks the Verifier by the last path segment of the request URL, so it
+// should be mounted with each provider's name as a trailing segment, e.g.
+// mux.Handle("/webhooks/", receiver.Handler()) with PayFast's notify_url
+// set to ".../webhooks/payfast".
+func (r *Receiver) Handler() http.Handler {
+	return http.HandlerFunc(r.serveHTTP)
+}
+
+func (r *Receiver) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	provider, verifier, ok := r.verifierFor(req)
+	if !ok {
+		http.Error(w, fmt.Sprintf("webhooks: no verifier registered for %q", provider), http.StatusNotFound)
+		return
+	}
+
+	event, err := verifier.Verify(req)
+	if err != nil {
+		http.Error(w, "webhook verification failed", http.StatusForbidden)
+		return
+	}
+	event.ReceivedAt = time.Now()
+
+	if r.tolerance > 0 && event.Timestamp != nil {
+		if d := time.Since(*event.Timestamp); d > r.tolerance || d < -r.tolerance {
+			http.Error(w, "webhook delivery outside the allowed tolerance window", http.StatusForbidden)
+			return
+		}
+	}
+
+	seen, err := r.store.SeenAndMark(req.Context(), event)
+	if err != nil {
+		http.Error(w, "idempotency check failed", http.StatusInternalServerError)
+		return
+	}
+	if seen {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	r.mu.RLock()
+	handlers := append([]EventHandler(nil), r.handlers[event.Type]...)
+	r.mu.RUnlock()
+
+	ctx := req.Context()
+	for _, handler := range handlers {
+		r.dispatch(ctx, handler, event)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// dispatch calls handler for event, retrying per RetryPolicy on error and
+// handing the event to DeadLetterSink once attempts are exhausted. It
+// never returns an error: a misbehaving handler must not stop the gateway
+// from getting its 200, or the gateway will just keep retrying the whole
+// delivery forever.
+func (r *Receiver) dispatch(ctx context.Context, handler EventHandler, event Event) {
+	var lastErr error
+	for attempt := 0; attempt <= r.retry.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(r.retry.delay(attempt - 1)):
+			case <-ctx.Done():
+				r.deadLetter.DeadLetter(ctx, event, ctx.Err())
+				return
+			}
+		}
+		if lastErr = handler(ctx, &event); lastErr == nil {
+			return
+		}
+	}
+	r.deadLetter.DeadLetter(ctx, event, lastErr)
+}
+
+// verifierFor resolves which registered Verifier should handle req, keyed
+// by the last non-empty path segment (e.g. ".../webhooks/yoco" -> "yoco").
+func (r *Receiver) verifierFor(req *http.Request) (payments.PaymentProvider, Verifier, bool) {
+	segment := strings.Trim(req.URL.Path, "/")
+	if i := strings.LastIndex(segment, "/"); i >= 0 {
+		segment = segment[i+1:]
+	}
+	provider := payments.PaymentProvider(segment)
+	verifier, ok := r.verifiers[provider]
+	return provider, verifier, ok
+}
@@ -0,0 +1,114 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TestingReceiver wraps a Receiver with helpers that synthesize correctly
+// signed deliveries for each of the four gateways, so a caller's handler
+// tests can exercise Receiver.Handler() end-to-end without standing up a
+// real PayFast/Ozow/Yoco/PayShap sandbox account. It assumes the Receiver
+// under test was built with NewYocoVerifier(secret),
+// NewPayFastVerifier(passphrase, nil), NewOzowVerifier(privateKey), and/or
+// NewPayShapVerifier(secret) using the same credentials passed here.
+type TestingReceiver struct {
+	*Receiver
+
+	// BaseURL is prefixed to each provider's path segment, matching
+	// whatever mount point the Receiver's http.Handler is served at in the
+	// test (e.g. a httptest.Server's URL + "/webhooks").
+	BaseURL string
+}
+
+// NewTestingReceiver wraps receiver for use in tests.
+func NewTestingReceiver(receiver *Receiver, baseURL string) *TestingReceiver {
+	return &TestingReceiver{Receiver: receiver, BaseURL: strings.TrimRight(baseURL, "/")}
+}
+
+// YocoDelivery synthesizes a signed Yoco webhook request carrying body as
+// its JSON payload, as if delivered at sentAt.
+func (t *TestingReceiver) YocoDelivery(secret, id string, body []byte, sentAt time.Time) *http.Request {
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	signedContent := fmt.Sprintf("%s.%s.%s", id, timestamp, body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signedContent))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, t.BaseURL+"/yoco", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("webhook-id", id)
+	req.Header.Set("webhook-timestamp", timestamp)
+	req.Header.Set("webhook-signature", "v1,"+signature)
+	return req
+}
+
+// PayFastDelivery synthesizes a signed PayFast ITN request from fields
+// (already in the pf_* / m_payment_id / payment_status shape PayFast
+// posts), computing the same MD5 signature payfastSignature checks.
+func (t *TestingReceiver) PayFastDelivery(passphrase string, fields map[string]string) *http.Request {
+	payload := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		payload[k] = v
+	}
+	signature := payfastSignature(payload, passphrase)
+
+	form := url.Values{}
+	for k, v := range fields {
+		form.Set(k, v)
+	}
+	form.Set("signature", signature)
+
+	req := httptest.NewRequest(http.MethodPost, t.BaseURL+"/payfast", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// OzowDelivery synthesizes a signed Ozow notification request from fields,
+// computing the same SHA-512 Hash ozowHash checks.
+func (t *TestingReceiver) OzowDelivery(privateKey string, fields map[string]string) *http.Request {
+	payload := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		payload[k] = v
+	}
+	hash := ozowHash(payload, privateKey)
+
+	form := url.Values{}
+	for k, v := range fields {
+		form.Set(k, v)
+	}
+	form.Set("Hash", hash)
+
+	req := httptest.NewRequest(http.MethodPost, t.BaseURL+"/ozow", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+// PayShapDelivery synthesizes a signed PayShap ISO 20022 callback body
+// from orgnlInstrID/status/amount, computing the same HMAC-SHA256 digest
+// PayShapVerifier checks.
+func (t *TestingReceiver) PayShapDelivery(secret, orgnlInstrID, status, amount string) *http.Request {
+	body := []byte(fmt.Sprintf(
+		`<Document><CstmrPmtStsRpt><OrgnlGrpInfAndSts><OrgnlInstrId>%s</OrgnlInstrId><GrpSts>%s</GrpSts><OrgnlMsgInfAndSts><Amt>%s</Amt></OrgnlMsgInfAndSts></OrgnlGrpInfAndSts></CstmrPmtStsRpt><Sgntr></Sgntr></Document>`,
+		orgnlInstrID, status, amount,
+	))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(signedBody(body))
+	signature := hex.EncodeToString(mac.Sum(nil))
+	signed := bytes.Replace(body, []byte("<Sgntr></Sgntr>"), []byte("<Sgntr>"+signature+"</Sgntr>"), 1)
+
+	req := httptest.NewRequest(http.MethodPost, t.BaseURL+"/payshap", bytes.NewReader(signed))
+	req.Header.Set("Content-Type", "application/xml")
+	return req
+}
@@ -13,7 +13,15 @@ import (
 	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
 )
 
-// Yoco provides Yoco online payment integration.
+var yocoEventStatusMap = map[string]PaymentStatus{
+	"payment.succeeded": StatusCompleted,
+	"payment.failed":    StatusFailed,
+	"payment.cancelled": StatusCancelled,
+}
+
+// Yoco provides Yoco online payment integration (hosted checkout backed by
+// card tokenization and 3-D Secure). Checkouts capture immediately on
+// success, so Capture and Void return ErrUnsupported.
 type Yoco struct {
 	client *lunahttp.Client
 	config YocoConfig
@@ -27,26 +35,37 @@ func NewYoco(client *lunahttp.Client, config YocoConfig) *Yoco {
 	}
 }
 
+// Provider implements Gateway.
+func (y *Yoco) Provider() PaymentProvider { return ProviderYoco }
+
+// Capabilities implements Gateway. Yoco's hosted checkout captures on
+// success, but GetPayment can still look up a checkout's current state.
+func (y *Yoco) Capabilities() Capabilities {
+	return Capabilities{SupportsGetPayment: true}
+}
+
 // CreatePayment creates a checkout session and returns the redirect URL.
-func (y *Yoco) CreatePayment(ctx context.Context, req YocoPaymentRequest) (*YocoPayment, error) {
+func (y *Yoco) CreatePayment(ctx context.Context, req PaymentRequest) (*Payment, error) {
 	currency := req.Currency
 	if currency == "" {
 		currency = "ZAR"
 	}
 
 	body := map[string]interface{}{
-		"amount":     req.Amount,
+		"amount":     int(req.Amount * 100),
 		"currency":   currency,
 		"metadata":   req.Metadata,
 		"successUrl": req.SuccessURL,
 		"cancelUrl":  req.CancelURL,
-		"failureUrl": req.FailureURL,
+		"failureUrl": req.ErrorURL,
 	}
 
 	resp, err := y.client.Request(ctx, lunahttp.RequestConfig{
-		Method: "POST",
-		Path:   "/v1/payments/yoco/checkouts",
-		Body:   body,
+		Method:   "POST",
+		Path:     "/v1/payments/yoco/checkouts",
+		Body:     body,
+		Resource: "Payments.Yoco.CreatePayment",
+		Headers:  idempotencyHeaders(req.IdempotencyKey),
 	})
 	if err != nil {
 		return nil, err
@@ -69,39 +88,81 @@ func (y *Yoco) CreatePayment(ctx context.Context, req YocoPaymentRequest) (*Yoco
 		return nil, err
 	}
 
-	return &YocoPayment{
-		ID:         result.ID,
-		Provider:   "yoco",
-		CheckoutID: result.CheckoutID,
+	return &Payment{
+		ID:       result.ID,
+		Provider: ProviderYoco,
 		Amount: Amount{
 			Value:    int(result.Amount),
 			Currency: result.Currency,
 		},
 		Status:      result.Status,
 		Reference:   result.Reference,
-		RedirectURL: result.RedirectURL,
-		Metadata:    result.Metadata,
+		Description: req.Description,
+		PaymentURL:  result.RedirectURL,
+		NextAction:  redirectAction(result.RedirectURL),
+		Raw:         map[string]interface{}{"checkout_id": result.CheckoutID, "metadata": result.Metadata},
 		CreatedAt:   result.CreatedAt,
 		UpdatedAt:   result.UpdatedAt,
 	}, nil
 }
 
-// VerifyWebhook verifies the webhook signature.
-func (y *Yoco) VerifyWebhook(payload string, signature string) bool {
+// GetPayment retrieves a checkout's current state.
+func (y *Yoco) GetPayment(ctx context.Context, paymentID string) (*Payment, error) {
+	resp, err := y.client.Request(ctx, lunahttp.RequestConfig{
+		Method:   "GET",
+		Path:     fmt.Sprintf("/v1/payments/yoco/checkouts/%s", paymentID),
+		Resource: "Payments.Yoco.GetPayment",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		ID         string        `json:"id"`
+		CheckoutID string        `json:"checkoutId"`
+		Amount     float64       `json:"amount"`
+		Currency   string        `json:"currency"`
+		Status     PaymentStatus `json:"status"`
+		Reference  string        `json:"reference"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, err
+	}
+
+	return &Payment{
+		ID:        result.ID,
+		Provider:  ProviderYoco,
+		Amount:    Amount{Value: int(result.Amount), Currency: result.Currency},
+		Status:    result.Status,
+		Reference: result.Reference,
+		Raw:       map[string]interface{}{"checkout_id": result.CheckoutID},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// Capture is unsupported: Yoco's hosted checkout captures on success.
+func (y *Yoco) Capture(ctx context.Context, paymentID string) (*Payment, error) {
+	return nil, ErrUnsupported
+}
+
+// Void is unsupported: Yoco's hosted checkout captures on success.
+func (y *Yoco) Void(ctx context.Context, paymentID string) (*Payment, error) {
+	return nil, ErrUnsupported
+}
+
+// VerifyWebhook verifies the webhook signature. payload must carry the raw
+// JSON body under "raw_body" and the signature header value under
+// "signature" — Yoco signs the exact request bytes, not the parsed event.
+func (y *Yoco) VerifyWebhook(payload map[string]interface{}) bool {
 	mac := hmac.New(sha256.New, []byte(y.config.SecretKey))
-	mac.Write([]byte(payload))
+	mac.Write([]byte(stringField(payload, "raw_body")))
 	expectedSignature := hex.EncodeToString(mac.Sum(nil))
-	return signature == expectedSignature
+	return hmac.Equal([]byte(stringField(payload, "signature")), []byte(expectedSignature))
 }
 
 // ProcessWebhook processes a webhook event.
-func (y *Yoco) ProcessWebhook(payload map[string]interface{}) *YocoPayment {
-	statusMap := map[string]PaymentStatus{
-		"payment.succeeded": StatusCompleted,
-		"payment.failed":    StatusFailed,
-		"payment.cancelled": StatusCancelled,
-	}
-
+func (y *Yoco) ProcessWebhook(payload map[string]interface{}) (*Payment, error) {
 	eventType, _ := payload["type"].(string)
 	paymentData, _ := payload["payload"].(map[string]interface{})
 
@@ -113,7 +174,7 @@ func (y *Yoco) ProcessWebhook(payload map[string]interface{}) *YocoPayment {
 	}
 
 	status := StatusPending
-	if s, ok := statusMap[eventType]; ok {
+	if s, ok := yocoEventStatusMap[eventType]; ok {
 		status = s
 	}
 
@@ -122,20 +183,16 @@ func (y *Yoco) ProcessWebhook(payload map[string]interface{}) *YocoPayment {
 		metadata = m
 	}
 
-	return &YocoPayment{
-		ID:         fmt.Sprintf("yc_%s", id),
-		Provider:   "yoco",
-		CheckoutID: id,
-		Amount: Amount{
-			Value:    int(amount),
-			Currency: currency,
-		},
+	return &Payment{
+		ID:        fmt.Sprintf("yc_%s", id),
+		Provider:  ProviderYoco,
+		Amount:    Amount{Value: int(amount), Currency: currency},
 		Status:    status,
 		Reference: id,
-		Metadata:  metadata,
+		Raw:       map[string]interface{}{"checkout_id": id, "metadata": metadata},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
-	}
+	}, nil
 }
 
 // Refund requests a refund.
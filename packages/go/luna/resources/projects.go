@@ -56,6 +56,19 @@ func (r *ProjectsResource) List(ctx context.Context, params *ListParams) (*Proje
 	return &result, nil
 }
 
+// Iterate returns a paginator for iterating over projects
+func (r *ProjectsResource) Iterate(ctx context.Context, params *ListParams) *Paginator[Project] {
+	return NewPaginator(ctx, func(ctx context.Context, cursor string) (*ListResponse[Project], error) {
+		p := params
+		if p == nil {
+			p = &ListParams{}
+		}
+		newParams := *p
+		newParams.Cursor = cursor
+		return r.List(ctx, &newParams)
+	})
+}
+
 // Get retrieves a project by ID
 func (r *ProjectsResource) Get(ctx context.Context, projectID string) (*Project, error) {
 	if err := validateProjectID(projectID); err != nil {
@@ -137,6 +150,21 @@ func (r *ProjectsResource) Delete(ctx context.Context, projectID string) error {
 	return err
 }
 
+// Batch performs a mix of create/update/delete operations in a single
+// POST /v1/projects:batch request, returning one BatchResult per
+// operation in the same order. See BatchAll to transparently split an
+// oversize ops slice across multiple requests.
+func (r *ProjectsResource) Batch(ctx context.Context, ops []BatchOperation) ([]ProjectBatchResult, error) {
+	return runBatch[Project](ctx, r.client, r.basePath+":batch", ops, validateProjectID)
+}
+
+// BatchAll splits ops into chunks of at most chunkSize (DefaultBatchChunkSize
+// if chunkSize <= 0), runs Batch on each, and merges the results in input
+// order.
+func (r *ProjectsResource) BatchAll(ctx context.Context, ops []BatchOperation, chunkSize int) ([]ProjectBatchResult, error) {
+	return runBatchAll[Project](ctx, r.client, r.basePath+":batch", ops, validateProjectID, chunkSize)
+}
+
 func validateProjectID(id string) error {
 	if id == "" {
 		return fmt.Errorf("project ID is required")
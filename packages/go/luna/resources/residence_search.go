@@ -0,0 +1,97 @@
+package resources
+
+// ResidenceSearchBuilder builds a ResidenceSearch fluently, so a caller
+// chaining a handful of the many optional filters doesn't have to
+// construct the struct by hand. Build with NewResidenceSearch:
+//
+//	search := resources.NewResidenceSearch().
+//		NearCampus("cmp_uct").
+//		PriceBetween(3000, 6000).
+//		WithNSFAS(true).
+//		Build()
+type ResidenceSearchBuilder struct {
+	search ResidenceSearch
+}
+
+// NewResidenceSearch starts a new ResidenceSearchBuilder.
+func NewResidenceSearch() *ResidenceSearchBuilder {
+	return &ResidenceSearchBuilder{}
+}
+
+// Query sets a free-text search query.
+func (b *ResidenceSearchBuilder) Query(query string) *ResidenceSearchBuilder {
+	b.search.Query = query
+	return b
+}
+
+// NearCampus restricts results to residences associated with campusID.
+func (b *ResidenceSearchBuilder) NearCampus(campusID string) *ResidenceSearchBuilder {
+	b.search.CampusID = campusID
+	return b
+}
+
+// PriceBetween restricts results to a [min, max] monthly price range.
+func (b *ResidenceSearchBuilder) PriceBetween(min, max float64) *ResidenceSearchBuilder {
+	b.search.MinPrice = min
+	b.search.MaxPrice = max
+	return b
+}
+
+// WithNSFAS restricts results by NSFAS accreditation.
+func (b *ResidenceSearchBuilder) WithNSFAS(accredited bool) *ResidenceSearchBuilder {
+	b.search.NSFAS = &accredited
+	return b
+}
+
+// WithGender restricts results to a gender_policy value ("mixed", "male",
+// or "female").
+func (b *ResidenceSearchBuilder) WithGender(gender string) *ResidenceSearchBuilder {
+	b.search.Gender = gender
+	return b
+}
+
+// MinRating restricts results to a minimum average review rating.
+func (b *ResidenceSearchBuilder) MinRating(rating float64) *ResidenceSearchBuilder {
+	b.search.MinRating = rating
+	return b
+}
+
+// Near anchors a radius search at location, in the given unit ("km" or
+// "mi"; "km" if empty).
+func (b *ResidenceSearchBuilder) Near(location GeoPoint, radius float64, unit string) *ResidenceSearchBuilder {
+	b.search.Location = &location
+	b.search.Radius = radius
+	b.search.RadiusUnit = unit
+	return b
+}
+
+// WithinBoundingBox restricts results to box, as an alternative to Near.
+func (b *ResidenceSearchBuilder) WithinBoundingBox(box GeoBox) *ResidenceSearchBuilder {
+	b.search.BoundingBox = &box
+	return b
+}
+
+// SortBy appends a sort field, applied in the order SortBy is called.
+func (b *ResidenceSearchBuilder) SortBy(field string, direction SortDirection) *ResidenceSearchBuilder {
+	b.search.Sort = append(b.search.Sort, SortField{Field: field, Direction: direction})
+	return b
+}
+
+// WithFacets requests server-side aggregations for the named facets, in
+// addition to any already set.
+func (b *ResidenceSearchBuilder) WithFacets(facets ...string) *ResidenceSearchBuilder {
+	b.search.Facets = append(b.search.Facets, facets...)
+	return b
+}
+
+// Limit sets the page size.
+func (b *ResidenceSearchBuilder) Limit(limit int) *ResidenceSearchBuilder {
+	b.search.Limit = limit
+	return b
+}
+
+// Build returns the assembled ResidenceSearch.
+func (b *ResidenceSearchBuilder) Build() *ResidenceSearch {
+	search := b.search
+	return &search
+}
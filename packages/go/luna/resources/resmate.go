@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 
 	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
 )
@@ -14,6 +17,12 @@ import (
 type ResidencesResource struct {
 	client   *lunahttp.Client
 	basePath string
+
+	// campusCoords caches Campus.Location by campus ID across NearestCampus
+	// calls, so rendering a results list (one NearestCampus call per
+	// residence) doesn't re-derive the same handful of campus coordinates
+	// on every row.
+	campusCoords sync.Map
 }
 
 // List searches for residences
@@ -50,6 +59,30 @@ func (r *ResidencesResource) List(ctx context.Context, params *ResidenceSearch)
 		if params.MinRating > 0 {
 			query.Set("min_rating", fmt.Sprintf("%f", params.MinRating))
 		}
+		if params.Location != nil {
+			query.Set("location", fmt.Sprintf("%f,%f", params.Location.Latitude, params.Location.Longitude))
+			if params.RadiusUnit != "" {
+				query.Set("radius_unit", params.RadiusUnit)
+			}
+		}
+		if params.BoundingBox != nil {
+			box := params.BoundingBox
+			query.Set("bbox", fmt.Sprintf("%f,%f,%f,%f", box.MinLng, box.MinLat, box.MaxLng, box.MaxLat))
+		}
+		if len(params.Sort) > 0 {
+			fields := make([]string, len(params.Sort))
+			for i, s := range params.Sort {
+				if s.Direction == "" {
+					fields[i] = s.Field
+				} else {
+					fields[i] = s.Field + ":" + string(s.Direction)
+				}
+			}
+			query.Set("sort", strings.Join(fields, ","))
+		}
+		if len(params.Facets) > 0 {
+			query.Set("facets", strings.Join(params.Facets, ","))
+		}
 	}
 
 	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
@@ -87,9 +120,11 @@ func (r *ResidencesResource) Get(ctx context.Context, id string) (*Residence, er
 	return &result, nil
 }
 
-// Iterate returns a paginator for iterating over residences
-func (r *ResidencesResource) Iterate(ctx context.Context, params *ResidenceSearch) *Paginator[Residence] {
-	return NewPaginator(ctx, func(ctx context.Context, cursor string) (*ListResponse[Residence], error) {
+// Iterate returns a ResidenceIterator for streaming over residences,
+// accumulating facet counts across every page fetched.
+func (r *ResidencesResource) Iterate(ctx context.Context, params *ResidenceSearch) *ResidenceIterator {
+	it := &ResidenceIterator{facets: make(map[string][]FacetBucket)}
+	it.Paginator = NewPaginator(ctx, func(ctx context.Context, cursor string) (*ListResponse[Residence], error) {
 		p := params
 		if p == nil {
 			p = &ResidenceSearch{}
@@ -98,8 +133,70 @@ func (r *ResidencesResource) Iterate(ctx context.Context, params *ResidenceSearc
 		// For robustness, we should copy.
 		newParams := *p
 		newParams.Cursor = cursor
-		return r.List(ctx, &newParams)
+
+		resp, err := r.List(ctx, &newParams)
+		if err != nil {
+			return nil, err
+		}
+		it.mergeFacets(resp.Facets)
+		return &resp.ListResponse, nil
 	})
+	return it
+}
+
+// ResidenceIterator streams ResidencesResource.Iterate results page by
+// page like a Paginator[Residence], while also accumulating facet bucket
+// counts across every page fetched so far -- something a plain
+// Paginator[T] has no hook for, since ListResponse carries Facets outside
+// the generic envelope it tracks.
+type ResidenceIterator struct {
+	*Paginator[Residence]
+
+	mu     sync.Mutex
+	facets map[string][]FacetBucket
+}
+
+// mergeFacets folds one page's facet buckets into the running totals,
+// summing counts for values seen on more than one page.
+func (it *ResidenceIterator) mergeFacets(facets map[string][]FacetBucket) {
+	if len(facets) == 0 {
+		return
+	}
+
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	for name, buckets := range facets {
+		counts := make(map[string]int, len(it.facets[name]))
+		for _, b := range it.facets[name] {
+			counts[b.Value] = b.Count
+		}
+		for _, b := range buckets {
+			counts[b.Value] += b.Count
+		}
+
+		merged := make([]FacetBucket, 0, len(counts))
+		for value, count := range counts {
+			merged = append(merged, FacetBucket{Value: value, Count: count})
+		}
+		sort.Slice(merged, func(i, j int) bool { return merged[i].Value < merged[j].Value })
+		it.facets[name] = merged
+	}
+}
+
+// Facets returns the facet bucket counts aggregated across every page
+// fetched so far. Safe to call concurrently with Next.
+func (it *ResidenceIterator) Facets() map[string][]FacetBucket {
+	it.mu.Lock()
+	defer it.mu.Unlock()
+
+	out := make(map[string][]FacetBucket, len(it.facets))
+	for name, buckets := range it.facets {
+		cp := make([]FacetBucket, len(buckets))
+		copy(cp, buckets)
+		out[name] = cp
+	}
+	return out
 }
 
 // CampusesResource provides access to campus operations
@@ -108,11 +205,23 @@ type CampusesResource struct {
 	basePath string
 }
 
-// List retrieves all campuses
-func (r *CampusesResource) List(ctx context.Context) (*CampusList, error) {
+// List retrieves campuses with pagination. params may be nil to use the
+// backend's defaults.
+func (r *CampusesResource) List(ctx context.Context, params *ListParams) (*CampusList, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.Cursor != "" {
+			query.Set("cursor", params.Cursor)
+		}
+	}
+
 	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
 		Method: "GET",
 		Path:   r.basePath,
+		Query:  query,
 	})
 	if err != nil {
 		return nil, err
@@ -126,6 +235,19 @@ func (r *CampusesResource) List(ctx context.Context) (*CampusList, error) {
 	return &result, nil
 }
 
+// Iterate returns a paginator for iterating over campuses.
+func (r *CampusesResource) Iterate(ctx context.Context, params *ListParams) *Paginator[Campus] {
+	return NewPaginator(ctx, func(ctx context.Context, cursor string) (*ListResponse[Campus], error) {
+		p := params
+		if p == nil {
+			p = &ListParams{}
+		}
+		newParams := *p
+		newParams.Cursor = cursor
+		return r.List(ctx, &newParams)
+	})
+}
+
 // ResMateResource groups ResMate service resources
 type ResMateResource struct {
 	Residences *ResidencesResource
@@ -1,20 +1,24 @@
 package resources
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"mime/multipart"
+	"strconv"
+	"time"
 
 	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/options"
 )
 
 // BucketsResource provides access to bucket operations
 type BucketsResource struct {
 	client   *lunahttp.Client
 	basePath string
+	// clock reads the current time when computing presigned-URL expiry;
+	// overridable via WithClock for deterministic tests.
+	clock func() time.Time
 }
 
 // List retrieves all buckets
@@ -35,36 +39,93 @@ func (r *BucketsResource) List(ctx context.Context) (*BucketList, error) {
 	return &result, nil
 }
 
-// Upload uploads a file to a bucket
-func (r *BucketsResource) Upload(ctx context.Context, bucketID string, file io.Reader, filename string, metadata map[string]string) (*FileObject, error) {
-	// Create multipart body
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+// InitiateUpload starts a resumable upload of a size-byte file named
+// filename into bucketID, returning the server-issued session (including
+// its recommended ChunkSize) that UploadChunk and CompleteUpload operate
+// on. Callers that want retry/resume across process restarts should
+// prefer the UploadFile helper instead of driving these three calls
+// directly.
+func (r *BucketsResource) InitiateUpload(ctx context.Context, bucketID, filename string, size int64, metadata map[string]string) (*UploadSession, error) {
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "POST",
+		Path:   fmt.Sprintf("%s/%s/uploads", r.basePath, bucketID),
+		Body: map[string]interface{}{
+			"filename": filename,
+			"size":     size,
+			"metadata": metadata,
+		},
+		Resource: "Buckets.InitiateUpload",
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	// Add file
-	part, err := writer.CreateFormFile("file", filename)
+	var session UploadSession
+	if err := json.Unmarshal(resp.Data, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &session, nil
+}
+
+// UploadChunk PUTs one part of sessionID's upload. data is streamed as the
+// request body (it's read exactly once and not retried internally — see
+// RequestConfig.BodyReader); offset and size place it within the total
+// byte stream via a Content-Range header, and checksum (its hex-encoded
+// SHA-256) lets the server reject a corrupted part before acknowledging
+// it. index is 0-based and identifies the part for CompleteUpload/
+// ListParts.
+func (r *BucketsResource) UploadChunk(ctx context.Context, sessionID string, index int, data io.Reader, offset, size, total int64, checksum string) (*UploadPart, error) {
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method:      "PUT",
+		Path:        fmt.Sprintf("%s/uploads/%s/parts/%d", r.basePath, sessionID, index),
+		BodyReader:  data,
+		ContentType: "application/octet-stream",
+		Headers: map[string]string{
+			"Content-Range":           fmt.Sprintf("bytes %d-%d/%d", offset, offset+size-1, total),
+			"X-Chunk-Checksum-Sha256": checksum,
+			"Content-Length":          strconv.FormatInt(size, 10),
+		},
+		Resource: "Buckets.UploadChunk",
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		return nil, err
 	}
-	if _, err := io.Copy(part, file); err != nil {
-		return nil, fmt.Errorf("failed to copy file content: %w", err)
+
+	var part UploadPart
+	if err := json.Unmarshal(resp.Data, &part); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	return &part, nil
+}
 
-	// Add metadata
-	if len(metadata) > 0 {
-		metaBytes, _ := json.Marshal(metadata)
-		_ = writer.WriteField("metadata", string(metaBytes))
+// ListParts returns the parts the server has already received for
+// sessionID, so a resumed upload can skip re-sending them even if the
+// local .luna-upload-<id>.state sidecar was lost.
+func (r *BucketsResource) ListParts(ctx context.Context, sessionID string) ([]UploadPart, error) {
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method:   "GET",
+		Path:     fmt.Sprintf("%s/uploads/%s/parts", r.basePath, sessionID),
+		Resource: "Buckets.ListParts",
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	var parts []UploadPart
+	if err := json.Unmarshal(resp.Data, &parts); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
+	return parts, nil
+}
 
+// CompleteUpload finalizes sessionID once every part in parts has been
+// uploaded, and returns the resulting FileObject.
+func (r *BucketsResource) CompleteUpload(ctx context.Context, sessionID string, parts []UploadPart) (*FileObject, error) {
 	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
-		Method:      "POST",
-		Path:        fmt.Sprintf("%s/%s/upload", r.basePath, bucketID),
-		BodyReader:  body,
-		ContentType: writer.FormDataContentType(),
+		Method:   "POST",
+		Path:     fmt.Sprintf("%s/uploads/%s/complete", r.basePath, sessionID),
+		Body:     map[string]interface{}{"parts": parts},
+		Resource: "Buckets.CompleteUpload",
 	})
 	if err != nil {
 		return nil, err
@@ -74,7 +135,6 @@ func (r *BucketsResource) Upload(ctx context.Context, bucketID string, file io.R
 	if err := json.Unmarshal(resp.Data, &result); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
-
 	return &result, nil
 }
 
@@ -82,6 +142,9 @@ func (r *BucketsResource) Upload(ctx context.Context, bucketID string, file io.R
 type FilesResource struct {
 	client   *lunahttp.Client
 	basePath string
+	// clock reads the current time when computing presigned-URL expiry;
+	// overridable via WithClock for deterministic tests.
+	clock func() time.Time
 }
 
 // GetDownloadURL retrieves the download URL for a file
@@ -109,18 +172,88 @@ func (r *FilesResource) GetDownloadURL(ctx context.Context, id string) (string,
 type StorageResource struct {
 	Buckets *BucketsResource
 	Files   *FilesResource
+	config  *StorageConfig
+}
+
+// storageOptions is StorageResource's option target; NewStorageResource
+// starts from its zero value (matching the resource's pre-options
+// defaults) and applies each StorageOption in order.
+type storageOptions struct {
+	bucketsPath string
+	filesPath   string
+	decorator   func(*lunahttp.Client) *lunahttp.Client
+	clock       func() time.Time
+	config      *StorageConfig
+}
+
+// StorageOption configures NewStorageResource.
+type StorageOption = options.Option[storageOptions]
+
+// WithBucketsPath overrides the base path BucketsResource requests
+// against. Defaults to "/v1/storage/buckets".
+func WithBucketsPath(path string) StorageOption {
+	return func(o *storageOptions) { o.bucketsPath = path }
+}
+
+// WithFilesPath overrides the base path FilesResource requests against.
+// Defaults to "/v1/storage/files".
+func WithFilesPath(path string) StorageOption {
+	return func(o *storageOptions) { o.filesPath = path }
+}
+
+// WithRequestDecorator wraps the *lunahttp.Client used by Buckets and
+// Files, letting callers inject middleware (extra headers, request
+// logging, a test double) around the client NewStorageResource was given.
+func WithRequestDecorator(fn func(*lunahttp.Client) *lunahttp.Client) StorageOption {
+	return func(o *storageOptions) { o.decorator = fn }
 }
 
-// NewStorageResource creates a new Storage resource
-func NewStorageResource(client *lunahttp.Client) *StorageResource {
+// WithClock overrides the func used to read the current time, for tests
+// that need deterministic presigned-URL expiry calculations. Defaults to
+// time.Now.
+func WithClock(fn func() time.Time) StorageOption {
+	return func(o *storageOptions) { o.clock = fn }
+}
+
+// WithStorageBackendConfig sets the StorageConfig describing an
+// S3-compatible backend (MinIO, DO Spaces, R2) the Luna API proxies
+// directly. Omit to use the Luna-managed backend.
+func WithStorageBackendConfig(config StorageConfig) StorageOption {
+	return func(o *storageOptions) { o.config = &config }
+}
+
+// NewStorageResource creates a new Storage resource. With no options it
+// behaves exactly as before options existed: Buckets/Files request against
+// their default paths on client, talking to the Luna-managed backend.
+func NewStorageResource(client *lunahttp.Client, opts ...StorageOption) *StorageResource {
+	o := storageOptions{
+		bucketsPath: "/v1/storage/buckets",
+		filesPath:   "/v1/storage/files",
+		clock:       time.Now,
+	}
+	options.Apply(&o, opts)
+
+	effectiveClient := client
+	if o.decorator != nil {
+		effectiveClient = o.decorator(client)
+	}
+
+	config := o.config
+	if config == nil {
+		config = &StorageConfig{}
+	}
+
 	return &StorageResource{
 		Buckets: &BucketsResource{
-			client:   client,
-			basePath: "/v1/storage/buckets",
+			client:   effectiveClient,
+			basePath: o.bucketsPath,
+			clock:    o.clock,
 		},
 		Files: &FilesResource{
-			client:   client,
-			basePath: "/v1/storage/files",
+			client:   effectiveClient,
+			basePath: o.filesPath,
+			clock:    o.clock,
 		},
+		config: config,
 	}
 }
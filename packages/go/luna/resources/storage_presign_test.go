@@ -0,0 +1,50 @@
+package resources_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesResource_Download(t *testing.T) {
+	ms := testutil.NewMockServer()
+	defer ms.Close()
+
+	client, err := luna.NewClient(
+		luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
+		luna.WithBaseURL(ms.URL()),
+	)
+	require.NoError(t, err)
+
+	content := []byte("hello from a presigned url")
+	ms.SetMockObject("file_123456789", content)
+
+	var buf bytes.Buffer
+	err = client.Storage().Files.Download(context.Background(), "file_123456789", &buf)
+	require.NoError(t, err)
+	assert.Equal(t, content, buf.Bytes())
+}
+
+func TestBucketsResource_GetPresignedUploadURL(t *testing.T) {
+	ms := testutil.NewMockServer()
+	defer ms.Close()
+
+	client, err := luna.NewClient(
+		luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
+		luna.WithBaseURL(ms.URL()),
+	)
+	require.NoError(t, err)
+
+	url, err := client.Storage().Buckets.GetPresignedUploadURL(context.Background(), "bkt_123456789", "report.pdf", 10*time.Minute, resources.PresignOptions{
+		ContentType: "application/pdf",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, url)
+}
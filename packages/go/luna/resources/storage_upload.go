@@ -0,0 +1,277 @@
+package resources
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// defaultUploadConcurrency is how many chunks UploadFile sends in parallel
+// when UploadOptions.Concurrency isn't set.
+const defaultUploadConcurrency = 4
+
+// UploadOptions configures UploadFile.
+type UploadOptions struct {
+	// Metadata is attached to the file via InitiateUpload.
+	Metadata map[string]string
+	// ChunkSize overrides the server's recommended chunk size from
+	// InitiateUpload. Leave zero to use the server's recommendation.
+	ChunkSize int64
+	// Concurrency is how many chunks to upload in parallel. Defaults to
+	// defaultUploadConcurrency.
+	Concurrency int
+	// Progress, if set, is called after each chunk finishes uploading
+	// with the cumulative bytes sent (including chunks skipped because
+	// they were already uploaded) and the total file size.
+	Progress func(bytesSent, bytesTotal int64)
+}
+
+// uploadState is the sidecar UploadFile persists next to the source file
+// as .luna-upload-<id>.state, recording which parts have already reached
+// the server so a retried UploadFile call can skip them.
+type uploadState struct {
+	SessionID string `json:"session_id"`
+	Completed []int  `json:"completed"`
+}
+
+// sidecarPath returns the state file UploadFile keeps alongside path for
+// an upload session with the given ID.
+func sidecarPath(path, sessionID string) string {
+	return fmt.Sprintf("%s.luna-upload-%s.state", path, sessionID)
+}
+
+// findSidecar looks for an existing .luna-upload-*.state file next to
+// path, returning the loaded state if one exists and its file path.
+func findSidecar(path string) (*uploadState, string, error) {
+	matches, err := filepath.Glob(fmt.Sprintf("%s.luna-upload-*.state", path))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(matches) == 0 {
+		return nil, "", nil
+	}
+
+	// Multiple sidecars would mean two incomplete uploads of the same
+	// file; take the most recently modified one and let the orphaned
+	// rest be cleaned up manually.
+	sort.Strings(matches)
+	statePath := matches[len(matches)-1]
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, "", err
+	}
+	var state uploadState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, "", fmt.Errorf("corrupt upload state file %s: %w", statePath, err)
+	}
+	return &state, statePath, nil
+}
+
+func writeSidecar(statePath string, state *uploadState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(statePath, data, 0600)
+}
+
+// UploadFile uploads the file at path to bucketID using the chunked,
+// resumable protocol (InitiateUpload/UploadChunk/CompleteUpload), sending
+// up to opts.Concurrency chunks at a time. It never buffers more than one
+// chunk per in-flight upload, so memory use doesn't scale with file size.
+//
+// If a previous call was interrupted, UploadFile finds the .luna-upload-
+// <id>.state sidecar it left next to path, cross-checks it against
+// ListParts (in case the sidecar is stale or was deleted but the server
+// still has the session), and resumes by uploading only the parts
+// missing from both. The sidecar is removed once CompleteUpload succeeds.
+func (r *BucketsResource) UploadFile(ctx context.Context, bucketID, path string, opts UploadOptions) (*FileObject, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	size := info.Size()
+
+	session, statePath, completed, err := r.resumeOrInitiateUpload(ctx, bucketID, path, size, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	chunkSize := opts.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = session.ChunkSize
+	}
+	numChunks := int((size + chunkSize - 1) / chunkSize)
+	if size == 0 {
+		numChunks = 0
+	}
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultUploadConcurrency
+	}
+
+	parts := make([]UploadPart, numChunks)
+	var bytesSent int64
+	for index := range completed {
+		if index < numChunks {
+			parts[index] = completed[index]
+			bytesSent += completed[index].Size
+		}
+	}
+	if opts.Progress != nil && bytesSent > 0 {
+		opts.Progress(bytesSent, size)
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		sem       = make(chan struct{}, concurrency)
+		uploadErr error
+	)
+
+	for index := 0; index < numChunks; index++ {
+		if _, ok := completed[index]; ok {
+			continue
+		}
+
+		offset := int64(index) * chunkSize
+		length := chunkSize
+		if offset+length > size {
+			length = size - offset
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(index int, offset, length int64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			part, err := r.uploadChunkFromFile(ctx, session.ID, file, index, offset, length, size)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if uploadErr == nil {
+					uploadErr = fmt.Errorf("chunk %d: %w", index, err)
+				}
+				return
+			}
+			parts[index] = *part
+			bytesSent += length
+			if opts.Progress != nil {
+				opts.Progress(bytesSent, size)
+			}
+
+			state := &uploadState{SessionID: session.ID}
+			for i, p := range parts {
+				if p.Checksum != "" {
+					state.Completed = append(state.Completed, i)
+				}
+			}
+			_ = writeSidecar(statePath, state)
+		}(index, offset, length)
+	}
+	wg.Wait()
+
+	if uploadErr != nil {
+		return nil, fmt.Errorf("upload of %s failed, rerun UploadFile to resume: %w", path, uploadErr)
+	}
+
+	result, err := r.CompleteUpload(ctx, session.ID, parts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete upload: %w", err)
+	}
+
+	_ = os.Remove(statePath)
+	return result, nil
+}
+
+// resumeOrInitiateUpload finds an existing sidecar for path and, if one
+// exists, reconciles it against the server's ListParts before resuming;
+// otherwise it starts a fresh InitiateUpload. It returns the session, the
+// sidecar path to maintain as parts complete, and a map of already-done
+// parts keyed by index.
+func (r *BucketsResource) resumeOrInitiateUpload(ctx context.Context, bucketID, path string, size int64, opts UploadOptions) (*UploadSession, string, map[int]UploadPart, error) {
+	state, statePath, err := findSidecar(path)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if state != nil {
+		serverParts, err := r.ListParts(ctx, state.SessionID)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("failed to list parts for session %s: %w", state.SessionID, err)
+		}
+
+		// Trust the server over the sidecar: a part the sidecar lists as
+		// done but ListParts doesn't know about gets re-uploaded rather
+		// than assumed complete.
+		completed := make(map[int]UploadPart, len(serverParts))
+		for _, p := range serverParts {
+			completed[p.Index] = p
+		}
+
+		return &UploadSession{ID: state.SessionID, BucketID: bucketID, Size: size}, statePath, completed, nil
+	}
+
+	session, err := r.InitiateUpload(ctx, bucketID, filepath.Base(path), size, opts.Metadata)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to initiate upload: %w", err)
+	}
+
+	newStatePath := sidecarPath(path, session.ID)
+	if err := writeSidecar(newStatePath, &uploadState{SessionID: session.ID}); err != nil {
+		return nil, "", nil, fmt.Errorf("failed to write upload state file: %w", err)
+	}
+
+	return session, newStatePath, map[int]UploadPart{}, nil
+}
+
+// uploadChunkFromFile reads the length bytes at offset in two passes: one
+// to hash them into a SHA-256 checksum, and a second, streamed through an
+// io.Pipe straight into the HTTP request body, so at no point is more
+// than one chunk's worth of data held in memory.
+func (r *BucketsResource) uploadChunkFromFile(ctx context.Context, sessionID string, file *os.File, index int, offset, length, total int64) (*UploadPart, error) {
+	checksum, err := hashSection(file, offset, length)
+	if err != nil {
+		return nil, fmt.Errorf("failed to checksum chunk: %w", err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		section := io.NewSectionReader(file, offset, length)
+		_, copyErr := io.Copy(pw, section)
+		pw.CloseWithError(copyErr)
+	}()
+
+	part, err := r.UploadChunk(ctx, sessionID, index, pr, offset, length, total, checksum)
+	if err != nil {
+		return nil, err
+	}
+	return part, nil
+}
+
+// hashSection computes the hex-encoded SHA-256 of the length bytes at
+// offset in r, without loading the rest of the file.
+func hashSection(r io.ReaderAt, offset, length int64) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, io.NewSectionReader(r, offset, length)); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
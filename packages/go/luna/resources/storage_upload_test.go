@@ -0,0 +1,48 @@
+package resources_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucketsResource_UploadFile(t *testing.T) {
+	ms := testutil.NewMockServer()
+	defer ms.Close()
+
+	client, err := luna.NewClient(
+		luna.WithAPIKey("lk_test_12345678901234567890123456789012"),
+		luna.WithBaseURL(ms.URL()),
+	)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "upload.bin")
+	require.NoError(t, os.WriteFile(path, make([]byte, 12*1024*1024), 0600))
+
+	t.Run("splits the file into multiple chunks and completes the upload", func(t *testing.T) {
+		var lastSent, total int64
+		result, err := client.Storage().Buckets.UploadFile(context.Background(), "bkt_123456789", path, resources.UploadOptions{
+			ChunkSize:   4 * 1024 * 1024,
+			Concurrency: 2,
+			Progress: func(bytesSent, bytesTotal int64) {
+				lastSent, total = bytesSent, bytesTotal
+			},
+		})
+
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, int64(12*1024*1024), total)
+		assert.Equal(t, total, lastSent)
+
+		matches, _ := filepath.Glob(path + ".luna-upload-*.state")
+		assert.Empty(t, matches, "sidecar state file should be removed after a successful upload")
+	})
+}
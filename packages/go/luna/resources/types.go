@@ -1,7 +1,10 @@
 // Package resources provides API resource implementations for the Luna SDK.
 package resources
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // ListParams holds common pagination parameters
 type ListParams struct {
@@ -35,6 +38,10 @@ type UserUpdate struct {
 // UserList holds a paginated list of users
 type UserList = ListResponse[User]
 
+// UserBatchResult is the outcome of one operation in a UsersResource.Batch
+// call.
+type UserBatchResult = BatchResult[User]
+
 // Project represents a project resource
 type Project struct {
 	ID          string    `json:"id"`
@@ -60,6 +67,10 @@ type ProjectUpdate struct {
 // ProjectList holds a paginated list of projects
 type ProjectList = ListResponse[Project]
 
+// ProjectBatchResult is the outcome of one operation in a
+// ProjectsResource.Batch call.
+type ProjectBatchResult = BatchResult[Project]
+
 // ResidenceLocation represents a residence location
 type ResidenceLocation struct {
 	Latitude  float64 `json:"latitude"`
@@ -95,7 +106,49 @@ type Residence struct {
 	Amenities []string `json:"amenities"`
 }
 
-// ResidenceSearch holds search/filter parameters for residences
+// GeoPoint is a latitude/longitude pair used to anchor a
+// ResidenceSearch.Radius search.
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// GeoBox is an axis-aligned bounding box used by ResidenceSearch as an
+// alternative to a radius search, in the minLng,minLat,maxLng,maxLat
+// field order the `bbox` query parameter expects.
+type GeoBox struct {
+	MinLng float64
+	MinLat float64
+	MaxLng float64
+	MaxLat float64
+}
+
+// SortDirection is the direction of a ResidenceSearch SortField.
+type SortDirection string
+
+const (
+	SortAsc  SortDirection = "asc"
+	SortDesc SortDirection = "desc"
+)
+
+// SortField orders ResidenceSearch results by a single field, e.g.
+// {Field: "price", Direction: SortAsc}. Direction may be left empty for
+// fields (like "distance") the API sorts ascending by default.
+type SortField struct {
+	Field     string
+	Direction SortDirection
+}
+
+// FacetBucket is one value and its matching-result count within a
+// requested ResidenceSearch.Facets aggregation.
+type FacetBucket struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// ResidenceSearch holds search/filter parameters for residences. Build
+// one with NewResidenceSearch for the common, fluent case, or construct
+// the struct directly for full control.
 type ResidenceSearch struct {
 	ListParams
 	Query     string  `json:"query,omitempty"`
@@ -106,10 +159,34 @@ type ResidenceSearch struct {
 	CampusID  string  `json:"campus_id,omitempty"`
 	Radius    float64 `json:"radius,omitempty"`
 	MinRating float64 `json:"min_rating,omitempty"`
+
+	// Location pairs with Radius for a point-radius search; RadiusUnit is
+	// "km" (the default) or "mi".
+	Location   *GeoPoint `json:"location,omitempty"`
+	RadiusUnit string    `json:"radius_unit,omitempty"`
+	// Origin is the point SearchNearby filters around client-side, once the
+	// server has narrowed results to BoundingBox. It's not itself a
+	// recognized query parameter -- the server only ever sees BoundingBox --
+	// so List ignores it; set it via SearchNearby, not directly.
+	Origin *ResidenceLocation `json:"-"`
+	// BoundingBox is an alternative to Location+Radius for a rectangular
+	// search area; the two are mutually exclusive.
+	BoundingBox *GeoBox `json:"bbox,omitempty"`
+
+	Sort []SortField `json:"sort,omitempty"`
+	// Facets requests server-side aggregations for the named fields
+	// (e.g. "gender_policy", "campus_id"), returned in ResidenceList.Facets.
+	Facets []string `json:"facets,omitempty"`
 }
 
-// ResidenceList holds a paginated list of residences
-type ResidenceList = ListResponse[Residence]
+// ResidenceList holds a paginated list of residences, plus any facet
+// aggregations requested via ResidenceSearch.Facets.
+type ResidenceList struct {
+	ListResponse[Residence]
+	// Facets holds one bucket list per requested facet name. Nil unless
+	// the search set Facets.
+	Facets map[string][]FacetBucket `json:"facets,omitempty"`
+}
 
 // CampusLocation represents a campus location
 type CampusLocation struct {
@@ -167,29 +244,171 @@ type FileObject struct {
 	URL         string `json:"url"`
 }
 
+// UploadSession is a resumable upload in progress, returned by
+// BucketsResource.InitiateUpload.
+type UploadSession struct {
+	ID        string `json:"id"`
+	BucketID  string `json:"bucket_id"`
+	Filename  string `json:"filename"`
+	Size      int64  `json:"size"`
+	ChunkSize int64  `json:"chunk_size"`
+}
+
+// UploadPart describes one uploaded chunk of an UploadSession, as returned
+// by BucketsResource.UploadChunk/ListParts and accepted by CompleteUpload.
+type UploadPart struct {
+	Index    int    `json:"index"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
 // CompletionRequest represents an AI completion request
 type CompletionRequest struct {
 	Model       string    `json:"model"`
 	Messages    []Message `json:"messages"`
 	Temperature *float64  `json:"temperature,omitempty"`
+	// Stream is set by ChatCompletionsStream; CompletionRequest callers of
+	// the blocking ChatCompletions never need to set it themselves.
+	Stream bool `json:"stream,omitempty"`
+	// Tools lists the functions the model may call instead of (or before)
+	// producing a final assistant message. Leave nil for plain chat.
+	Tools []ToolDefinition `json:"tools,omitempty"`
+	// ToolChoice steers tool selection: "auto" (the default), "none", or
+	// {"type": "function", "function": {"name": "..."}} to force a specific
+	// one. Left as interface{} since its shape depends on which of those
+	// forms the caller wants, same as the OpenAI-compatible wire format.
+	ToolChoice interface{} `json:"tool_choice,omitempty"`
+
+	MaxTokens        *int     `json:"max_tokens,omitempty"`
+	TopP             *float64 `json:"top_p,omitempty"`
+	Stop             []string `json:"stop,omitempty"`
+	PresencePenalty  *float64 `json:"presence_penalty,omitempty"`
+	FrequencyPenalty *float64 `json:"frequency_penalty,omitempty"`
+	// ResponseFormat constrains the model to plain text, JSON, or a
+	// specific JSON schema (ResponseFormatJSONSchema).
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
+}
+
+// ResponseFormat constrains CompletionRequest's output shape. Type is
+// "text" (the default), "json_object" for free-form JSON, or
+// "json_schema" with JSONSchema set for a structured output the caller can
+// unmarshal without its own prompt-engineered coaxing.
+type ResponseFormat struct {
+	Type       string                    `json:"type"`
+	JSONSchema *ResponseFormatJSONSchema `json:"json_schema,omitempty"`
+}
+
+// ResponseFormatJSONSchema names and constrains a "json_schema"
+// ResponseFormat. Strict, when true, asks the model to guarantee the
+// response validates against Schema rather than merely aim for it.
+type ResponseFormatJSONSchema struct {
+	Name   string                 `json:"name"`
+	Schema map[string]interface{} `json:"schema"`
+	Strict bool                   `json:"strict,omitempty"`
 }
 
 // Message represents a chat message
 type Message struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
+	// ToolCalls is set on an assistant Message whose FinishReason is
+	// "tool_calls": one entry per function the model wants invoked before
+	// it continues.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID is set on a Message with Role "tool", echoing the
+	// ToolCall.ID it answers so the model can match results to calls.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+// ToolDefinition advertises one callable function to the model, in the
+// OpenAI-style `tools` request shape.
+type ToolDefinition struct {
+	Type     string             `json:"type"`
+	Function ToolFunctionSchema `json:"function"`
+}
+
+// ToolFunctionSchema describes a callable function's name and JSON-schema
+// parameters, so the model knows what arguments to produce.
+type ToolFunctionSchema struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// ToolCall is a single function invocation the model has requested. Index
+// identifies which tool call a streamed delta belongs to (a streaming
+// response may interleave deltas for several tool calls); it's unused and
+// always 0 on a non-streaming Choice.Message.ToolCalls.
+type ToolCall struct {
+	Index    int              `json:"index,omitempty"`
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Function ToolCallFunction `json:"function"`
+}
+
+// ToolCallFunction names the function being invoked and its arguments,
+// JSON-encoded as a string (per the OpenAI tool-calling wire format)
+// rather than as a raw object.
+type ToolCallFunction struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
 }
 
 // CompletionResponse represents an AI completion response
 type CompletionResponse struct {
 	ID      string   `json:"id"`
 	Choices []Choice `json:"choices"`
+	// Usage reports how many tokens the request and response consumed, so
+	// callers can meter spend without a separate accounting call.
+	Usage *Usage `json:"usage,omitempty"`
 }
 
 // Choice represents a completion choice
 type Choice struct {
 	Index   int     `json:"index"`
 	Message Message `json:"message"`
+	// FinishReason is "stop" for a normal completion or "tool_calls" when
+	// Message.ToolCalls must be executed before the model can continue.
+	FinishReason string `json:"finish_reason,omitempty"`
+}
+
+// CompletionChunk is one incremental step of a ChatCompletionsStream,
+// parsed from a single "data: {...}" server-sent event.
+type CompletionChunk struct {
+	ID      string        `json:"id"`
+	Choices []ChunkChoice `json:"choices"`
+	// Usage is only present on the final chunk of a stream, when the
+	// provider supports mid-stream usage reporting.
+	Usage *Usage `json:"usage,omitempty"`
+}
+
+// ChunkChoice is the streaming analogue of Choice: Delta carries only the
+// incremental content added by this chunk, not the full message so far.
+type ChunkChoice struct {
+	Index        int     `json:"index"`
+	Delta        Message `json:"delta"`
+	FinishReason string  `json:"finish_reason,omitempty"`
+}
+
+// Usage reports token counts for a completion.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// PromptStarterRequest configures AiResource.PromptStarters.
+type PromptStarterRequest struct {
+	AppName     string `json:"app_name"`
+	Description string `json:"description"`
+	// Limit caps how many prompts are returned; it must be in [1, 9].
+	Limit int `json:"limit"`
+}
+
+// promptStarterResponse is the wire shape of a successful
+// /ai/prompt-starters response.
+type promptStarterResponse struct {
+	Prompts []string `json:"prompts"`
 }
 
 // Workflow represents an automation workflow
@@ -210,3 +429,75 @@ type WorkflowRun struct {
 	Status     string `json:"status"`
 	StartedAt  string `json:"started_at"`
 }
+
+// WorkflowRunList holds a paginated list of workflow runs
+type WorkflowRunList = ListResponse[WorkflowRun]
+
+// LogEvent is one line of a workflow run's streamed execution log, as
+// delivered by WorkflowsResource.StreamLogs.
+type LogEvent struct {
+	ID        string `json:"id"`
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+}
+
+// WebhookSubscription represents a registered delivery endpoint for
+// platform events such as user.created or storage.file.uploaded. Pair it
+// with the luna/webhooks package's Handler to verify and dispatch
+// deliveries on the receiving end.
+type WebhookSubscription struct {
+	ID        string    `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	Status    string    `json:"status"` // "enabled" | "disabled"
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookSubscriptionCreate holds parameters for creating a webhook
+// subscription.
+type WebhookSubscriptionCreate struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// WebhookSubscriptionCreated is the response to a
+// WebhooksResource.Create call. It embeds WebhookSubscription and
+// additionally carries Secret, the HMAC signing secret used to verify
+// this subscription's deliveries -- returned only once, at creation time,
+// the same way a newly issued API key would be.
+type WebhookSubscriptionCreated struct {
+	WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// WebhookSubscriptionUpdate holds parameters for updating a webhook
+// subscription. A nil field leaves that attribute unchanged.
+type WebhookSubscriptionUpdate struct {
+	URL    *string   `json:"url,omitempty"`
+	Events *[]string `json:"events,omitempty"`
+	Status *string   `json:"status,omitempty"`
+}
+
+// WebhookSubscriptionList holds a paginated list of webhook subscriptions.
+type WebhookSubscriptionList = ListResponse[WebhookSubscription]
+
+// WebhookDelivery is one attempted delivery of an event to a
+// WebhookSubscription, as returned by WebhooksResource.ListDeliveries.
+// Payload carries the exact envelope that was (or would be) sent, so an
+// undelivered entry can be replayed locally via the luna/webhooks
+// package's Replay helper.
+type WebhookDelivery struct {
+	ID         string          `json:"id"`
+	EventID    string          `json:"event_id"`
+	EventType  string          `json:"event_type"`
+	Delivered  bool            `json:"delivered"`
+	StatusCode int             `json:"status_code,omitempty"`
+	Attempts   int             `json:"attempts"`
+	Payload    json.RawMessage `json:"payload"`
+	CreatedAt  time.Time       `json:"created_at"`
+}
+
+// WebhookDeliveryList holds a paginated list of webhook deliveries.
+type WebhookDeliveryList = ListResponse[WebhookDelivery]
@@ -63,8 +63,9 @@ func (r *UsersResource) Iterate(ctx context.Context, params *ListParams) *Pagina
 		if p == nil {
 			p = &ListParams{}
 		}
-		p.Cursor = cursor
-		return r.List(ctx, p)
+		newParams := *p
+		newParams.Cursor = cursor
+		return r.List(ctx, &newParams)
 	})
 }
 
@@ -75,8 +76,9 @@ func (r *UsersResource) Get(ctx context.Context, userID string) (*User, error) {
 	}
 
 	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
-		Method: "GET",
-		Path:   fmt.Sprintf("%s/%s", r.basePath, userID),
+		Method:   "GET",
+		Path:     fmt.Sprintf("%s/%s", r.basePath, userID),
+		Resource: "Users.Get",
 	})
 	if err != nil {
 		return nil, err
@@ -152,6 +154,21 @@ func (r *UsersResource) Delete(ctx context.Context, userID string) error {
 	return err
 }
 
+// Batch performs a mix of create/update/delete operations in a single
+// POST /v1/users:batch request, returning one BatchResult per operation
+// in the same order. See BatchAll to transparently split an oversize ops
+// slice across multiple requests.
+func (r *UsersResource) Batch(ctx context.Context, ops []BatchOperation) ([]UserBatchResult, error) {
+	return runBatch[User](ctx, r.client, r.basePath+":batch", ops, validateUserID)
+}
+
+// BatchAll splits ops into chunks of at most chunkSize (DefaultBatchChunkSize
+// if chunkSize <= 0), runs Batch on each, and merges the results in input
+// order.
+func (r *UsersResource) BatchAll(ctx context.Context, ops []BatchOperation, chunkSize int) ([]UserBatchResult, error) {
+	return runBatchAll[User](ctx, r.client, r.basePath+":batch", ops, validateUserID, chunkSize)
+}
+
 func validateUserID(id string) error {
 	if id == "" {
 		return fmt.Errorf("user ID is required")
@@ -0,0 +1,189 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+)
+
+// WebhooksResource provides access to webhook subscription operations.
+type WebhooksResource struct {
+	client   *lunahttp.Client
+	basePath string
+}
+
+// NewWebhooksResource creates a new webhooks resource
+func NewWebhooksResource(client *lunahttp.Client) *WebhooksResource {
+	return &WebhooksResource{
+		client:   client,
+		basePath: "/v1/webhooks",
+	}
+}
+
+// List retrieves all webhook subscriptions with pagination
+func (r *WebhooksResource) List(ctx context.Context, params *ListParams) (*WebhookSubscriptionList, error) {
+	query := url.Values{}
+	if params != nil {
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.Cursor != "" {
+			query.Set("cursor", params.Cursor)
+		}
+	}
+
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "GET",
+		Path:   r.basePath,
+		Query:  query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result WebhookSubscriptionList
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Iterate returns a paginator for iterating over webhook subscriptions
+func (r *WebhooksResource) Iterate(ctx context.Context, params *ListParams) *Paginator[WebhookSubscription] {
+	return NewPaginator(ctx, func(ctx context.Context, cursor string) (*ListResponse[WebhookSubscription], error) {
+		p := params
+		if p == nil {
+			p = &ListParams{}
+		}
+		newParams := *p
+		newParams.Cursor = cursor
+		return r.List(ctx, &newParams)
+	})
+}
+
+// Get retrieves a webhook subscription by ID
+func (r *WebhooksResource) Get(ctx context.Context, id string) (*WebhookSubscription, error) {
+	if id == "" {
+		return nil, fmt.Errorf("webhook subscription ID is required")
+	}
+
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s", r.basePath, id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result WebhookSubscription
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Create creates a new webhook subscription. The returned
+// WebhookSubscriptionCreated.Secret is the only time the signing secret is
+// ever returned -- callers should persist it for verifying this
+// subscription's deliveries with luna/webhooks.NewHandler.
+func (r *WebhooksResource) Create(ctx context.Context, data WebhookSubscriptionCreate) (*WebhookSubscriptionCreated, error) {
+	if data.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	if len(data.Events) == 0 {
+		return nil, fmt.Errorf("at least one event is required")
+	}
+
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "POST",
+		Path:   r.basePath,
+		Body:   data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result WebhookSubscriptionCreated
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Update updates an existing webhook subscription
+func (r *WebhooksResource) Update(ctx context.Context, id string, data WebhookSubscriptionUpdate) (*WebhookSubscription, error) {
+	if id == "" {
+		return nil, fmt.Errorf("webhook subscription ID is required")
+	}
+
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "PATCH",
+		Path:   fmt.Sprintf("%s/%s", r.basePath, id),
+		Body:   data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result WebhookSubscription
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Delete deletes a webhook subscription
+func (r *WebhooksResource) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return fmt.Errorf("webhook subscription ID is required")
+	}
+
+	_, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "DELETE",
+		Path:   fmt.Sprintf("%s/%s", r.basePath, id),
+	})
+	return err
+}
+
+// ListDeliveries retrieves a webhook subscription's delivery attempts,
+// including undelivered ones -- see luna/webhooks.Replay to re-run any
+// undelivered entries through a local Handler.
+func (r *WebhooksResource) ListDeliveries(ctx context.Context, id string, params *ListParams) (*WebhookDeliveryList, error) {
+	if id == "" {
+		return nil, fmt.Errorf("webhook subscription ID is required")
+	}
+
+	query := url.Values{}
+	if params != nil {
+		if params.Limit > 0 {
+			query.Set("limit", strconv.Itoa(params.Limit))
+		}
+		if params.Cursor != "" {
+			query.Set("cursor", params.Cursor)
+		}
+	}
+
+	resp, err := r.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "GET",
+		Path:   fmt.Sprintf("%s/%s/deliveries", r.basePath, id),
+		Query:  query,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result WebhookDeliveryList
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
@@ -7,11 +7,15 @@ import (
 )
 
 // AddressUtils provides South African address utilities.
-type AddressUtils struct{}
+type AddressUtils struct {
+	localizer Localizer
+}
 
-// NewAddressUtils creates a new address utils instance.
-func NewAddressUtils() *AddressUtils {
-	return &AddressUtils{}
+// NewAddressUtils creates a new address utils instance, localizing
+// Validate's issues and Format/GetProvinceName's province names into
+// locale.
+func NewAddressUtils(locale Locale) *AddressUtils {
+	return &AddressUtils{localizer: NewLocalizer(locale)}
 }
 
 // Postal code ranges by province
@@ -59,19 +63,21 @@ var provinceNames = map[SAProvince]string{
 	ProvinceWC:  "Western Cape",
 }
 
-// Validate validates a South African address.
-func (a *AddressUtils) Validate(address SAAddress) map[string]interface{} {
-	var errors []string
-	var warnings []string
+// Validate validates a South African address, returning structured issues
+// whose Code stays stable across Locale (unlike Message, which is
+// localized into a.localizer's Locale).
+func (a *AddressUtils) Validate(address SAAddress) ValidationResult {
+	var errors []ValidationIssue
+	var warnings []ValidationIssue
 
 	// Validate postal code
 	if address.PostalCode != "" {
 		if !regexp.MustCompile(`^\d{4}$`).MatchString(address.PostalCode) {
-			errors = append(errors, "Postal code must be 4 digits")
+			errors = append(errors, a.localizer.Issue("invalid_postal_code", "postal_code"))
 		} else {
 			detectedProvince := a.GetProvinceFromPostalCode(address.PostalCode)
 			if address.Province != "" && detectedProvince != "" && address.Province != detectedProvince {
-				warnings = append(warnings, "Postal code province mismatch")
+				warnings = append(warnings, a.localizer.Issue("postal_province_mismatch", "province"))
 			}
 		}
 	}
@@ -79,14 +85,14 @@ func (a *AddressUtils) Validate(address SAAddress) map[string]interface{} {
 	// Validate province
 	if address.Province != "" {
 		if _, ok := provinceNames[address.Province]; !ok {
-			errors = append(errors, "Invalid province code")
+			errors = append(errors, a.localizer.Issue("invalid_province_code", "province"))
 		}
 	}
 
-	return map[string]interface{}{
-		"valid":    len(errors) == 0,
-		"errors":   errors,
-		"warnings": warnings,
+	return ValidationResult{
+		Valid:    len(errors) == 0,
+		Errors:   errors,
+		Warnings: warnings,
 	}
 }
 
@@ -104,11 +110,7 @@ func (a *AddressUtils) Format(address SAAddress, multiline bool) string {
 		parts = append(parts, address.City)
 	}
 	if address.Province != "" {
-		if name, ok := provinceNames[address.Province]; ok {
-			parts = append(parts, name)
-		} else {
-			parts = append(parts, string(address.Province))
-		}
+		parts = append(parts, a.localizer.ProvinceName(address.Province))
 	}
 	if address.PostalCode != "" {
 		parts = append(parts, address.PostalCode)
@@ -144,12 +146,10 @@ func (a *AddressUtils) GetProvinceFromPostalCode(postalCode string) SAProvince {
 	return ""
 }
 
-// GetProvinceName gets full province name from code.
+// GetProvinceName gets the full province name from code, localized into
+// a.localizer's Locale.
 func (a *AddressUtils) GetProvinceName(code SAProvince) string {
-	if name, ok := provinceNames[code]; ok {
-		return name
-	}
-	return string(code)
+	return a.localizer.ProvinceName(code)
 }
 
 // LookupPostalCode looks up postal code information.
@@ -166,7 +166,7 @@ func (a *AddressUtils) LookupPostalCode(postalCode string) map[string]interface{
 	return map[string]interface{}{
 		"postal_code":   postalCode,
 		"province":      province,
-		"province_name": provinceNames[province],
+		"province_name": a.localizer.ProvinceName(province),
 	}
 }
 
@@ -72,20 +72,32 @@ func (b *BBBEE) MeetsRequirement(ctx context.Context, registrationNumber string,
 		return map[string]interface{}{"meets": false}, nil
 	}
 
-	levelNumber := 9
-	if level, ok := certificate.Level.(int); ok {
-		levelNumber = level
-	}
-
 	return map[string]interface{}{
-		"meets":        levelNumber <= minimumLevel,
+		"meets":        levelNumber(certificate.Level) <= minimumLevel,
 		"actual_level": certificate.Level,
 		"certificate":  certificate,
 	}, nil
 }
 
+// levelNumber normalizes a BBBEELevel to its numeric scorecard level,
+// defaulting to 9 (effectively non-compliant) for anything that isn't a
+// plain int, such as the "non-compliant" sentinel string.
+func levelNumber(level BBBEELevel) int {
+	if n, ok := level.(int); ok {
+		return n
+	}
+	return 9
+}
+
 // GetRecognitionLevel gets procurement recognition percentage.
 func (b *BBBEE) GetRecognitionLevel(level interface{}) int {
+	return RecognitionLevelForLevel(level)
+}
+
+// RecognitionLevelForLevel is the standalone form of GetRecognitionLevel,
+// usable without a *BBBEE instance -- e.g. by Policy.Evaluate, which
+// operates on an already-fetched certificate.
+func RecognitionLevelForLevel(level interface{}) int {
 	recognitionMap := map[interface{}]int{
 		1:               135,
 		2:               125,
@@ -175,8 +187,26 @@ func (b *BBBEE) IsCertificateValid(certificate BBBEECertificate) bool {
 	return certificate.IsValid && expiryDate.After(time.Now())
 }
 
+// Check composes LookupByCompany with Policy.Evaluate, so procurement code
+// gets an explainable allow/deny decision in one call instead of wiring
+// the lookup and evaluation together itself.
+func (b *BBBEE) Check(ctx context.Context, registrationNumber string, policy Policy) (*PolicyResult, error) {
+	certificate, err := b.LookupByCompany(ctx, registrationNumber)
+	if err != nil {
+		return nil, err
+	}
+	return policy.Evaluate(ctx, certificate)
+}
+
 // GetDaysUntilExpiry gets days until certificate expiry.
 func (b *BBBEE) GetDaysUntilExpiry(certificate BBBEECertificate) int {
+	return DaysUntilExpiry(certificate)
+}
+
+// DaysUntilExpiry is the standalone form of GetDaysUntilExpiry, usable
+// without a *BBBEE instance -- e.g. by Policy.Evaluate, which operates on
+// an already-fetched certificate.
+func DaysUntilExpiry(certificate BBBEECertificate) int {
 	expiryDate, err := time.Parse(time.RFC3339, certificate.ExpiryDate)
 	if err != nil {
 		return 0
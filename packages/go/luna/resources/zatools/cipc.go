@@ -13,20 +13,29 @@ import (
 
 // CIPC provides Companies and Intellectual Property Commission integration.
 type CIPC struct {
-	client *lunahttp.Client
-	config CIPCConfig
-	strict bool
+	client    *lunahttp.Client
+	config    CIPCConfig
+	strict    bool
+	localizer Localizer
 }
 
-// NewCIPC creates a new CIPC service instance.
-func NewCIPC(client *lunahttp.Client, config CIPCConfig, strict bool) *CIPC {
+// NewCIPC creates a new CIPC service instance, localizing
+// StatusDescription's output into locale.
+func NewCIPC(client *lunahttp.Client, config CIPCConfig, strict bool, locale Locale) *CIPC {
 	return &CIPC{
-		client: client,
-		config: config,
-		strict: strict,
+		client:    client,
+		config:    config,
+		strict:    strict,
+		localizer: NewLocalizer(locale),
 	}
 }
 
+// StatusDescription returns a human-readable description of status,
+// localized into c.localizer's Locale.
+func (c *CIPC) StatusDescription(status CompanyStatus) string {
+	return c.localizer.CompanyStatusDescription(status)
+}
+
 // Lookup searches for a company by registration number.
 func (c *CIPC) Lookup(ctx context.Context, registrationNumber string) (*Company, error) {
 	cleaned := regexp.MustCompile(`[\s/]`).ReplaceAllString(registrationNumber, "")
@@ -2,17 +2,24 @@
 package zatools
 
 import (
+	"context"
 	"regexp"
 	"strconv"
 	"time"
+
+	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
 )
 
 // IDValidation provides South African ID number validation.
-type IDValidation struct{}
+type IDValidation struct {
+	client    *lunahttp.Client
+	localizer Localizer
+}
 
-// NewIDValidation creates a new ID validation instance.
-func NewIDValidation() *IDValidation {
-	return &IDValidation{}
+// NewIDValidation creates a new ID validation instance, localizing
+// Issues' output into locale.
+func NewIDValidation(client *lunahttp.Client, locale Locale) *IDValidation {
+	return &IDValidation{client: client, localizer: NewLocalizer(locale)}
 }
 
 // Validate validates and parses a South African ID number.
@@ -55,7 +62,7 @@ func (v *IDValidation) Validate(idNumber string) SAIDInfo {
 		gender = "male"
 	}
 
-	return SAIDInfo{
+	result := SAIDInfo{
 		IDNumber:      cleaned,
 		IsValid:       checksumValid,
 		DateOfBirth:   dateOfBirth,
@@ -63,6 +70,44 @@ func (v *IDValidation) Validate(idNumber string) SAIDInfo {
 		IsSACitizen:   citizenshipDigit == 0,
 		ChecksumValid: checksumValid,
 	}
+
+	v.client.Track(context.Background(), "zatools.said.validated", map[string]interface{}{
+		"is_valid": result.IsValid,
+	})
+
+	return result
+}
+
+// Issues explains why idNumber failed Validate, as localized
+// ValidationIssues a caller can show a user instead of just IsValid=false.
+// Returns nil if idNumber is valid.
+func (v *IDValidation) Issues(idNumber string) []ValidationIssue {
+	cleaned := regexp.MustCompile(`[\s-]`).ReplaceAllString(idNumber, "")
+
+	if len(cleaned) != 13 {
+		return []ValidationIssue{v.localizer.Issue("invalid_id_length", "id_number")}
+	}
+	if !regexp.MustCompile(`^\d{13}$`).MatchString(cleaned) {
+		return []ValidationIssue{v.localizer.Issue("invalid_id_format", "id_number")}
+	}
+
+	year, _ := strconv.Atoi(cleaned[0:2])
+	month, _ := strconv.Atoi(cleaned[2:4])
+	day, _ := strconv.Atoi(cleaned[4:6])
+	fullYear := 1900 + year
+	if year <= 30 {
+		fullYear = 2000 + year
+	}
+	dateOfBirth := time.Date(fullYear, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if dateOfBirth.Month() != time.Month(month) || dateOfBirth.Day() != day {
+		return []ValidationIssue{v.localizer.Issue("invalid_id_date", "id_number")}
+	}
+
+	if !v.validateLuhn(cleaned) {
+		return []ValidationIssue{v.localizer.Issue("invalid_id_checksum", "id_number")}
+	}
+
+	return nil
 }
 
 // IsValid returns whether the ID number is valid.
@@ -0,0 +1,240 @@
+// Package zatools provides South African business tool integrations.
+package zatools
+
+// Locale selects which language AddressUtils, CIPC, and IDValidation
+// localize their messages into. The zero value behaves as LocaleEN.
+type Locale string
+
+const (
+	LocaleEN Locale = "en" // English
+	LocaleAF Locale = "af" // Afrikaans
+	LocaleZU Locale = "zu" // isiZulu
+	LocaleXH Locale = "xh" // isiXhosa
+)
+
+// ValidationIssue is one problem Validate found, with Code staying stable
+// across locales for callers that branch on it and Message carrying the
+// localized, human-readable text.
+type ValidationIssue struct {
+	// Code identifies the issue independent of Locale, e.g.
+	// "invalid_postal_code".
+	Code string `json:"code"`
+	// Message is Code's text in the Localizer's configured Locale.
+	Message string `json:"message"`
+	// Field is the SAAddress (or other input struct) field the issue
+	// applies to, e.g. "postal_code". Empty for issues that aren't
+	// specific to one field.
+	Field string `json:"field,omitempty"`
+}
+
+// ValidationResult is the structured outcome of a localized Validate call.
+type ValidationResult struct {
+	Valid    bool              `json:"valid"`
+	Errors   []ValidationIssue `json:"errors"`
+	Warnings []ValidationIssue `json:"warnings"`
+}
+
+// Localizer translates message codes and province/status names into a
+// chosen Locale. AddressUtils, CIPC, and IDValidation each hold one,
+// defaulting to LocaleEN so callers that never touch WithLocale see
+// exactly the English text they always have.
+type Localizer struct {
+	locale Locale
+}
+
+// NewLocalizer creates a Localizer for locale. An unrecognized or empty
+// locale falls back to LocaleEN.
+func NewLocalizer(locale Locale) Localizer {
+	if _, ok := messageCatalog["invalid_postal_code"][locale]; !ok {
+		locale = LocaleEN
+	}
+	return Localizer{locale: locale}
+}
+
+// Locale returns the Localizer's configured Locale.
+func (l Localizer) Locale() Locale { return l.locale }
+
+// Issue builds a ValidationIssue for code and field, translated into l's
+// Locale.
+func (l Localizer) Issue(code, field string) ValidationIssue {
+	return ValidationIssue{Code: code, Message: l.message(code), Field: field}
+}
+
+// message looks up code in messageCatalog, falling back to LocaleEN and
+// then to code itself if no translation exists.
+func (l Localizer) message(code string) string {
+	translations, ok := messageCatalog[code]
+	if !ok {
+		return code
+	}
+	if msg, ok := translations[l.locale]; ok {
+		return msg
+	}
+	return translations[LocaleEN]
+}
+
+// ProvinceName returns province's name in l's Locale, e.g. "Western Cape"
+// (LocaleEN) vs "Wes-Kaap" (LocaleAF).
+func (l Localizer) ProvinceName(province SAProvince) string {
+	names, ok := provinceNamesByLocale[province]
+	if !ok {
+		return string(province)
+	}
+	if name, ok := names[l.locale]; ok {
+		return name
+	}
+	return names[LocaleEN]
+}
+
+// CompanyStatusDescription returns a human-readable description of status
+// in l's Locale, for display alongside the raw CompanyStatus code.
+func (l Localizer) CompanyStatusDescription(status CompanyStatus) string {
+	descriptions, ok := companyStatusDescriptions[status]
+	if !ok {
+		return string(status)
+	}
+	if desc, ok := descriptions[l.locale]; ok {
+		return desc
+	}
+	return descriptions[LocaleEN]
+}
+
+// messageCatalog holds every ValidationIssue message, keyed by its
+// locale-stable code and then by Locale.
+var messageCatalog = map[string]map[Locale]string{
+	"invalid_postal_code": {
+		LocaleEN: "Postal code must be 4 digits",
+		LocaleAF: "Poskode moet 4 syfers wees",
+		LocaleZU: "Ikhodi yeposi kumele ibe nezinombolo ezi-4",
+		LocaleXH: "Ikhowudi yeposi kufuneka ibe namanani ama-4",
+	},
+	"postal_province_mismatch": {
+		LocaleEN: "Postal code province mismatch",
+		LocaleAF: "Poskode-provinsie stem nie ooreen nie",
+		LocaleZU: "Isifunda sekhodi yeposi asihambelani",
+		LocaleXH: "Iphondo lekhowudi yeposi alihambelani",
+	},
+	"invalid_province_code": {
+		LocaleEN: "Invalid province code",
+		LocaleAF: "Ongeldige provinsiekode",
+		LocaleZU: "Ikhodi yesifunda engavumelekile",
+		LocaleXH: "Ikhowudi yephondo engekho emthethweni",
+	},
+	"invalid_id_length": {
+		LocaleEN: "ID number must be 13 digits",
+		LocaleAF: "ID-nommer moet 13 syfers wees",
+		LocaleZU: "Inombolo ka-ID kumele ibe nezinombolo ezingu-13",
+		LocaleXH: "Inombolo ye-ID kufuneka ibe namanani ali-13",
+	},
+	"invalid_id_format": {
+		LocaleEN: "ID number must contain only digits",
+		LocaleAF: "ID-nommer mag slegs syfers bevat",
+		LocaleZU: "Inombolo ka-ID kumele iqukathe izinombolo kuphela",
+		LocaleXH: "Inombolo ye-ID kufuneka iqulathe amanani kuphela",
+	},
+	"invalid_id_date": {
+		LocaleEN: "ID number contains an invalid date of birth",
+		LocaleAF: "ID-nommer bevat 'n ongeldige geboortedatum",
+		LocaleZU: "Inombolo ka-ID iqukethe usuku lokuzalwa olungavumelekile",
+		LocaleXH: "Inombolo ye-ID iqulathe umhla wokuzalwa ongekho emthethweni",
+	},
+	"invalid_id_checksum": {
+		LocaleEN: "ID number checksum is invalid",
+		LocaleAF: "ID-nommer se kontrolesom is ongeldig",
+		LocaleZU: "Isamba sokuhlola senombolo ka-ID asilungile",
+		LocaleXH: "Isiqhekezo sokuhlola senombolo ye-ID asisesosemthethweni",
+	},
+}
+
+// provinceNamesByLocale holds each province's display name per Locale.
+var provinceNamesByLocale = map[SAProvince]map[Locale]string{
+	ProvinceEC: {
+		LocaleEN: "Eastern Cape",
+		LocaleAF: "Oos-Kaap",
+		LocaleZU: "Mpumalanga Kapa",
+		LocaleXH: "Mpuma Koloni",
+	},
+	ProvinceFS: {
+		LocaleEN: "Free State",
+		LocaleAF: "Vrystaat",
+		LocaleZU: "Free State",
+		LocaleXH: "Free State",
+	},
+	ProvinceGP: {
+		LocaleEN: "Gauteng",
+		LocaleAF: "Gauteng",
+		LocaleZU: "Gauteng",
+		LocaleXH: "Gauteng",
+	},
+	ProvinceKZN: {
+		LocaleEN: "KwaZulu-Natal",
+		LocaleAF: "KwaZulu-Natal",
+		LocaleZU: "KwaZulu-Natal",
+		LocaleXH: "KwaZulu-Natal",
+	},
+	ProvinceLP: {
+		LocaleEN: "Limpopo",
+		LocaleAF: "Limpopo",
+		LocaleZU: "Limpopo",
+		LocaleXH: "Limpopo",
+	},
+	ProvinceMP: {
+		LocaleEN: "Mpumalanga",
+		LocaleAF: "Mpumalanga",
+		LocaleZU: "Mpumalanga",
+		LocaleXH: "Mpumalanga",
+	},
+	ProvinceNC: {
+		LocaleEN: "Northern Cape",
+		LocaleAF: "Noord-Kaap",
+		LocaleZU: "Mpumalanga Kapa eyisiNyakatho",
+		LocaleXH: "Mntla Koloni",
+	},
+	ProvinceNW: {
+		LocaleEN: "North West",
+		LocaleAF: "Noordwes",
+		LocaleZU: "Mpumalanga eyisiNyakatho-Ntshonalanga",
+		LocaleXH: "Mntla Ntshona",
+	},
+	ProvinceWC: {
+		LocaleEN: "Western Cape",
+		LocaleAF: "Wes-Kaap",
+		LocaleZU: "Mpumalanga Kapa eyisiNtshonalanga",
+		LocaleXH: "Ntshona Koloni",
+	},
+}
+
+// companyStatusDescriptions holds each CompanyStatus's human-readable
+// description per Locale.
+var companyStatusDescriptions = map[CompanyStatus]map[Locale]string{
+	StatusActive: {
+		LocaleEN: "Active",
+		LocaleAF: "Aktief",
+		LocaleZU: "Iyasebenza",
+		LocaleXH: "Iyasebenza",
+	},
+	StatusBusinessRescue: {
+		LocaleEN: "In business rescue",
+		LocaleAF: "In besigheidsredding",
+		LocaleZU: "Ekuhlengeni kwebhizinisi",
+		LocaleXH: "Ekuhlangulweni kweshishini",
+	},
+	StatusDeregistered: {
+		LocaleEN: "Deregistered",
+		LocaleAF: "Deregistreer",
+		LocaleZU: "Ikhanselwe ukubhaliswa",
+		LocaleXH: "Irhoxisiwe ukubhaliswa",
+	},
+	StatusLiquidated: {
+		LocaleEN: "Liquidated",
+		LocaleAF: "Gelikwideer",
+		LocaleZU: "Ichithiwe",
+		LocaleXH: "Itshitshiswe",
+	},
+	StatusDissolved: {
+		LocaleEN: "Dissolved",
+		LocaleAF: "Ontbind",
+		LocaleZU: "Ichithiwe ngokuphelele",
+		LocaleXH: "Iqhawuliwe",
+	},
+}
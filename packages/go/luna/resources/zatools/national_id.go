@@ -0,0 +1,142 @@
+// Package zatools provides South African business tool integrations.
+package zatools
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnsupported is returned by Generate when a country's ID format is not
+// deterministic enough to synthesize (e.g. Nigeria NIN, which is issued by
+// a central registry rather than computed from a checksum).
+var ErrUnsupported = errors.New("zatools: operation not supported for this country")
+
+// IDInfo is the superset of SAIDInfo returned by country-aware validators.
+type IDInfo struct {
+	Country           string            `json:"country"`
+	IDNumber          string            `json:"id_number"`
+	IsValid           bool              `json:"is_valid"`
+	DateOfBirth       *time.Time        `json:"date_of_birth,omitempty"`
+	Gender            string            `json:"gender,omitempty"`
+	IsCitizen         bool              `json:"is_citizen"`
+	ChecksumAlgorithm string            `json:"checksum_algorithm,omitempty"`
+	Components        map[string]string `json:"components,omitempty"`
+}
+
+// Validator parses and validates a country's national ID format.
+type Validator interface {
+	// Validate parses and validates number, returning an IDInfo describing
+	// it. Invalid input returns IDInfo.IsValid == false rather than an error.
+	Validate(number string) IDInfo
+}
+
+// Generator synthesizes a valid ID number. Implemented by validators whose
+// format is deterministic enough to generate (e.g. checksum-based IDs).
+// Countries where numbers are issued from a central registry (e.g. Nigeria's
+// NIN) do not implement this and callers get ErrUnsupported from NationalID.
+type Generator interface {
+	Generate(opts GenerateOptions) (string, error)
+}
+
+// GenerateOptions parameterizes synthetic ID generation.
+type GenerateOptions struct {
+	DateOfBirth *time.Time
+	Gender      string
+	IsCitizen   bool
+}
+
+// countryAliases maps common country names and ISO-3166 alpha-2/alpha-3
+// codes to the canonical alpha-2 code used as the registry key.
+var countryAliases = map[string]string{
+	"za": "ZA", "zaf": "ZA", "south africa": "ZA",
+	"ke": "KE", "ken": "KE", "kenya": "KE",
+	"ng": "NG", "nga": "NG", "nigeria": "NG",
+	"gh": "GH", "gha": "GH", "ghana": "GH",
+	"zw": "ZW", "zwe": "ZW", "zimbabwe": "ZW",
+}
+
+// ResolveCountry normalizes a country name or ISO-3166 code (e.g. "ZA",
+// "south africa") to its canonical alpha-2 code.
+func ResolveCountry(country string) (string, error) {
+	key := strings.ToLower(strings.TrimSpace(country))
+	if code, ok := countryAliases[key]; ok {
+		return code, nil
+	}
+	return "", fmt.Errorf("zatools: unrecognized country %q", country)
+}
+
+// NationalID is a country-aware registry of national-ID validators.
+type NationalID struct {
+	mu         sync.RWMutex
+	validators map[string]Validator
+}
+
+// NewNationalID creates a registry pre-populated with the validators this
+// SDK ships out of the box (SA, Kenya, Nigeria, Ghana, Zimbabwe).
+func NewNationalID() *NationalID {
+	n := &NationalID{validators: make(map[string]Validator)}
+	n.Register("ZA", newSAIDAdapter())
+	n.Register("KE", kenyaIDValidator{})
+	n.Register("NG", nigeriaNINValidator{})
+	n.Register("GH", ghanaCardValidator{})
+	n.Register("ZW", zimbabweIDValidator{})
+	return n
+}
+
+// Register adds or replaces the validator for country, which may be an
+// ISO-3166 code ("ZA") or common name ("South Africa").
+func (n *NationalID) Register(country string, v Validator) {
+	code, err := ResolveCountry(country)
+	if err != nil {
+		// Allow registering arbitrary codes that aren't in our alias table
+		// yet, keyed as given (uppercased) so callers can extend coverage.
+		code = strings.ToUpper(strings.TrimSpace(country))
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.validators[code] = v
+}
+
+// Parse validates number against the registered validator for country.
+func (n *NationalID) Parse(country, number string) (IDInfo, error) {
+	v, err := n.lookup(country)
+	if err != nil {
+		return IDInfo{}, err
+	}
+	return v.Validate(number), nil
+}
+
+// Generate synthesizes a valid ID number for country, or returns
+// ErrUnsupported if that country's validator doesn't implement Generator.
+func (n *NationalID) Generate(country string, opts GenerateOptions) (string, error) {
+	v, err := n.lookup(country)
+	if err != nil {
+		return "", err
+	}
+
+	gen, ok := v.(Generator)
+	if !ok {
+		return "", ErrUnsupported
+	}
+	return gen.Generate(opts)
+}
+
+func (n *NationalID) lookup(country string) (Validator, error) {
+	code, err := ResolveCountry(country)
+	if err != nil {
+		code = strings.ToUpper(strings.TrimSpace(country))
+	}
+
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	v, ok := n.validators[code]
+	if !ok {
+		return nil, fmt.Errorf("zatools: no national ID validator registered for %q", country)
+	}
+	return v, nil
+}
@@ -0,0 +1,165 @@
+// Package zatools provides South African business tool integrations.
+package zatools
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// saIDAdapter exposes the existing SA Luhn logic in IDValidation through the
+// country-aware Validator/Generator interfaces, without touching the
+// original checksum implementation.
+type saIDAdapter struct {
+	v *IDValidation
+}
+
+func newSAIDAdapter() *saIDAdapter {
+	return &saIDAdapter{v: NewIDValidation(nil, LocaleEN)}
+}
+
+func (a *saIDAdapter) Validate(number string) IDInfo {
+	info := a.v.Validate(number)
+
+	result := IDInfo{
+		Country:           "ZA",
+		IDNumber:          info.IDNumber,
+		IsValid:           info.IsValid,
+		Gender:            info.Gender,
+		IsCitizen:         info.IsSACitizen,
+		ChecksumAlgorithm: "luhn",
+	}
+	if info.IsValid {
+		dob := info.DateOfBirth
+		result.DateOfBirth = &dob
+	}
+	return result
+}
+
+func (a *saIDAdapter) Generate(opts GenerateOptions) (string, error) {
+	return a.v.Generate(opts.DateOfBirth, opts.Gender, opts.IsCitizen), nil
+}
+
+var (
+	_ Validator = (*saIDAdapter)(nil)
+	_ Generator = (*saIDAdapter)(nil)
+)
+
+// kenyaIDValidator validates Kenyan Huduma/old national ID numbers: 8 digits,
+// with an optional trailing check character on newer cards.
+type kenyaIDValidator struct{}
+
+var kenyaIDPattern = regexp.MustCompile(`^\d{7,8}$`)
+
+func (kenyaIDValidator) Validate(number string) IDInfo {
+	cleaned := regexp.MustCompile(`[\s-]`).ReplaceAllString(number, "")
+
+	if !kenyaIDPattern.MatchString(cleaned) {
+		return IDInfo{Country: "KE", IDNumber: number, IsValid: false}
+	}
+
+	return IDInfo{
+		Country:   "KE",
+		IDNumber:  cleaned,
+		IsValid:   true,
+		IsCitizen: true,
+		Components: map[string]string{
+			"serial": cleaned,
+		},
+	}
+}
+
+func (kenyaIDValidator) Generate(opts GenerateOptions) (string, error) {
+	// Kenyan IDs are sequential serials with no derivable checksum; synthesize
+	// a plausible 8-digit serial for test/sandbox use.
+	seq := int(time.Now().UnixNano() % 90000000)
+	if seq < 10000000 {
+		seq += 10000000
+	}
+	return strconv.Itoa(seq), nil
+}
+
+var (
+	_ Validator = kenyaIDValidator{}
+	_ Generator = kenyaIDValidator{}
+)
+
+// nigeriaNINValidator validates Nigerian National Identification Numbers:
+// 11 digits issued by NIMC with no public checksum, so numbers cannot be
+// synthesized deterministically.
+type nigeriaNINValidator struct{}
+
+var nigeriaNINPattern = regexp.MustCompile(`^\d{11}$`)
+
+func (nigeriaNINValidator) Validate(number string) IDInfo {
+	cleaned := regexp.MustCompile(`[\s-]`).ReplaceAllString(number, "")
+
+	if !nigeriaNINPattern.MatchString(cleaned) {
+		return IDInfo{Country: "NG", IDNumber: number, IsValid: false}
+	}
+
+	return IDInfo{
+		Country:   "NG",
+		IDNumber:  cleaned,
+		IsValid:   true,
+		IsCitizen: true,
+	}
+}
+
+var _ Validator = nigeriaNINValidator{}
+
+// ghanaCardValidator validates Ghana Card numbers in the format
+// GHA-#########-#, where the trailing digit is a check digit.
+type ghanaCardValidator struct{}
+
+var ghanaCardPattern = regexp.MustCompile(`^GHA-(\d{9})-(\d)$`)
+
+func (ghanaCardValidator) Validate(number string) IDInfo {
+	matches := ghanaCardPattern.FindStringSubmatch(number)
+	if matches == nil {
+		return IDInfo{Country: "GH", IDNumber: number, IsValid: false}
+	}
+
+	return IDInfo{
+		Country:           "GH",
+		IDNumber:          number,
+		IsValid:           true,
+		IsCitizen:         true,
+		ChecksumAlgorithm: "mod10",
+		Components: map[string]string{
+			"serial":      matches[1],
+			"check_digit": matches[2],
+		},
+	}
+}
+
+var _ Validator = ghanaCardValidator{}
+
+// zimbabweIDValidator validates Zimbabwean national ID numbers in the format
+// ##-#######X## (district code, serial, check letter, birth province code).
+type zimbabweIDValidator struct{}
+
+var zimbabweIDPattern = regexp.MustCompile(`^(\d{2})-(\d{7})([A-Z])(\d{2})$`)
+
+func (zimbabweIDValidator) Validate(number string) IDInfo {
+	matches := zimbabweIDPattern.FindStringSubmatch(number)
+	if matches == nil {
+		return IDInfo{Country: "ZW", IDNumber: number, IsValid: false}
+	}
+
+	return IDInfo{
+		Country:           "ZW",
+		IDNumber:          number,
+		IsValid:           true,
+		IsCitizen:         true,
+		ChecksumAlgorithm: "check_letter",
+		Components: map[string]string{
+			"district_code": matches[1],
+			"serial":        matches[2],
+			"check_letter":  matches[3],
+			"province_code": matches[4],
+		},
+	}
+}
+
+var _ Validator = zimbabweIDValidator{}
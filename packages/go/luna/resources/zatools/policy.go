@@ -0,0 +1,190 @@
+package zatools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	yaml "gopkg.in/yaml.v3"
+)
+
+// PolicyAction is what a Policy does when none of its rules decide an
+// evaluation one way or the other.
+type PolicyAction string
+
+const (
+	PolicyAllow PolicyAction = "allow"
+	PolicyDeny  PolicyAction = "deny"
+)
+
+// Rule is one condition a Policy evaluates against a BBBEECertificate.
+// Zero-valued fields are unconstrained; a Rule matches a certificate when
+// every field it sets is satisfied. MinBlackOwnershipPct,
+// MinBlackWomenOwnershipPct, EnterpriseCategory, and SectorCode are read
+// from the certificate's Scorecard map under the matching keys, since
+// those aren't broken out as their own BBBEECertificate fields.
+type Rule struct {
+	Name                      string  `yaml:"name" json:"name"`
+	MinLevel                  int     `yaml:"min_level,omitempty" json:"min_level,omitempty"`
+	MinBlackOwnershipPct      float64 `yaml:"min_black_ownership_pct,omitempty" json:"min_black_ownership_pct,omitempty"`
+	MinBlackWomenOwnershipPct float64 `yaml:"min_black_women_ownership_pct,omitempty" json:"min_black_women_ownership_pct,omitempty"`
+	EnterpriseCategory        string  `yaml:"enterprise_category,omitempty" json:"enterprise_category,omitempty"`
+	SectorCode                string  `yaml:"sector_code,omitempty" json:"sector_code,omitempty"`
+	ExpiryWindowDays          int     `yaml:"expiry_window_days,omitempty" json:"expiry_window_days,omitempty"`
+	// Reason overrides the generated denial message when this rule is a
+	// deny rule that the certificate violates.
+	Reason string `yaml:"reason,omitempty" json:"reason,omitempty"`
+}
+
+// Policy is a procurement/compliance decision, expressed as allow/deny
+// rule lists plus a default action, so application code evaluates a
+// BBBEECertificate against a declared policy instead of hard-coding
+// thresholds around MeetsRequirement. Deny rules are evaluated first and
+// short-circuit the decision; then allow rules; then Default.
+type Policy struct {
+	Name    string       `yaml:"name,omitempty" json:"name,omitempty"`
+	Deny    []Rule       `yaml:"deny,omitempty" json:"deny,omitempty"`
+	Allow   []Rule       `yaml:"allow,omitempty" json:"allow,omitempty"`
+	Default PolicyAction `yaml:"default" json:"default"`
+}
+
+// PolicyDenial explains which deny rule rejected a certificate.
+type PolicyDenial struct {
+	Rule   string `json:"rule"`
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// PolicyResult is the outcome of Policy.Evaluate, carrying enough context
+// (recognition percentage, expiry window) for a caller to render an
+// explainable decision without re-deriving it from the certificate.
+type PolicyResult struct {
+	Allowed bool `json:"allowed"`
+	// MatchedRule is the name of the allow rule (or "default") that
+	// decided an Allowed result; empty when Denial is set instead.
+	MatchedRule         string        `json:"matched_rule,omitempty"`
+	Denial              *PolicyDenial `json:"denial,omitempty"`
+	RecognitionLevelPct int           `json:"recognition_level_pct"`
+	DaysUntilExpiry     int           `json:"days_until_expiry"`
+}
+
+// Evaluate decides whether cert passes policy: deny rules first (the
+// first one cert violates wins), then allow rules (the first one cert
+// satisfies wins), then Default.
+func (p Policy) Evaluate(ctx context.Context, cert *BBBEECertificate) (*PolicyResult, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if cert == nil {
+		return nil, fmt.Errorf("zatools: policy evaluation requires a certificate")
+	}
+
+	result := &PolicyResult{
+		RecognitionLevelPct: RecognitionLevelForLevel(cert.Level),
+		DaysUntilExpiry:     DaysUntilExpiry(*cert),
+	}
+
+	for _, rule := range p.Deny {
+		field, reason, satisfied := ruleEvaluate(rule, cert, result.DaysUntilExpiry)
+		if satisfied {
+			continue
+		}
+		if rule.Reason != "" {
+			reason = rule.Reason
+		}
+		result.Denial = &PolicyDenial{Rule: rule.Name, Field: field, Reason: reason}
+		return result, nil
+	}
+
+	for _, rule := range p.Allow {
+		if _, _, satisfied := ruleEvaluate(rule, cert, result.DaysUntilExpiry); satisfied {
+			result.Allowed = true
+			result.MatchedRule = rule.Name
+			return result, nil
+		}
+	}
+
+	result.Allowed = p.Default == PolicyAllow
+	if result.Allowed {
+		result.MatchedRule = "default"
+	}
+	return result, nil
+}
+
+// ruleEvaluate checks cert against every constraint rule sets, returning
+// the first one it fails along with a human-readable reason. satisfied is
+// true only when every constraint holds.
+func ruleEvaluate(rule Rule, cert *BBBEECertificate, daysUntilExpiry int) (field, reason string, satisfied bool) {
+	if rule.MinLevel != 0 {
+		if lvl := levelNumber(cert.Level); lvl > rule.MinLevel {
+			return "min_level", fmt.Sprintf("B-BBEE level %d does not meet minimum level %d", lvl, rule.MinLevel), false
+		}
+	}
+	if rule.MinBlackOwnershipPct != 0 {
+		if pct := scorecardFloat(cert, "black_ownership_pct"); pct < rule.MinBlackOwnershipPct {
+			return "min_black_ownership_pct", fmt.Sprintf("black ownership %.1f%% is below the required %.1f%%", pct, rule.MinBlackOwnershipPct), false
+		}
+	}
+	if rule.MinBlackWomenOwnershipPct != 0 {
+		if pct := scorecardFloat(cert, "black_women_ownership_pct"); pct < rule.MinBlackWomenOwnershipPct {
+			return "min_black_women_ownership_pct", fmt.Sprintf("black women ownership %.1f%% is below the required %.1f%%", pct, rule.MinBlackWomenOwnershipPct), false
+		}
+	}
+	if rule.EnterpriseCategory != "" {
+		if cat := scorecardString(cert, "enterprise_category"); !strings.EqualFold(cat, rule.EnterpriseCategory) {
+			return "enterprise_category", fmt.Sprintf("enterprise category %q does not match required %q", cat, rule.EnterpriseCategory), false
+		}
+	}
+	if rule.SectorCode != "" {
+		if code := scorecardString(cert, "sector_code"); !strings.EqualFold(code, rule.SectorCode) {
+			return "sector_code", fmt.Sprintf("sector code %q does not match required %q", code, rule.SectorCode), false
+		}
+	}
+	if rule.ExpiryWindowDays != 0 && daysUntilExpiry < rule.ExpiryWindowDays {
+		return "expiry_window_days", fmt.Sprintf("certificate expires in %d days, inside the required %d-day window", daysUntilExpiry, rule.ExpiryWindowDays), false
+	}
+	return "", "", true
+}
+
+func scorecardFloat(cert *BBBEECertificate, key string) float64 {
+	switch v := cert.Scorecard[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	default:
+		return 0
+	}
+}
+
+func scorecardString(cert *BBBEECertificate, key string) string {
+	s, _ := cert.Scorecard[key].(string)
+	return s
+}
+
+// LoadPolicy reads a Policy from path, a YAML or JSON file selected by
+// extension (".json" is parsed as JSON, anything else as YAML) -- the
+// same convention config.LoadFile uses -- so procurement teams can
+// version policies in git alongside their own SDK config.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("zatools: read policy %s: %w", path, err)
+	}
+
+	var policy Policy
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(data, &policy); err != nil {
+			return nil, fmt.Errorf("zatools: parse policy %s: %w", path, err)
+		}
+		return &policy, nil
+	}
+
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("zatools: parse policy %s: %w", path, err)
+	}
+	return &policy, nil
+}
@@ -0,0 +1,163 @@
+// Package zatools provides South African business tool integrations.
+package zatools
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+
+	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+)
+
+// sarsCategoryDigits holds the leading digits SARS assigns by taxpayer
+// category (individual, company, trust, ...) in a 10-digit income tax
+// reference number.
+var sarsCategoryDigits = map[byte]bool{'0': true, '1': true, '2': true, '3': true, '9': true}
+
+var sarsVATPattern = regexp.MustCompile(`^4\d{9}$`)
+
+// SARS provides South African Revenue Service tax-number and VAT-number
+// integration.
+type SARS struct {
+	client *lunahttp.Client
+	config SARSConfig
+	strict bool
+}
+
+// NewSARS creates a new SARS service instance.
+func NewSARS(client *lunahttp.Client, config SARSConfig, strict bool) *SARS {
+	return &SARS{
+		client: client,
+		config: config,
+		strict: strict,
+	}
+}
+
+// IsValidTaxNumber reports whether taxNumber is a well-formed 10-digit SARS
+// income tax reference: digits 1-9 weighted 0-8 (left to right) summed mod
+// 10 must equal digit 10, and the leading digit must be one of {0,1,2,3,9}
+// indicating the taxpayer category.
+func (s *SARS) IsValidTaxNumber(taxNumber string) bool {
+	if len(taxNumber) != 10 {
+		return false
+	}
+	if !sarsCategoryDigits[taxNumber[0]] {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < 9; i++ {
+		digit, err := strconv.Atoi(string(taxNumber[i]))
+		if err != nil {
+			return false
+		}
+		sum += digit * i
+	}
+
+	checkDigit, err := strconv.Atoi(string(taxNumber[9]))
+	if err != nil {
+		return false
+	}
+
+	return sum%10 == checkDigit
+}
+
+// IsValidVATNumber reports whether vatNumber is a well-formed 10-digit SARS
+// VAT number: 10 digits beginning with 4.
+func (s *SARS) IsValidVATNumber(vatNumber string) bool {
+	return sarsVATPattern.MatchString(vatNumber)
+}
+
+// ValidateTaxNumber confirms taxNumber with SARS. In strict mode it
+// rejects a malformed number via IsValidTaxNumber before hitting the
+// network.
+func (s *SARS) ValidateTaxNumber(ctx context.Context, taxNumber string) (bool, error) {
+	if s.strict && !s.IsValidTaxNumber(taxNumber) {
+		return false, &ValidationError{"invalid tax number format (strict mode)"}
+	}
+
+	resp, err := s.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "GET",
+		Path:   "/v1/za/sars/validate-tax-number/" + taxNumber,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var result struct {
+		Valid bool `json:"valid"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return false, &ValidationError{"failed to parse response"}
+	}
+
+	return result.Valid, nil
+}
+
+// ValidateVATNumber confirms vatNumber with SARS. In strict mode it
+// rejects a malformed number via IsValidVATNumber before hitting the
+// network.
+func (s *SARS) ValidateVATNumber(ctx context.Context, vatNumber string) (bool, error) {
+	if s.strict && !s.IsValidVATNumber(vatNumber) {
+		return false, &ValidationError{"invalid VAT number format (strict mode)"}
+	}
+
+	resp, err := s.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "GET",
+		Path:   "/v1/za/sars/validate-vat-number/" + vatNumber,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	var result struct {
+		Valid bool `json:"valid"`
+	}
+	if err := json.Unmarshal(resp.Data, &result); err != nil {
+		return false, &ValidationError{"failed to parse response"}
+	}
+
+	return result.Valid, nil
+}
+
+// Lookup retrieves taxpayer information by tax number.
+func (s *SARS) Lookup(ctx context.Context, taxNumber string) (*Taxpayer, error) {
+	if s.strict && !s.IsValidTaxNumber(taxNumber) {
+		return nil, &ValidationError{"invalid tax number format (strict mode)"}
+	}
+
+	resp, err := s.client.Request(ctx, lunahttp.RequestConfig{
+		Method: "GET",
+		Path:   "/v1/za/sars/taxpayers/" + taxNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var taxpayer Taxpayer
+	if err := json.Unmarshal(resp.Data, &taxpayer); err != nil {
+		return nil, &ValidationError{"failed to parse response"}
+	}
+
+	return &taxpayer, nil
+}
+
+// Verify checks if a taxpayer is registered and active, following the
+// exact pattern of CIPC.Verify.
+func (s *SARS) Verify(ctx context.Context, taxNumber string) (map[string]interface{}, error) {
+	taxpayer, _ := s.Lookup(ctx, taxNumber)
+
+	if taxpayer == nil {
+		return map[string]interface{}{
+			"exists":    false,
+			"is_active": false,
+		}, nil
+	}
+
+	return map[string]interface{}{
+		"exists":    true,
+		"is_active": taxpayer.Status == TaxpayerActive,
+		"taxpayer":  taxpayer,
+	}, nil
+}
@@ -113,6 +113,30 @@ type CIPCConfig struct {
 	Sandbox bool   `json:"sandbox"`
 }
 
+// TaxpayerStatus represents SARS taxpayer registration status
+type TaxpayerStatus string
+
+const (
+	TaxpayerActive   TaxpayerStatus = "active"
+	TaxpayerInactive TaxpayerStatus = "inactive"
+)
+
+// Taxpayer represents SARS taxpayer information
+type Taxpayer struct {
+	TaxNumber      string         `json:"tax_number"`
+	VATNumber      string         `json:"vat_number,omitempty"`
+	Name           string         `json:"name"`
+	Status         TaxpayerStatus `json:"status"`
+	TaxType        string         `json:"tax_type,omitempty"`
+	RegisteredDate string         `json:"registered_date,omitempty"`
+}
+
+// SARSConfig holds SARS configuration
+type SARSConfig struct {
+	APIKey  string `json:"api_key,omitempty"`
+	Sandbox bool   `json:"sandbox"`
+}
+
 // BBBEEConfig holds B-BBEE configuration
 type BBBEEConfig struct {
 	APIKey  string `json:"api_key,omitempty"`
@@ -123,5 +147,9 @@ type BBBEEConfig struct {
 type Config struct {
 	CIPC   *CIPCConfig
 	BBBEE  *BBBEEConfig
+	SARS   *SARSConfig
 	Strict bool
+	// Locale is the language AddressUtils, CIPC, and IDValidation localize
+	// their output into. Zero value behaves as LocaleEN.
+	Locale Locale
 }
@@ -3,6 +3,7 @@ package zatools
 
 import (
 	lunahttp "github.com/eclipse-softworks/luna-sdk-go/luna/http"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/options"
 )
 
 // ZATools provides unified access to SA business tools.
@@ -11,18 +12,68 @@ type ZATools struct {
 	config       *Config
 	cipc         *CIPC
 	bbbee        *BBBEE
+	sars         *SARS
 	idValidation *IDValidation
 	address      *AddressUtils
+	nationalID   *NationalID
 }
 
-// NewZATools creates a new ZATools resource.
-func NewZATools(client *lunahttp.Client, config *Config) *ZATools {
-	if config == nil {
-		config = &Config{}
-	}
+// zaToolsOptions is ZATools's option target; NewZATools starts from its
+// zero value and applies each ZAToolsOption in order.
+type zaToolsOptions struct {
+	client *lunahttp.Client
+	config Config
+}
+
+// ZAToolsOption configures NewZATools.
+type ZAToolsOption = options.Option[zaToolsOptions]
+
+// WithCIPCConfig sets the CIPC service configuration.
+func WithCIPCConfig(config CIPCConfig) ZAToolsOption {
+	return func(o *zaToolsOptions) { o.config.CIPC = &config }
+}
+
+// WithBBBEEConfig sets the B-BBEE service configuration.
+func WithBBBEEConfig(config BBBEEConfig) ZAToolsOption {
+	return func(o *zaToolsOptions) { o.config.BBBEE = &config }
+}
+
+// WithSARSConfig sets the SARS service configuration.
+func WithSARSConfig(config SARSConfig) ZAToolsOption {
+	return func(o *zaToolsOptions) { o.config.SARS = &config }
+}
+
+// WithStrict sets Config.Strict, which individual services interpret as
+// whether to reject ambiguous input rather than best-effort it.
+func WithStrict(strict bool) ZAToolsOption {
+	return func(o *zaToolsOptions) { o.config.Strict = strict }
+}
+
+// WithHTTPClient overrides the *lunahttp.Client NewZATools was given,
+// letting callers inject a decorated client (middleware, a test double)
+// without constructing ZATools through a different entry point.
+func WithHTTPClient(client *lunahttp.Client) ZAToolsOption {
+	return func(o *zaToolsOptions) { o.client = client }
+}
+
+// WithLocale sets the language AddressUtils.Validate/Format, CIPC's
+// StatusDescription, and IDValidation.Issues localize their output into.
+// English (LocaleEN) if never set.
+func WithLocale(locale Locale) ZAToolsOption {
+	return func(o *zaToolsOptions) { o.config.Locale = locale }
+}
+
+// NewZATools creates a new ZATools resource. With no options it behaves
+// exactly as before options existed: every sub-service uses its own
+// zero-value config.
+func NewZATools(client *lunahttp.Client, opts ...ZAToolsOption) *ZATools {
+	o := zaToolsOptions{client: client}
+	options.Apply(&o, opts)
+
+	config := o.config
 	return &ZATools{
-		client: client,
-		config: config,
+		client: o.client,
+		config: &config,
 	}
 }
 
@@ -33,7 +84,7 @@ func (z *ZATools) CIPC() *CIPC {
 		if config == nil {
 			config = &CIPCConfig{}
 		}
-		z.cipc = NewCIPC(z.client, *config, z.config.Strict)
+		z.cipc = NewCIPC(z.client, *config, z.config.Strict, z.config.Locale)
 	}
 	return z.cipc
 }
@@ -50,10 +101,22 @@ func (z *ZATools) BBBEE() *BBBEE {
 	return z.bbbee
 }
 
+// SARS returns the SARS service instance.
+func (z *ZATools) SARS() *SARS {
+	if z.sars == nil {
+		config := z.config.SARS
+		if config == nil {
+			config = &SARSConfig{}
+		}
+		z.sars = NewSARS(z.client, *config, z.config.Strict)
+	}
+	return z.sars
+}
+
 // IDValidation returns the ID validation utility.
 func (z *ZATools) IDValidation() *IDValidation {
 	if z.idValidation == nil {
-		z.idValidation = NewIDValidation()
+		z.idValidation = NewIDValidation(z.client, z.config.Locale)
 	}
 	return z.idValidation
 }
@@ -61,11 +124,19 @@ func (z *ZATools) IDValidation() *IDValidation {
 // Address returns the address utilities.
 func (z *ZATools) Address() *AddressUtils {
 	if z.address == nil {
-		z.address = NewAddressUtils()
+		z.address = NewAddressUtils(z.config.Locale)
 	}
 	return z.address
 }
 
+// NationalID returns the pan-African national-ID validator registry.
+func (z *ZATools) NationalID() *NationalID {
+	if z.nationalID == nil {
+		z.nationalID = NewNationalID()
+	}
+	return z.nationalID
+}
+
 // ValidateID is a convenience method to validate SA ID number.
 func (z *ZATools) ValidateID(idNumber string) SAIDInfo {
 	return z.IDValidation().Validate(idNumber)
@@ -73,5 +144,5 @@ func (z *ZATools) ValidateID(idNumber string) SAIDInfo {
 
 // List returns available ZA tools.
 func (z *ZATools) List() []string {
-	return []string{"cipc", "bbbee", "id_validation", "address"}
+	return []string{"cipc", "bbbee", "sars", "id_validation", "address", "national_id"}
 }
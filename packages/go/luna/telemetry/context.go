@@ -0,0 +1,44 @@
+package telemetry
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+type contextKey int
+
+const loggerKey contextKey = iota
+
+// WithLoggerContext attaches logger to ctx so downstream resource methods
+// can pull it back out with LoggerFromContext.
+func WithLoggerContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, logger)
+}
+
+// LoggerFromContext returns the logger attached to ctx, or a disabled
+// ConsoleLogger (level above Error) if none was attached, so callers can
+// always log unconditionally.
+func LoggerFromContext(ctx context.Context) Logger {
+	return LoggerFromContextOr(ctx, nil)
+}
+
+// LoggerFromContextOr returns the logger attached to ctx, falling back to
+// fallback (or a disabled logger if fallback is nil) when ctx carries none.
+func LoggerFromContextOr(ctx context.Context, fallback Logger) Logger {
+	if logger, ok := ctx.Value(loggerKey).(Logger); ok && logger != nil {
+		return logger
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return NewConsoleLogger(LogLevelError + 1)
+}
+
+// NewRequestID generates a correlation ID for a single logical SDK call. It
+// is stamped onto every log event emitted for that call's HTTP attempts.
+func NewRequestID() string {
+	var buf [8]byte
+	_, _ = rand.Read(buf[:])
+	return "req_" + hex.EncodeToString(buf[:])
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"regexp"
 
 	"time"
 )
@@ -32,31 +31,12 @@ type Logger interface {
 
 // ConsoleLogger logs to stdout/stderr with JSON formatting
 type ConsoleLogger struct {
-	level    LogLevel
-	redactRe []*regexp.Regexp
+	level LogLevel
 }
 
 // NewConsoleLogger creates a new console logger
 func NewConsoleLogger(level LogLevel) *ConsoleLogger {
-	return &ConsoleLogger{
-		level: level,
-		redactRe: []*regexp.Regexp{
-			regexp.MustCompile(`(?i)api[_-]?key`),
-			regexp.MustCompile(`(?i)authorization`),
-			regexp.MustCompile(`(?i)x-luna-api-key`),
-			regexp.MustCompile(`(?i)password`),
-			regexp.MustCompile(`(?i)secret`),
-			regexp.MustCompile(`(?i)token`),
-			regexp.MustCompile(`(?i)bearer`),
-			// POPIA / SA Specific
-			regexp.MustCompile(`(?i)id[_-]?number`),
-			regexp.MustCompile(`(?i)tax[_-]?ref`),
-			regexp.MustCompile(`(?i)registration[_-]?number`),
-			regexp.MustCompile(`(?i)account[_-]?number`),
-			regexp.MustCompile(`(?i)cvv`),
-			regexp.MustCompile(`(?i)pan`),
-		},
-	}
+	return &ConsoleLogger{level: level}
 }
 
 func (l *ConsoleLogger) Error(message string, context map[string]interface{}) {
@@ -94,7 +74,7 @@ func (l *ConsoleLogger) log(level LogLevel, levelStr, message string, context ma
 	}
 
 	if context != nil {
-		entry["context"] = l.sanitize(context)
+		entry["context"] = redactContext(context)
 	}
 
 	output, _ := json.Marshal(entry)
@@ -106,29 +86,4 @@ func (l *ConsoleLogger) log(level LogLevel, levelStr, message string, context ma
 	}
 }
 
-func (l *ConsoleLogger) sanitize(obj map[string]interface{}) map[string]interface{} {
-	result := make(map[string]interface{})
-
-	for key, value := range obj {
-		if l.isSensitiveKey(key) {
-			result[key] = "[REDACTED]"
-		} else if nested, ok := value.(map[string]interface{}); ok {
-			result[key] = l.sanitize(nested)
-		} else {
-			result[key] = value
-		}
-	}
-
-	return result
-}
-
-func (l *ConsoleLogger) isSensitiveKey(key string) bool {
-	for _, re := range l.redactRe {
-		if re.MatchString(key) {
-			return true
-		}
-	}
-	return false
-}
-
 var _ Logger = (*ConsoleLogger)(nil)
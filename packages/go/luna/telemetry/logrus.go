@@ -0,0 +1,49 @@
+//go:build logrus
+
+package telemetry
+
+import "github.com/sirupsen/logrus"
+
+// LogrusLogger implements Logger on top of logrus.FieldLogger (satisfied
+// by both *logrus.Logger and *logrus.Entry). Built only when the caller's
+// build includes the "logrus" tag, so the SDK doesn't force a logrus
+// dependency on users who don't want one.
+type LogrusLogger struct {
+	logger logrus.FieldLogger
+}
+
+// NewLogrusLogger wraps logger.
+func NewLogrusLogger(logger logrus.FieldLogger) *LogrusLogger {
+	return &LogrusLogger{logger: logger}
+}
+
+func (l *LogrusLogger) Error(message string, context map[string]interface{}) {
+	l.logger.WithFields(toLogrusFields(context)).Error(message)
+}
+
+func (l *LogrusLogger) Warn(message string, context map[string]interface{}) {
+	l.logger.WithFields(toLogrusFields(context)).Warn(message)
+}
+
+func (l *LogrusLogger) Info(message string, context map[string]interface{}) {
+	l.logger.WithFields(toLogrusFields(context)).Info(message)
+}
+
+func (l *LogrusLogger) Debug(message string, context map[string]interface{}) {
+	l.logger.WithFields(toLogrusFields(context)).Debug(message)
+}
+
+func (l *LogrusLogger) Trace(message string, context map[string]interface{}) {
+	l.logger.WithFields(toLogrusFields(context)).Trace(message)
+}
+
+func toLogrusFields(context map[string]interface{}) logrus.Fields {
+	redacted := redactContext(context)
+	fields := make(logrus.Fields, len(redacted))
+	for k, v := range redacted {
+		fields[k] = v
+	}
+	return fields
+}
+
+var _ Logger = (*LogrusLogger)(nil)
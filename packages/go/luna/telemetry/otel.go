@@ -0,0 +1,129 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies this SDK as the source of the spans and
+// metrics it emits, per the OTel convention of scoping a Tracer/Meter to
+// the instrumenting library rather than the application using it.
+const instrumentationName = "github.com/eclipse-softworks/luna-sdk-go"
+
+// Otel wraps the OpenTelemetry tracer and meter the SDK emits spans and
+// metrics through. A zero-value Otel is never used directly; NewOtel
+// always returns one backed by real (possibly no-op) providers, so every
+// resource call can instrument unconditionally without nil checks.
+type Otel struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+
+	requestCount    metric.Int64Counter
+	requestDuration metric.Float64Histogram
+	retryCount      metric.Int64Counter
+	rateLimitCount  metric.Int64Counter
+}
+
+// NewOtel builds an Otel from tp and mp. A nil tp or mp falls back to
+// otel's globally configured provider (itself a no-op until the host
+// application calls otel.SetTracerProvider/otel.SetMeterProvider), so
+// luna.NewClient can always construct an Otel even when the caller hasn't
+// opted in.
+func NewOtel(tp trace.TracerProvider, mp metric.MeterProvider) *Otel {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	meter := mp.Meter(instrumentationName)
+
+	requestCount, _ := meter.Int64Counter(
+		"luna_http_requests_total",
+		metric.WithDescription("Total number of SDK HTTP requests, by resource and status."),
+	)
+	requestDuration, _ := meter.Float64Histogram(
+		"luna_http_request_duration_ms",
+		metric.WithDescription("SDK HTTP request latency in milliseconds."),
+		metric.WithUnit("ms"),
+	)
+	retryCount, _ := meter.Int64Counter(
+		"luna_http_retries_total",
+		metric.WithDescription("Total number of SDK HTTP retry attempts, by resource."),
+	)
+	rateLimitCount, _ := meter.Int64Counter(
+		"luna_http_rate_limit_total",
+		metric.WithDescription("Total number of SDK HTTP requests that hit a rate limit, by resource."),
+	)
+
+	return &Otel{
+		tracer:          tp.Tracer(instrumentationName),
+		propagator:      propagation.TraceContext{},
+		requestCount:    requestCount,
+		requestDuration: requestDuration,
+		retryCount:      retryCount,
+		rateLimitCount:  rateLimitCount,
+	}
+}
+
+// StartSpan starts a span named for the calling resource method (e.g.
+// "Users.List", "Buckets.InitiateUpload") with the given attributes
+// already attached.
+func (o *Otel) StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return o.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// InjectHeaders writes a W3C traceparent (and tracestate, if present) onto
+// headers from the span context carried by ctx, so a host application can
+// stitch the downstream API call into the same trace as the SDK call.
+func (o *Otel) InjectHeaders(ctx context.Context, headers http.Header) {
+	o.propagator.Inject(ctx, propagation.HeaderCarrier(headers))
+}
+
+// errorClass is one of the *errors.XxxError type names (AuthenticationError,
+// RateLimitError, ServerError, ValidationError, ...), or empty on success.
+// The http package derives it with errors.As rather than telemetry
+// importing the errors package, keeping Otel free of SDK-specific types.
+type errorClass = string
+
+// RecordRequest finishes span with the outcome of one logical SDK call
+// (after retries) and records the request-count and latency-histogram
+// metrics. status is the HTTP status code, or 0 if the call never got a
+// response. class is the error classification (see ClassifyError in the
+// http package) and is empty on success.
+func (o *Otel) RecordRequest(ctx context.Context, span trace.Span, resource string, status int, duration time.Duration, class errorClass) {
+	attrs := []attribute.KeyValue{
+		attribute.String("luna.resource", resource),
+		attribute.Int("http.status_code", status),
+	}
+	if class != "" {
+		attrs = append(attrs, attribute.String("luna.error_class", class))
+		span.SetStatus(codes.Error, class)
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+	span.SetAttributes(attrs...)
+	span.End()
+
+	o.requestCount.Add(ctx, 1, metric.WithAttributes(attrs...))
+	o.requestDuration.Record(ctx, float64(duration.Milliseconds()), metric.WithAttributes(attrs...))
+}
+
+// RecordRetry increments the retry-count metric for resource.
+func (o *Otel) RecordRetry(ctx context.Context, resource string) {
+	o.retryCount.Add(ctx, 1, metric.WithAttributes(attribute.String("luna.resource", resource)))
+}
+
+// RecordRateLimit increments the rate-limit-hit metric for resource.
+func (o *Otel) RecordRateLimit(ctx context.Context, resource string) {
+	o.rateLimitCount.Add(ctx, 1, metric.WithAttributes(attribute.String("luna.resource", resource)))
+}
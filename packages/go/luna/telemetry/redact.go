@@ -0,0 +1,22 @@
+package telemetry
+
+import "github.com/eclipse-softworks/luna-sdk-go/luna/telemetry/redact"
+
+// defaultRedactor backs redactContext with the redact package's built-in
+// SA/POPIA key rules and value detectors. Applications needing custom
+// KeyRule/ValueDetector entries should build their own redact.Redactor and
+// use the redact package directly; ConsoleLogger and the other Logger
+// adapters in this package only need the default behavior.
+var defaultRedactor = redact.Default()
+
+// redactContext returns a copy of ctx with sensitive values replaced,
+// recursing into nested maps, slices, and structs. Every Logger adapter
+// must run its incoming context through this before forwarding it to a
+// backend.
+func redactContext(ctx map[string]interface{}) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	redacted, _ := defaultRedactor.Redact(ctx).(map[string]interface{})
+	return redacted
+}
@@ -0,0 +1,119 @@
+package redact
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// defaultValueDetectors catch SA-specific PII by shape alone, so it's
+// redacted even when it surfaces somewhere a KeyRule wouldn't catch (a
+// free-text message field, a list element, a nested struct with an
+// unrelated field name).
+//
+// CVV is deliberately not included here: a bare 3-4 digit string has no
+// distinguishing shape, so detecting it by value alone would false-positive
+// on nearly every short number in a log line. It's covered by the "cvv"
+// KeyRule instead.
+var defaultValueDetectors = []ValueDetector{
+	{Name: "sa_id_number", Match: isSAIDNumber},
+	{Name: "sa_tax_ref", Match: isSATaxRefNumber},
+	{Name: "sa_bank_account", Match: isSABankAccountNumber},
+	{Name: "pan", Match: isPAN},
+	{Name: "sa_phone_e164", Match: isSAPhoneE164},
+}
+
+var digitsOnly = regexp.MustCompile(`^\d+$`)
+
+// isSAIDNumber reports whether value is a 13-digit South African ID number:
+// Luhn-valid, with the first 6 digits forming a plausible YYMMDD birthdate.
+func isSAIDNumber(value string) bool {
+	if len(value) != 13 || !digitsOnly.MatchString(value) {
+		return false
+	}
+	if !isValidYYMMDD(value[:6]) {
+		return false
+	}
+	return luhnValid(value)
+}
+
+// isValidYYMMDD reports whether digits (exactly 6) parses as a real date,
+// trying both 19YY and 20YY since the ID number alone doesn't disambiguate
+// the century.
+func isValidYYMMDD(digits string) bool {
+	if len(digits) != 6 {
+		return false
+	}
+	month, err := strconv.Atoi(digits[2:4])
+	if err != nil || month < 1 || month > 12 {
+		return false
+	}
+	day, err := strconv.Atoi(digits[4:6])
+	if err != nil || day < 1 || day > 31 {
+		return false
+	}
+	year, err := strconv.Atoi(digits[0:2])
+	if err != nil {
+		return false
+	}
+
+	for _, century := range []int{1900, 2000} {
+		t, err := time.Parse("2006-01-02", strconv.Itoa(century+year)+"-"+digits[2:4]+"-"+digits[4:6])
+		if err == nil && t.Day() == day && int(t.Month()) == month {
+			return true
+		}
+	}
+	return false
+}
+
+// isSATaxRefNumber reports whether value is a 10-digit SARS tax reference
+// number. SARS doesn't publish a check-digit scheme for these, so this is
+// a shape check only.
+func isSATaxRefNumber(value string) bool {
+	return len(value) == 10 && digitsOnly.MatchString(value)
+}
+
+// isSABankAccountNumber reports whether value looks like a South African
+// bank account number: 9-11 digits passing a Luhn (modulus-10) check digit.
+func isSABankAccountNumber(value string) bool {
+	if len(value) < 9 || len(value) > 11 || !digitsOnly.MatchString(value) {
+		return false
+	}
+	return luhnValid(value)
+}
+
+// isPAN reports whether value is a Luhn-valid payment card number, 13-19
+// digits per ISO/IEC 7812.
+func isPAN(value string) bool {
+	if len(value) < 13 || len(value) > 19 || !digitsOnly.MatchString(value) {
+		return false
+	}
+	return luhnValid(value)
+}
+
+var saPhoneE164 = regexp.MustCompile(`^\+27[1-9]\d{8}$`)
+
+// isSAPhoneE164 reports whether value is an E.164-formatted South African
+// phone number (+27 followed by 9 digits, not starting with 0).
+func isSAPhoneE164(value string) bool {
+	return saPhoneE164.MatchString(value)
+}
+
+// luhnValid implements the Luhn (modulus-10) check digit algorithm, used by
+// SA ID numbers, SA bank account numbers, and payment card numbers alike.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,29 @@
+package redact
+
+import "regexp"
+
+// defaultKeyRules mirrors the key patterns telemetry.redactContext used
+// before this package existed, so wiring ConsoleLogger (and every other
+// Logger adapter) through Default() changes no existing redaction
+// behavior by key name.
+var defaultKeyRules = []KeyRule{
+	keyPattern("api_key", `(?i)api[_-]?key`),
+	keyPattern("authorization", `(?i)authorization`),
+	keyPattern("x_luna_api_key", `(?i)x-luna-api-key`),
+	keyPattern("password", `(?i)password`),
+	keyPattern("secret", `(?i)secret`),
+	keyPattern("token", `(?i)token`),
+	keyPattern("bearer", `(?i)bearer`),
+	// POPIA / SA specific.
+	keyPattern("id_number", `(?i)id[_-]?number`),
+	keyPattern("tax_ref", `(?i)tax[_-]?ref`),
+	keyPattern("registration_number", `(?i)registration[_-]?number`),
+	keyPattern("account_number", `(?i)account[_-]?number`),
+	keyPattern("cvv", `(?i)cvv`),
+	keyPattern("pan", `(?i)pan`),
+}
+
+func keyPattern(name, pattern string) KeyRule {
+	re := regexp.MustCompile(pattern)
+	return KeyRule{Name: name, Match: re.MatchString}
+}
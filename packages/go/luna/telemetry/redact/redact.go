@@ -0,0 +1,193 @@
+// Package redact walks arbitrary Go values (maps, slices, structs, and
+// anything nested inside them via reflection) and replaces whatever looks
+// like a secret or POPIA-regulated personal value before it reaches a log
+// sink. A value is redacted for either of two independent reasons: its map
+// key or struct field name matches a KeyRule ("password", "id_number", ...)
+// or its string content matches a ValueDetector (a Luhn-valid South African
+// ID number, a PAN, ...) regardless of what key it was found under.
+package redact
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// KeyRule redacts any value whose map key or struct field name matches
+// Match, regardless of the value's shape.
+type KeyRule struct {
+	Name     string
+	Match    func(key string) bool
+	Strategy Strategy
+}
+
+// ValueDetector redacts a string value's content regardless of which key it
+// was found under -- a South African ID number pasted into a free-text
+// message field is still a South African ID number.
+type ValueDetector struct {
+	Name     string
+	Match    func(value string) bool
+	Strategy Strategy
+}
+
+// Redactor walks a value and returns a copy with every sensitive value
+// replaced. The zero value is not usable; build one with New or Default.
+type Redactor struct {
+	keyRules  []KeyRule
+	detectors []ValueDetector
+	strategy  Strategy
+}
+
+// Option configures a Redactor built by New.
+type Option func(*Redactor)
+
+// WithKeyRule registers an additional KeyRule, checked after the built-in
+// ones.
+func WithKeyRule(rule KeyRule) Option {
+	return func(r *Redactor) {
+		r.keyRules = append(r.keyRules, rule)
+	}
+}
+
+// WithValueDetector registers an additional ValueDetector, checked after
+// the built-in ones.
+func WithValueDetector(detector ValueDetector) Option {
+	return func(r *Redactor) {
+		r.detectors = append(r.detectors, detector)
+	}
+}
+
+// WithStrategy overrides the replacement strategy used for matches that
+// don't specify their own (every built-in KeyRule/ValueDetector in this
+// package uses the Redactor's default strategy).
+func WithStrategy(strategy Strategy) Option {
+	return func(r *Redactor) {
+		r.strategy = strategy
+	}
+}
+
+// New builds a Redactor from the built-in SA/POPIA key rules and value
+// detectors, plus whatever opts add or override.
+func New(opts ...Option) *Redactor {
+	r := &Redactor{
+		keyRules:  append([]KeyRule(nil), defaultKeyRules...),
+		detectors: append([]ValueDetector(nil), defaultValueDetectors...),
+		strategy:  Mask,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Default returns a Redactor configured with only the built-in key rules
+// and value detectors.
+func Default() *Redactor {
+	return New()
+}
+
+// Redact returns a copy of v with every sensitive value replaced. Maps,
+// slices, arrays, structs (exported fields only, keyed by their JSON tag
+// name if present), and pointers are walked recursively; every other type
+// is returned as-is unless its string form matches a ValueDetector.
+func (r *Redactor) Redact(v interface{}) interface{} {
+	return r.redactValue("", reflect.ValueOf(v))
+}
+
+func (r *Redactor) redactValue(key string, rv reflect.Value) interface{} {
+	if !rv.IsValid() {
+		return nil
+	}
+
+	if safe, ok := rv.Interface().(safeValue); ok {
+		return safe.v
+	}
+
+	if rule := r.matchingKeyRule(key); rule != nil {
+		return r.strategyFor(rule.Strategy)(fmt.Sprint(rv.Interface()))
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		out := make(map[string]interface{}, rv.Len())
+		for _, k := range rv.MapKeys() {
+			out[fmt.Sprint(k.Interface())] = r.redactValue(fmt.Sprint(k.Interface()), rv.MapIndex(k))
+		}
+		return out
+
+	case reflect.Struct:
+		out := make(map[string]interface{}, rv.NumField())
+		t := rv.Type()
+		for i := 0; i < rv.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			out[fieldName(field)] = r.redactValue(fieldName(field), rv.Field(i))
+		}
+		return out
+
+	case reflect.Slice, reflect.Array:
+		out := make([]interface{}, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			out[i] = r.redactValue(key, rv.Index(i))
+		}
+		return out
+
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		return r.redactValue(key, rv.Elem())
+
+	case reflect.String:
+		s := rv.String()
+		if detector := r.matchingValueDetector(s); detector != nil {
+			return r.strategyFor(detector.Strategy)(s)
+		}
+		return s
+
+	default:
+		return rv.Interface()
+	}
+}
+
+func (r *Redactor) matchingKeyRule(key string) *KeyRule {
+	if key == "" {
+		return nil
+	}
+	for i := range r.keyRules {
+		if r.keyRules[i].Match(key) {
+			return &r.keyRules[i]
+		}
+	}
+	return nil
+}
+
+func (r *Redactor) matchingValueDetector(value string) *ValueDetector {
+	for i := range r.detectors {
+		if r.detectors[i].Match(value) {
+			return &r.detectors[i]
+		}
+	}
+	return nil
+}
+
+func (r *Redactor) strategyFor(strategy Strategy) Strategy {
+	if strategy != nil {
+		return strategy
+	}
+	return r.strategy
+}
+
+func fieldName(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok || tag == "" || tag == "-" {
+		return field.Name
+	}
+	for i, c := range tag {
+		if c == ',' {
+			return tag[:i]
+		}
+	}
+	return tag
+}
@@ -0,0 +1,16 @@
+package redact
+
+// safeValue marks a subtree as exempt from redaction; Redactor unwraps and
+// returns v unchanged whenever it encounters one.
+type safeValue struct {
+	v interface{}
+}
+
+// Safe marks v (and everything nested inside it) as known not to contain
+// sensitive data, so Redactor passes it through unchanged. Use this for
+// values a KeyRule or ValueDetector would otherwise false-positive on --
+// a field literally named "token_count", or a test fixture ID that happens
+// to be Luhn-valid.
+func Safe(v interface{}) interface{} {
+	return safeValue{v: v}
+}
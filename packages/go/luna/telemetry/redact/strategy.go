@@ -0,0 +1,38 @@
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Strategy replaces a detected sensitive value's string form with its
+// redacted form.
+type Strategy func(value string) string
+
+// Mask replaces the whole value with the literal "[REDACTED]".
+func Mask(value string) string {
+	return "[REDACTED]"
+}
+
+// Hash replaces the value with the first 16 hex characters of its SHA-256
+// hash, so the same input always redacts to the same token -- useful for
+// correlating repeated occurrences of the same secret across log lines
+// without exposing it.
+func Hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// FirstLast keeps the first and last n characters of value and masks
+// everything in between, e.g. FirstLast(4)("4111111111111111") returns
+// "4111********1111". Values too short for n characters on each side fall
+// back to Mask.
+func FirstLast(n int) Strategy {
+	return func(value string) string {
+		if len(value) <= n*2 {
+			return Mask(value)
+		}
+		return value[:n] + strings.Repeat("*", len(value)-n*2) + value[len(value)-n:]
+	}
+}
@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// SlogLogger implements Logger on top of log/slog.Handler, so hosts that
+// have already standardized on slog can route the SDK's logging through
+// their existing handler chain instead of adopting zerolog.
+type SlogLogger struct {
+	handler slog.Handler
+}
+
+// NewSlogLogger wraps handler. Luna's LogLevelError..LogLevelTrace map onto
+// slog's Error/Warn/Info/Debug levels, with Trace logged at slog.LevelDebug
+// minus 4 (slog has no native trace level).
+func NewSlogLogger(handler slog.Handler) *SlogLogger {
+	return &SlogLogger{handler: handler}
+}
+
+func (l *SlogLogger) Error(message string, context map[string]interface{}) {
+	l.log(slog.LevelError, message, context)
+}
+
+func (l *SlogLogger) Warn(message string, context map[string]interface{}) {
+	l.log(slog.LevelWarn, message, context)
+}
+
+func (l *SlogLogger) Info(message string, context map[string]interface{}) {
+	l.log(slog.LevelInfo, message, context)
+}
+
+func (l *SlogLogger) Debug(message string, context map[string]interface{}) {
+	l.log(slog.LevelDebug, message, context)
+}
+
+func (l *SlogLogger) Trace(message string, context map[string]interface{}) {
+	l.log(slog.LevelDebug-4, message, context)
+}
+
+func (l *SlogLogger) log(level slog.Level, message string, fields map[string]interface{}) {
+	ctx := context.Background()
+	if !l.handler.Enabled(ctx, level) {
+		return
+	}
+
+	record := slog.NewRecord(time.Now(), level, message, 0)
+	for k, v := range redactContext(fields) {
+		record.AddAttrs(slog.Any(k, v))
+	}
+
+	_ = l.handler.Handle(ctx, record)
+}
+
+var _ Logger = (*SlogLogger)(nil)
@@ -0,0 +1,50 @@
+//go:build zap
+
+package telemetry
+
+import "go.uber.org/zap"
+
+// ZapLogger implements Logger on top of zap.Logger. Built only when the
+// caller's build includes the "zap" tag, so the SDK doesn't force a zap
+// dependency on users who don't want one.
+type ZapLogger struct {
+	logger *zap.Logger
+}
+
+// NewZapLogger wraps logger.
+func NewZapLogger(logger *zap.Logger) *ZapLogger {
+	return &ZapLogger{logger: logger}
+}
+
+func (l *ZapLogger) Error(message string, context map[string]interface{}) {
+	l.logger.Error(message, toZapFields(context)...)
+}
+
+func (l *ZapLogger) Warn(message string, context map[string]interface{}) {
+	l.logger.Warn(message, toZapFields(context)...)
+}
+
+func (l *ZapLogger) Info(message string, context map[string]interface{}) {
+	l.logger.Info(message, toZapFields(context)...)
+}
+
+func (l *ZapLogger) Debug(message string, context map[string]interface{}) {
+	l.logger.Debug(message, toZapFields(context)...)
+}
+
+func (l *ZapLogger) Trace(message string, context map[string]interface{}) {
+	// zap has no trace level; log at debug with an explicit marker rather
+	// than silently dropping trace-level events.
+	l.logger.Debug(message, append(toZapFields(context), zap.Bool("trace", true))...)
+}
+
+func toZapFields(context map[string]interface{}) []zap.Field {
+	redacted := redactContext(context)
+	fields := make([]zap.Field, 0, len(redacted))
+	for k, v := range redacted {
+		fields = append(fields, zap.Any(k, v))
+	}
+	return fields
+}
+
+var _ Logger = (*ZapLogger)(nil)
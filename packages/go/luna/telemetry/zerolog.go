@@ -0,0 +1,74 @@
+package telemetry
+
+import (
+	"os"
+
+	"github.com/rs/zerolog"
+)
+
+// ZerologLogger implements Logger on top of zerolog, giving structured,
+// leveled output with per-event fields instead of the ConsoleLogger's ad-hoc
+// JSON marshalling. It is the default Logger once a client opts in via
+// luna.WithLogger(telemetry.NewZerologLogger(...)).
+type ZerologLogger struct {
+	logger zerolog.Logger
+}
+
+// NewZerologLogger creates a ZerologLogger writing to os.Stderr at level.
+func NewZerologLogger(level LogLevel) *ZerologLogger {
+	return NewZerologLoggerWith(zerolog.New(os.Stderr).With().Timestamp().Logger(), level)
+}
+
+// NewZerologLoggerWith wraps an existing zerolog.Logger (e.g. one already
+// configured by the host application) instead of constructing a new writer.
+func NewZerologLoggerWith(logger zerolog.Logger, level LogLevel) *ZerologLogger {
+	return &ZerologLogger{logger: logger.Level(toZerologLevel(level))}
+}
+
+// With returns a ZerologLogger that stamps the given fields on every
+// subsequent event. Resource methods use this to attach request_id,
+// resource, method, and path once per call rather than repeating them.
+func (l *ZerologLogger) With(fields map[string]interface{}) *ZerologLogger {
+	ctx := l.logger.With()
+	for k, v := range fields {
+		ctx = ctx.Interface(k, v)
+	}
+	return &ZerologLogger{logger: ctx.Logger()}
+}
+
+func (l *ZerologLogger) Error(message string, context map[string]interface{}) {
+	l.logger.Error().Fields(redactContext(context)).Msg(message)
+}
+
+func (l *ZerologLogger) Warn(message string, context map[string]interface{}) {
+	l.logger.Warn().Fields(redactContext(context)).Msg(message)
+}
+
+func (l *ZerologLogger) Info(message string, context map[string]interface{}) {
+	l.logger.Info().Fields(redactContext(context)).Msg(message)
+}
+
+func (l *ZerologLogger) Debug(message string, context map[string]interface{}) {
+	l.logger.Debug().Fields(redactContext(context)).Msg(message)
+}
+
+func (l *ZerologLogger) Trace(message string, context map[string]interface{}) {
+	l.logger.Trace().Fields(redactContext(context)).Msg(message)
+}
+
+func toZerologLevel(level LogLevel) zerolog.Level {
+	switch {
+	case level >= LogLevelError:
+		return zerolog.ErrorLevel
+	case level >= LogLevelWarn:
+		return zerolog.WarnLevel
+	case level >= LogLevelInfo:
+		return zerolog.InfoLevel
+	case level >= LogLevelDebug:
+		return zerolog.DebugLevel
+	default:
+		return zerolog.TraceLevel
+	}
+}
+
+var _ Logger = (*ZerologLogger)(nil)
@@ -0,0 +1,183 @@
+package testutil
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// redactedHeaders lists the request headers stripped before a cassette is
+// written to disk, so recorded fixtures never leak credentials into the
+// repo.
+var redactedHeaders = map[string]bool{
+	"Authorization": true,
+	"Cookie":        true,
+	"X-Api-Key":     true,
+}
+
+const redactedValue = "[REDACTED]"
+
+// apiKeySecretPattern matches a Luna API key's lk_<env>_<secret> shape (see
+// auth.NewAPIKeyAuth), so a redacted header can keep the env prefix --
+// useful for telling a test/live cassette apart -- while still wiping the
+// secret itself.
+var apiKeySecretPattern = regexp.MustCompile(`(lk_(?:live|test|dev)_)[a-zA-Z0-9]+`)
+
+func redactAPIKeySecret(value string) string {
+	if apiKeySecretPattern.MatchString(value) {
+		return apiKeySecretPattern.ReplaceAllString(value, "${1}"+redactedValue)
+	}
+	return redactedValue
+}
+
+// Cassette is the on-disk record/replay format for a sequence of HTTP
+// interactions, persisted as JSON alongside the tests that use it.
+type Cassette struct {
+	Entries []CassetteEntry `json:"entries"`
+}
+
+// CassetteEntry is one recorded request/response pair. Key is the matcher
+// key the request was recorded under (see MatcherFunc); during replay it's
+// also what incoming requests are looked up by.
+type CassetteEntry struct {
+	Key      string           `json:"key"`
+	Request  CassetteRequest  `json:"request"`
+	Response CassetteResponse `json:"response"`
+}
+
+// CassetteRequest is the redacted, replayable shape of a recorded request.
+type CassetteRequest struct {
+	Method  string            `json:"method"`
+	Path    string            `json:"path"`
+	Query   string            `json:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// CassetteResponse is the recorded response a replay server plays back.
+type CassetteResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    string            `json:"body,omitempty"`
+}
+
+// MatcherFunc derives the key a request is recorded or replayed under.
+// The default, DefaultMatcher, keys on method + path + sorted query + a
+// hash of the body; pass a custom MatcherFunc to, say, ignore an
+// Idempotency-Key header or a timestamp field in the body.
+type MatcherFunc func(r *http.Request, body []byte) string
+
+// DefaultMatcher is the default MatcherFunc: method + path + the query
+// string with its keys sorted (so param order never causes a spurious
+// mismatch) + a sha256 of the body.
+func DefaultMatcher(r *http.Request, body []byte) string {
+	sum := sha256.Sum256(body)
+	return fmt.Sprintf("%s %s?%s#%s", r.Method, r.URL.Path, SortedQuery(r), hex.EncodeToString(sum[:]))
+}
+
+// SortedQuery renders r's query string with its parameter values sorted
+// and its keys in sorted order, so two requests differing only in query
+// parameter order produce the same string.
+func SortedQuery(r *http.Request) string {
+	values := r.URL.Query()
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		sort.Strings(values[k])
+		for _, v := range values[k] {
+			parts = append(parts, k+"="+v)
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// CaptureHeaders copies h into a plain map suitable for a CassetteEntry,
+// redacting the headers in redactedHeaders. A redacted API key keeps its
+// lk_<env>_ prefix (see redactAPIKeySecret) so a recorded cassette still
+// shows which environment it was captured against.
+func CaptureHeaders(h http.Header) map[string]string {
+	captured := make(map[string]string, len(h))
+	for k := range h {
+		if redactedHeaders[http.CanonicalHeaderKey(k)] {
+			captured[k] = redactAPIKeySecret(h.Get(k))
+			continue
+		}
+		captured[k] = h.Get(k)
+	}
+	return captured
+}
+
+// LoadCassette reads a cassette from path, returning an empty Cassette if
+// the file doesn't exist yet -- recording into a fresh cassette file is
+// the common case.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Cassette{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("testutil: read cassette %s: %w", path, err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("testutil: parse cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// SaveCassette writes cassette to path as indented JSON.
+func SaveCassette(path string, cassette *Cassette) error {
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("testutil: marshal cassette %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("testutil: write cassette %s: %w", path, err)
+	}
+	return nil
+}
+
+// ClosestEntry returns the cassette entry whose method+path most plausibly
+// corresponds to an unmatched request, for a useful failure message -- nil
+// if the cassette has no entries at all.
+func ClosestEntry(cassette *Cassette, r *http.Request) *CassetteEntry {
+	var closest *CassetteEntry
+	for i := range cassette.Entries {
+		entry := &cassette.Entries[i]
+		if entry.Request.Method == r.Method && entry.Request.Path == r.URL.Path {
+			return entry
+		}
+		if closest == nil {
+			closest = entry
+		}
+	}
+	return closest
+}
+
+// DiffAgainstEntry describes how r (and its body) differs from entry, for
+// a replay failure message. entry may be nil if the cassette had no
+// entries to compare against at all.
+func DiffAgainstEntry(r *http.Request, body []byte, entry *CassetteEntry) string {
+	if entry == nil {
+		return "cassette has no recorded entries"
+	}
+	return fmt.Sprintf(
+		"closest recorded entry:\n  method: %s (got %s)\n  path:   %s (got %s)\n  query:  %s (got %s)\n  body:   %s (got %s)",
+		entry.Request.Method, r.Method,
+		entry.Request.Path, r.URL.Path,
+		entry.Request.Query, SortedQuery(r),
+		entry.Request.Body, string(body),
+	)
+}
@@ -0,0 +1,234 @@
+package testutil
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+)
+
+// CassetteServer is an httptest-backed server that either records real
+// request/response pairs against an upstream Luna API into a cassette
+// file, or replays a previously recorded cassette deterministically. It
+// exists alongside MockServer for endpoints where hand-writing a default
+// route would just be re-deriving what a live call already returns.
+type CassetteServer struct {
+	Server *httptest.Server
+
+	matcher  MatcherFunc
+	path     string
+	upstream *url.URL // nil in replay mode
+
+	mu        sync.Mutex
+	cassette  *Cassette
+	nextIndex map[string]int // replay mode: next entry index per matcher key
+}
+
+// CassetteOption configures a CassetteServer.
+type CassetteOption func(*CassetteServer)
+
+// WithMatcher overrides the default method+path+sorted-query+body-hash
+// MatcherFunc, e.g. to ignore a volatile header or body field.
+func WithMatcher(matcher MatcherFunc) CassetteOption {
+	return func(cs *CassetteServer) {
+		cs.matcher = matcher
+	}
+}
+
+// NewRecordingServer proxies every incoming request to upstreamURL and
+// appends the method/path/query/body/headers plus the response
+// status/body/headers to the cassette at cassettePath, creating it if it
+// doesn't exist. Sensitive headers such as Authorization are redacted
+// before being written to disk.
+func NewRecordingServer(upstreamURL, cassettePath string, opts ...CassetteOption) (*CassetteServer, error) {
+	upstream, err := url.Parse(upstreamURL)
+	if err != nil {
+		return nil, fmt.Errorf("testutil: parse upstream URL %q: %w", upstreamURL, err)
+	}
+
+	cassette, err := LoadCassette(cassettePath)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &CassetteServer{
+		matcher:  DefaultMatcher,
+		path:     cassettePath,
+		upstream: upstream,
+		cassette: cassette,
+	}
+	for _, opt := range opts {
+		opt(cs)
+	}
+
+	cs.Server = httptest.NewServer(http.HandlerFunc(cs.handleRecord))
+	return cs, nil
+}
+
+// NewReplayServer loads the cassette at cassettePath and serves recorded
+// responses back in the order they were recorded, per matcher key. A
+// request with no matching (or no remaining) entry fails loudly with a
+// diff against the closest recorded entry, rather than silently falling
+// through to a 404.
+func NewReplayServer(cassettePath string, opts ...CassetteOption) (*CassetteServer, error) {
+	cassette, err := LoadCassette(cassettePath)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &CassetteServer{
+		matcher:   DefaultMatcher,
+		path:      cassettePath,
+		cassette:  cassette,
+		nextIndex: make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(cs)
+	}
+
+	cs.Server = httptest.NewServer(http.HandlerFunc(cs.handleReplay))
+	return cs, nil
+}
+
+// NewCassetteServer picks between NewRecordingServer and NewReplayServer
+// based on update, mirroring the `go test -update` idiom: run tests with
+// update true to refresh cassettePath against a live upstreamURL, commit
+// the resulting file, then leave update false so CI replays it instead of
+// depending on the live API.
+func NewCassetteServer(update bool, upstreamURL, cassettePath string, opts ...CassetteOption) (*CassetteServer, error) {
+	if update {
+		return NewRecordingServer(upstreamURL, cassettePath, opts...)
+	}
+	return NewReplayServer(cassettePath, opts...)
+}
+
+// URL returns the server's base URL.
+func (cs *CassetteServer) URL() string {
+	return cs.Server.URL
+}
+
+// Close shuts down the underlying httptest.Server. In record mode it also
+// flushes the cassette to disk, so callers don't need a separate Save
+// step.
+func (cs *CassetteServer) Close() error {
+	cs.Server.Close()
+	if cs.upstream == nil {
+		return nil
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	return SaveCassette(cs.path, cs.cassette)
+}
+
+func (cs *CassetteServer) handleRecord(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "testutil: read request body: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	key := cs.matcher(r, body)
+
+	proxyURL := *cs.upstream
+	proxyURL.Path = r.URL.Path
+	proxyURL.RawQuery = r.URL.RawQuery
+
+	proxyReq, err := http.NewRequestWithContext(r.Context(), r.Method, proxyURL.String(), bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, "testutil: build upstream request: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	proxyReq.Header = r.Header.Clone()
+
+	resp, err := http.DefaultClient.Do(proxyReq)
+	if err != nil {
+		http.Error(w, "testutil: upstream request failed: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		http.Error(w, "testutil: read upstream response: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cs.mu.Lock()
+	cs.cassette.Entries = append(cs.cassette.Entries, CassetteEntry{
+		Key: key,
+		Request: CassetteRequest{
+			Method:  r.Method,
+			Path:    r.URL.Path,
+			Query:   SortedQuery(r),
+			Headers: CaptureHeaders(r.Header),
+			Body:    string(body),
+		},
+		Response: CassetteResponse{
+			Status:  resp.StatusCode,
+			Headers: CaptureHeaders(resp.Header),
+			Body:    string(respBody),
+		},
+	})
+	// Recording is typically a one-off, short-lived run against a live
+	// API, so flush after every interaction rather than only on Close --
+	// a crash mid-run shouldn't lose everything captured so far.
+	saveErr := SaveCassette(cs.path, cs.cassette)
+	cs.mu.Unlock()
+	if saveErr != nil {
+		http.Error(w, saveErr.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for k, values := range resp.Header {
+		for _, v := range values {
+			w.Header().Add(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	w.Write(respBody)
+}
+
+func (cs *CassetteServer) handleReplay(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "testutil: read request body: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	key := cs.matcher(r, body)
+
+	cs.mu.Lock()
+	var entry *CassetteEntry
+	for i := range cs.cassette.Entries {
+		if cs.cassette.Entries[i].Key != key {
+			continue
+		}
+		if cs.nextIndex[key] > i {
+			continue
+		}
+		entry = &cs.cassette.Entries[i]
+		cs.nextIndex[key] = i + 1
+		break
+	}
+	var diff string
+	if entry == nil {
+		diff = DiffAgainstEntry(r, body, ClosestEntry(cs.cassette, r))
+	}
+	cs.mu.Unlock()
+
+	if entry == nil {
+		http.Error(w, fmt.Sprintf(
+			"testutil: no cassette entry for %s %s (key %q)\n%s",
+			r.Method, r.URL.Path, key, diff,
+		), http.StatusNotImplemented)
+		return
+	}
+
+	for k, v := range entry.Response.Headers {
+		w.Header().Set(k, v)
+	}
+	w.WriteHeader(entry.Response.Status)
+	w.Write([]byte(entry.Response.Body))
+}
@@ -54,31 +54,117 @@ var MockProjects = []map[string]interface{}{
 	},
 }
 
+// Workflow fixtures
+var MockWorkflow = map[string]interface{}{
+	"id":           "wf_123456789",
+	"name":         "Test Workflow",
+	"trigger_type": "manual",
+	"is_active":    true,
+}
+
+var MockWorkflows = []map[string]interface{}{
+	MockWorkflow,
+}
+
+var MockWorkflowRun = map[string]interface{}{
+	"id":          "run_123456789",
+	"workflow_id": "wf_123456789",
+	"status":      "succeeded",
+	"started_at":  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+}
+
+var MockWorkflowRuns = []map[string]interface{}{
+	MockWorkflowRun,
+	{
+		"id":          "run_987654321",
+		"workflow_id": "wf_123456789",
+		"status":      "running",
+		"started_at":  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	},
+}
+
+// Residence fixtures
+var MockResidence = map[string]interface{}{
+	"id":                  "res_123456789",
+	"name":                "Jammie Residence",
+	"slug":                "jammie-residence",
+	"address":             "1 University Ave, Rondebosch",
+	"description":         "Student residence near upper campus.",
+	"is_nsfas_accredited": true,
+	"min_price":           3500.0,
+	"max_price":           6200.0,
+	"currency_code":       "ZAR",
+	"gender_policy":       "mixed",
+	"location": map[string]interface{}{
+		"latitude":  -33.9575,
+		"longitude": 18.4613,
+		"suburb":    "Rondebosch",
+		"city":      "Cape Town",
+	},
+	"campus_ids":   []string{"cmp_uct"},
+	"rating":       4.2,
+	"review_count": 18,
+	"images":       []string{"https://example.com/res1.jpg"},
+	"amenities":    []string{"wifi", "laundry"},
+}
+
+var MockResidences = []map[string]interface{}{
+	MockResidence,
+}
+
+// MockFacetBuckets is a sample server-side aggregation, in the shape a
+// ResidenceSearch.Facets=["gender_policy"] request returns under
+// ResidenceList.Facets["gender_policy"].
+var MockFacetBuckets = []map[string]interface{}{
+	{"value": "mixed", "count": 12},
+	{"value": "female", "count": 5},
+	{"value": "male", "count": 3},
+}
+
+// MockResidenceList is a sample ResidenceList response, including the
+// facet aggregation a ResidenceSearch.Facets request would return.
+var MockResidenceList = map[string]interface{}{
+	"data":     MockResidences,
+	"has_more": false,
+	"facets": map[string]interface{}{
+		"gender_policy": MockFacetBuckets,
+	},
+}
+
 // Storage fixtures
 var MockBucket = map[string]interface{}{
-	"id":         "bkt_123456789",
-	"name":       "test-bucket",
-	"public":     false,
-	"created_at": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	"id":     "bkt_123456789",
+	"name":   "test-bucket",
+	"region": "us-east-1",
 }
 
 var MockBuckets = []map[string]interface{}{
 	MockBucket,
 	{
-		"id":         "bkt_987654321",
-		"name":       "public-bucket",
-		"public":     true,
-		"created_at": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		"id":     "bkt_987654321",
+		"name":   "public-bucket",
+		"region": "eu-west-1",
 	},
 }
 
 var MockFile = map[string]interface{}{
-	"id":         "file_123456789",
-	"name":       "test-file.pdf",
-	"bucket_id":  "bkt_123456789",
-	"size":       1024,
-	"mime_type":  "application/pdf",
-	"created_at": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	"id":           "file_123456789",
+	"bucket_id":    "bkt_123456789",
+	"key":          "test-file.pdf",
+	"size":         1024,
+	"content_type": "application/pdf",
+	"url":          "https://cdn.example.com/files/file_123456789",
+}
+
+// MockUploadedFile is what the resumable upload mock returns from
+// CompleteUpload.
+var MockUploadedFile = map[string]interface{}{
+	"id":           "file_upload00001",
+	"bucket_id":    "bkt_123456789",
+	"key":          "upload.bin",
+	"size":         0,
+	"content_type": "application/octet-stream",
+	"url":          "https://cdn.example.com/files/file_upload00001",
 }
 
 // Helper functions
@@ -93,6 +179,66 @@ func MockListResponse(data interface{}, hasMore bool, nextCursor string) map[str
 	return response
 }
 
+// MockBatchResponse builds a batch endpoint's {"results":[...]} body from
+// per-item results, each either {"status":200,"data":{...}} or
+// {"status":<4xx/5xx>,"error":{...}}.
+func MockBatchResponse(results ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"results": results}
+}
+
+// Webhook fixtures
+var MockWebhookSubscription = map[string]interface{}{
+	"id":         "wh_123456789",
+	"url":        "https://example.com/webhooks/luna",
+	"events":     []string{"user.created", "user.updated"},
+	"status":     "enabled",
+	"created_at": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	"updated_at": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+}
+
+var MockWebhookSubscriptions = []map[string]interface{}{
+	MockWebhookSubscription,
+}
+
+// MockWebhookSubscriptionCreated is what WebhooksResource.Create returns:
+// MockWebhookSubscription plus the signing secret, only ever present on
+// the create response.
+var MockWebhookSubscriptionCreated = map[string]interface{}{
+	"id":         "wh_123456789",
+	"url":        "https://example.com/webhooks/luna",
+	"events":     []string{"user.created", "user.updated"},
+	"status":     "enabled",
+	"secret":     MockWebhookSecret,
+	"created_at": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	"updated_at": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+}
+
+// MockWebhookEnvelope is a sample user.created delivery envelope, in the
+// shape webhooks.Envelope decodes.
+var MockWebhookEnvelope = map[string]interface{}{
+	"id":         "evt_123456789",
+	"type":       "user.created",
+	"created_at": time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	"data": map[string]interface{}{
+		"user": MockUser,
+	},
+}
+
+var MockWebhookDelivery = map[string]interface{}{
+	"id":          "whd_123456789",
+	"event_id":    "evt_123456789",
+	"event_type":  "user.created",
+	"delivered":   false,
+	"status_code": 0,
+	"attempts":    1,
+	"payload":     MockWebhookEnvelope,
+	"created_at":  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Format(time.RFC3339),
+}
+
+var MockWebhookDeliveries = []map[string]interface{}{
+	MockWebhookDelivery,
+}
+
 // Error response fixtures
 var MockErrorNotFound = map[string]interface{}{
 	"error": map[string]interface{}{
@@ -0,0 +1,65 @@
+package testutil
+
+import (
+	"sync"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/telemetry"
+)
+
+// LogEntry is a single captured log event.
+type LogEntry struct {
+	Level   string
+	Message string
+	Fields  map[string]interface{}
+}
+
+// CapturedLogger implements telemetry.Logger, recording every event in
+// memory instead of writing it anywhere, so tests can assert on the
+// request_id/resource/duration_ms fields the SDK stamps on its log events.
+type CapturedLogger struct {
+	mu      sync.Mutex
+	entries []LogEntry
+}
+
+// CaptureLogs returns a Logger to pass to luna.WithLogger (or
+// luna.WithLoggerContext) for the duration of a test.
+func CaptureLogs() *CapturedLogger {
+	return &CapturedLogger{}
+}
+
+// Entries returns a copy of the events captured so far.
+func (l *CapturedLogger) Entries() []LogEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	out := make([]LogEntry, len(l.entries))
+	copy(out, l.entries)
+	return out
+}
+
+func (l *CapturedLogger) record(level, message string, context map[string]interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, LogEntry{Level: level, Message: message, Fields: context})
+}
+
+func (l *CapturedLogger) Error(message string, context map[string]interface{}) {
+	l.record("error", message, context)
+}
+
+func (l *CapturedLogger) Warn(message string, context map[string]interface{}) {
+	l.record("warn", message, context)
+}
+
+func (l *CapturedLogger) Info(message string, context map[string]interface{}) {
+	l.record("info", message, context)
+}
+
+func (l *CapturedLogger) Debug(message string, context map[string]interface{}) {
+	l.record("debug", message, context)
+}
+
+func (l *CapturedLogger) Trace(message string, context map[string]interface{}) {
+	l.record("trace", message, context)
+}
+
+var _ telemetry.Logger = (*CapturedLogger)(nil)
@@ -1,10 +1,16 @@
 package testutil
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 )
 
 // MockServer wraps httptest.Server with helper methods
@@ -12,6 +18,22 @@ type MockServer struct {
 	Server   *httptest.Server
 	Mux      *http.ServeMux
 	handlers map[string]http.HandlerFunc
+
+	uploadsMu sync.Mutex
+	uploads   map[string]*mockUploadSession
+
+	objectsMu sync.Mutex
+	objects   map[string][]byte
+}
+
+// mockUploadSession tracks one resumable upload for the storage upload
+// endpoints, so UploadChunk/ListParts/CompleteUpload behave statefully
+// across calls within a test.
+type mockUploadSession struct {
+	bucketID string
+	filename string
+	size     int64
+	parts    map[int]map[string]interface{}
 }
 
 // NewMockServer creates a new mock server for testing
@@ -23,6 +45,8 @@ func NewMockServer() *MockServer {
 		Server:   server,
 		Mux:      mux,
 		handlers: make(map[string]http.HandlerFunc),
+		uploads:  make(map[string]*mockUploadSession),
+		objects:  make(map[string][]byte),
 	}
 
 	// Set up default routes
@@ -41,6 +65,14 @@ func (ms *MockServer) Close() {
 	ms.Server.Close()
 }
 
+// SetMockObject makes data available for download at /mock-objects/id, the
+// URL handleGetPresignedDownloadURL hands out for id.
+func (ms *MockServer) SetMockObject(id string, data []byte) {
+	ms.objectsMu.Lock()
+	defer ms.objectsMu.Unlock()
+	ms.objects[id] = data
+}
+
 // SetHandler sets a custom handler for a specific path
 func (ms *MockServer) SetHandler(method, path string, handler http.HandlerFunc) {
 	key := method + " " + path
@@ -144,6 +176,20 @@ func (ms *MockServer) setupDefaultRoutes() {
 		}
 	})
 
+	// Batch endpoints
+	ms.Mux.HandleFunc("/v1/users:batch", func(w http.ResponseWriter, r *http.Request) {
+		if !ms.checkAuth(w, r) {
+			return
+		}
+		ms.handleBatch(w, r, "usr_nonexistent", MockUser)
+	})
+	ms.Mux.HandleFunc("/v1/projects:batch", func(w http.ResponseWriter, r *http.Request) {
+		if !ms.checkAuth(w, r) {
+			return
+		}
+		ms.handleBatch(w, r, "prj_nonexistent", MockProject)
+	})
+
 	// Storage endpoints
 	ms.Mux.HandleFunc("/v1/storage/buckets", func(w http.ResponseWriter, r *http.Request) {
 		if !ms.checkAuth(w, r) {
@@ -158,6 +204,48 @@ func (ms *MockServer) setupDefaultRoutes() {
 		}
 	})
 
+	// Resumable upload endpoints
+	ms.Mux.HandleFunc("/v1/storage/buckets/", func(w http.ResponseWriter, r *http.Request) {
+		if !ms.checkAuth(w, r) {
+			return
+		}
+		ms.handleUploadRoute(w, r)
+	})
+
+	// Presigned-download endpoint and the object-serving route a
+	// presigned URL it hands out actually points to.
+	ms.Mux.HandleFunc("/v1/storage/files/", func(w http.ResponseWriter, r *http.Request) {
+		if !ms.checkAuth(w, r) {
+			return
+		}
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/v1/storage/files/"), "/presigned-download")
+		ms.handleGetPresignedDownloadURL(w, r, id)
+	})
+	ms.Mux.HandleFunc("/mock-objects/", func(w http.ResponseWriter, r *http.Request) {
+		ms.handleServeObject(w, strings.TrimPrefix(r.URL.Path, "/mock-objects/"))
+	})
+
+	// Automation endpoints
+	ms.Mux.HandleFunc("/v1/automation/workflows", func(w http.ResponseWriter, r *http.Request) {
+		if !ms.checkAuth(w, r) {
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			ms.writeJSON(w, http.StatusOK, MockListResponse(MockWorkflows, false, ""))
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	ms.Mux.HandleFunc("/v1/automation/workflows/", func(w http.ResponseWriter, r *http.Request) {
+		if !ms.checkAuth(w, r) {
+			return
+		}
+		ms.handleWorkflowRoute(w, r)
+	})
+
 	// Health endpoint
 	ms.Mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		ms.writeJSON(w, http.StatusOK, map[string]string{
@@ -167,6 +255,314 @@ func (ms *MockServer) setupDefaultRoutes() {
 	})
 }
 
+// handleUploadRoute dispatches the subpaths under /v1/storage/buckets/
+// that drive a resumable upload: initiating a session, uploading a part,
+// listing parts, and completing the upload.
+func (ms *MockServer) handleUploadRoute(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/v1/storage/buckets/")
+
+	if strings.HasSuffix(rest, "/uploads") && r.Method == http.MethodPost {
+		ms.handleInitiateUpload(w, r, strings.TrimSuffix(rest, "/uploads"))
+		return
+	}
+
+	if strings.HasSuffix(rest, "/presigned-upload") && r.Method == http.MethodPost {
+		ms.handleGetPresignedUploadURL(w, strings.TrimSuffix(rest, "/presigned-upload"))
+		return
+	}
+
+	if segments := strings.Split(strings.TrimPrefix(rest, "uploads/"), "/"); strings.HasPrefix(rest, "uploads/") {
+		switch {
+		case len(segments) == 2 && segments[1] == "complete" && r.Method == http.MethodPost:
+			ms.handleCompleteUpload(w, r, segments[0])
+			return
+		case len(segments) == 2 && segments[1] == "parts" && r.Method == http.MethodGet:
+			ms.handleListParts(w, segments[0])
+			return
+		case len(segments) == 3 && segments[1] == "parts" && r.Method == http.MethodPut:
+			index, err := strconv.Atoi(segments[2])
+			if err != nil {
+				http.Error(w, "invalid part index", http.StatusBadRequest)
+				return
+			}
+			ms.handleUploadChunk(w, r, segments[0], index)
+			return
+		}
+	}
+
+	http.Error(w, "not found", http.StatusNotFound)
+}
+
+// handleBatch serves a generic POST .../:batch endpoint for contract
+// tests: it decodes the {"operations":[...]} body and echoes back one
+// result per operation, in order. An operation whose id equals missingID
+// gets a 404 error result (exercising the partial-failure path); a
+// "delete" op gets a bodyless 200; anything else gets baseFixture merged
+// with the operation's own "data" (and its id, if given) as a 200 result.
+func (ms *MockServer) handleBatch(w http.ResponseWriter, r *http.Request, missingID string, baseFixture map[string]interface{}) {
+	var body struct {
+		Operations []struct {
+			Op   string                 `json:"op"`
+			ID   string                 `json:"id"`
+			Data map[string]interface{} `json:"data"`
+		} `json:"operations"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	results := make([]map[string]interface{}, len(body.Operations))
+	for i, op := range body.Operations {
+		switch {
+		case op.ID == missingID:
+			results[i] = map[string]interface{}{
+				"status": http.StatusNotFound,
+				"error": map[string]interface{}{
+					"code":    "NOT_FOUND",
+					"message": "Resource not found",
+				},
+			}
+		case op.Op == "delete":
+			results[i] = map[string]interface{}{"status": http.StatusOK}
+		default:
+			item := make(map[string]interface{}, len(baseFixture))
+			for k, v := range baseFixture {
+				item[k] = v
+			}
+			for k, v := range op.Data {
+				item[k] = v
+			}
+			if op.ID != "" {
+				item["id"] = op.ID
+			}
+			results[i] = map[string]interface{}{"status": http.StatusOK, "data": item}
+		}
+	}
+
+	ms.writeJSON(w, http.StatusOK, MockBatchResponse(results...))
+}
+
+// handleWorkflowRoute dispatches the subpaths under
+// /v1/automation/workflows/ covering a workflow run's lifecycle: trigger,
+// get/list runs, cancel, and streamed logs.
+func (ms *MockServer) handleWorkflowRoute(w http.ResponseWriter, r *http.Request) {
+	rest := strings.TrimPrefix(r.URL.Path, "/v1/automation/workflows/")
+	segments := strings.Split(rest, "/")
+
+	switch {
+	case len(segments) == 2 && segments[1] == "trigger" && r.Method == http.MethodPost:
+		ms.writeJSON(w, http.StatusOK, MockWorkflowRun)
+		return
+	case len(segments) == 2 && segments[1] == "runs" && r.Method == http.MethodGet:
+		ms.writeJSON(w, http.StatusOK, MockListResponse(MockWorkflowRuns, false, ""))
+		return
+	case len(segments) == 3 && segments[1] == "runs" && r.Method == http.MethodGet:
+		ms.handleGetWorkflowRun(w, segments[2])
+		return
+	case len(segments) == 4 && segments[1] == "runs" && segments[3] == "cancel" && r.Method == http.MethodPost:
+		ms.handleCancelWorkflowRun(w, segments[2])
+		return
+	case len(segments) == 4 && segments[1] == "runs" && segments[3] == "logs" && r.Method == http.MethodGet:
+		ms.handleStreamWorkflowLogs(w, segments[2])
+		return
+	}
+
+	http.Error(w, "not found", http.StatusNotFound)
+}
+
+func (ms *MockServer) handleGetWorkflowRun(w http.ResponseWriter, runID string) {
+	if runID == "run_nonexistent" {
+		ms.writeJSON(w, http.StatusNotFound, MockErrorNotFound)
+		return
+	}
+	ms.writeJSON(w, http.StatusOK, MockWorkflowRun)
+}
+
+func (ms *MockServer) handleCancelWorkflowRun(w http.ResponseWriter, runID string) {
+	if runID == "run_nonexistent" {
+		ms.writeJSON(w, http.StatusNotFound, MockErrorNotFound)
+		return
+	}
+	run := map[string]interface{}{}
+	for k, v := range MockWorkflowRun {
+		run[k] = v
+	}
+	run["status"] = "cancelled"
+	ms.writeJSON(w, http.StatusOK, run)
+}
+
+// handleStreamWorkflowLogs serves a fixed two-event SSE log stream ending
+// in "data: [DONE]", the shape WorkflowsResource.StreamLogs expects.
+func (ms *MockServer) handleStreamWorkflowLogs(w http.ResponseWriter, runID string) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	events := []struct{ id, data string }{
+		{"evt_1", `{"id":"evt_1","timestamp":"2024-01-01T00:00:00Z","level":"info","message":"run ` + runID + ` started"}`},
+		{"evt_2", `{"id":"evt_2","timestamp":"2024-01-01T00:00:01Z","level":"info","message":"run ` + runID + ` finished"}`},
+	}
+	for _, event := range events {
+		fmt.Fprintf(w, "id: %s\ndata: %s\n\n", event.id, event.data)
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	fmt.Fprint(w, "data: [DONE]\n\n")
+	if flusher != nil {
+		flusher.Flush()
+	}
+}
+
+func (ms *MockServer) handleInitiateUpload(w http.ResponseWriter, r *http.Request, bucketID string) {
+	var body struct {
+		Filename string            `json:"filename"`
+		Size     int64             `json:"size"`
+		Metadata map[string]string `json:"metadata"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid body", http.StatusBadRequest)
+		return
+	}
+
+	sessionID := "up_" + strconv.FormatInt(int64(len(ms.uploads)+1), 10)
+
+	ms.uploadsMu.Lock()
+	ms.uploads[sessionID] = &mockUploadSession{
+		bucketID: bucketID,
+		filename: body.Filename,
+		size:     body.Size,
+		parts:    make(map[int]map[string]interface{}),
+	}
+	ms.uploadsMu.Unlock()
+
+	ms.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":         sessionID,
+		"bucket_id":  bucketID,
+		"filename":   body.Filename,
+		"size":       body.Size,
+		"chunk_size": 5 * 1024 * 1024,
+	})
+}
+
+func (ms *MockServer) handleUploadChunk(w http.ResponseWriter, r *http.Request, sessionID string, index int) {
+	ms.uploadsMu.Lock()
+	session, ok := ms.uploads[sessionID]
+	ms.uploadsMu.Unlock()
+	if !ok {
+		http.Error(w, "unknown upload session", http.StatusNotFound)
+		return
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read chunk", http.StatusBadRequest)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(sum[:])
+	if want := r.Header.Get("X-Chunk-Checksum-Sha256"); want != "" && want != checksum {
+		http.Error(w, "checksum mismatch", http.StatusBadRequest)
+		return
+	}
+
+	part := map[string]interface{}{
+		"index":    index,
+		"size":     len(data),
+		"checksum": checksum,
+	}
+
+	ms.uploadsMu.Lock()
+	session.parts[index] = part
+	ms.uploadsMu.Unlock()
+
+	ms.writeJSON(w, http.StatusOK, part)
+}
+
+func (ms *MockServer) handleListParts(w http.ResponseWriter, sessionID string) {
+	ms.uploadsMu.Lock()
+	session, ok := ms.uploads[sessionID]
+	ms.uploadsMu.Unlock()
+	if !ok {
+		http.Error(w, "unknown upload session", http.StatusNotFound)
+		return
+	}
+
+	ms.uploadsMu.Lock()
+	parts := make([]map[string]interface{}, 0, len(session.parts))
+	for _, p := range session.parts {
+		parts = append(parts, p)
+	}
+	ms.uploadsMu.Unlock()
+
+	ms.writeJSON(w, http.StatusOK, parts)
+}
+
+func (ms *MockServer) handleCompleteUpload(w http.ResponseWriter, r *http.Request, sessionID string) {
+	ms.uploadsMu.Lock()
+	_, ok := ms.uploads[sessionID]
+	if ok {
+		delete(ms.uploads, sessionID)
+	}
+	ms.uploadsMu.Unlock()
+	if !ok {
+		http.Error(w, "unknown upload session", http.StatusNotFound)
+		return
+	}
+
+	result := make(map[string]interface{}, len(MockUploadedFile))
+	for k, v := range MockUploadedFile {
+		result[k] = v
+	}
+	ms.writeJSON(w, http.StatusOK, result)
+}
+
+// handleGetPresignedDownloadURL returns a URL under /mock-objects/ that
+// serves id's bytes (as registered via SetMockObject) along with their
+// SHA-256, mimicking what an S3-compatible backend's presigned URL plus
+// checksum metadata would look like.
+func (ms *MockServer) handleGetPresignedDownloadURL(w http.ResponseWriter, r *http.Request, id string) {
+	ms.objectsMu.Lock()
+	data, ok := ms.objects[id]
+	ms.objectsMu.Unlock()
+	if !ok {
+		ms.writeJSON(w, http.StatusNotFound, MockErrorNotFound)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	ms.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"url":      ms.URL() + "/mock-objects/" + id,
+		"checksum": hex.EncodeToString(sum[:]),
+	})
+}
+
+func (ms *MockServer) handleGetPresignedUploadURL(w http.ResponseWriter, bucketID string) {
+	ms.writeJSON(w, http.StatusOK, map[string]interface{}{
+		"url": ms.URL() + "/mock-objects/" + bucketID + "-upload",
+	})
+}
+
+// handleServeObject is what a presigned download URL from
+// handleGetPresignedDownloadURL actually points to: no auth, just the raw
+// bytes and an ETag, like a real object store would serve.
+func (ms *MockServer) handleServeObject(w http.ResponseWriter, id string) {
+	ms.objectsMu.Lock()
+	data, ok := ms.objects[id]
+	ms.objectsMu.Unlock()
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:])+`"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
 func (ms *MockServer) checkAuth(w http.ResponseWriter, r *http.Request) bool {
 	auth := r.Header.Get("Authorization")
 	if auth == "" || !strings.HasPrefix(auth, "Bearer ") {
@@ -0,0 +1,24 @@
+package recorder
+
+import "regexp"
+
+// RedactJSONFields returns a Filter that replaces the value of each named
+// top-level JSON field -- e.g. "email", "phone" -- with "[REDACTED]"
+// wherever it appears as a `"field":"..."` pair, for scrubbing PII out of
+// a live API's response bodies before they're committed to a cassette.
+// It's a syntactic, not a JSON-aware, replacement: good enough for flat
+// resource payloads without pulling in a JSON-path dependency this module
+// doesn't otherwise need.
+func RedactJSONFields(fields ...string) Filter {
+	patterns := make([]*regexp.Regexp, len(fields))
+	for i, field := range fields {
+		patterns[i] = regexp.MustCompile(`"` + regexp.QuoteMeta(field) + `"\s*:\s*"[^"]*"`)
+	}
+
+	return func(body []byte) []byte {
+		for i, field := range fields {
+			body = patterns[i].ReplaceAll(body, []byte(`"`+field+`":"[REDACTED]"`))
+		}
+		return body
+	}
+}
@@ -0,0 +1,48 @@
+package recorder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil"
+)
+
+// IgnoreQueryParams returns a MatcherFunc like testutil.DefaultMatcher but
+// excluding the named query parameters from the match key -- e.g.
+// "cursor" for a paginated endpoint whose cursor value is expected to
+// differ between the recording run and any later replay.
+func IgnoreQueryParams(params ...string) testutil.MatcherFunc {
+	ignore := make(map[string]bool, len(params))
+	for _, p := range params {
+		ignore[p] = true
+	}
+
+	return func(r *http.Request, body []byte) string {
+		values := r.URL.Query()
+		for p := range ignore {
+			values.Del(p)
+		}
+
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			vs := values[k]
+			sort.Strings(vs)
+			for _, v := range vs {
+				parts = append(parts, k+"="+v)
+			}
+		}
+
+		sum := sha256.Sum256(body)
+		return fmt.Sprintf("%s %s?%s#%s", r.Method, r.URL.Path, strings.Join(parts, "&"), hex.EncodeToString(sum[:]))
+	}
+}
@@ -0,0 +1,221 @@
+// Package recorder provides a go-vcr-style http.RoundTripper for
+// recording and replaying HTTP interactions against the real Luna API, so
+// SDK tests can exercise genuine request/response shapes without network
+// access or a live API key. It reads and writes the same Cassette format
+// as testutil.CassetteServer, so fixtures are interchangeable between the
+// two: CassetteServer fronts a whole httptest.Server a test points its
+// BaseURL at, while Recorder instead plugs into a single *http.Client's
+// Transport via lunahttp.WithTransport -- useful when a test otherwise
+// wants to build its client the normal way.
+package recorder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil"
+)
+
+// Mode selects how a Recorder behaves for every request it sees.
+type Mode int
+
+const (
+	// Replay serves a previously recorded response for each request, in
+	// the order entries were recorded per matcher key, and fails the
+	// request if the cassette has no matching entry left.
+	Replay Mode = iota
+	// Record always calls the upstream RoundTripper and appends the
+	// interaction to the cassette, regardless of what's already recorded.
+	Record
+	// ReplayOrRecord serves a matching recorded entry if one remains, and
+	// falls back to Record for anything the cassette doesn't cover --
+	// the mode for extending a cassette incrementally against a live API.
+	ReplayOrRecord
+)
+
+// Filter scrubs a request or response body before it's written to the
+// cassette, e.g. to strip PII a live API response might contain. It runs
+// only against the copy persisted to disk; the bytes returned to the
+// caller are never touched.
+type Filter func(body []byte) []byte
+
+// Recorder is an http.RoundTripper that records interactions to, or
+// replays them from, a cassette file. Construct one with New and pass it
+// to lunahttp.WithTransport.
+type Recorder struct {
+	mode    Mode
+	path    string
+	next    http.RoundTripper
+	matcher testutil.MatcherFunc
+	filters []Filter
+
+	mu        sync.Mutex
+	cassette  *testutil.Cassette
+	nextIndex map[string]int
+	dirty     bool
+}
+
+// Option configures a Recorder.
+type Option func(*Recorder)
+
+// WithUpstream overrides the http.RoundTripper used in Record and
+// ReplayOrRecord mode to make the real call; http.DefaultTransport if
+// unset.
+func WithUpstream(next http.RoundTripper) Option {
+	return func(r *Recorder) { r.next = next }
+}
+
+// WithMatcher overrides the default method+path+sorted-query+body-hash
+// MatcherFunc used to key cassette entries. See IgnoreQueryParams for a
+// matcher that excludes volatile query parameters (e.g. a pagination
+// cursor) from the key.
+func WithMatcher(matcher testutil.MatcherFunc) Option {
+	return func(r *Recorder) { r.matcher = matcher }
+}
+
+// WithFilter registers a Filter run, in order, against both the request
+// and response body of every interaction before it's written to the
+// cassette.
+func WithFilter(filter Filter) Option {
+	return func(r *Recorder) { r.filters = append(r.filters, filter) }
+}
+
+// New loads (or initializes) the cassette at cassettePath and returns a
+// Recorder operating in mode.
+func New(mode Mode, cassettePath string, opts ...Option) (*Recorder, error) {
+	cassette, err := testutil.LoadCassette(cassettePath)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Recorder{
+		mode:      mode,
+		path:      cassettePath,
+		next:      http.DefaultTransport,
+		matcher:   testutil.DefaultMatcher,
+		cassette:  cassette,
+		nextIndex: make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("recorder: read request body: %w", err)
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	key := r.matcher(req, body)
+
+	if r.mode != Record {
+		if resp, ok := r.replay(req, key); ok {
+			return resp, nil
+		}
+		if r.mode == Replay {
+			r.mu.Lock()
+			diff := testutil.DiffAgainstEntry(req, body, testutil.ClosestEntry(r.cassette, req))
+			r.mu.Unlock()
+			return nil, fmt.Errorf("recorder: no cassette entry for %s %s (key %q)\n%s", req.Method, req.URL.Path, key, diff)
+		}
+	}
+
+	return r.record(req, body, key)
+}
+
+func (r *Recorder) replay(req *http.Request, key string) (*http.Response, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.cassette.Entries {
+		entry := &r.cassette.Entries[i]
+		if entry.Key != key || r.nextIndex[key] > i {
+			continue
+		}
+		r.nextIndex[key] = i + 1
+
+		header := make(http.Header, len(entry.Response.Headers))
+		for k, v := range entry.Response.Headers {
+			header.Set(k, v)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", entry.Response.Status, http.StatusText(entry.Response.Status)),
+			StatusCode:    entry.Response.Status,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(entry.Response.Body))),
+			ContentLength: int64(len(entry.Response.Body)),
+			Request:       req,
+		}, true
+	}
+	return nil, false
+}
+
+func (r *Recorder) record(req *http.Request, body []byte, key string) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, fmt.Errorf("recorder: upstream request failed: %w", err)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("recorder: read response body: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	filteredReqBody, filteredRespBody := body, respBody
+	for _, filter := range r.filters {
+		filteredReqBody = filter(filteredReqBody)
+		filteredRespBody = filter(filteredRespBody)
+	}
+
+	entry := testutil.CassetteEntry{
+		Key: key,
+		Request: testutil.CassetteRequest{
+			Method:  req.Method,
+			Path:    req.URL.Path,
+			Query:   testutil.SortedQuery(req),
+			Headers: testutil.CaptureHeaders(req.Header),
+			Body:    string(filteredReqBody),
+		},
+		Response: testutil.CassetteResponse{
+			Status:  resp.StatusCode,
+			Headers: testutil.CaptureHeaders(resp.Header),
+			Body:    string(filteredRespBody),
+		},
+	}
+
+	r.mu.Lock()
+	r.cassette.Entries = append(r.cassette.Entries, entry)
+	r.dirty = true
+	r.mu.Unlock()
+
+	return resp, nil
+}
+
+// Save flushes any recorded interactions to the cassette file. It's a
+// no-op if nothing has been recorded since the last Save (or at all, e.g.
+// in pure Replay mode), so it's safe to defer unconditionally.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.dirty {
+		return nil
+	}
+	if err := testutil.SaveCassette(r.path, r.cassette); err != nil {
+		return err
+	}
+	r.dirty = false
+	return nil
+}
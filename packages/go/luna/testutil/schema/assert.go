@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// AssertMatchesSchema fails t, with the validation error as the message, if
+// payload doesn't conform to the named schema (see Names). payload may be
+// raw JSON bytes/a json.RawMessage, or any value that encoding/json can
+// marshal (e.g. a resources.User or a map[string]interface{} fixture).
+func AssertMatchesSchema(t *testing.T, schemaName string, payload interface{}) {
+	t.Helper()
+
+	data, err := toJSON(payload)
+	if err != nil {
+		t.Fatalf("schema: %s: could not marshal payload: %v", schemaName, err)
+		return
+	}
+
+	if err := Get(schemaName).ValidateJSON(data); err != nil {
+		t.Errorf("schema: payload does not match %s schema: %v", schemaName, err)
+	}
+}
+
+func toJSON(payload interface{}) ([]byte, error) {
+	switch v := payload.(type) {
+	case []byte:
+		return v, nil
+	case json.RawMessage:
+		return v, nil
+	default:
+		return json.Marshal(payload)
+	}
+}
@@ -0,0 +1,69 @@
+package schema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil"
+	"github.com/eclipse-softworks/luna-sdk-go/luna/testutil/schema"
+)
+
+// roundTrip decodes fixture (typically a testutil Mock* map) into a new v,
+// then re-encodes v, so the bytes handed to the schema validator reflect
+// exactly what the Go type emits on the wire -- not whatever shape the
+// fixture happened to be authored with.
+func roundTrip(t *testing.T, fixture interface{}, v interface{}) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(fixture)
+	if err != nil {
+		t.Fatalf("failed to marshal fixture: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("failed to unmarshal fixture into %T: %v", v, err)
+	}
+
+	out, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("failed to marshal %T: %v", v, err)
+	}
+	return out
+}
+
+// TestResourceTypesMatchSchemas round-trips each resource's mock fixture
+// through its Go type and validates the result against the matching
+// schemas/*.json document. It's meant to fail the moment a resource struct
+// gains, loses, or renames a field without the schema being updated to
+// match -- the drift this package exists to catch.
+func TestResourceTypesMatchSchemas(t *testing.T) {
+	t.Run("User", func(t *testing.T) {
+		var v resources.User
+		schema.AssertMatchesSchema(t, "User", roundTrip(t, testutil.MockUser, &v))
+	})
+
+	t.Run("Project", func(t *testing.T) {
+		var v resources.Project
+		schema.AssertMatchesSchema(t, "Project", roundTrip(t, testutil.MockProject, &v))
+	})
+
+	t.Run("Bucket", func(t *testing.T) {
+		var v resources.Bucket
+		schema.AssertMatchesSchema(t, "Bucket", roundTrip(t, testutil.MockBucket, &v))
+	})
+
+	t.Run("File", func(t *testing.T) {
+		var v resources.FileObject
+		schema.AssertMatchesSchema(t, "File", roundTrip(t, testutil.MockFile, &v))
+	})
+
+	t.Run("Residence", func(t *testing.T) {
+		var v resources.Residence
+		schema.AssertMatchesSchema(t, "Residence", roundTrip(t, testutil.MockResidence, &v))
+	})
+
+	t.Run("ListResponse", func(t *testing.T) {
+		var v resources.UserList
+		schema.AssertMatchesSchema(t, "ListResponse", roundTrip(t, testutil.MockListResponse(testutil.MockUsers, true, "cursor_abc123"), &v))
+	})
+}
@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+// WrapHandler returns an http.Handler that runs next and then asserts (via
+// AssertMatchesSchema) that the response body it wrote matches schemaName,
+// failing t if it doesn't. Use it around a MockServer route, or around its
+// whole Mux, to get contract validation on every response a test's fake
+// server emits without touching the route's own handler code.
+func WrapHandler(t *testing.T, schemaName string, next http.Handler) http.Handler {
+	t.Helper()
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &responseRecorder{ResponseWriter: w, body: &bytes.Buffer{}, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if rec.body.Len() > 0 {
+			AssertMatchesSchema(t, schemaName, rec.body.Bytes())
+		}
+	})
+}
+
+// responseRecorder mirrors the written response to w while buffering the
+// body so it can be validated after next.ServeHTTP returns.
+type responseRecorder struct {
+	http.ResponseWriter
+	body   *bytes.Buffer
+	status int
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"embed"
+	"fmt"
+	"sync"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// Names lists every schema shipped under schemas/, for callers that want
+// to enumerate or validate against all of them (e.g. the drift test).
+var Names = []string{
+	"User",
+	"Project",
+	"Bucket",
+	"File",
+	"Residence",
+	"ListResponse",
+	"ErrorResponse",
+}
+
+var (
+	cacheMu sync.Mutex
+	cache   = map[string]*Schema{}
+)
+
+// Get loads and caches the named schema (e.g. "User", "ListResponse").
+// Panics if the name has no corresponding schemas/<name>.schema.json file
+// or that file fails to parse, since this only ever happens from test
+// setup.
+func Get(name string) *Schema {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	if s, ok := cache[name]; ok {
+		return s
+	}
+
+	data, err := schemaFS.ReadFile(fmt.Sprintf("schemas/%s.schema.json", name))
+	if err != nil {
+		panic(fmt.Sprintf("schema: no such schema %q: %v", name, err))
+	}
+	s, err := Load(data)
+	if err != nil {
+		panic(fmt.Sprintf("schema: %q: %v", name, err))
+	}
+
+	cache[name] = s
+	return s
+}
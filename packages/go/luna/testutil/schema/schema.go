@@ -0,0 +1,229 @@
+// Package schema provides a JSON-Schema-driven contract test harness for
+// the Luna SDK's resource types. It ships a small subset of draft 2020-12
+// (type, enum, required, properties, additionalProperties, items, pattern,
+// format, min/max, minLength/maxLength) -- enough to describe the flat,
+// REST-ish payloads every Luna resource returns -- rather than pulling in
+// a full external validator, since packages/go has no dependency manifest
+// of its own to add one to.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Schema is a parsed JSON Schema document, as loaded by Load/MustLoad.
+type Schema struct {
+	Type                 interface{}        `json:"type"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+
+	compiledPattern *regexp.Regexp
+}
+
+// Load parses a JSON Schema document.
+func Load(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("schema: failed to parse: %w", err)
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("schema: invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.compiledPattern = re
+	}
+	for name, prop := range s.Properties {
+		if prop.Pattern != "" && prop.compiledPattern == nil {
+			re, err := regexp.Compile(prop.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("schema: invalid pattern %q for property %q: %w", prop.Pattern, name, err)
+			}
+			prop.compiledPattern = re
+		}
+	}
+	if s.Items != nil && s.Items.Pattern != "" && s.Items.compiledPattern == nil {
+		re, err := regexp.Compile(s.Items.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("schema: invalid items pattern %q: %w", s.Items.Pattern, err)
+		}
+		s.Items.compiledPattern = re
+	}
+	return &s, nil
+}
+
+var emailPattern = regexp.MustCompile(`^[^\s@]+@[^\s@]+\.[^\s@]+$`)
+
+// Validate checks payload (a decoded JSON value: map[string]interface{},
+// []interface{}, string, float64, bool, or nil) against s, returning the
+// first violation found.
+func (s *Schema) Validate(payload interface{}) error {
+	return s.validate(payload, "$")
+}
+
+// ValidateJSON decodes data as JSON and validates the result against s.
+func (s *Schema) ValidateJSON(data []byte) error {
+	var payload interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return fmt.Errorf("schema: payload is not valid JSON: %w", err)
+	}
+	return s.Validate(payload)
+}
+
+func (s *Schema) validate(payload interface{}, path string) error {
+	if err := s.validateType(payload, path); err != nil {
+		return err
+	}
+
+	if len(s.Enum) > 0 && !containsValue(s.Enum, payload) {
+		return fmt.Errorf("%s: value %v is not one of %v", path, payload, s.Enum)
+	}
+
+	switch v := payload.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+			for name := range v {
+				if _, known := s.Properties[name]; !known {
+					return fmt.Errorf("%s: unexpected property %q", path, name)
+				}
+			}
+		}
+		for name, propSchema := range s.Properties {
+			value, ok := v[name]
+			if !ok || value == nil {
+				continue
+			}
+			if err := propSchema.validate(value, fmt.Sprintf("%s.%s", path, name)); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case string:
+		if s.compiledPattern != nil && !s.compiledPattern.MatchString(v) {
+			return fmt.Errorf("%s: value %q does not match pattern %q", path, v, s.Pattern)
+		}
+		if err := validateFormat(s.Format, v, path); err != nil {
+			return err
+		}
+		if s.MinLength != nil && len(v) < *s.MinLength {
+			return fmt.Errorf("%s: length %d is less than minLength %d", path, len(v), *s.MinLength)
+		}
+		if s.MaxLength != nil && len(v) > *s.MaxLength {
+			return fmt.Errorf("%s: length %d is greater than maxLength %d", path, len(v), *s.MaxLength)
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			return fmt.Errorf("%s: value %v is less than minimum %v", path, v, *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			return fmt.Errorf("%s: value %v is greater than maximum %v", path, v, *s.Maximum)
+		}
+	}
+
+	return nil
+}
+
+func validateFormat(format, value, path string) error {
+	switch format {
+	case "", "unset":
+		return nil
+	case "email":
+		if !emailPattern.MatchString(value) {
+			return fmt.Errorf("%s: value %q is not a valid email address", path, value)
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fmt.Errorf("%s: value %q is not a valid RFC 3339 date-time: %w", path, value, err)
+		}
+	case "uri":
+		if value == "" {
+			return fmt.Errorf("%s: value must be a non-empty URI", path)
+		}
+	}
+	return nil
+}
+
+func (s *Schema) validateType(payload interface{}, path string) error {
+	if s.Type == nil {
+		return nil
+	}
+
+	types := make([]string, 0, 1)
+	switch t := s.Type.(type) {
+	case string:
+		types = append(types, t)
+	case []interface{}:
+		for _, v := range t {
+			if name, ok := v.(string); ok {
+				types = append(types, name)
+			}
+		}
+	}
+
+	for _, typeName := range types {
+		if matchesJSONType(typeName, payload) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: value %v does not match type %v", path, payload, types)
+}
+
+func matchesJSONType(typeName string, payload interface{}) bool {
+	switch typeName {
+	case "null":
+		return payload == nil
+	case "string":
+		_, ok := payload.(string)
+		return ok
+	case "boolean":
+		_, ok := payload.(bool)
+		return ok
+	case "object":
+		_, ok := payload.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := payload.([]interface{})
+		return ok
+	case "number":
+		_, ok := payload.(float64)
+		return ok
+	case "integer":
+		n, ok := payload.(float64)
+		return ok && n == float64(int64(n))
+	default:
+		return false
+	}
+}
+
+func containsValue(options []interface{}, value interface{}) bool {
+	for _, option := range options {
+		if fmt.Sprint(option) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
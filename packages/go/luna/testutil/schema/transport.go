@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// ValidatingTransport wraps an http.RoundTripper and validates every
+// response body against the schema SchemaFor names for that request,
+// failing T if it doesn't match. Requests SchemaFor maps to "" are passed
+// through unvalidated.
+//
+// Plug it into a *http.Client that's been handed to lunahttp.NewClient via
+// lunahttp.WithTransport, then wrap that in a lunahttp.Client passed to
+// luna.NewClient via luna.WithHTTPClient, to validate real API traffic
+// against the shipped schemas from an integration test.
+type ValidatingTransport struct {
+	// Next is the underlying transport; http.DefaultTransport if nil.
+	Next http.RoundTripper
+	// T receives Errorf calls for schema violations.
+	T *testing.T
+	// SchemaFor picks the schema name (see Names) to validate resp's body
+	// against, given the request that produced it. Return "" to skip
+	// validation for that response.
+	SchemaFor func(req *http.Request, resp *http.Response) string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (vt *ValidatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	vt.T.Helper()
+
+	next := vt.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil || resp == nil {
+		return resp, err
+	}
+
+	name := ""
+	if vt.SchemaFor != nil {
+		name = vt.SchemaFor(req, resp)
+	}
+	if name == "" {
+		return resp, nil
+	}
+
+	body, readErr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		vt.T.Errorf("schema: %s %s: failed to read response body: %v", req.Method, req.URL, readErr)
+		return resp, nil
+	}
+
+	if err := Get(name).ValidateJSON(body); err != nil {
+		vt.T.Errorf("schema: %s %s: response does not match %s schema: %v", req.Method, req.URL, name, err)
+	}
+	return resp, nil
+}
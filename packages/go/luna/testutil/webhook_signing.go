@@ -0,0 +1,41 @@
+package testutil
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+// MockWebhookSecret is the signing secret used by SignedWebhookRequest's
+// default timestamp, convenient for tests that don't care which secret is
+// in play.
+const MockWebhookSecret = "whsec_test_0000000000000000"
+
+// SignedWebhookRequest builds an httptest POST to target carrying body as
+// its payload and a valid X-Luna-Signature header computed the same way
+// webhooks.VerifySignature checks it -- for tests that exercise a
+// webhooks.Handler without standing up a real subscription.
+func SignedWebhookRequest(secret, target string, body []byte) *http.Request {
+	return SignedWebhookRequestAt(secret, target, body, time.Now())
+}
+
+// SignedWebhookRequestAt is SignedWebhookRequest with an explicit
+// timestamp, for tests asserting stale-timestamp rejection.
+func SignedWebhookRequestAt(secret, target string, body []byte, at time.Time) *http.Request {
+	timestamp := at.Unix()
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(body)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	req := httptest.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Luna-Signature", fmt.Sprintf("t=%d,v1=%s", timestamp, signature))
+	return req
+}
@@ -0,0 +1,32 @@
+package webhooks
+
+import "github.com/eclipse-softworks/luna-sdk-go/luna/resources"
+
+// UserCreatedEvent is the typed Data payload of a user.created delivery.
+type UserCreatedEvent struct {
+	User resources.User `json:"user"`
+}
+
+// UserUpdatedEvent is the typed Data payload of a user.updated delivery.
+type UserUpdatedEvent struct {
+	User resources.User `json:"user"`
+}
+
+// ProjectDeletedEvent is the typed Data payload of a project.deleted
+// delivery. The project no longer exists by the time the event fires, so
+// only its ID is carried.
+type ProjectDeletedEvent struct {
+	ProjectID string `json:"project_id"`
+}
+
+// StorageFileUploadedEvent is the typed Data payload of a
+// storage.file.uploaded delivery.
+type StorageFileUploadedEvent struct {
+	File resources.FileObject `json:"file"`
+}
+
+// ResidencePublishedEvent is the typed Data payload of a
+// resmate.residence.published delivery.
+type ResidencePublishedEvent struct {
+	Residence resources.Residence `json:"residence"`
+}
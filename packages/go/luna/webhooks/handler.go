@@ -0,0 +1,168 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Handler is an http.Handler for a single webhook subscription's delivery
+// endpoint. Mount one Handler per subscription URL registered via
+// WebhooksResource.Create, constructed with that subscription's own
+// secret; register listeners with OnUserCreated/OnUserUpdated/... for
+// whichever events the subscription covers.
+type Handler struct {
+	secret    string
+	tolerance time.Duration
+
+	onUserCreated         []func(context.Context, *UserCreatedEvent) error
+	onUserUpdated         []func(context.Context, *UserUpdatedEvent) error
+	onProjectDeleted      []func(context.Context, *ProjectDeletedEvent) error
+	onStorageFileUploaded []func(context.Context, *StorageFileUploadedEvent) error
+	onResidencePublished  []func(context.Context, *ResidencePublishedEvent) error
+}
+
+// HandlerOption configures a Handler.
+type HandlerOption func(*Handler)
+
+// WithTolerance overrides DefaultTolerance for how old a delivery's
+// signature timestamp can be before Handler rejects it.
+func WithTolerance(tolerance time.Duration) HandlerOption {
+	return func(h *Handler) { h.tolerance = tolerance }
+}
+
+// NewHandler creates a Handler that verifies deliveries signed with
+// secret -- the WebhookSubscriptionCreated.Secret returned when the
+// subscription was created.
+func NewHandler(secret string, opts ...HandlerOption) *Handler {
+	h := &Handler{secret: secret, tolerance: DefaultTolerance}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// OnUserCreated registers fn to be called for every user.created event
+// this Handler dispatches.
+func (h *Handler) OnUserCreated(fn func(context.Context, *UserCreatedEvent) error) {
+	h.onUserCreated = append(h.onUserCreated, fn)
+}
+
+// OnUserUpdated registers fn to be called for every user.updated event
+// this Handler dispatches.
+func (h *Handler) OnUserUpdated(fn func(context.Context, *UserUpdatedEvent) error) {
+	h.onUserUpdated = append(h.onUserUpdated, fn)
+}
+
+// OnProjectDeleted registers fn to be called for every project.deleted
+// event this Handler dispatches.
+func (h *Handler) OnProjectDeleted(fn func(context.Context, *ProjectDeletedEvent) error) {
+	h.onProjectDeleted = append(h.onProjectDeleted, fn)
+}
+
+// OnStorageFileUploaded registers fn to be called for every
+// storage.file.uploaded event this Handler dispatches.
+func (h *Handler) OnStorageFileUploaded(fn func(context.Context, *StorageFileUploadedEvent) error) {
+	h.onStorageFileUploaded = append(h.onStorageFileUploaded, fn)
+}
+
+// OnResidencePublished registers fn to be called for every
+// resmate.residence.published event this Handler dispatches.
+func (h *Handler) OnResidencePublished(fn func(context.Context, *ResidencePublishedEvent) error) {
+	h.onResidencePublished = append(h.onResidencePublished, fn)
+}
+
+// ServeHTTP implements http.Handler. A signature verification failure
+// responds 403; a decode or listener failure responds 500 so the platform
+// retries the delivery.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if err := VerifySignature(h.secret, r.Header.Get("X-Luna-Signature"), body, h.tolerance); err != nil {
+		http.Error(w, "webhook verification failed", http.StatusForbidden)
+		return
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), envelope); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// dispatch decodes envelope.Data into its typed event and runs every
+// listener registered for envelope.Type, stopping at the first error.
+// An envelope of a type this Handler doesn't recognize is ignored rather
+// than failing the delivery, so a new event type the SDK doesn't know
+// about yet doesn't break existing subscriptions.
+func (h *Handler) dispatch(ctx context.Context, envelope Envelope) error {
+	switch envelope.Type {
+	case UserCreated:
+		var event UserCreatedEvent
+		if err := json.Unmarshal(envelope.Data, &event); err != nil {
+			return fmt.Errorf("webhooks: decode %s: %w", envelope.Type, err)
+		}
+		for _, fn := range h.onUserCreated {
+			if err := fn(ctx, &event); err != nil {
+				return err
+			}
+		}
+	case UserUpdated:
+		var event UserUpdatedEvent
+		if err := json.Unmarshal(envelope.Data, &event); err != nil {
+			return fmt.Errorf("webhooks: decode %s: %w", envelope.Type, err)
+		}
+		for _, fn := range h.onUserUpdated {
+			if err := fn(ctx, &event); err != nil {
+				return err
+			}
+		}
+	case ProjectDeleted:
+		var event ProjectDeletedEvent
+		if err := json.Unmarshal(envelope.Data, &event); err != nil {
+			return fmt.Errorf("webhooks: decode %s: %w", envelope.Type, err)
+		}
+		for _, fn := range h.onProjectDeleted {
+			if err := fn(ctx, &event); err != nil {
+				return err
+			}
+		}
+	case StorageFileUploaded:
+		var event StorageFileUploadedEvent
+		if err := json.Unmarshal(envelope.Data, &event); err != nil {
+			return fmt.Errorf("webhooks: decode %s: %w", envelope.Type, err)
+		}
+		for _, fn := range h.onStorageFileUploaded {
+			if err := fn(ctx, &event); err != nil {
+				return err
+			}
+		}
+	case ResidencePublished:
+		var event ResidencePublishedEvent
+		if err := json.Unmarshal(envelope.Data, &event); err != nil {
+			return fmt.Errorf("webhooks: decode %s: %w", envelope.Type, err)
+		}
+		for _, fn := range h.onResidencePublished {
+			if err := fn(ctx, &event); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var _ http.Handler = (*Handler)(nil)
@@ -0,0 +1,42 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/eclipse-softworks/luna-sdk-go/luna/resources"
+)
+
+// Replay fetches every undelivered delivery recorded for subscriptionID
+// via resource.ListDeliveries and re-runs each one's payload through
+// handler locally, so a consumer that was unreachable when an event fired
+// can catch up without waiting on the platform's own retry schedule.
+func Replay(ctx context.Context, resource *resources.WebhooksResource, subscriptionID string, handler *Handler) error {
+	params := &resources.ListParams{}
+	for {
+		deliveries, err := resource.ListDeliveries(ctx, subscriptionID, params)
+		if err != nil {
+			return fmt.Errorf("webhooks: list deliveries: %w", err)
+		}
+
+		for _, delivery := range deliveries.Data {
+			if delivery.Delivered {
+				continue
+			}
+
+			var envelope Envelope
+			if err := json.Unmarshal(delivery.Payload, &envelope); err != nil {
+				return fmt.Errorf("webhooks: decode delivery %s: %w", delivery.ID, err)
+			}
+			if err := handler.dispatch(ctx, envelope); err != nil {
+				return fmt.Errorf("webhooks: replay delivery %s: %w", delivery.ID, err)
+			}
+		}
+
+		if !deliveries.HasMore || deliveries.NextCursor == nil {
+			return nil
+		}
+		params.Cursor = *deliveries.NextCursor
+	}
+}
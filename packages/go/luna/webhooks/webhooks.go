@@ -0,0 +1,35 @@
+// Package webhooks provides server-side verification and dispatch for
+// webhook deliveries sent by WebhooksResource subscriptions. A delivery's
+// X-Luna-Signature header is an HMAC-SHA256 of its timestamp and raw body
+// keyed by the subscription's secret; Handler verifies that header,
+// decodes the JSON envelope into one of the typed events below, and
+// dispatches it to whatever listeners were registered with its
+// OnUserCreated/OnUserUpdated/... methods. Replay re-runs any deliveries
+// a subscription never succeeded at through a Handler locally, without
+// waiting on the platform's own retry schedule.
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EventType identifies what happened, independent of delivery mechanics.
+type EventType string
+
+const (
+	UserCreated         EventType = "user.created"
+	UserUpdated         EventType = "user.updated"
+	ProjectDeleted      EventType = "project.deleted"
+	StorageFileUploaded EventType = "storage.file.uploaded"
+	ResidencePublished  EventType = "resmate.residence.published"
+)
+
+// Envelope is the JSON shape every webhook delivery's body decodes into,
+// before Data is decoded further into a typed event by EventType.
+type Envelope struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}